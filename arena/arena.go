@@ -0,0 +1,74 @@
+// Package arena provides allocators for the storage of request-scoped collections.
+// A collection allocated from an arena costs the garbage collector a few large objects instead of one per block,
+// and dropping it is a single Free.
+package arena
+
+import "sync"
+
+// Allocator allocates the storage of collections
+type Allocator[E any] interface {
+	// MakeSlice returns a slice with zero length and the capacity
+	MakeSlice(capacity int) []E
+	// Free releases every slice made by the allocator, they must not be used afterwards
+	Free()
+}
+
+// DefaultSlabSize slab size of a slab allocator created with a non-positive slab size
+const DefaultSlabSize = 4096
+
+// NewSlab new slab allocator which carves slices out of slabs of slabSize elements
+func NewSlab[E any](slabSize int) *Slab[E] {
+	if slabSize <= 0 {
+		slabSize = DefaultSlabSize
+	}
+	slab := new(Slab[E])
+	slab.slabSize = slabSize
+	return slab
+}
+
+// Slab allocator which carves slices out of large slabs.
+// A slice larger than the slab size gets a slab of its own.
+// Memory is only reclaimed by Free, so it suits collections which are built, used and dropped together.
+// It is safe for concurrent use.
+type Slab[E any] struct {
+	lock     sync.Mutex
+	slabSize int
+	slabs    [][]E
+	current  []E
+	used     int
+}
+
+// MakeSlice returns a slice with zero length and the capacity
+func (s *Slab[E]) MakeSlice(capacity int) []E {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if capacity > s.slabSize {
+		dedicated := make([]E, capacity)
+		s.slabs = append(s.slabs, dedicated)
+		return dedicated[:0]
+	}
+	if s.current == nil || s.slabSize-s.used < capacity {
+		s.current = make([]E, s.slabSize)
+		s.slabs = append(s.slabs, s.current)
+		s.used = 0
+	}
+	slice := s.current[s.used : s.used : s.used+capacity]
+	s.used += capacity
+	return slice
+}
+
+// Slabs returns the number of slabs allocated since the last Free
+func (s *Slab[E]) Slabs() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.slabs)
+}
+
+// Free drops every slab
+func (s *Slab[E]) Free() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.slabs = nil
+	s.current = nil
+	s.used = 0
+}
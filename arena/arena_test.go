@@ -0,0 +1,36 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlab_MakeSlice(t *testing.T) {
+	slab := NewSlab[int](8)
+	a := slab.MakeSlice(3)
+	b := slab.MakeSlice(5)
+	assert.Equal(t, 0, len(a))
+	assert.Equal(t, 3, cap(a))
+	assert.Equal(t, 5, cap(b))
+	assert.Equal(t, 1, slab.Slabs())
+	a = append(a, 1, 2, 3)
+	b = append(b, 4)
+	assert.Equal(t, []int{1, 2, 3}, a)
+	assert.Equal(t, []int{4}, b)
+
+	slab.MakeSlice(1)
+	assert.Equal(t, 2, slab.Slabs())
+	assert.Equal(t, 20, cap(slab.MakeSlice(20)))
+	assert.Equal(t, 3, slab.Slabs())
+	assert.Equal(t, DefaultSlabSize, NewSlab[int](0).slabSize)
+}
+
+func TestSlab_Free(t *testing.T) {
+	slab := NewSlab[int](8)
+	slab.MakeSlice(8)
+	slab.Free()
+	assert.Equal(t, 0, slab.Slabs())
+	assert.Equal(t, 8, cap(slab.MakeSlice(8)))
+	assert.Equal(t, 1, slab.Slabs())
+}
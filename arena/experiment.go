@@ -0,0 +1,26 @@
+//go:build goexperiment.arenas
+
+package arena
+
+import goarena "arena"
+
+// NewRuntime new allocator backed by a runtime arena of the arenas experiment
+func NewRuntime[E any]() *Runtime[E] {
+	return &Runtime[E]{arena: goarena.NewArena()}
+}
+
+// Runtime allocator backed by a runtime arena, Free returns its memory to the runtime at once.
+// It is only available with GOEXPERIMENT=arenas.
+type Runtime[E any] struct {
+	arena *goarena.Arena
+}
+
+// MakeSlice returns a slice with zero length and the capacity
+func (r *Runtime[E]) MakeSlice(capacity int) []E {
+	return goarena.MakeSlice[E](r.arena, 0, capacity)
+}
+
+// Free frees the arena
+func (r *Runtime[E]) Free() {
+	r.arena.Free()
+}
@@ -0,0 +1,18 @@
+//go:build goexperiment.arenas
+
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntime_MakeSlice(t *testing.T) {
+	allocator := NewRuntime[int]()
+	defer allocator.Free()
+	slice := allocator.MakeSlice(4)
+	assert.Equal(t, 4, cap(slice))
+	slice = append(slice, 1, 2)
+	assert.Equal(t, []int{1, 2}, slice)
+}
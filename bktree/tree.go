@@ -0,0 +1,222 @@
+// Package bktree provides the BK-tree, an index of a metric space for finding the values near a query.
+package bktree
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/contract"
+)
+
+// Result value found by a search
+type Result[E any] struct {
+	// Value found value
+	Value E `json:"value"`
+	// Distance distance between the query and the value
+	Distance int `json:"distance"`
+}
+
+type child[E any] struct {
+	distance int
+	node     *node[E]
+}
+
+type node[E any] struct {
+	value    E
+	children []child[E]
+}
+
+// New new BK-tree over the metric, such as the edit distance of strings or the hamming distance of hashes.
+// The metric must be a metric space distance: non-negative, zero only for equal values, symmetric
+// and satisfying the triangle inequality, otherwise searches may miss values.
+func New[E any](metric func(a, b E) int, values ...E) *Tree[E] {
+	tree := new(Tree[E])
+	tree.metric = metric
+	for _, value := range values {
+		tree.Add(value)
+	}
+	return tree
+}
+
+// Tree BK-tree, searches only visit the subtrees which may hold values within the radius
+type Tree[E any] struct {
+	sync.RWMutex
+	metric func(a, b E) int
+	root   *node[E]
+	size   int64
+}
+
+// Count returns the number of values
+func (t *Tree[E]) Count() int64 {
+	return t.size
+}
+
+// IsEmpty returns whether the tree is empty
+func (t *Tree[E]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// IsNotEmpty returns whether the tree is not empty
+func (t *Tree[E]) IsNotEmpty() bool {
+	return !t.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (t *Tree[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindTree]
+func (t *Tree[E]) Kind() collection.CollectionKind {
+	return collection.KindTree
+}
+
+// Add adds the value, it returns false when the tree holds a value at distance zero already
+func (t *Tree[E]) Add(value E) bool {
+	if t.root == nil {
+		t.root = &node[E]{value: value}
+		t.size++
+		return true
+	}
+	current := t.root
+	for {
+		distance := t.metric(value, current.value)
+		if distance == 0 {
+			return false
+		}
+		index, found := slices.BinarySearchFunc(current.children, distance, func(c child[E], distance int) int {
+			return c.distance - distance
+		})
+		if !found {
+			current.children = slices.Insert(current.children, index, child[E]{distance: distance, node: &node[E]{value: value}})
+			t.size++
+			return true
+		}
+		current = current.children[index].node
+	}
+}
+
+// Contains returns whether the tree holds a value at distance zero from the value
+func (t *Tree[E]) Contains(value E) bool {
+	return len(t.Search(value, 0)) > 0
+}
+
+// Search returns the values within the radius of the query, the closest first
+func (t *Tree[E]) Search(query E, radius int) []Result[E] {
+	var results []Result[E]
+	t.walk(query, func(value E, distance int) int {
+		if distance <= radius {
+			results = append(results, Result[E]{Value: value, Distance: distance})
+		}
+		return radius
+	})
+	slices.SortStableFunc(results, func(a, b Result[E]) int {
+		return a.Distance - b.Distance
+	})
+	return results
+}
+
+// Nearest returns the k values closest to the query, the closest first
+func (t *Tree[E]) Nearest(query E, k int) []Result[E] {
+	if k <= 0 {
+		return nil
+	}
+	results := make([]Result[E], 0, k)
+	t.walk(query, func(value E, distance int) int {
+		if len(results) < k || distance < results[len(results)-1].Distance {
+			index, _ := slices.BinarySearchFunc(results, distance+1, func(r Result[E], distance int) int {
+				return r.Distance - distance
+			})
+			if len(results) == k {
+				results = results[:k-1]
+			}
+			results = slices.Insert(results, index, Result[E]{Value: value, Distance: distance})
+		}
+		if len(results) < k {
+			return math.MaxInt
+		}
+		// only values closer than the current k-th result are of interest
+		return results[len(results)-1].Distance - 1
+	})
+	return results
+}
+
+// walk visits the nodes which may hold values within the radius returned by visit for the last node
+func (t *Tree[E]) walk(query E, visit func(value E, distance int) int) {
+	if t.root == nil {
+		return
+	}
+	stack := []*node[E]{t.root}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		distance := t.metric(query, current.value)
+		radius := visit(current.value, distance)
+		if radius < 0 {
+			continue
+		}
+		for _, c := range current.children {
+			if c.distance >= distance-radius && c.distance <= distance+min(radius, math.MaxInt-distance) {
+				stack = append(stack, c.node)
+			}
+		}
+	}
+}
+
+// Each traverses the values in depth-first order, it breaks when the callback returns false
+func (t *Tree[E]) Each(callback func(value E) bool) {
+	if t.root == nil {
+		return
+	}
+	stack := []*node[E]{t.root}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !callback(current.value) {
+			return
+		}
+		for i := len(current.children) - 1; i >= 0; i-- {
+			stack = append(stack, current.children[i].node)
+		}
+	}
+}
+
+// ToArray converts to array in depth-first order
+func (t *Tree[E]) ToArray() []E {
+	values := make([]E, 0, t.size)
+	t.Each(func(value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// String converts to string
+func (t *Tree[E]) String() string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("Tree[%T](len=%d)", *new(E), t.size))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	index := 0
+	t.Each(func(value E) bool {
+		str.WriteByte('\t')
+		if v, ok := any(value).(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		index++
+		return index < 5
+	})
+	if t.size > 5 {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
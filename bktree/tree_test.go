@@ -0,0 +1,81 @@
+package bktree
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func abs(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestTree_Add(t *testing.T) {
+	tree := New(hamming, 0b0000, 0b0001, 0b0011)
+	assert.False(t, tree.Add(0b0001))
+	assert.True(t, tree.Add(0b1111))
+	assert.Equal(t, int64(4), tree.Count())
+	assert.True(t, tree.Contains(0b0011))
+	assert.False(t, tree.Contains(0b0111))
+	assert.ElementsMatch(t, []uint64{0b0000, 0b0001, 0b0011, 0b1111}, tree.ToArray())
+}
+
+func TestTree_Search(t *testing.T) {
+	tree := New(hamming, 0b0000, 0b0001, 0b0011, 0b0111, 0b1111)
+	results := tree.Search(0b0011, 1)
+	assert.Equal(t, Result[uint64]{Value: 0b0011, Distance: 0}, results[0])
+	assert.ElementsMatch(t, []Result[uint64]{{Value: 0b0001, Distance: 1}, {Value: 0b0111, Distance: 1}}, results[1:])
+	assert.Empty(t, New(hamming).Search(0, 10))
+}
+
+func TestTree_Nearest(t *testing.T) {
+	tree := New(abs, 10, 20, 30, 40, 50)
+	assert.Equal(t, []Result[int]{{Value: 30, Distance: 2}, {Value: 20, Distance: 8}}, tree.Nearest(28, 2))
+	assert.Len(t, tree.Nearest(0, 10), 5)
+	assert.Empty(t, tree.Nearest(0, 0))
+}
+
+func TestTree_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	values := make([]uint64, 500)
+	for i := range values {
+		values[i] = random.Uint64() & 0xffff
+	}
+	tree := New(hamming, values...)
+	for i := 0; i < 50; i++ {
+		query := random.Uint64() & 0xffff
+		var expected []uint64
+		for _, value := range values {
+			if hamming(query, value) <= 3 && !slices.Contains(expected, value) {
+				expected = append(expected, value)
+			}
+		}
+		var actual []uint64
+		for _, result := range tree.Search(query, 3) {
+			actual = append(actual, result.Value)
+		}
+		assert.ElementsMatch(t, expected, actual)
+		nearest := tree.Nearest(query, 5)
+		assert.Len(t, nearest, 5)
+		closest := slices.MinFunc(values, func(a, b uint64) int { return hamming(query, a) - hamming(query, b) })
+		assert.Equal(t, hamming(query, closest), nearest[0].Distance)
+	}
+}
+
+func TestTree_String(t *testing.T) {
+	tree := New(abs, 1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`Tree\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, tree.Count()))
+	assert.True(t, pattern.MatchString(tree.String()))
+}
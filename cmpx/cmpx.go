@@ -0,0 +1,101 @@
+// Package cmpx provides helpers to build comparators.
+//
+// A [Func] is both a plain comparison callback, accepted by Sort methods,
+// and a [contract.Comparator], accepted by sorted sets, trees and priority queues.
+package cmpx
+
+import (
+	"cmp"
+
+	"github.com/gopi-frame/contract"
+)
+
+// Func comparison callback, it returns a negative number when a < b, zero when a == b and a positive number when a > b
+type Func[E any] func(a, b E) int
+
+// Compare implements [contract.Comparator]
+func (f Func[E]) Compare(a, b E) int {
+	return f(a, b)
+}
+
+// Reversed returns the comparator of the reversed order
+func (f Func[E]) Reversed() Func[E] {
+	return func(a, b E) int {
+		return f(b, a)
+	}
+}
+
+// ThenComparing returns the comparator which breaks the ties of f by next
+func (f Func[E]) ThenComparing(next Func[E]) Func[E] {
+	return func(a, b E) int {
+		if result := f(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// Of adapts the comparator to [Func]
+func Of[E any](comparator contract.Comparator[E]) Func[E] {
+	if f, ok := comparator.(Func[E]); ok {
+		return f
+	}
+	return comparator.Compare
+}
+
+// Natural returns the comparator of the natural order of ordered types
+func Natural[E cmp.Ordered]() Func[E] {
+	return cmp.Compare[E]
+}
+
+// By returns the comparator ordering elements by the natural order of the key
+func By[E any, K cmp.Ordered](key func(E) K) Func[E] {
+	return func(a, b E) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc returns the comparator ordering elements by the key compared with the comparator
+func ByFunc[E, K any](key func(E) K, comparator Func[K]) Func[E] {
+	return func(a, b E) int {
+		return comparator(key(a), key(b))
+	}
+}
+
+// Chain returns the comparator which applies the comparators in order until one of them is not zero
+func Chain[E any](comparators ...Func[E]) Func[E] {
+	return func(a, b E) int {
+		for _, comparator := range comparators {
+			if result := comparator(a, b); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
+// NullsFirst returns the comparator of pointers which orders nil before other pointers,
+// the pointed values are compared with the comparator
+func NullsFirst[E any](comparator Func[E]) Func[*E] {
+	return nulls(comparator, -1)
+}
+
+// NullsLast returns the comparator of pointers which orders nil after other pointers,
+// the pointed values are compared with the comparator
+func NullsLast[E any](comparator Func[E]) Func[*E] {
+	return nulls(comparator, 1)
+}
+
+func nulls[E any](comparator Func[E], order int) Func[*E] {
+	return func(a, b *E) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return order
+		case b == nil:
+			return -order
+		}
+		return comparator(*a, *b)
+	}
+}
@@ -0,0 +1,84 @@
+package cmpx
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/queue"
+	"github.com/gopi-frame/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+type person struct {
+	Name string
+	Age  int
+}
+
+var people = []person{
+	{Name: "carol", Age: 30},
+	{Name: "alice", Age: 30},
+	{Name: "bob", Age: 25},
+}
+
+func TestFunc_Reversed(t *testing.T) {
+	values := []int{2, 3, 1}
+	slices.SortFunc(values, Natural[int]().Reversed())
+	assert.Equal(t, []int{3, 2, 1}, values)
+}
+
+func TestFunc_ThenComparing(t *testing.T) {
+	values := slices.Clone(people)
+	slices.SortFunc(values, By(func(p person) int { return p.Age }).ThenComparing(By(func(p person) string { return p.Name })))
+	assert.Equal(t, []string{"bob", "alice", "carol"}, names(values))
+}
+
+func TestOf(t *testing.T) {
+	comparator := Of[int](Natural[int]())
+	assert.Equal(t, -1, comparator(1, 2))
+	s := set.NewSortedSet[int](Natural[int]().Reversed(), 1, 3, 2)
+	assert.Equal(t, []int{3, 2, 1}, s.ToArray())
+}
+
+func TestByFunc(t *testing.T) {
+	values := slices.Clone(people)
+	slices.SortStableFunc(values, ByFunc(func(p person) string { return p.Name }, Natural[string]().Reversed()))
+	assert.Equal(t, []string{"carol", "bob", "alice"}, names(values))
+}
+
+func TestChain(t *testing.T) {
+	l := list.NewList(people...)
+	l.Sort(Chain(
+		By(func(p person) int { return p.Age }).Reversed(),
+		By(func(p person) string { return p.Name }),
+	))
+	assert.Equal(t, []string{"alice", "carol", "bob"}, names(l.ToArray()))
+	assert.Equal(t, 0, Chain[int]()(1, 2))
+}
+
+func TestNullsFirst(t *testing.T) {
+	one, two := 1, 2
+	q := queue.NewPriorityQueue[*int](NullsFirst(Natural[int]()), &two, nil, &one)
+	first, _ := q.Dequeue()
+	assert.Nil(t, first)
+	second, _ := q.Dequeue()
+	assert.Equal(t, 1, *second)
+}
+
+func TestNullsLast(t *testing.T) {
+	one, two := 1, 2
+	values := []*int{nil, &two, &one, nil}
+	slices.SortFunc(values, NullsLast(Natural[int]()))
+	assert.Equal(t, 1, *values[0])
+	assert.Equal(t, 2, *values[1])
+	assert.Nil(t, values[2])
+	assert.Nil(t, values[3])
+}
+
+func names(values []person) []string {
+	var result []string
+	for _, value := range values {
+		result = append(result, value.Name)
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+// Package codec encodes and decodes the elements of collections in several formats.
+package codec
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format format of encoded elements
+type Format uint8
+
+const (
+	// JSON a JSON array of the elements
+	JSON Format = iota
+	// NDJSON one JSON element per line
+	NDJSON
+	// Gob a gob stream of the elements
+	Gob
+	// CSV a header row followed by one row per element, elements must be structs
+	CSV
+	// JSONEnvelope a JSON object of the elements with their type, schema version and length, see [Envelope]
+	JSONEnvelope
+)
+
+var (
+	// ErrUnknownFormat the format is not supported
+	ErrUnknownFormat = errors.New("codec: unknown format")
+	// ErrUnsupportedType the element type can not be encoded in the format
+	ErrUnsupportedType = errors.New("codec: unsupported type")
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case JSON:
+		return "json"
+	case NDJSON:
+		return "ndjson"
+	case Gob:
+		return "gob"
+	case CSV:
+		return "csv"
+	case JSONEnvelope:
+		return "json-envelope"
+	}
+	return fmt.Sprintf("Format(%d)", uint8(f))
+}
+
+// Encode writes the elements to the writer in the format
+func Encode[E any](w io.Writer, items []E, format Format) error {
+	switch format {
+	case JSON:
+		if items == nil {
+			items = []E{}
+		}
+		return json.NewEncoder(w).Encode(items)
+	case NDJSON:
+		return WriteNDJSON(w, eachOf(items))
+	case Gob:
+		return gob.NewEncoder(w).Encode(items)
+	case CSV:
+		return encodeCSV(w, items)
+	case JSONEnvelope:
+		return EncodeEnvelope(w, items)
+	}
+	return ErrUnknownFormat
+}
+
+// Decode reads the elements in the format from the reader
+func Decode[E any](r io.Reader, format Format) ([]E, error) {
+	var items []E
+	switch format {
+	case JSON:
+		if err := json.NewDecoder(r).Decode(&items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case NDJSON:
+		err := ReadNDJSON(r, func(item E) bool {
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	case Gob:
+		if err := gob.NewDecoder(r).Decode(&items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case CSV:
+		return decodeCSV[E](r)
+	case JSONEnvelope:
+		envelope, err := DecodeEnvelope[E](r)
+		if err != nil {
+			return nil, err
+		}
+		return envelope.Items, nil
+	}
+	return nil, ErrUnknownFormat
+}
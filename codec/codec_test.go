@@ -0,0 +1,65 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFormat_String(t *testing.T) {
+	assert.Equal(t, "json", JSON.String())
+	assert.Equal(t, "ndjson", NDJSON.String())
+	assert.Equal(t, "gob", Gob.String())
+	assert.Equal(t, "csv", CSV.String())
+	assert.Equal(t, "json-envelope", JSONEnvelope.String())
+	assert.Equal(t, "Format(9)", Format(9).String())
+}
+
+func TestEncode(t *testing.T) {
+	users := []user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, Encode(buf, users, JSON))
+	assert.Equal(t, `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`+"\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, Encode(buf, users, NDJSON))
+	assert.Equal(t, `{"id":1,"name":"a"}`+"\n"+`{"id":2,"name":"b"}`+"\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, Encode[int](buf, nil, JSON))
+	assert.Equal(t, "[]\n", buf.String())
+
+	assert.ErrorIs(t, Encode(buf, users, Format(9)), ErrUnknownFormat)
+}
+
+func TestDecode(t *testing.T) {
+	users := []user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	for _, format := range []Format{JSON, NDJSON, Gob, CSV, JSONEnvelope} {
+		if format == CSV && !equal.Reflect {
+			continue
+		}
+		buf := new(bytes.Buffer)
+		assert.Nil(t, Encode(buf, users, format), format.String())
+		decoded, err := Decode[user](buf, format)
+		assert.Nil(t, err, format.String())
+		assert.Equal(t, users, decoded, format.String())
+	}
+
+	decoded, err := Decode[int](strings.NewReader("1\n\n2 3\n"), NDJSON)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, decoded)
+
+	_, err = Decode[int](strings.NewReader("1\n{"), NDJSON)
+	assert.NotNil(t, err)
+
+	_, err = Decode[int](strings.NewReader(""), Format(9))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
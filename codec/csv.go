@@ -0,0 +1,189 @@
+//go:build !tinygo && !collection_noreflect
+
+package codec
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+type column struct {
+	name  string
+	index []int
+}
+
+// columns returns the exported fields of the struct, a csv tag renames the column and "-" skips the field
+func columns(t reflect.Type) ([]column, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: csv requires struct elements, got %s", ErrUnsupportedType, t)
+	}
+	var result []column
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		result = append(result, column{name: name, index: field.Index})
+	}
+	return result, nil
+}
+
+func encodeCSV[E any](w io.Writer, items []E) error {
+	cols, err := columns(reflect.TypeFor[E]())
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	record := make([]string, len(cols))
+	for i, col := range cols {
+		record[i] = col.name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, item := range items {
+		value := reflect.ValueOf(&item).Elem()
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return fmt.Errorf("%w: nil element", ErrUnsupportedType)
+			}
+			value = value.Elem()
+		}
+		for i, col := range cols {
+			if record[i], err = formatField(value.FieldByIndex(col.index)); err != nil {
+				return fmt.Errorf("codec: column %s: %w", col.name, err)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func decodeCSV[E any](r io.Reader) ([]E, error) {
+	t := reflect.TypeFor[E]()
+	cols, err := columns(t)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]int, len(cols))
+	for _, col := range cols {
+		byName[col.name] = col.index
+	}
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// unknown columns are ignored
+	indexes := make([][]int, len(header))
+	for i, name := range header {
+		indexes[i] = byName[name]
+	}
+	var items []E
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item E
+		value := reflect.ValueOf(&item).Elem()
+		if value.Kind() == reflect.Pointer {
+			value.Set(reflect.New(t.Elem()))
+			value = value.Elem()
+		}
+		for i, field := range record {
+			if indexes[i] == nil {
+				continue
+			}
+			if err := parseField(value.FieldByIndex(indexes[i]), field); err != nil {
+				return nil, fmt.Errorf("codec: column %s: %w", header[i], err)
+			}
+		}
+		items = append(items, item)
+	}
+}
+
+func formatField(value reflect.Value) (string, error) {
+	if value.Type().Implements(textMarshalerType) {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return "", nil
+		}
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+}
+
+func parseField(value reflect.Value, field string) error {
+	if reflect.PointerTo(value.Type()).Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(field))
+	}
+	if field == "" {
+		value.SetZero()
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(field)
+		return nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(field)
+		value.SetBool(v)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(field, 10, value.Type().Bits())
+		value.SetInt(v)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(field, 10, value.Type().Bits())
+		value.SetUint(v)
+		return err
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(field, value.Type().Bits())
+		value.SetFloat(v)
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+}
@@ -0,0 +1,19 @@
+//go:build tinygo || collection_noreflect
+
+package codec
+
+import (
+	"fmt"
+	"io"
+)
+
+// errCSV reading the fields of struct elements requires reflect, which the build leaves out
+var errCSV = fmt.Errorf("%w: csv requires reflect, which is left out by the build", ErrUnsupportedType)
+
+func encodeCSV[E any](io.Writer, []E) error {
+	return errCSV
+}
+
+func decodeCSV[E any](io.Reader) ([]E, error) {
+	return nil, errCSV
+}
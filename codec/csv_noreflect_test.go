@@ -0,0 +1,17 @@
+//go:build tinygo || collection_noreflect
+
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode_CSV(t *testing.T) {
+	assert.ErrorIs(t, Encode(new(bytes.Buffer), []struct{ Name string }{{}}, CSV), ErrUnsupportedType)
+	_, err := Decode[struct{ Name string }](strings.NewReader("Name\na\n"), CSV)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
@@ -0,0 +1,54 @@
+//go:build !tinygo && !collection_noreflect
+
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	Name    string    `csv:"name"`
+	Score   float64   `csv:"score"`
+	Active  bool      `csv:"active"`
+	At      time.Time `csv:"at"`
+	Secret  string    `csv:"-"`
+	Count   uint8
+	private int
+}
+
+func TestEncode_CSV(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	err := Encode(buf, []record{{Name: "a,b", Score: 1.5, Active: true, At: at, Secret: "x", Count: 3, private: 1}}, CSV)
+	assert.Nil(t, err)
+	assert.Equal(t, "name,score,active,at,Count\n\"a,b\",1.5,true,2024-01-02T03:04:05Z,3\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, Encode(buf, []*record{{Name: "a"}}, CSV))
+	assert.Equal(t, "name,score,active,at,Count\na,0,false,0001-01-01T00:00:00Z,0\n", buf.String())
+
+	assert.ErrorIs(t, Encode(buf, []int{1}, CSV), ErrUnsupportedType)
+	assert.ErrorIs(t, Encode(buf, []struct{ Tags []string }{{}}, CSV), ErrUnsupportedType)
+	assert.ErrorIs(t, Encode(buf, []*record{nil}, CSV), ErrUnsupportedType)
+}
+
+func TestDecode_CSV(t *testing.T) {
+	records, err := Decode[*record](strings.NewReader("Count,unknown,name,score\n7,x,a,\n"), CSV)
+	assert.Nil(t, err)
+	assert.Equal(t, []*record{{Name: "a", Count: 7}}, records)
+
+	records, err = Decode[*record](strings.NewReader(""), CSV)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+
+	_, err = Decode[record](strings.NewReader("Count\n300\n"), CSV)
+	assert.ErrorContains(t, err, "column Count")
+
+	_, err = Decode[string](strings.NewReader("a\n"), CSV)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
@@ -0,0 +1,79 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidEnvelope the envelope does not match its elements
+var ErrInvalidEnvelope = errors.New("codec: invalid envelope")
+
+// Versioned is implemented by elements with a schema version, the version is written to envelopes.
+// SchemaVersion is called on a zero value.
+type Versioned interface {
+	SchemaVersion() int
+}
+
+// Envelope JSON object of the elements with their metadata.
+// Unknown fields are ignored when it is decoded, so envelopes written by newer versions can be read.
+type Envelope[E any] struct {
+	// Type type of the elements
+	Type string `json:"type"`
+	// Version schema version of the elements, see [Versioned]
+	Version int `json:"version"`
+	// Length number of the elements
+	Length int `json:"length"`
+	Items  []E `json:"items"`
+}
+
+// NewEnvelope new envelope of the elements
+func NewEnvelope[E any](items []E) *Envelope[E] {
+	if items == nil {
+		items = []E{}
+	}
+	return &Envelope[E]{
+		Type:    fmt.Sprintf("%T", new(E))[1:],
+		Version: SchemaVersion[E](),
+		Length:  len(items),
+		Items:   items,
+	}
+}
+
+// SchemaVersion returns the schema version of the element type, it is zero when the type does not implement [Versioned].
+// Built with TinyGo or the collection_noreflect build tag, a pointer type is asked through a nil pointer,
+// so its version is zero unless SchemaVersion has a pointer receiver which does not dereference it.
+func SchemaVersion[E any]() int {
+	if v, ok := any(versionProbe[E]()).(Versioned); ok {
+		return schemaVersion(v)
+	}
+	return 0
+}
+
+// schemaVersion returns the schema version of the value, it is zero when asking a nil pointer panics
+func schemaVersion(v Versioned) (version int) {
+	defer func() {
+		if recover() != nil {
+			version = 0
+		}
+	}()
+	return v.SchemaVersion()
+}
+
+// EncodeEnvelope writes the elements in an envelope to the writer
+func EncodeEnvelope[E any](w io.Writer, items []E) error {
+	return json.NewEncoder(w).Encode(NewEnvelope(items))
+}
+
+// DecodeEnvelope reads an envelope from the reader, it returns [ErrInvalidEnvelope] when the length does not match the elements
+func DecodeEnvelope[E any](r io.Reader) (*Envelope[E], error) {
+	envelope := new(Envelope[E])
+	if err := json.NewDecoder(r).Decode(envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Length != len(envelope.Items) {
+		return nil, fmt.Errorf("%w: length %d, got %d elements", ErrInvalidEnvelope, envelope.Length, len(envelope.Items))
+	}
+	return envelope, nil
+}
@@ -0,0 +1,62 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedUser struct {
+	ID int `json:"id"`
+}
+
+func (versionedUser) SchemaVersion() int {
+	return 2
+}
+
+type versionedEvent struct{}
+
+func (*versionedEvent) SchemaVersion() int {
+	return 3
+}
+
+func TestSchemaVersion(t *testing.T) {
+	assert.Equal(t, 0, SchemaVersion[int]())
+	assert.Equal(t, 2, SchemaVersion[versionedUser]())
+	assert.Equal(t, 3, SchemaVersion[*versionedEvent]())
+	assert.Equal(t, 0, SchemaVersion[Versioned]())
+	if equal.Reflect {
+		assert.Equal(t, 2, SchemaVersion[*versionedUser]())
+	} else {
+		assert.Equal(t, 0, SchemaVersion[*versionedUser]())
+	}
+}
+
+func TestEncodeEnvelope(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, Encode(buf, []versionedUser{{ID: 1}}, JSONEnvelope))
+	assert.JSONEq(t, `{"type":"codec.versionedUser","version":2,"length":1,"items":[{"id":1}]}`, buf.String())
+
+	buf.Reset()
+	assert.Nil(t, EncodeEnvelope[int](buf, nil))
+	assert.JSONEq(t, `{"type":"int","version":0,"length":0,"items":[]}`, buf.String())
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	envelope, err := DecodeEnvelope[versionedUser](strings.NewReader(
+		`{"type":"codec.versionedUser","version":3,"length":1,"items":[{"id":1,"email":"a@b"}],"checksum":"x"}`,
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, envelope.Version)
+	assert.Equal(t, []versionedUser{{ID: 1}}, envelope.Items)
+
+	_, err = DecodeEnvelope[int](strings.NewReader(`{"length":2,"items":[1]}`))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+
+	items, err := Decode[int](strings.NewReader(`{"length":0,"items":null}`), JSONEnvelope)
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
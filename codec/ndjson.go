@@ -0,0 +1,48 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// WriteNDJSON writes the elements passed to yield by each to the writer, one JSON element per line.
+// Elements are encoded as they are ranged, so the memory used does not grow with the number of elements.
+func WriteNDJSON[E any](w io.Writer, each func(yield func(value E) bool)) error {
+	encoder := json.NewEncoder(w)
+	var err error
+	each(func(value E) bool {
+		err = encoder.Encode(value)
+		return err == nil
+	})
+	return err
+}
+
+// ReadNDJSON reads the JSON elements separated by newlines from the reader and passes them to the callback one at a time,
+// it stops when the callback returns false.
+func ReadNDJSON[E any](r io.Reader, callback func(value E) bool) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var value E
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !callback(value) {
+			return nil
+		}
+	}
+}
+
+func eachOf[E any](items []E) func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
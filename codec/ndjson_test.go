@@ -0,0 +1,55 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("closed")
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := WriteNDJSON(buf, func(yield func(value string) bool) {
+		for _, value := range []string{"a", "b"} {
+			if !yield(value) {
+				return
+			}
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "\"a\"\n\"b\"\n", buf.String())
+
+	w := new(failingWriter)
+	err = WriteNDJSON(w, eachOf([]int{1, 2, 3}))
+	assert.EqualError(t, err, "closed")
+	assert.Equal(t, 1, w.writes)
+}
+
+func TestReadNDJSON(t *testing.T) {
+	var values []int
+	err := ReadNDJSON(strings.NewReader("1\n2\n3\n"), func(value int) bool {
+		values = append(values, value)
+		return value < 2
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+
+	values = nil
+	err = ReadNDJSON(strings.NewReader("1\nx\n"), func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, []int{1}, values)
+}
@@ -0,0 +1,14 @@
+//go:build !tinygo && !collection_noreflect
+
+package codec
+
+import "reflect"
+
+// versionProbe returns the value asked for the schema version, a pointer type is probed through a new element
+func versionProbe[E any]() E {
+	var zero E
+	if t := reflect.TypeFor[E](); t.Kind() == reflect.Pointer {
+		zero = reflect.New(t.Elem()).Interface().(E)
+	}
+	return zero
+}
@@ -0,0 +1,9 @@
+//go:build tinygo || collection_noreflect
+
+package codec
+
+// versionProbe returns the zero value asked for the schema version, a pointer type is probed through a nil pointer
+func versionProbe[E any]() E {
+	var zero E
+	return zero
+}
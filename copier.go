@@ -0,0 +1,26 @@
+package collection
+
+import "github.com/gopi-frame/collection/internal/deepcopy"
+
+// Copier is implemented by values which make their own deep copies.
+// DeepCopy calls Copy instead of copying by reflection, also for values nested in other values.
+type Copier[E any] interface {
+	Copy() E
+}
+
+// DeepCopy returns a deep copy of the value: pointers, slices, maps and interfaces are copied recursively
+// and shared pointers stay shared in the copy. Values without pointers are returned as is.
+// Unexported struct fields are shared with the original, use [DeepCopyUnsafe] to copy them too.
+// Channels and funcs are shared, and a nil pointer is returned as is rather than copied by its Copy method.
+//
+// When built with TinyGo or with the collection_noreflect build tag, only [Copier] values are deeply copied.
+func DeepCopy[E any](value E) E {
+	return deepcopy.Copy(value, false)
+}
+
+// DeepCopyUnsafe returns a deep copy of the value like [DeepCopy], unexported struct fields included.
+// It writes unexported fields through unsafe, so copies of types which rely on pointer identity in their
+// unexported fields, such as the location of a time.Time or a sync.Mutex, may not behave like the original.
+func DeepCopyUnsafe[E any](value E) E {
+	return deepcopy.Copy(value, true)
+}
@@ -0,0 +1,43 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/deepcopy"
+	"github.com/stretchr/testify/assert"
+)
+
+type copierTestValue struct {
+	Items  []int
+	copies *int
+}
+
+func (v *copierTestValue) Copy() *copierTestValue {
+	*v.copies++
+	return &copierTestValue{Items: append([]int(nil), v.Items...), copies: v.copies}
+}
+
+func TestDeepCopy(t *testing.T) {
+	original := map[string][]int{"a": {1, 2}}
+	copied := DeepCopy(original)
+	copied["a"][0] = 10
+	assert.Equal(t, deepcopy.Reflect, original["a"][0] == 1)
+
+	copies := 0
+	value := &copierTestValue{Items: []int{1}, copies: &copies}
+	assert.Equal(t, []int{1}, DeepCopy(value).Items)
+	assert.Equal(t, 1, copies)
+	if deepcopy.Reflect {
+		DeepCopy([]*copierTestValue{value})
+		assert.Equal(t, 2, copies)
+	}
+	assert.Nil(t, DeepCopy[*copierTestValue](nil))
+	assert.Nil(t, DeepCopyUnsafe[*copierTestValue](nil))
+}
+
+func TestDeepCopyUnsafe(t *testing.T) {
+	copies := 0
+	value := struct{ value *copierTestValue }{&copierTestValue{copies: &copies}}
+	assert.Same(t, value.value, DeepCopy(value).value)
+	assert.Equal(t, deepcopy.Reflect, value.value != DeepCopyUnsafe(value).value)
+}
@@ -0,0 +1,195 @@
+// Package counters provides maps of int64 counters built for concurrent increments on many cores.
+package counters
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// cell stripe of a counter, padded to a cache line so stripes do not share one
+type cell struct {
+	value atomic.Int64
+	_     [56]byte
+}
+
+// counter counts into base until an increment loses a race,
+// then it spreads increments over stripes so a hot key does not serialize the cores incrementing it
+type counter struct {
+	base  atomic.Int64
+	cells atomic.Pointer[[]cell]
+}
+
+func (c *counter) add(delta int64, stripes int) {
+	cells := c.cells.Load()
+	if cells == nil {
+		old := c.base.Load()
+		if c.base.CompareAndSwap(old, old+delta) {
+			return
+		}
+		if stripes <= 1 {
+			c.base.Add(delta)
+			return
+		}
+		striped := make([]cell, stripes)
+		c.cells.CompareAndSwap(nil, &striped)
+		cells = c.cells.Load()
+	}
+	(*cells)[rand.Uint32()%uint32(len(*cells))].value.Add(delta)
+}
+
+func (c *counter) sum() int64 {
+	sum := c.base.Load()
+	if cells := c.cells.Load(); cells != nil {
+		for i := range *cells {
+			sum += (*cells)[i].value.Load()
+		}
+	}
+	return sum
+}
+
+func (c *counter) drain() int64 {
+	sum := c.base.Swap(0)
+	if cells := c.cells.Load(); cells != nil {
+		for i := range *cells {
+			sum += (*cells)[i].value.Swap(0)
+		}
+	}
+	return sum
+}
+
+// New new counter map, a hot key spreads its increments over stripes,
+// a non-positive number of stripes defaults to GOMAXPROCS
+func New[K comparable](stripes int) *Map[K] {
+	m := new(Map[K])
+	if stripes <= 0 {
+		stripes = runtime.GOMAXPROCS(0)
+	}
+	m.stripes = stripes
+	return m
+}
+
+// Map map of int64 counters, it is safe for concurrent use.
+// Keys are found without locking once they exist, and each counter starts as a single atomic
+// which is split into stripes the first time concurrent increments collide,
+// so incrementing a hot key scales with the number of cores.
+// Reads sum the stripes and are not atomic with concurrent increments.
+type Map[K comparable] struct {
+	counters sync.Map
+	size     atomic.Int64
+	stripes  int
+}
+
+func (m *Map[K]) counter(key K) *counter {
+	if c, ok := m.counters.Load(key); ok {
+		return c.(*counter)
+	}
+	c, loaded := m.counters.LoadOrStore(key, new(counter))
+	if !loaded {
+		m.size.Add(1)
+	}
+	return c.(*counter)
+}
+
+// Count returns the number of keys
+func (m *Map[K]) Count() int64 {
+	return m.size.Load()
+}
+
+// IsEmpty returns whether the map is empty
+func (m *Map[K]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *Map[K]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// Incr increments the counter of the key by one
+func (m *Map[K]) Incr(key K) {
+	m.counter(key).add(1, m.stripes)
+}
+
+// Add adds delta to the counter of the key
+func (m *Map[K]) Add(key K, delta int64) {
+	m.counter(key).add(delta, m.stripes)
+}
+
+// Get returns the value of the counter of the key, zero when the key does not exist
+func (m *Map[K]) Get(key K) int64 {
+	c, ok := m.counters.Load(key)
+	if !ok {
+		return 0
+	}
+	return c.(*counter).sum()
+}
+
+// Delete deletes the counter of the key.
+// Increments racing with the deletion may be lost, prefer DrainAndReset for periodic flushes.
+func (m *Map[K]) Delete(key K) {
+	if _, ok := m.counters.LoadAndDelete(key); ok {
+		m.size.Add(-1)
+	}
+}
+
+// Keys returns all keys
+func (m *Map[K]) Keys() []K {
+	var keys []K
+	m.counters.Range(func(key, _ any) bool {
+		keys = append(keys, key.(K))
+		return true
+	})
+	return keys
+}
+
+// Each ranges the counters by callback, it will break the loop when the callback returns false
+func (m *Map[K]) Each(callback func(key K, value int64) bool) {
+	m.counters.Range(func(key, c any) bool {
+		return callback(key.(K), c.(*counter).sum())
+	})
+}
+
+// Snapshot returns the values of all counters
+func (m *Map[K]) Snapshot() map[K]int64 {
+	snapshot := make(map[K]int64)
+	m.Each(func(key K, value int64) bool {
+		snapshot[key] = value
+		return true
+	})
+	return snapshot
+}
+
+// DrainAndReset resets all counters to zero and returns their values before the reset,
+// counters which were already zero are omitted.
+// Every increment is reported by exactly one drain, so it suits periodic metric flushes.
+// Keys are kept so the next interval does not allocate them again.
+func (m *Map[K]) DrainAndReset() map[K]int64 {
+	drained := make(map[K]int64)
+	m.counters.Range(func(key, c any) bool {
+		if value := c.(*counter).drain(); value != 0 {
+			drained[key.(K)] = value
+		}
+		return true
+	})
+	return drained
+}
+
+// ToJSON converts to json
+func (m *Map[K]) ToJSON() ([]byte, error) {
+	return jsonx.Object(m.Snapshot())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *Map[K]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// String converts to string
+func (m *Map[K]) String() string {
+	return format.Entries[K, int64]("Map", m.Count(), m.Each)
+}
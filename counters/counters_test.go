@@ -0,0 +1,114 @@
+package counters
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap_Incr(t *testing.T) {
+	m := New[string](0)
+	m.Incr("a")
+	m.Incr("a")
+	m.Add("b", 5)
+	m.Add("b", -2)
+	assert.Equal(t, int64(2), m.Get("a"))
+	assert.Equal(t, int64(3), m.Get("b"))
+	assert.Equal(t, int64(0), m.Get("c"))
+	assert.Equal(t, int64(2), m.Count())
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+}
+
+func TestMap_Concurrent(t *testing.T) {
+	m := New[string](4)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.Incr("hot")
+				m.Add(fmt.Sprintf("key%d", j%10), 2)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(8000), m.Get("hot"))
+	assert.Equal(t, int64(1600), m.Get("key3"))
+	assert.Equal(t, int64(11), m.Count())
+}
+
+func TestMap_Snapshot(t *testing.T) {
+	m := New[string](1)
+	m.Add("a", 1)
+	m.Add("b", 2)
+	assert.Equal(t, map[string]int64{"a": 1, "b": 2}, m.Snapshot())
+}
+
+func TestMap_DrainAndReset(t *testing.T) {
+	m := New[string](2)
+	var wg sync.WaitGroup
+	var total int64
+	var lock sync.Mutex
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			drained := m.DrainAndReset()
+			lock.Lock()
+			total += drained["a"]
+			lock.Unlock()
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.Incr("a")
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+	total += m.DrainAndReset()["a"]
+	assert.Equal(t, int64(4000), total)
+	assert.Equal(t, int64(0), m.Get("a"))
+	assert.Empty(t, m.DrainAndReset())
+	assert.Equal(t, int64(1), m.Count())
+}
+
+func TestMap_Delete(t *testing.T) {
+	m := New[int](0)
+	m.Incr(1)
+	m.Delete(1)
+	m.Delete(2)
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, int64(0), m.Get(1))
+}
+
+func TestMap_MarshalJSON(t *testing.T) {
+	m := New[string](0)
+	m.Add("a", 3)
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":3}`, string(data))
+}
+
+func TestMap_String(t *testing.T) {
+	m := New[string](0)
+	m.Add("a", 3)
+	pattern := regexp.MustCompile(fmt.Sprintf(`Map\[string, int64\]\(len=%d\)\{\n\ta:\s3,\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+
+	for i := 0; i < 10; i++ {
+		m.Add(fmt.Sprint(i), 1)
+	}
+	assert.True(t, strings.HasSuffix(m.String(), "\t...\n}"))
+	assert.Equal(t, 5, strings.Count(m.String(), ",\n"))
+}
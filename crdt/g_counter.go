@@ -0,0 +1,87 @@
+package crdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type gCounterJSON struct {
+	Replica string            `json:"replica"`
+	Counts  map[string]uint64 `json:"counts"`
+}
+
+// NewGCounter new grow-only counter owned by the replica
+func NewGCounter(replica string) *GCounter {
+	counter := new(GCounter)
+	counter.replica = replica
+	counter.counts = make(map[string]uint64)
+	return counter
+}
+
+// GCounter grow-only counter
+type GCounter struct {
+	sync.RWMutex
+	replica string
+	counts  map[string]uint64
+}
+
+// Replica returns the replica id of the counter
+func (c *GCounter) Replica() string {
+	return c.replica
+}
+
+// Increment increments the counter of the local replica by delta
+func (c *GCounter) Increment(delta uint64) {
+	c.counts[c.replica] += delta
+}
+
+// Value returns the total value of the counter
+func (c *GCounter) Value() uint64 {
+	var value uint64
+	for _, count := range c.counts {
+		value += count
+	}
+	return value
+}
+
+// Merge merges the state of another replica into the counter
+func (c *GCounter) Merge(other *GCounter) {
+	for replica, count := range other.counts {
+		if count > c.counts[replica] {
+			c.counts[replica] = count
+		}
+	}
+}
+
+// ToJSON converts to json
+func (c *GCounter) ToJSON() ([]byte, error) {
+	return json.Marshal(gCounterJSON{
+		Replica: c.replica,
+		Counts:  c.counts,
+	})
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (c *GCounter) MarshalJSON() ([]byte, error) {
+	return c.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (c *GCounter) UnmarshalJSON(data []byte) error {
+	var container gCounterJSON
+	if err := json.Unmarshal(data, &container); err != nil {
+		return err
+	}
+	c.replica = container.Replica
+	c.counts = container.Counts
+	if c.counts == nil {
+		c.counts = make(map[string]uint64)
+	}
+	return nil
+}
+
+// String converts to string
+func (c *GCounter) String() string {
+	return fmt.Sprintf("GCounter(replica=%s, value=%d)", c.replica, c.Value())
+}
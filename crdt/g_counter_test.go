@@ -0,0 +1,52 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCounter_Increment(t *testing.T) {
+	counter := NewGCounter("a")
+	counter.Increment(1)
+	counter.Increment(2)
+	assert.Equal(t, uint64(3), counter.Value())
+}
+
+func TestGCounter_Merge(t *testing.T) {
+	a := NewGCounter("a")
+	b := NewGCounter("b")
+	a.Increment(2)
+	b.Increment(3)
+	a.Merge(b)
+	b.Merge(a)
+	assert.Equal(t, uint64(5), a.Value())
+	assert.Equal(t, uint64(5), b.Value())
+
+	// merge is idempotent
+	a.Merge(b)
+	assert.Equal(t, uint64(5), a.Value())
+}
+
+func TestGCounter_MarshalJSON(t *testing.T) {
+	counter := NewGCounter("a")
+	counter.Increment(2)
+	jsonBytes, err := json.Marshal(counter)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `{"replica":"a","counts":{"a":2}}`, string(jsonBytes))
+}
+
+func TestGCounter_UnmarshalJSON(t *testing.T) {
+	counter := new(GCounter)
+	err := json.Unmarshal([]byte(`{"replica":"a","counts":{"a":2,"b":3}}`), counter)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "a", counter.Replica())
+	assert.Equal(t, uint64(5), counter.Value())
+	counter.Increment(1)
+	assert.Equal(t, uint64(6), counter.Value())
+}
@@ -0,0 +1,233 @@
+package crdt
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+type lwwEntry[K comparable, V any] struct {
+	Key       K      `json:"key"`
+	Value     V      `json:"value"`
+	Timestamp int64  `json:"timestamp"`
+	Replica   string `json:"replica"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+// newer returns whether the entry wins over the other one,
+// ties on timestamp are broken by the replica id
+func (e *lwwEntry[K, V]) newer(other *lwwEntry[K, V]) bool {
+	if e.Timestamp != other.Timestamp {
+		return e.Timestamp > other.Timestamp
+	}
+	return e.Replica > other.Replica
+}
+
+type lwwMapJSON[K comparable, V any] struct {
+	Replica string            `json:"replica"`
+	Entries []*lwwEntry[K, V] `json:"entries"`
+}
+
+// NewLWWMap new last-writer-wins map owned by the replica
+func NewLWWMap[K comparable, V any](replica string) *LWWMap[K, V] {
+	m := new(LWWMap[K, V])
+	m.replica = replica
+	m.entries = make(map[K]*lwwEntry[K, V])
+	m.now = time.Now
+	return m
+}
+
+// LWWMap last-writer-wins map, removed keys are kept as tombstones.
+// Writes are stamped by a hybrid logical clock: the wall clock in nanoseconds,
+// moved past the latest timestamp written or merged so far,
+// so a write always wins over the writes the replica has already seen, even within the same clock tick.
+type LWWMap[K comparable, V any] struct {
+	sync.RWMutex
+	replica string
+	entries map[K]*lwwEntry[K, V]
+	clock   int64
+	now     func() time.Time
+}
+
+// tick returns the timestamp of a local write
+func (m *LWWMap[K, V]) tick() int64 {
+	m.clock = max(m.now().UnixNano(), m.clock+1)
+	return m.clock
+}
+
+func (m *LWWMap[K, V]) write(entry *lwwEntry[K, V]) {
+	m.clock = max(m.clock, entry.Timestamp)
+	if current, ok := m.entries[entry.Key]; ok && !entry.newer(current) {
+		return
+	}
+	m.entries[entry.Key] = entry
+}
+
+// Replica returns the replica id of the map
+func (m *LWWMap[K, V]) Replica() string {
+	return m.replica
+}
+
+// Count returns the size of map
+func (m *LWWMap[K, V]) Count() int64 {
+	var count int64
+	for _, entry := range m.entries {
+		if !entry.Deleted {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty returns whether the map is empty
+func (m *LWWMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *LWWMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *LWWMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *LWWMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *LWWMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get gets element by specific key.
+// A zero value and false will be returned when the given key is not exist
+func (m *LWWMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := m.entries[key]
+	if !ok || entry.Deleted {
+		return *new(V), false
+	}
+	return entry.Value, true
+}
+
+// GetOr gets element by specific key, the default value will be returned when the given key is not exist
+func (m *LWWMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// ContainsKey returns whether the map contains the specific key
+func (m *LWWMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set sets element to the specific key
+func (m *LWWMap[K, V]) Set(key K, value V) {
+	m.write(&lwwEntry[K, V]{
+		Key:       key,
+		Value:     value,
+		Timestamp: m.tick(),
+		Replica:   m.replica,
+	})
+}
+
+// Remove removes the element of specific key
+func (m *LWWMap[K, V]) Remove(key K) {
+	m.write(&lwwEntry[K, V]{
+		Key:       key,
+		Timestamp: m.tick(),
+		Replica:   m.replica,
+		Deleted:   true,
+	})
+}
+
+// Keys returns all keys
+func (m *LWWMap[K, V]) Keys() []K {
+	var keys []K
+	for key, entry := range m.entries {
+		if !entry.Deleted {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Each ranges the map by callback, it will break the loop when the callback returns false
+func (m *LWWMap[K, V]) Each(callback func(key K, value V) bool) {
+	for key, entry := range m.entries {
+		if entry.Deleted {
+			continue
+		}
+		if !callback(key, entry.Value) {
+			break
+		}
+	}
+}
+
+// ToMap converts to map
+func (m *LWWMap[K, V]) ToMap() map[K]V {
+	items := make(map[K]V)
+	m.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// Merge merges the state of another replica into the map
+func (m *LWWMap[K, V]) Merge(other *LWWMap[K, V]) {
+	for _, entry := range other.entries {
+		e := *entry
+		m.write(&e)
+	}
+}
+
+// ToJSON converts to json
+func (m *LWWMap[K, V]) ToJSON() ([]byte, error) {
+	container := lwwMapJSON[K, V]{
+		Replica: m.replica,
+		Entries: make([]*lwwEntry[K, V], 0, len(m.entries)),
+	}
+	for _, entry := range m.entries {
+		container.Entries = append(container.Entries, entry)
+	}
+	return json.Marshal(container)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *LWWMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (m *LWWMap[K, V]) UnmarshalJSON(data []byte) error {
+	var container lwwMapJSON[K, V]
+	if err := json.Unmarshal(data, &container); err != nil {
+		return err
+	}
+	m.replica = container.Replica
+	m.entries = make(map[K]*lwwEntry[K, V])
+	m.clock = 0
+	if m.now == nil {
+		m.now = time.Now
+	}
+	for _, entry := range container.Entries {
+		m.write(entry)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *LWWMap[K, V]) String() string {
+	return format.Entries[K, V]("LWWMap", m.Count(), m.Each)
+}
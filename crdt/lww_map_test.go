@@ -0,0 +1,101 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLWWMap(replica string, now *time.Time) *LWWMap[string, int] {
+	m := NewLWWMap[string, int](replica)
+	m.now = func() time.Time {
+		return *now
+	}
+	return m
+}
+
+func TestLWWMap_Set(t *testing.T) {
+	m := NewLWWMap[string, int]("a")
+	m.Set("x", 1)
+	value, ok := m.Get("x")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, int64(1), m.Count())
+}
+
+func TestLWWMap_Remove(t *testing.T) {
+	now := time.Now()
+	m := newTestLWWMap("a", &now)
+	m.Set("x", 1)
+	now = now.Add(time.Second)
+	m.Remove("x")
+	assert.False(t, m.ContainsKey("x"))
+	assert.True(t, m.IsEmpty())
+}
+
+func TestLWWMap_FrozenClock(t *testing.T) {
+	now := time.Now()
+	a := newTestLWWMap("a", &now)
+	b := newTestLWWMap("b", &now)
+	a.Set("x", 1)
+	a.Set("x", 2)
+	assert.Equal(t, 2, a.GetOr("x", 0))
+	a.Set("y", 1)
+	a.Remove("y")
+	assert.False(t, a.ContainsKey("y"))
+
+	// a replica which has seen a write orders its own writes after it
+	b.Set("x", 3)
+	b.Merge(a)
+	assert.Equal(t, 2, b.GetOr("x", 0))
+	b.Set("x", 4)
+	a.Merge(b)
+	assert.Equal(t, 4, a.GetOr("x", 0))
+	assert.Equal(t, a.ToMap(), b.ToMap())
+}
+
+func TestLWWMap_Merge(t *testing.T) {
+	now := time.Now()
+	a := newTestLWWMap("a", &now)
+	b := newTestLWWMap("b", &now)
+	a.Set("x", 1)
+	now = now.Add(time.Second)
+	b.Set("x", 2)
+	b.Set("y", 3)
+	now = now.Add(time.Second)
+	a.Remove("y")
+
+	a.Merge(b)
+	b.Merge(a)
+	assert.Equal(t, map[string]int{"x": 2}, a.ToMap())
+	assert.Equal(t, a.ToMap(), b.ToMap())
+
+	// ties are broken by replica id
+	a.Set("z", 1)
+	b.Set("z", 2)
+	a.Merge(b)
+	b.Merge(a)
+	assert.Equal(t, 2, a.GetOr("z", 0))
+	assert.Equal(t, 2, b.GetOr("z", 0))
+}
+
+func TestLWWMap_JSON(t *testing.T) {
+	now := time.Now()
+	a := newTestLWWMap("a", &now)
+	a.Set("x", 1)
+	a.Set("y", 2)
+	now = now.Add(time.Second)
+	a.Remove("y")
+	jsonBytes, err := json.Marshal(a)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	b := new(LWWMap[string, int])
+	if err := json.Unmarshal(jsonBytes, b); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "a", b.Replica())
+	assert.Equal(t, map[string]int{"x": 1}, b.ToMap())
+}
@@ -0,0 +1,206 @@
+package crdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+type orSetEntry[E comparable] struct {
+	Value E        `json:"value"`
+	Tags  []string `json:"tags"`
+}
+
+type orSetJSON[E comparable] struct {
+	Replica    string          `json:"replica"`
+	Clock      uint64          `json:"clock"`
+	Entries    []orSetEntry[E] `json:"entries"`
+	Tombstones []string        `json:"tombstones"`
+}
+
+// NewORSet new observed-remove set owned by the replica
+func NewORSet[E comparable](replica string, values ...E) *ORSet[E] {
+	set := new(ORSet[E])
+	set.replica = replica
+	set.entries = make(map[E]map[string]struct{})
+	set.tombstones = make(map[string]struct{})
+	set.Add(values...)
+	return set
+}
+
+// ORSet observed-remove set, concurrent add wins over remove
+type ORSet[E comparable] struct {
+	sync.RWMutex
+	replica    string
+	clock      uint64
+	entries    map[E]map[string]struct{}
+	tombstones map[string]struct{}
+}
+
+// Replica returns the replica id of the set
+func (s *ORSet[E]) Replica() string {
+	return s.replica
+}
+
+// Count returns the size of set
+func (s *ORSet[E]) Count() int64 {
+	return int64(len(s.entries))
+}
+
+// IsEmpty returns whether the set is empty
+func (s *ORSet[E]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// IsNotEmpty returns whether the set is not empty
+func (s *ORSet[E]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (s *ORSet[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *ORSet[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
+// Contains returns whether the set contains the specific element
+func (s *ORSet[E]) Contains(value E) bool {
+	_, ok := s.entries[value]
+	return ok
+}
+
+// Add adds elements into the set
+func (s *ORSet[E]) Add(values ...E) {
+	for _, value := range values {
+		s.clock++
+		tag := fmt.Sprintf("%s:%d", s.replica, s.clock)
+		tags, ok := s.entries[value]
+		if !ok {
+			tags = make(map[string]struct{})
+			s.entries[value] = tags
+		}
+		tags[tag] = struct{}{}
+	}
+}
+
+// Remove removes the specific element, only the additions observed by this replica are removed
+func (s *ORSet[E]) Remove(value E) {
+	tags, ok := s.entries[value]
+	if !ok {
+		return
+	}
+	for tag := range tags {
+		s.tombstones[tag] = struct{}{}
+	}
+	delete(s.entries, value)
+}
+
+// Merge merges the state of another replica into the set
+func (s *ORSet[E]) Merge(other *ORSet[E]) {
+	for tag := range other.tombstones {
+		s.tombstones[tag] = struct{}{}
+	}
+	for value, tags := range other.entries {
+		local, ok := s.entries[value]
+		if !ok {
+			local = make(map[string]struct{})
+			s.entries[value] = local
+		}
+		for tag := range tags {
+			local[tag] = struct{}{}
+		}
+	}
+	for value, tags := range s.entries {
+		for tag := range tags {
+			if _, removed := s.tombstones[tag]; removed {
+				delete(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			delete(s.entries, value)
+		}
+	}
+}
+
+// Each runs callback for each element, it breaks when callback returns false
+func (s *ORSet[E]) Each(callback func(_ int, value E) bool) {
+	for value := range s.entries {
+		if !callback(-1, value) {
+			break
+		}
+	}
+}
+
+// ToArray converts to array
+func (s *ORSet[E]) ToArray() []E {
+	var values []E
+	for value := range s.entries {
+		values = append(values, value)
+	}
+	return values
+}
+
+// ToJSON converts to json
+func (s *ORSet[E]) ToJSON() ([]byte, error) {
+	container := orSetJSON[E]{
+		Replica:    s.replica,
+		Clock:      s.clock,
+		Entries:    make([]orSetEntry[E], 0, len(s.entries)),
+		Tombstones: make([]string, 0, len(s.tombstones)),
+	}
+	for value, tags := range s.entries {
+		entry := orSetEntry[E]{Value: value}
+		for tag := range tags {
+			entry.Tags = append(entry.Tags, tag)
+		}
+		container.Entries = append(container.Entries, entry)
+	}
+	for tag := range s.tombstones {
+		container.Tombstones = append(container.Tombstones, tag)
+	}
+	return json.Marshal(container)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *ORSet[E]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (s *ORSet[E]) UnmarshalJSON(data []byte) error {
+	var container orSetJSON[E]
+	if err := json.Unmarshal(data, &container); err != nil {
+		return err
+	}
+	s.replica = container.Replica
+	s.clock = container.Clock
+	s.entries = make(map[E]map[string]struct{})
+	s.tombstones = make(map[string]struct{})
+	for _, entry := range container.Entries {
+		tags := make(map[string]struct{})
+		for _, tag := range entry.Tags {
+			tags[tag] = struct{}{}
+		}
+		s.entries[entry.Value] = tags
+	}
+	for _, tag := range container.Tombstones {
+		s.tombstones[tag] = struct{}{}
+	}
+	return nil
+}
+
+// String converts to string
+func (s *ORSet[E]) String() string {
+	return format.Values("ORSet", s.Count(), func(yield func(value E) bool) {
+		s.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	})
+}
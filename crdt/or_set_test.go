@@ -0,0 +1,73 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestORSet_Add(t *testing.T) {
+	set := NewORSet("a", 1, 2)
+	set.Add(2, 3)
+	assert.Equal(t, int64(3), set.Count())
+	assert.True(t, set.Contains(3))
+}
+
+func TestORSet_Remove(t *testing.T) {
+	set := NewORSet("a", 1, 2)
+	set.Remove(1)
+	assert.False(t, set.Contains(1))
+	assert.ElementsMatch(t, []int{2}, set.ToArray())
+}
+
+func TestORSet_Merge(t *testing.T) {
+	t.Run("union", func(t *testing.T) {
+		a := NewORSet("a", 1)
+		b := NewORSet("b", 2)
+		a.Merge(b)
+		assert.ElementsMatch(t, []int{1, 2}, a.ToArray())
+	})
+
+	t.Run("observed remove", func(t *testing.T) {
+		a := NewORSet("a", 1)
+		b := NewORSet[int]("b")
+		b.Merge(a)
+		b.Remove(1)
+		a.Merge(b)
+		assert.False(t, a.Contains(1))
+	})
+
+	t.Run("add wins", func(t *testing.T) {
+		a := NewORSet("a", 1)
+		b := NewORSet[int]("b")
+		b.Merge(a)
+		b.Remove(1)
+		a.Add(1)
+		a.Merge(b)
+		b.Merge(a)
+		assert.True(t, a.Contains(1))
+		assert.True(t, b.Contains(1))
+	})
+}
+
+func TestORSet_JSON(t *testing.T) {
+	a := NewORSet("a", "x", "y")
+	a.Remove("x")
+	jsonBytes, err := json.Marshal(a)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	b := new(ORSet[string])
+	if err := json.Unmarshal(jsonBytes, b); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "a", b.Replica())
+	assert.ElementsMatch(t, []string{"y"}, b.ToArray())
+
+	// the tombstone survives serialization
+	c := NewORSet[string]("c")
+	c.entries["x"] = map[string]struct{}{"a:1": {}}
+	c.Merge(b)
+	assert.False(t, c.Contains("x"))
+}
@@ -0,0 +1,229 @@
+// Package debug provides an HTTP handler which inspects the live collections of a process,
+// such as its queues and caches during an incident.
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/registry"
+)
+
+// DefaultLimit the number of items of a page when the request does not ask for a limit
+const DefaultLimit = 50
+
+// Collection collection which can be inspected by the handler, every collection of the module implements it
+type Collection interface {
+	collection.Introspectable
+	MarshalJSON() ([]byte, error)
+}
+
+// StatsReporter collection which reports statistics besides its size, such as hit rates,
+// wrap a collection to add the statistics it is inspected with
+type StatsReporter interface {
+	DebugStats() map[string]any
+}
+
+// Summary description of a registered collection
+type Summary struct {
+	Name        string         `json:"name"`
+	Kind        string         `json:"kind"`
+	ElementType string         `json:"elementType"`
+	KeyType     string         `json:"keyType,omitempty"`
+	Count       int64          `json:"count"`
+	Stats       map[string]any `json:"stats,omitempty"`
+}
+
+// Item element of a collection, maps also have the key of the value
+type Item struct {
+	Key   json.RawMessage `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Page page of the items of a collection
+type Page struct {
+	Summary
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Items  []Item `json:"items"`
+}
+
+// NewHandler new handler which inspects the collections of the registry
+func NewHandler(collections *registry.Registry[Collection]) *Handler {
+	h := new(Handler)
+	h.collections = collections
+	h.limit = DefaultLimit
+	return h
+}
+
+// Handler read-only [http.Handler] which inspects the collections of a registry:
+//   - GET / returns the summaries of the collections in the order of names.
+//   - GET /{name}?offset=0&limit=50 returns the summary of the collection and a page of its items.
+//
+// A collection which has an RLock method is read locked while it is inspected.
+// The whole collection is encoded for every page, so keep it for debugging rather than serving traffic.
+// Mount it with [http.StripPrefix] under a path which is not exposed publicly.
+type Handler struct {
+	collections *registry.Registry[Collection]
+	limit       int
+	redact      func(name string, item Item) Item
+}
+
+// Limit sets the number of items of a page when the request does not ask for a limit
+func (h *Handler) Limit(limit int) *Handler {
+	h.limit = limit
+	return h
+}
+
+// Redact sets the callback which rewrites every item of the collection of the name before it is returned,
+// e.g. to mask credentials or personal data
+func (h *Handler) Redact(callback func(name string, item Item) Item) *Handler {
+	h.redact = callback
+	return h
+}
+
+// ServeHTTP implements [http.Handler]
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		summaries := make([]Summary, 0, h.collections.Count())
+		h.collections.Each(func(name string, c Collection) bool {
+			unlock := rlock(c)
+			summaries = append(summaries, summarize(name, c))
+			unlock()
+			return true
+		})
+		writeJSON(w, summaries)
+		return
+	}
+	c, ok := h.collections.Get(name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("collection %q not found", name), http.StatusNotFound)
+		return
+	}
+	offset, err := param(r, "offset", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := param(r, "limit", h.limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	unlock := rlock(c)
+	page := &Page{Summary: summarize(name, c), Offset: offset, Limit: limit}
+	data, err := c.MarshalJSON()
+	unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	items, err := split(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	offset = min(offset, len(items))
+	page.Items = items[offset : offset+min(max(limit, 0), len(items)-offset)]
+	if h.redact != nil {
+		for i, item := range page.Items {
+			page.Items[i] = h.redact(name, item)
+		}
+	}
+	writeJSON(w, page)
+}
+
+// rlock read locks the collection when it has an RLock method, it returns the function to unlock it
+func rlock(c Collection) func() {
+	if locker, ok := c.(interface {
+		RLock()
+		RUnlock()
+	}); ok {
+		locker.RLock()
+		return locker.RUnlock
+	}
+	return func() {}
+}
+
+func summarize(name string, c Collection) Summary {
+	summary := Summary{Name: name, Kind: c.Kind().String(), ElementType: c.ElementType(), Count: c.Count()}
+	if keyed, ok := c.(collection.KeyedIntrospectable); ok {
+		summary.KeyType = keyed.KeyType()
+	}
+	if reporter, ok := c.(StatsReporter); ok {
+		summary.Stats = reporter.DebugStats()
+	}
+	return summary
+}
+
+// split splits the JSON of a collection into its items, an object is split into its members in order
+func split(data []byte) ([]Item, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return []Item{}, nil
+	}
+	switch data[0] {
+	case '[':
+		var values []json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		items := make([]Item, len(values))
+		for i, value := range values {
+			items[i] = Item{Value: value}
+		}
+		return items, nil
+	case '{':
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		items := []Item{}
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, err := json.Marshal(token)
+			if err != nil {
+				return nil, err
+			}
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			items = append(items, Item{Key: key, Value: value})
+		}
+		return items, nil
+	default:
+		return []Item{{Value: data}}, nil
+	}
+}
+
+func param(r *http.Request, name string, value int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return value, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return n, nil
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
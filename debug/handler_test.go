@@ -0,0 +1,123 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/queue"
+	"github.com/gopi-frame/collection/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+type statsQueue struct {
+	*queue.LinkedQueue[string]
+}
+
+func (q statsQueue) DebugStats() map[string]any {
+	return map[string]any{"dropped": 3}
+}
+
+func newTestHandler() *Handler {
+	collections := registry.NewRegistry[Collection](registry.Reject)
+	collections.MustRegister("jobs", statsQueue{queue.NewLinkedQueue("a", "b", "c")})
+	collections.MustRegister("numbers", list.NewList(1, 2, 3, 4, 5))
+	sessions := kv.NewOrderedMap[string, string]()
+	sessions.Set("alice", "token-1")
+	sessions.Set("bob", "token-2")
+	collections.Namespace("cache").MustRegister("sessions", sessions)
+	return NewHandler(collections)
+}
+
+func get(t *testing.T, h http.Handler, target string, value any) int {
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	if recorder.Code == http.StatusOK {
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), value))
+	}
+	return recorder.Code
+}
+
+func TestHandler_Index(t *testing.T) {
+	var summaries []Summary
+	assert.Equal(t, http.StatusOK, get(t, newTestHandler(), "/", &summaries))
+	assert.Equal(t, []Summary{
+		{Name: "cache/sessions", Kind: "map", ElementType: "string", KeyType: "string", Count: 2},
+		{Name: "jobs", Kind: "queue", ElementType: "string", Count: 3, Stats: map[string]any{"dropped": float64(3)}},
+		{Name: "numbers", Kind: "list", ElementType: "int", Count: 5},
+	}, summaries)
+}
+
+func TestHandler_Page(t *testing.T) {
+	h := newTestHandler().Limit(2)
+	var page Page
+	assert.Equal(t, http.StatusOK, get(t, h, "/numbers?offset=1", &page))
+	assert.Equal(t, int64(5), page.Count)
+	assert.Equal(t, 2, page.Limit)
+	assert.Equal(t, []Item{{Value: json.RawMessage("2")}, {Value: json.RawMessage("3")}}, page.Items)
+
+	page = Page{}
+	assert.Equal(t, http.StatusOK, get(t, h, "/numbers?offset=10&limit=5", &page))
+	assert.Empty(t, page.Items)
+
+	page = Page{}
+	assert.Equal(t, http.StatusOK, get(t, h, "/numbers?offset=1&limit=9223372036854775807", &page))
+	assert.Len(t, page.Items, 4)
+
+	assert.Equal(t, http.StatusBadRequest, get(t, h, "/numbers?limit=-1", &page))
+	assert.Equal(t, http.StatusNotFound, get(t, h, "/missing", &page))
+}
+
+func TestHandler_Redact(t *testing.T) {
+	h := newTestHandler().Redact(func(name string, item Item) Item {
+		if name == "cache/sessions" {
+			item.Value = json.RawMessage(`"***"`)
+		}
+		return item
+	})
+	var page Page
+	assert.Equal(t, http.StatusOK, get(t, h, "/cache/sessions", &page))
+	assert.Equal(t, []Item{
+		{Key: json.RawMessage(`"alice"`), Value: json.RawMessage(`"***"`)},
+		{Key: json.RawMessage(`"bob"`), Value: json.RawMessage(`"***"`)},
+	}, page.Items)
+}
+
+func TestHandler_Method(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/numbers", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestHandler_ConcurrentReads(t *testing.T) {
+	collections := registry.NewRegistry[Collection](registry.Reject)
+	cache := kv.NewLRUCache[string, int](10)
+	cache.PutWithTTL("a", 1, time.Nanosecond)
+	cache.Put("b", 2)
+	collections.MustRegister("cache", cache)
+	sessions := kv.NewExpiringMap[string, int](0)
+	sessions.SetWithTTL("a", 1, time.Nanosecond)
+	sessions.Set("b", 2)
+	collections.MustRegister("sessions", sessions)
+	h := NewHandler(collections)
+	time.Sleep(time.Millisecond)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, name := range []string{"cache", "sessions"} {
+				var page Page
+				assert.Equal(t, http.StatusOK, get(t, h, "/"+name, &page))
+				assert.Equal(t, []Item{{Key: json.RawMessage(`"b"`), Value: json.RawMessage("2")}}, page.Items)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,124 @@
+package dedup
+
+import (
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// Shingles returns the lower-cased word n-grams of the text, the features which near-duplicate texts share.
+// A text with less than n words is one shingle.
+func Shingles(text string, n int) []string {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return nil
+	}
+	n = min(max(n, 1), len(words))
+	shingles := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+n], " "))
+	}
+	return shingles
+}
+
+// hashString returns the 64-bit FNV-1a hash of the string
+func hashString(value string) uint64 {
+	hash := uint64(14695981039346656037)
+	for i := 0; i < len(value); i++ {
+		hash ^= uint64(value[i])
+		hash *= 1099511628211
+	}
+	return hash
+}
+
+// mix scrambles the bits of the value with the finalizer of splitmix64
+func mix(value uint64) uint64 {
+	value ^= value >> 30
+	value *= 0xbf58476d1ce4e5b9
+	value ^= value >> 27
+	value *= 0x94d049bb133111eb
+	value ^= value >> 31
+	return value
+}
+
+// SimHash returns the 64-bit SimHash of the features,
+// the signatures of similar feature sets differ in few bits, see [Hamming]
+func SimHash(features []string) uint64 {
+	var weights [64]int
+	for _, feature := range features {
+		hash := mix(hashString(feature))
+		for bit := range weights {
+			if hash&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var signature uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			signature |= 1 << bit
+		}
+	}
+	return signature
+}
+
+// Hamming returns the number of bits which differ between the signatures
+func Hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// MinSignature MinHash signature of a feature set
+type MinSignature []uint64
+
+// Similarity estimates the Jaccard similarity of the feature sets of the signatures,
+// signatures of different sizes are not similar
+func (s MinSignature) Similarity(other MinSignature) float64 {
+	if len(s) != len(other) || len(s) == 0 {
+		return 0
+	}
+	equal := 0
+	for i, value := range s {
+		if value == other[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(s))
+}
+
+// NewMinHasher new MinHash hasher producing signatures of size values,
+// signatures are only comparable when made by hashers of the same size and seed
+func NewMinHasher(size int, seed uint64) *MinHasher {
+	hasher := &MinHasher{seeds: make([]uint64, size)}
+	for i := range hasher.seeds {
+		seed += 0x9e3779b97f4a7c15
+		hasher.seeds[i] = mix(seed)
+	}
+	return hasher
+}
+
+// MinHasher makes MinHash signatures, the larger the signature the better the similarity estimate
+type MinHasher struct {
+	seeds []uint64
+}
+
+// Size returns the size of the signatures
+func (h *MinHasher) Size() int {
+	return len(h.seeds)
+}
+
+// Signature returns the MinHash signature of the features
+func (h *MinHasher) Signature(features []string) MinSignature {
+	signature := make(MinSignature, len(h.seeds))
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for _, feature := range features {
+		hash := hashString(feature)
+		for i, seed := range h.seeds {
+			signature[i] = min(signature[i], mix(hash^seed))
+		}
+	}
+	return signature
+}
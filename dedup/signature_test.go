@@ -0,0 +1,35 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShingles(t *testing.T) {
+	assert.Equal(t, []string{"the quick", "quick brown", "brown fox"}, Shingles("The quick  brown fox", 2))
+	assert.Equal(t, []string{"fox"}, Shingles("fox", 3))
+	assert.Nil(t, Shingles(" ", 2))
+}
+
+func TestSimHash(t *testing.T) {
+	a := SimHash(Shingles("the quick brown fox jumps over the lazy dog near the river bank today", 2))
+	b := SimHash(Shingles("the quick brown fox jumps over the lazy dog near the river bank", 2))
+	c := SimHash(Shingles("completely different text about databases and their indexes", 2))
+	assert.Less(t, Hamming(a, b), Hamming(a, c))
+	assert.Equal(t, 0, Hamming(a, a))
+	assert.Equal(t, uint64(0), SimHash(nil))
+}
+
+func TestMinHasher_Signature(t *testing.T) {
+	hasher := NewMinHasher(128, 1)
+	assert.Equal(t, 128, hasher.Size())
+	a := hasher.Signature([]string{"a", "b", "c", "d"})
+	b := hasher.Signature([]string{"a", "b", "c", "e"})
+	c := hasher.Signature([]string{"x", "y", "z"})
+	assert.Equal(t, 1.0, a.Similarity(a))
+	assert.InDelta(t, 0.6, a.Similarity(b), 0.15)
+	assert.Less(t, a.Similarity(c), 0.1)
+	assert.Equal(t, 0.0, a.Similarity(NewMinHasher(64, 1).Signature([]string{"a"})))
+	assert.Equal(t, a, NewMinHasher(128, 1).Signature([]string{"d", "c", "b", "a"}))
+}
@@ -0,0 +1,194 @@
+package dedup
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection/bktree"
+)
+
+// NewSimHashIndex new index of SimHash signatures,
+// signatures within maxDistance differing bits are considered similar
+func NewSimHashIndex[K comparable](maxDistance int) *SimHashIndex[K] {
+	index := new(SimHashIndex[K])
+	index.maxDistance = maxDistance
+	index.signatures = bktree.New(Hamming)
+	index.keys = make(map[uint64][]K)
+	return index
+}
+
+// SimHashIndex remembers the SimHash signatures of documents and finds the documents similar to a signature.
+// It is safe for concurrent use.
+type SimHashIndex[K comparable] struct {
+	lock        sync.Mutex
+	maxDistance int
+	signatures  *bktree.Tree[uint64]
+	keys        map[uint64][]K
+	size        int64
+}
+
+// Add remembers the signature of the document
+func (i *SimHashIndex[K]) Add(key K, signature uint64) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.add(key, signature)
+}
+
+func (i *SimHashIndex[K]) add(key K, signature uint64) {
+	i.signatures.Add(signature)
+	i.keys[signature] = append(i.keys[signature], key)
+	i.size++
+}
+
+// Similar returns the documents similar to the signature, the closest first
+func (i *SimHashIndex[K]) Similar(signature uint64) []K {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.similar(signature)
+}
+
+func (i *SimHashIndex[K]) similar(signature uint64) []K {
+	var keys []K
+	for _, result := range i.signatures.Search(signature, i.maxDistance) {
+		keys = append(keys, i.keys[result.Value]...)
+	}
+	return keys
+}
+
+// SeenSimilar returns whether a similar document has been seen,
+// the document will be remembered if it has not.
+func (i *SimHashIndex[K]) SeenSimilar(key K, signature uint64) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if len(i.similar(signature)) > 0 {
+		return true
+	}
+	i.add(key, signature)
+	return false
+}
+
+// Count returns the number of remembered documents
+func (i *SimHashIndex[K]) Count() int64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.size
+}
+
+// NewMinHashIndex new index of MinHash signatures, documents with an estimated Jaccard similarity
+// of at least threshold are considered similar.
+// Signatures are split into bands which are hashed into buckets, and only the documents sharing a bucket
+// with the query are compared. More bands find more of the less similar documents at the cost of more candidates.
+func NewMinHashIndex[K comparable](bands int, threshold float64) *MinHashIndex[K] {
+	index := new(MinHashIndex[K])
+	index.bands = max(bands, 1)
+	index.threshold = threshold
+	index.buckets = make(map[uint64][]K)
+	index.signatures = make(map[K]MinSignature)
+	return index
+}
+
+// MinHashIndex remembers the MinHash signatures of documents and finds the documents similar to a signature
+// with locality-sensitive hashing. It is safe for concurrent use.
+type MinHashIndex[K comparable] struct {
+	lock       sync.Mutex
+	bands      int
+	threshold  float64
+	buckets    map[uint64][]K
+	signatures map[K]MinSignature
+}
+
+// bucketsOf returns the bucket of every band of the signature
+func (i *MinHashIndex[K]) bucketsOf(signature MinSignature) []uint64 {
+	rows := max(len(signature)/i.bands, 1)
+	var buckets []uint64
+	for band := 0; band*rows < len(signature); band++ {
+		hash := mix(uint64(band) + 1)
+		for _, value := range signature[band*rows : min((band+1)*rows, len(signature))] {
+			hash = mix(hash ^ value)
+		}
+		buckets = append(buckets, hash)
+	}
+	return buckets
+}
+
+// Add remembers the signature of the document, it replaces the previous signature of the key
+func (i *MinHashIndex[K]) Add(key K, signature MinSignature) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.add(key, signature)
+}
+
+func (i *MinHashIndex[K]) add(key K, signature MinSignature) {
+	if previous, ok := i.signatures[key]; ok {
+		for _, bucket := range i.bucketsOf(previous) {
+			i.buckets[bucket] = slices.DeleteFunc(i.buckets[bucket], func(k K) bool { return k == key })
+			if len(i.buckets[bucket]) == 0 {
+				delete(i.buckets, bucket)
+			}
+		}
+	}
+	i.signatures[key] = signature
+	for _, bucket := range i.bucketsOf(signature) {
+		i.buckets[bucket] = append(i.buckets[bucket], key)
+	}
+}
+
+// Similar returns the documents similar to the signature, the most similar first
+func (i *MinHashIndex[K]) Similar(signature MinSignature) []K {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.similar(signature)
+}
+
+func (i *MinHashIndex[K]) similar(signature MinSignature) []K {
+	type candidate struct {
+		key        K
+		similarity float64
+	}
+	var candidates []candidate
+	seen := make(map[K]struct{})
+	for _, bucket := range i.bucketsOf(signature) {
+		for _, key := range i.buckets[bucket] {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if similarity := signature.Similarity(i.signatures[key]); similarity >= i.threshold {
+				candidates = append(candidates, candidate{key, similarity})
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.similarity > b.similarity:
+			return -1
+		case a.similarity < b.similarity:
+			return 1
+		}
+		return 0
+	})
+	keys := make([]K, len(candidates))
+	for j, c := range candidates {
+		keys[j] = c.key
+	}
+	return keys
+}
+
+// SeenSimilar returns whether a similar document has been seen,
+// the document will be remembered if it has not.
+func (i *MinHashIndex[K]) SeenSimilar(key K, signature MinSignature) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if len(i.similar(signature)) > 0 {
+		return true
+	}
+	i.add(key, signature)
+	return false
+}
+
+// Count returns the number of remembered documents
+func (i *MinHashIndex[K]) Count() int64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return int64(len(i.signatures))
+}
@@ -0,0 +1,41 @@
+package dedup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gopi-frame/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimHashIndex_SeenSimilar(t *testing.T) {
+	index := NewSimHashIndex[string](3)
+	assert.False(t, index.SeenSimilar("a", 0b1111))
+	assert.True(t, index.SeenSimilar("b", 0b1000))
+	assert.False(t, index.SeenSimilar("c", 0b1111<<8))
+	index.Add("d", 0b1111)
+	assert.Equal(t, int64(3), index.Count())
+	assert.Equal(t, []string{"a", "d"}, index.Similar(0b0111))
+	assert.Empty(t, index.Similar(0b1111<<20))
+}
+
+func TestMinHashIndex_SeenSimilar(t *testing.T) {
+	hasher := NewMinHasher(64, 7)
+	index := NewMinHashIndex[int](16, 0.5)
+	words := func(from, to int) []string {
+		s := set.NewSet[string]()
+		for i := from; i < to; i++ {
+			s.Push(fmt.Sprint("w", i))
+		}
+		return s.ToArray()
+	}
+	assert.False(t, index.SeenSimilar(1, hasher.Signature(words(0, 100))))
+	assert.True(t, index.SeenSimilar(2, hasher.Signature(words(5, 100))))
+	assert.False(t, index.SeenSimilar(3, hasher.Signature(words(200, 300))))
+	assert.Equal(t, int64(2), index.Count())
+	assert.Equal(t, []int{3}, index.Similar(hasher.Signature(words(210, 300))))
+
+	index.Add(3, hasher.Signature(words(400, 500)))
+	assert.Empty(t, index.Similar(hasher.Signature(words(210, 300))))
+	assert.Equal(t, int64(2), index.Count())
+}
@@ -0,0 +1,32 @@
+package dedup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection/internal/stress"
+)
+
+func TestWindow_Stress(t *testing.T) {
+	const workers, keys = 8, 100
+	window := NewWindow[int](keys, time.Hour)
+	recorder := stress.Exactly[int]()
+	stop := stress.Watch(t, func() error {
+		if count := window.Count(); count > keys {
+			return fmt.Errorf("count %d exceeds size %d", count, keys)
+		}
+		return nil
+	})
+	stress.Run(t, workers, keys, func(_, key int) {
+		if !window.SeenBefore(key) {
+			recorder.Record(key)
+		}
+	})
+	stop()
+	var expected []int
+	for key := 0; key < keys; key++ {
+		expected = append(expected, key)
+	}
+	recorder.Verify(t, expected...)
+}
@@ -0,0 +1,116 @@
+package dedup
+
+import (
+	listlib "container/list"
+	"sync"
+	"time"
+)
+
+type windowEntry[K comparable] struct {
+	key    K
+	seenAt time.Time
+}
+
+// NewWindow new dedup window.
+// size limits the number of remembered keys and ttl limits how long a key is remembered,
+// a non-positive value means no limit.
+func NewWindow[K comparable](size int, ttl time.Duration) *Window[K] {
+	window := new(Window[K])
+	window.size = size
+	window.ttl = ttl
+	window.keys = make(map[K]*listlib.Element)
+	window.order = listlib.New()
+	window.now = time.Now
+	return window
+}
+
+// Window remembers recently seen keys, the oldest keys are forgotten
+// when the window is full or when they are expired.
+type Window[K comparable] struct {
+	lock  sync.Mutex
+	size  int
+	ttl   time.Duration
+	keys  map[K]*listlib.Element
+	order *listlib.List
+	now   func() time.Time
+}
+
+func (w *Window[K]) evict(now time.Time) {
+	if w.ttl > 0 {
+		for e := w.order.Front(); e != nil; e = w.order.Front() {
+			if now.Sub(e.Value.(*windowEntry[K]).seenAt) < w.ttl {
+				break
+			}
+			w.remove(e)
+		}
+	}
+	if w.size > 0 {
+		for w.order.Len() > w.size {
+			w.remove(w.order.Front())
+		}
+	}
+}
+
+func (w *Window[K]) remove(e *listlib.Element) {
+	delete(w.keys, e.Value.(*windowEntry[K]).key)
+	w.order.Remove(e)
+}
+
+// SeenBefore returns whether the key has been seen in the window,
+// the key will be remembered if it has not been seen.
+func (w *Window[K]) SeenBefore(key K) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	now := w.now()
+	w.evict(now)
+	if _, ok := w.keys[key]; ok {
+		return true
+	}
+	w.keys[key] = w.order.PushBack(&windowEntry[K]{key: key, seenAt: now})
+	w.evict(now)
+	return false
+}
+
+// Contains returns whether the key has been seen in the window without remembering it.
+func (w *Window[K]) Contains(key K) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.evict(w.now())
+	_, ok := w.keys[key]
+	return ok
+}
+
+// Forget removes the key from the window
+func (w *Window[K]) Forget(key K) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if e, ok := w.keys[key]; ok {
+		w.remove(e)
+	}
+}
+
+// Count returns the number of remembered keys
+func (w *Window[K]) Count() int64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.evict(w.now())
+	return int64(len(w.keys))
+}
+
+// IsEmpty returns whether the window is empty
+func (w *Window[K]) IsEmpty() bool {
+	return w.Count() == 0
+}
+
+// IsNotEmpty returns whether the window is not empty
+func (w *Window[K]) IsNotEmpty() bool {
+	return !w.IsEmpty()
+}
+
+// Clear clears the window
+func (w *Window[K]) Clear() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.keys = make(map[K]*listlib.Element)
+	w.order.Init()
+}
@@ -0,0 +1,80 @@
+package dedup
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindow_SeenBefore(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		window := NewWindow[string](0, 0)
+		assert.False(t, window.SeenBefore("a"))
+		assert.True(t, window.SeenBefore("a"))
+		assert.False(t, window.SeenBefore("b"))
+		assert.Equal(t, int64(2), window.Count())
+	})
+
+	t.Run("size", func(t *testing.T) {
+		window := NewWindow[int](2, 0)
+		assert.False(t, window.SeenBefore(1))
+		assert.False(t, window.SeenBefore(2))
+		assert.False(t, window.SeenBefore(3))
+		assert.False(t, window.Contains(1))
+		assert.True(t, window.SeenBefore(2))
+		assert.True(t, window.SeenBefore(3))
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		now := time.Now()
+		window := NewWindow[int](0, time.Second)
+		window.now = func() time.Time {
+			return now
+		}
+		assert.False(t, window.SeenBefore(1))
+		now = now.Add(500 * time.Millisecond)
+		assert.False(t, window.SeenBefore(2))
+		assert.True(t, window.SeenBefore(1))
+		now = now.Add(500 * time.Millisecond)
+		assert.False(t, window.Contains(1))
+		assert.True(t, window.Contains(2))
+		assert.False(t, window.SeenBefore(1))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		window := NewWindow[int](0, 0)
+		var firstSeen atomic.Int64
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if !window.SeenBefore(1) {
+					firstSeen.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(1), firstSeen.Load())
+	})
+}
+
+func TestWindow_Forget(t *testing.T) {
+	window := NewWindow[int](0, 0)
+	window.SeenBefore(1)
+	window.Forget(1)
+	assert.False(t, window.SeenBefore(1))
+}
+
+func TestWindow_Clear(t *testing.T) {
+	window := NewWindow[int](0, 0)
+	window.SeenBefore(1)
+	window.SeenBefore(2)
+	assert.True(t, window.IsNotEmpty())
+	window.Clear()
+	assert.True(t, window.IsEmpty())
+	assert.False(t, window.SeenBefore(1))
+}
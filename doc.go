@@ -0,0 +1,3 @@
+// Package collection defines the errors shared by the collection packages,
+// the collections themselves live in the sub packages.
+package collection
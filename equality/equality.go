@@ -0,0 +1,186 @@
+// Package equality provides pluggable element equality and hashing.
+//
+// An [Equaler] decides whether two elements are equal, a [Hasher] additionally hashes elements
+// so hashing structures such as set.HashSet can use the same custom equality.
+// Equal elements must always write the same bytes into the hash.
+package equality
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gopi-frame/collection/internal/equal"
+)
+
+// Equaler decides whether two elements are equal
+type Equaler[E any] interface {
+	Equal(a, b E) bool
+}
+
+// Hasher equality with a hash function consistent with it
+type Hasher[E any] interface {
+	Equaler[E]
+	// Hash writes the element into the hash, equal elements must write the same bytes
+	Hash(hash *maphash.Hash, value E)
+}
+
+// EqualFunc adapts a function to [Equaler]
+type EqualFunc[E any] func(a, b E) bool
+
+// Equal implements [Equaler]
+func (f EqualFunc[E]) Equal(a, b E) bool {
+	return f(a, b)
+}
+
+// New returns the hasher of the equality and hash functions
+func New[E any](equal func(a, b E) bool, hash func(hash *maphash.Hash, value E)) Hasher[E] {
+	return funcHasher[E]{equal: equal, hash: hash}
+}
+
+type funcHasher[E any] struct {
+	equal func(a, b E) bool
+	hash  func(hash *maphash.Hash, value E)
+}
+
+func (h funcHasher[E]) Equal(a, b E) bool {
+	return h.equal(a, b)
+}
+
+func (h funcHasher[E]) Hash(hash *maphash.Hash, value E) {
+	h.hash(hash, value)
+}
+
+// Sum returns the hash of the element with the seed
+func Sum[E any](hasher Hasher[E], seed maphash.Seed, value E) uint64 {
+	hash := new(maphash.Hash)
+	hash.SetSeed(seed)
+	hasher.Hash(hash, value)
+	return hash.Sum64()
+}
+
+// Deep returns the equality used by the collections, see the documentation of the collections about reflect
+func Deep[E any]() Equaler[E] {
+	return EqualFunc[E](equal.Equal[E])
+}
+
+// Comparable returns the hasher of the == operator.
+// Values are hashed field by field and element by element, so that -0 and 0 hash alike wherever they are nested,
+// pointers and channels are hashed by address and interfaces by their dynamic value.
+// Built with TinyGo or the collection_noreflect build tag, values of other than the predeclared types are hashed
+// by their %#v formatting instead, so -0 and 0 nested in them hash apart.
+func Comparable[E comparable]() Hasher[E] {
+	return comparableHasher[E]{}
+}
+
+type comparableHasher[E comparable] struct{}
+
+func (comparableHasher[E]) Equal(a, b E) bool {
+	return a == b
+}
+
+func (comparableHasher[E]) Hash(hash *maphash.Hash, value E) {
+	writeComparable(hash, value)
+}
+
+func writeComparable(hash *maphash.Hash, value any) {
+	var buf [8]byte
+	switch v := value.(type) {
+	case string:
+		hash.WriteString(v)
+		return
+	case bool:
+		if v {
+			hash.WriteByte(1)
+		} else {
+			hash.WriteByte(0)
+		}
+		return
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case int8:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case int16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case int32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case uint:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case uint8:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case uint16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case uint32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], v)
+	case uintptr:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	case float32:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(float64(v)))
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(v))
+	default:
+		writeOther(hash, value)
+		return
+	}
+	_, _ = hash.Write(buf[:])
+}
+
+// floatBits returns the bits of the float, -0 is normalized to 0 since they are equal
+func floatBits(v float64) uint64 {
+	if v == 0 {
+		return 0
+	}
+	return math.Float64bits(v)
+}
+
+// Field returns the hasher which compares elements by the key
+func Field[E any, K comparable](key func(E) K) Hasher[E] {
+	return fieldHasher[E, K]{key: key}
+}
+
+type fieldHasher[E any, K comparable] struct {
+	key func(E) K
+}
+
+func (h fieldHasher[E, K]) Equal(a, b E) bool {
+	return h.key(a) == h.key(b)
+}
+
+func (h fieldHasher[E, K]) Hash(hash *maphash.Hash, value E) {
+	writeComparable(hash, h.key(value))
+}
+
+// FoldCase returns the hasher of strings equal under Unicode case folding, see [strings.EqualFold]
+func FoldCase() Hasher[string] {
+	return foldCaseHasher{}
+}
+
+type foldCaseHasher struct{}
+
+func (foldCaseHasher) Equal(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+func (foldCaseHasher) Hash(hash *maphash.Hash, value string) {
+	buf := make([]byte, 0, len(value))
+	for _, r := range value {
+		buf = utf8.AppendRune(buf, foldRune(r))
+	}
+	_, _ = hash.Write(buf)
+}
+
+// foldRune returns the least rune of the case folding orbit of the rune
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+	return folded
+}
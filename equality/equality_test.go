@@ -0,0 +1,81 @@
+package equality
+
+import (
+	"hash/maphash"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/stretchr/testify/assert"
+)
+
+var seed = maphash.MakeSeed()
+
+func TestEqualFunc_Equal(t *testing.T) {
+	equaler := EqualFunc[string](func(a, b string) bool { return len(a) == len(b) })
+	assert.True(t, equaler.Equal("ab", "cd"))
+	assert.False(t, equaler.Equal("a", "cd"))
+}
+
+func TestNew(t *testing.T) {
+	hasher := New(func(a, b string) bool {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}, func(hash *maphash.Hash, value string) {
+		hash.WriteString(strings.TrimSpace(value))
+	})
+	assert.True(t, hasher.Equal(" a", "a "))
+	assert.Equal(t, Sum(hasher, seed, " a"), Sum(hasher, seed, "a "))
+}
+
+func TestDeep(t *testing.T) {
+	assert.Equal(t, equal.Reflect, Deep[[]int]().Equal([]int{1, 2}, []int{1, 2}))
+	assert.False(t, Deep[[]int]().Equal([]int{1, 2}, []int{2, 1}))
+	assert.True(t, Deep[string]().Equal("a", "a"))
+}
+
+func TestComparable(t *testing.T) {
+	type point struct{ X, Y int }
+	points := Comparable[point]()
+	assert.True(t, points.Equal(point{1, 2}, point{1, 2}))
+	assert.Equal(t, Sum(points, seed, point{1, 2}), Sum(points, seed, point{1, 2}))
+	assert.NotEqual(t, Sum(points, seed, point{1, 2}), Sum(points, seed, point{2, 1}))
+
+	floats := Comparable[float64]()
+	assert.True(t, floats.Equal(0, math.Copysign(0, -1)))
+	assert.Equal(t, Sum(floats, seed, 0), Sum(floats, seed, math.Copysign(0, -1)))
+	assert.NotEqual(t, Sum(Comparable[string](), seed, "a"), Sum(Comparable[string](), seed, "b"))
+
+	type celsius float64
+	type reading struct {
+		Value celsius
+		Scale [2]float32
+		Label any
+	}
+	readings := Comparable[reading]()
+	a, b := reading{0, [2]float32{0, 1}, 0.0}, reading{celsius(math.Copysign(0, -1)), [2]float32{float32(math.Copysign(0, -1)), 1}, math.Copysign(0, -1)}
+	assert.True(t, readings.Equal(a, b))
+	if equal.Reflect {
+		assert.Equal(t, Sum(readings, seed, a), Sum(readings, seed, b))
+	}
+	assert.NotEqual(t, Sum(readings, seed, a), Sum(readings, seed, reading{Value: 1}))
+}
+
+func TestField(t *testing.T) {
+	type user struct {
+		ID   int
+		Tags []string
+	}
+	byID := Field(func(u user) int { return u.ID })
+	assert.True(t, byID.Equal(user{ID: 1, Tags: []string{"a"}}, user{ID: 1}))
+	assert.Equal(t, Sum(byID, seed, user{ID: 1, Tags: []string{"a"}}), Sum(byID, seed, user{ID: 1}))
+}
+
+func TestFoldCase(t *testing.T) {
+	hasher := FoldCase()
+	for _, pair := range [][2]string{{"Go", "gO"}, {"ΣΑΣ", "σας"}, {"K", "K"}} {
+		assert.True(t, hasher.Equal(pair[0], pair[1]))
+		assert.Equal(t, Sum(hasher, seed, pair[0]), Sum(hasher, seed, pair[1]), pair)
+	}
+	assert.False(t, hasher.Equal("go", "goo"))
+}
@@ -0,0 +1,71 @@
+//go:build !tinygo && !collection_noreflect
+
+package equality
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"reflect"
+)
+
+// writeOther writes a value of a type other than the predeclared ones by its kind
+func writeOther(hash *maphash.Hash, value any) {
+	writeValue(hash, reflect.ValueOf(value))
+}
+
+// writeValue writes the value by its kind, it covers the kinds of comparable types
+func writeValue(hash *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	switch v.Kind() {
+	case reflect.Invalid:
+		hash.WriteByte(0)
+		return
+	case reflect.String:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Len()))
+		_, _ = hash.Write(buf[:])
+		hash.WriteString(v.String())
+		return
+	case reflect.Bool:
+		if v.Bool() {
+			hash.WriteByte(1)
+		} else {
+			hash.WriteByte(0)
+		}
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		binary.LittleEndian.PutUint64(buf[:], v.Uint())
+	case reflect.Float32, reflect.Float64:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(real(v.Complex())))
+		_, _ = hash.Write(buf[:])
+		binary.LittleEndian.PutUint64(buf[:], floatBits(imag(v.Complex())))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			hash.WriteByte(0)
+			return
+		}
+		hash.WriteByte(1)
+		writeValue(hash, v.Elem())
+		return
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeValue(hash, v.Index(i))
+		}
+		return
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeValue(hash, v.Field(i))
+		}
+		return
+	default:
+		_, _ = fmt.Fprintf(hash, "%#v", v)
+		return
+	}
+	_, _ = hash.Write(buf[:])
+}
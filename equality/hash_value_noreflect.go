@@ -0,0 +1,13 @@
+//go:build tinygo || collection_noreflect
+
+package equality
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// writeOther writes a value of a type other than the predeclared ones by its %#v formatting
+func writeOther(hash *maphash.Hash, value any) {
+	_, _ = fmt.Fprintf(hash, "%#v", value)
+}
@@ -0,0 +1,110 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrIndexOutOfRange the index is out of the range of the collection
+	ErrIndexOutOfRange = errors.New("collection: index out of range")
+	// ErrEmptyCollection the operation requires a non-empty collection
+	ErrEmptyCollection = errors.New("collection: empty collection")
+	// ErrCapacityExceeded the collection is full
+	ErrCapacityExceeded = errors.New("collection: capacity exceeded")
+	// ErrKeyNotFound the key does not exist in the collection
+	ErrKeyNotFound = errors.New("collection: key not found")
+	// ErrTypeMismatch the value is not of the expected type
+	ErrTypeMismatch = errors.New("collection: type mismatch")
+	// ErrQuotaExceeded the operation exceeds the quota of a key
+	ErrQuotaExceeded = errors.New("collection: quota exceeded")
+	// ErrClosed the collection is closed
+	ErrClosed = errors.New("collection: closed")
+)
+
+// NewRangeError new range error
+func NewRangeError(index, length int) *RangeError {
+	return &RangeError{Index: index, Length: length}
+}
+
+// RangeError error of an index out of range, it matches [ErrIndexOutOfRange]
+type RangeError struct {
+	Index  int
+	Length int
+}
+
+// Error implements [error]
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("collection: index %d out of range [0, %d)", e.Index, e.Length)
+}
+
+// Unwrap returns [ErrIndexOutOfRange]
+func (e *RangeError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
+
+// NewKeyError new key error
+func NewKeyError(key any) *KeyError {
+	return &KeyError{Key: key}
+}
+
+// KeyError error of a missing key, it matches [ErrKeyNotFound]
+type KeyError struct {
+	Key any
+}
+
+// Error implements [error]
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("collection: key %v not found", e.Key)
+}
+
+// Unwrap returns [ErrKeyNotFound]
+func (e *KeyError) Unwrap() error {
+	return ErrKeyNotFound
+}
+
+// NewTypeError new type error
+func NewTypeError(value any, expected string) *TypeError {
+	return &TypeError{Value: value, Expected: expected}
+}
+
+// TypeError error of a value of an unexpected type, it matches [ErrTypeMismatch]
+type TypeError struct {
+	Value    any
+	Expected string
+}
+
+// Error implements [error]
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("collection: value of type %T is not %s", e.Value, e.Expected)
+}
+
+// Unwrap returns [ErrTypeMismatch]
+func (e *TypeError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// NewQuotaError new quota error
+func NewQuotaError(key any, resource string, limit, usage int64) *QuotaError {
+	return &QuotaError{Key: key, Resource: resource, Limit: limit, Usage: usage}
+}
+
+// QuotaError error of an operation exceeding the quota of a key, it matches [ErrQuotaExceeded]
+type QuotaError struct {
+	Key any
+	// Resource name of the exceeded quota, such as values or bytes
+	Resource string
+	Limit    int64
+	// Usage usage the operation would have resulted in
+	Usage int64
+}
+
+// Error implements [error]
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("collection: key %v exceeds quota of %d %s with %d", e.Key, e.Limit, e.Resource, e.Usage)
+}
+
+// Unwrap returns [ErrQuotaExceeded]
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
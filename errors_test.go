@@ -0,0 +1,35 @@
+package collection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeError(t *testing.T) {
+	var err error = NewRangeError(3, 2)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, "collection: index 3 out of range [0, 2)", err.Error())
+	var rangeErr *RangeError
+	assert.True(t, errors.As(err, &rangeErr))
+	assert.Equal(t, 3, rangeErr.Index)
+}
+
+func TestKeyError(t *testing.T) {
+	var err error = NewKeyError("a")
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
+	assert.Equal(t, "collection: key a not found", err.Error())
+}
+
+func TestTypeError(t *testing.T) {
+	var err error = NewTypeError(1, "string")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "collection: value of type int is not string", err.Error())
+}
+
+func TestQuotaError(t *testing.T) {
+	var err error = NewQuotaError("tenant", "bytes", 10, 12)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	assert.Equal(t, "collection: key tenant exceeds quota of 10 bytes with 12", err.Error())
+}
@@ -0,0 +1,113 @@
+// Package fuzzy provides nearest-string search over string collections, such as for "did you mean" suggestions.
+package fuzzy
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Source collection of strings, such as a list or a set
+type Source interface {
+	ToArray() []string
+}
+
+// Match string found by a search
+type Match struct {
+	// Value matched string
+	Value string `json:"value"`
+	// Distance edit distance between the query and the string
+	Distance int `json:"distance"`
+}
+
+// Distance returns the Levenshtein distance between a and b,
+// the number of rune insertions, deletions and substitutions turning a into b
+func Distance(a, b string) int {
+	distance, _ := boundedDistance([]rune(a), []rune(b), max(utf8.RuneCountInString(a), utf8.RuneCountInString(b)))
+	return distance
+}
+
+// boundedDistance returns the distance between a and b, it gives up and returns false once it exceeds limit
+func boundedDistance(a, b []rune, limit int) (int, bool) {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a)-len(b) > limit {
+		return 0, false
+	}
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		lowest := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			lowest = min(lowest, current[j])
+		}
+		if lowest > limit {
+			return 0, false
+		}
+		previous, current = current, previous
+	}
+	if previous[len(b)] > limit {
+		return 0, false
+	}
+	return previous[len(b)], true
+}
+
+// BestMatch returns the string of the source closest to the query within maxDistance,
+// the first string in the order of the source wins a tie.
+// A negative maxDistance matches nothing, use [TopMatches] with k of 1 for the closest string at any distance.
+func BestMatch(source Source, query string, maxDistance int) (Match, bool) {
+	if maxDistance < 0 {
+		return Match{}, false
+	}
+	matches := search(source.ToArray(), []rune(query), 1, maxDistance)
+	if len(matches) == 0 {
+		return Match{}, false
+	}
+	return matches[0], true
+}
+
+// TopMatches returns the k strings of the source closest to the query, the closest first,
+// strings at the same distance keep the order of the source
+func TopMatches(source Source, query string, k int) []Match {
+	return search(source.ToArray(), []rune(query), k, -1)
+}
+
+// search returns the k closest values within maxDistance, a negative maxDistance means no limit
+func search(values []string, query []rune, k, maxDistance int) []Match {
+	if k <= 0 {
+		return nil
+	}
+	matches := make([]Match, 0, k)
+	for _, value := range values {
+		target := []rune(value)
+		limit := maxDistance
+		if limit < 0 {
+			limit = max(len(query), len(target))
+		}
+		if len(matches) == k {
+			// only strings closer than the current k-th match can enter
+			limit = min(limit, matches[k-1].Distance-1)
+		}
+		distance, ok := boundedDistance(query, target, limit)
+		if !ok {
+			continue
+		}
+		index, _ := slices.BinarySearchFunc(matches, distance+1, func(match Match, distance int) int {
+			return match.Distance - distance
+		})
+		if len(matches) == k {
+			matches = matches[:k-1]
+		}
+		matches = slices.Insert(matches, index, Match{Value: value, Distance: distance})
+	}
+	return matches
+}
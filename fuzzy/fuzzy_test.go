@@ -0,0 +1,42 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 3, Distance("kitten", "sitting"))
+	assert.Equal(t, 0, Distance("", ""))
+	assert.Equal(t, 3, Distance("", "abc"))
+	assert.Equal(t, 1, Distance("héllo", "hello"))
+	assert.Equal(t, 2, Distance("ab", "ba"))
+}
+
+func TestBestMatch(t *testing.T) {
+	commands := list.NewList("status", "commit", "checkout", "stash")
+	match, ok := BestMatch(commands, "stats", 2)
+	assert.True(t, ok)
+	assert.Equal(t, Match{Value: "status", Distance: 1}, match)
+	_, ok = BestMatch(commands, "rebase", 2)
+	assert.False(t, ok)
+	match, ok = BestMatch(set.NewSet("commit"), "comit", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "commit", match.Value)
+	_, ok = BestMatch(commands, "status", -1)
+	assert.False(t, ok)
+}
+
+func TestTopMatches(t *testing.T) {
+	words := list.NewList("book", "back", "cook", "books", "boo", "look")
+	assert.Equal(t, []Match{
+		{Value: "book", Distance: 0},
+		{Value: "cook", Distance: 1},
+		{Value: "books", Distance: 1},
+	}, TopMatches(words, "book", 3))
+	assert.Len(t, TopMatches(words, "book", 10), 6)
+	assert.Empty(t, TopMatches(words, "book", 0))
+}
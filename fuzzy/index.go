@@ -0,0 +1,63 @@
+package fuzzy
+
+import (
+	"sync"
+
+	"github.com/gopi-frame/collection/bktree"
+)
+
+// NewIndex new index of the strings backed by a BK-tree,
+// which answers queries without comparing the query with every string
+func NewIndex(values ...string) *Index {
+	index := new(Index)
+	index.tree = bktree.New(Distance, values...)
+	return index
+}
+
+// Index strings indexed by edit distance, it suits large vocabularies queried often
+type Index struct {
+	sync.RWMutex
+	tree *bktree.Tree[string]
+}
+
+// Add adds the strings
+func (i *Index) Add(values ...string) {
+	for _, value := range values {
+		i.tree.Add(value)
+	}
+}
+
+// Count returns the number of distinct strings
+func (i *Index) Count() int64 {
+	return i.tree.Count()
+}
+
+// ToArray converts to array
+func (i *Index) ToArray() []string {
+	return i.tree.ToArray()
+}
+
+// BestMatch returns the string closest to the query within maxDistance, a negative maxDistance matches nothing like [BestMatch]
+func (i *Index) BestMatch(query string, maxDistance int) (Match, bool) {
+	if maxDistance < 0 {
+		return Match{}, false
+	}
+	results := i.tree.Search(query, maxDistance)
+	if len(results) == 0 {
+		return Match{}, false
+	}
+	return Match{Value: results[0].Value, Distance: results[0].Distance}, true
+}
+
+// TopMatches returns the k strings closest to the query, the closest first
+func (i *Index) TopMatches(query string, k int) []Match {
+	results := i.tree.Nearest(query, k)
+	if results == nil {
+		return nil
+	}
+	matches := make([]Match, len(results))
+	for j, result := range results {
+		matches[j] = Match{Value: result.Value, Distance: result.Distance}
+	}
+	return matches
+}
@@ -0,0 +1,30 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_BestMatch(t *testing.T) {
+	index := NewIndex("status", "commit", "checkout")
+	index.Add("stash", "commit")
+	assert.Equal(t, int64(4), index.Count())
+	match, ok := index.BestMatch("stats", 2)
+	assert.True(t, ok)
+	assert.Equal(t, Match{Value: "status", Distance: 1}, match)
+	_, ok = index.BestMatch("rebase", 2)
+	assert.False(t, ok)
+	_, ok = index.BestMatch("status", -1)
+	assert.False(t, ok)
+}
+
+func TestIndex_TopMatches(t *testing.T) {
+	words := []string{"book", "back", "cook", "books", "boo", "look", "bookkeeper"}
+	index := NewIndex(words...)
+	matches := index.TopMatches("book", 3)
+	assert.Equal(t, Match{Value: "book", Distance: 0}, matches[0])
+	assert.Equal(t, 1, matches[2].Distance)
+	assert.Equal(t, TopMatches(index, "bokkeeper", 1), index.TopMatches("bokkeeper", 1))
+	assert.Empty(t, index.TopMatches("book", 0))
+}
@@ -0,0 +1,255 @@
+package geo
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+// Item located element of a bucket map
+type Item[K comparable, V any] struct {
+	Key   K     `json:"key"`
+	Value V     `json:"value"`
+	Point Point `json:"point"`
+	// Distance the distance in meters to the query point, it is only set by [BucketMap.Nearby]
+	Distance float64 `json:"-"`
+}
+
+type bucketEntry[V any] struct {
+	value V
+	point Point
+	hash  string
+}
+
+// NewBucketMap new bucket map grouping items by geohashes of the given number of characters.
+// Longer geohashes make smaller buckets, 5 characters make cells of about 5 by 5 kilometers.
+func NewBucketMap[K comparable, V any](precision int) *BucketMap[K, V] {
+	m := new(BucketMap[K, V])
+	m.precision = min(max(precision, 1), 12)
+	m.items = make(map[K]bucketEntry[V])
+	m.buckets = make(map[string]map[K]struct{})
+	return m
+}
+
+// BucketMap map of located items grouped into geohash buckets,
+// it answers proximity queries by scanning the buckets around the query point only
+type BucketMap[K comparable, V any] struct {
+	sync.RWMutex
+	precision int
+	items     map[K]bucketEntry[V]
+	buckets   map[string]map[K]struct{}
+}
+
+// Precision returns the number of geohash characters of the buckets
+func (m *BucketMap[K, V]) Precision() int {
+	return m.precision
+}
+
+// Count returns the number of items
+func (m *BucketMap[K, V]) Count() int64 {
+	return int64(len(m.items))
+}
+
+// IsEmpty returns whether the map is empty
+func (m *BucketMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *BucketMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// Buckets returns the number of non-empty buckets
+func (m *BucketMap[K, V]) Buckets() int64 {
+	return int64(len(m.buckets))
+}
+
+// Set sets the value and the location of the key, an existing key is moved to the new location
+func (m *BucketMap[K, V]) Set(key K, lat, lng float64, value V) {
+	hash := Encode(lat, lng, m.precision)
+	if entry, ok := m.items[key]; ok && entry.hash != hash {
+		m.unlink(key, entry.hash)
+	}
+	m.items[key] = bucketEntry[V]{value: value, point: Point{Lat: lat, Lng: lng}, hash: hash}
+	bucket, ok := m.buckets[hash]
+	if !ok {
+		bucket = make(map[K]struct{})
+		m.buckets[hash] = bucket
+	}
+	bucket[key] = struct{}{}
+}
+
+// Get returns the value of the key
+func (m *BucketMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := m.items[key]
+	return entry.value, ok
+}
+
+// Location returns the location of the key
+func (m *BucketMap[K, V]) Location(key K) (Point, bool) {
+	entry, ok := m.items[key]
+	return entry.point, ok
+}
+
+// ContainsKey returns whether the map contains the key
+func (m *BucketMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.items[key]
+	return ok
+}
+
+// Remove removes the key
+func (m *BucketMap[K, V]) Remove(key K) {
+	if entry, ok := m.items[key]; ok {
+		m.unlink(key, entry.hash)
+		delete(m.items, key)
+	}
+}
+
+func (m *BucketMap[K, V]) unlink(key K, hash string) {
+	bucket := m.buckets[hash]
+	delete(bucket, key)
+	if len(bucket) == 0 {
+		delete(m.buckets, hash)
+	}
+}
+
+// Bucket returns the keys in the bucket of the point
+func (m *BucketMap[K, V]) Bucket(lat, lng float64) []K {
+	var keys []K
+	for key := range m.buckets[Encode(lat, lng, m.precision)] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Candidates returns the keys in the buckets overlapping the circle of the radius in meters around the point.
+// Candidates may lie outside the circle, use [BucketMap.Nearby] for exact results.
+func (m *BucketMap[K, V]) Candidates(lat, lng, radius float64) []K {
+	var keys []K
+	for _, hash := range m.cover(lat, lng, radius) {
+		for key := range m.buckets[hash] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Nearby returns the items within the radius in meters around the point, ordered by distance
+func (m *BucketMap[K, V]) Nearby(lat, lng, radius float64) []Item[K, V] {
+	center := Point{Lat: lat, Lng: lng}
+	var items []Item[K, V]
+	for _, key := range m.Candidates(lat, lng, radius) {
+		entry := m.items[key]
+		if distance := Distance(center, entry.point); distance <= radius {
+			items = append(items, Item[K, V]{Key: key, Value: entry.value, Point: entry.point, Distance: distance})
+		}
+	}
+	slices.SortStableFunc(items, func(a, b Item[K, V]) int {
+		if a.Distance < b.Distance {
+			return -1
+		} else if a.Distance > b.Distance {
+			return 1
+		}
+		return 0
+	})
+	return items
+}
+
+// cover returns the geohashes of the non-empty buckets overlapping the bounding box of the circle
+func (m *BucketMap[K, V]) cover(lat, lng, radius float64) []string {
+	dLat := radius / EarthRadius * 180 / math.Pi
+	minLat, maxLat := math.Max(lat-dLat, -90), math.Min(lat+dLat, 90)
+	dLng := 180.0
+	if cos := math.Cos(math.Max(math.Abs(minLat), math.Abs(maxLat)) * math.Pi / 180); cos > 0 {
+		dLng = math.Min(dLat/cos, 180)
+	}
+	cellLat, cellLng := CellSize(m.precision)
+	rows := math.Floor(maxLat/cellLat) - math.Floor(minLat/cellLat) + 1
+	columns := math.Floor((lng+dLng)/cellLng) - math.Floor((lng-dLng)/cellLng) + 1
+	if rows*columns >= float64(len(m.buckets)) {
+		hashes := make([]string, 0, len(m.buckets))
+		for hash := range m.buckets {
+			hashes = append(hashes, hash)
+		}
+		return hashes
+	}
+	seen := make(map[string]struct{})
+	var hashes []string
+	for row := 0.0; row < rows; row++ {
+		cLat := math.Min((math.Floor(minLat/cellLat)+row+0.5)*cellLat, 90)
+		for column := 0.0; column < columns; column++ {
+			cLng := (math.Floor((lng-dLng)/cellLng) + column + 0.5) * cellLng
+			// wrap around the antimeridian
+			cLng = math.Mod(math.Mod(cLng+180, 360)+360, 360) - 180
+			hash := Encode(cLat, cLng, m.precision)
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+			if _, ok := m.buckets[hash]; ok {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	return hashes
+}
+
+// Each runs callback for each item, it breaks when callback false
+func (m *BucketMap[K, V]) Each(callback func(key K, value V) bool) {
+	for key, entry := range m.items {
+		if !callback(key, entry.value) {
+			break
+		}
+	}
+}
+
+// Clear clears the map
+func (m *BucketMap[K, V]) Clear() {
+	m.items = make(map[K]bucketEntry[V])
+	m.buckets = make(map[string]map[K]struct{})
+}
+
+// ToArray converts to array of the items
+func (m *BucketMap[K, V]) ToArray() []Item[K, V] {
+	items := make([]Item[K, V], 0, len(m.items))
+	for key, entry := range m.items {
+		items = append(items, Item[K, V]{Key: key, Value: entry.value, Point: entry.point})
+	}
+	return items
+}
+
+// ToJSON converts to json
+func (m *BucketMap[K, V]) ToJSON() ([]byte, error) {
+	return json.Marshal(m.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *BucketMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the precision of the map is kept
+func (m *BucketMap[K, V]) UnmarshalJSON(data []byte) error {
+	var items []Item[K, V]
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	if m.precision == 0 {
+		*m = *NewBucketMap[K, V](0)
+	}
+	m.Clear()
+	for _, item := range items {
+		m.Set(item.Key, item.Point.Lat, item.Point.Lng, item.Value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *BucketMap[K, V]) String() string {
+	return format.Entries[K, V]("BucketMap", m.Count(), m.Each)
+}
@@ -0,0 +1,113 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCities() *BucketMap[string, int] {
+	m := NewBucketMap[string, int](4)
+	m.Set("berlin", 52.5200, 13.4050, 3_645_000)
+	m.Set("potsdam", 52.3906, 13.0645, 183_000)
+	m.Set("paris", 48.8566, 2.3522, 2_161_000)
+	m.Set("fiji", -17.7134, 178.0650, 900_000)
+	m.Set("samoa", -13.7590, -172.1046, 200_000)
+	return m
+}
+
+func TestBucketMap_Set(t *testing.T) {
+	m := newCities()
+	assert.Equal(t, int64(5), m.Count())
+	value, ok := m.Get("paris")
+	assert.True(t, ok)
+	assert.Equal(t, 2_161_000, value)
+	m.Set("paris", 40.7128, -74.0060, 1)
+	point, _ := m.Location("paris")
+	assert.Equal(t, Point{Lat: 40.7128, Lng: -74.0060}, point)
+	assert.Equal(t, []string{"paris"}, m.Bucket(40.7128, -74.0060))
+	assert.Empty(t, m.Bucket(48.8566, 2.3522))
+	assert.Equal(t, int64(5), m.Buckets())
+}
+
+func TestBucketMap_Remove(t *testing.T) {
+	m := newCities()
+	m.Remove("berlin")
+	m.Remove("missing")
+	assert.False(t, m.ContainsKey("berlin"))
+	assert.Equal(t, int64(4), m.Count())
+	assert.Equal(t, int64(4), m.Buckets())
+}
+
+func TestBucketMap_Nearby(t *testing.T) {
+	m := newCities()
+	items := m.Nearby(52.5, 13.4, 50_000)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "berlin", items[0].Key)
+	assert.Equal(t, "potsdam", items[1].Key)
+	assert.Less(t, items[0].Distance, items[1].Distance)
+	assert.Empty(t, m.Nearby(0, 0, 1_000))
+	// across the antimeridian, with enough buckets to scan the covering cells only
+	for i := 0; i < 1000; i++ {
+		m.Set(fmt.Sprint(i), float64(i%40), float64(i/40), 0)
+	}
+	m.Set("east", 0, 179.95, 0)
+	m.Set("west", 0, -179.95, 0)
+	items = m.Nearby(0, 180, 20_000)
+	assert.Len(t, items, 2)
+	assert.Len(t, m.cover(0, 180, 20_000), 2)
+}
+
+func TestBucketMap_Candidates(t *testing.T) {
+	m := newCities()
+	candidates := m.Candidates(52.5, 13.4, 1_000)
+	sort.Strings(candidates)
+	assert.Contains(t, candidates, "berlin")
+	assert.NotContains(t, candidates, "paris")
+}
+
+func TestBucketMap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewBucketMap[int, struct{}](5)
+	points := make([]Point, 2000)
+	for index := range points {
+		points[index] = Point{Lat: 45 + r.Float64()*10, Lng: 5 + r.Float64()*10}
+		m.Set(index, points[index].Lat, points[index].Lng, struct{}{})
+	}
+	for i := 0; i < 20; i++ {
+		center := Point{Lat: 45 + r.Float64()*10, Lng: 5 + r.Float64()*10}
+		radius := r.Float64() * 100_000
+		var expected []int
+		for index, point := range points {
+			if Distance(center, point) <= radius {
+				expected = append(expected, index)
+			}
+		}
+		var actual []int
+		for _, item := range m.Nearby(center.Lat, center.Lng, radius) {
+			actual = append(actual, item.Key)
+		}
+		assert.ElementsMatch(t, expected, actual)
+	}
+}
+
+func TestBucketMap_UnmarshalJSON(t *testing.T) {
+	data, err := json.Marshal(newCities())
+	assert.Nil(t, err)
+	m := NewBucketMap[string, int](3)
+	assert.Nil(t, json.Unmarshal(data, m))
+	assert.Equal(t, int64(5), m.Count())
+	assert.Equal(t, 3, m.Precision())
+	value, _ := m.Get("fiji")
+	assert.Equal(t, 900_000, value)
+}
+
+func TestBucketMap_String(t *testing.T) {
+	m := NewBucketMap[string, int](3)
+	m.Set("a", 0, 0, 1)
+	assert.Equal(t, "BucketMap[string, int](len=1){\n\ta: 1,\n}", m.String())
+}
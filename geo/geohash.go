@@ -0,0 +1,67 @@
+// Package geo provides geographic indexes over latitude and longitude.
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EarthRadius mean radius of the earth in meters
+const EarthRadius = 6371008.8
+
+// Point geographic point in degrees
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Distance returns the great-circle distance between the points in meters
+func Distance(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Encode returns the geohash of the point with the given number of characters
+func Encode(lat, lng float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	hash := new(strings.Builder)
+	even := true
+	bits, char := 0, 0
+	for hash.Len() < precision {
+		if even {
+			char = char<<1 | bisect(&lngRange, lng)
+		} else {
+			char = char<<1 | bisect(&latRange, lat)
+		}
+		even = !even
+		if bits++; bits == 5 {
+			hash.WriteByte(base32[char])
+			bits, char = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+func bisect(bounds *[2]float64, value float64) int {
+	mid := (bounds[0] + bounds[1]) / 2
+	if value >= mid {
+		bounds[0] = mid
+		return 1
+	}
+	bounds[1] = mid
+	return 0
+}
+
+// CellSize returns the height and width in degrees of the geohash cells of the given number of characters
+func CellSize(precision int) (lat, lng float64) {
+	bits := 5 * precision
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / math.Exp2(float64(latBits)), 360 / math.Exp2(float64(lngBits))
+}
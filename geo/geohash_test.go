@@ -0,0 +1,29 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "u4pruydqqvj", Encode(57.64911, 10.40744, 11))
+	assert.Equal(t, "u4pru", Encode(57.64911, 10.40744, 5))
+	assert.Equal(t, "", Encode(0, 0, 0))
+}
+
+func TestDistance(t *testing.T) {
+	berlin := Point{Lat: 52.5200, Lng: 13.4050}
+	paris := Point{Lat: 48.8566, Lng: 2.3522}
+	assert.InDelta(t, 877_500, Distance(berlin, paris), 2_000)
+	assert.Equal(t, 0.0, Distance(paris, paris))
+}
+
+func TestCellSize(t *testing.T) {
+	lat, lng := CellSize(1)
+	assert.Equal(t, 45.0, lat)
+	assert.Equal(t, 45.0, lng)
+	lat, lng = CellSize(2)
+	assert.Equal(t, 5.625, lat)
+	assert.Equal(t, 11.25, lng)
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"slices"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+)
+
+// StronglyConnectedComponents returns the strongly connected components by Tarjan's algorithm.
+// Components are returned in reverse topological order, each component of an undirected graph is a connected component.
+func (g *Graph[N]) StronglyConnectedComponents() *list.List[*list.List[N]] {
+	components := list.NewList[*list.List[N]]()
+	index := 0
+	indexes := make(map[N]int)
+	lowlinks := make(map[N]int)
+	onStack := make(map[N]bool)
+	var stack []N
+	var connect func(node N)
+	connect = func(node N) {
+		indexes[node] = index
+		lowlinks[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+		g.EachEdge(node, func(to N, _ float64) bool {
+			if _, ok := indexes[to]; !ok {
+				connect(to)
+				lowlinks[node] = min(lowlinks[node], lowlinks[to])
+			} else if onStack[to] {
+				lowlinks[node] = min(lowlinks[node], indexes[to])
+			}
+			return true
+		})
+		if lowlinks[node] != indexes[node] {
+			return
+		}
+		start := len(stack) - 1
+		for stack[start] != node {
+			start--
+		}
+		for _, member := range stack[start:] {
+			onStack[member] = false
+		}
+		components.Push(list.NewList(slices.Clone(stack[start:])...))
+		stack = stack[:start]
+	}
+	g.nodes.Each(func(node N, _ *kv.LinkedMap[N, float64]) bool {
+		if _, ok := indexes[node]; !ok {
+			connect(node)
+		}
+		return true
+	})
+	return components
+}
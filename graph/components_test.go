@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 4, 1)
+	g.AddNode(6)
+	var components [][]int
+	g.StronglyConnectedComponents().Each(func(_ int, component *list.List[int]) bool {
+		components = append(components, component.ToArray())
+		return true
+	})
+	assert.Equal(t, [][]int{{4, 5}, {1, 2, 3}, {6}}, components)
+}
@@ -0,0 +1,226 @@
+// Package graph provides weighted graphs and graph algorithms.
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/contract"
+)
+
+// Edge weighted edge of a graph
+type Edge[N comparable] struct {
+	From   N       `json:"from"`
+	To     N       `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+// NewDirected new directed graph
+func NewDirected[N comparable]() *Graph[N] {
+	return newGraph[N](true)
+}
+
+// NewUndirected new undirected graph
+func NewUndirected[N comparable]() *Graph[N] {
+	return newGraph[N](false)
+}
+
+func newGraph[N comparable](directed bool) *Graph[N] {
+	g := new(Graph[N])
+	g.directed = directed
+	g.nodes = kv.NewLinkedMap[N, *kv.LinkedMap[N, float64]]()
+	return g
+}
+
+// Graph weighted graph, nodes and edges are iterated in insertion order
+type Graph[N comparable] struct {
+	sync.RWMutex
+	directed bool
+	nodes    *kv.LinkedMap[N, *kv.LinkedMap[N, float64]]
+	edges    int64
+}
+
+// Directed returns whether the graph is directed
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// Count returns the number of nodes
+func (g *Graph[N]) Count() int64 {
+	return g.nodes.Count()
+}
+
+// EdgeCount returns the number of edges
+func (g *Graph[N]) EdgeCount() int64 {
+	return g.edges
+}
+
+// IsEmpty returns whether the graph has no nodes
+func (g *Graph[N]) IsEmpty() bool {
+	return g.Count() == 0
+}
+
+// IsNotEmpty returns whether the graph has nodes
+func (g *Graph[N]) IsNotEmpty() bool {
+	return !g.IsEmpty()
+}
+
+// AddNode adds nodes, existing nodes are ignored
+func (g *Graph[N]) AddNode(nodes ...N) {
+	for _, node := range nodes {
+		if !g.nodes.ContainsKey(node) {
+			g.nodes.Set(node, kv.NewLinkedMap[N, float64]())
+		}
+	}
+}
+
+// HasNode returns whether the graph contains the node
+func (g *Graph[N]) HasNode(node N) bool {
+	return g.nodes.ContainsKey(node)
+}
+
+// RemoveNode removes the node and its edges
+func (g *Graph[N]) RemoveNode(node N) {
+	out, ok := g.nodes.Get(node)
+	if !ok {
+		return
+	}
+	for _, to := range out.Keys() {
+		g.RemoveEdge(node, to)
+	}
+	if g.directed {
+		for _, from := range g.nodes.Keys() {
+			g.RemoveEdge(from, node)
+		}
+	}
+	g.nodes.Remove(node)
+}
+
+// AddEdge adds the edge from a node to another node with the weight, missing nodes are added.
+// The weight of an existing edge is replaced.
+func (g *Graph[N]) AddEdge(from, to N, weight float64) {
+	g.AddNode(from, to)
+	out, _ := g.nodes.Get(from)
+	if !out.ContainsKey(to) {
+		g.edges++
+	}
+	out.Set(to, weight)
+	if !g.directed {
+		in, _ := g.nodes.Get(to)
+		in.Set(from, weight)
+	}
+}
+
+// RemoveEdge removes the edge from a node to another node
+func (g *Graph[N]) RemoveEdge(from, to N) {
+	out, ok := g.nodes.Get(from)
+	if !ok || !out.ContainsKey(to) {
+		return
+	}
+	out.Remove(to)
+	g.edges--
+	if !g.directed {
+		in, _ := g.nodes.Get(to)
+		in.Remove(from)
+	}
+}
+
+// HasEdge returns whether the graph contains the edge from a node to another node
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	_, ok := g.Weight(from, to)
+	return ok
+}
+
+// Weight returns the weight of the edge from a node to another node.
+// It returns zero and false when there is no such edge.
+func (g *Graph[N]) Weight(from, to N) (float64, bool) {
+	out, ok := g.nodes.Get(from)
+	if !ok {
+		return 0, false
+	}
+	return out.Get(to)
+}
+
+// Nodes returns all nodes
+func (g *Graph[N]) Nodes() []N {
+	return g.nodes.Keys()
+}
+
+// Neighbors returns the nodes the node has edges to
+func (g *Graph[N]) Neighbors(node N) []N {
+	out, ok := g.nodes.Get(node)
+	if !ok {
+		return nil
+	}
+	return out.Keys()
+}
+
+// Edges returns all edges, every edge of an undirected graph is returned once
+func (g *Graph[N]) Edges() []Edge[N] {
+	var edges []Edge[N]
+	visited := make(map[N]struct{})
+	g.nodes.Each(func(from N, out *kv.LinkedMap[N, float64]) bool {
+		visited[from] = struct{}{}
+		out.Each(func(to N, weight float64) bool {
+			if _, ok := visited[to]; g.directed || !ok || to == from {
+				edges = append(edges, Edge[N]{From: from, To: to, Weight: weight})
+			}
+			return true
+		})
+		return true
+	})
+	return edges
+}
+
+// EachEdge ranges the edges from the node, it breaks when the callback returns false
+func (g *Graph[N]) EachEdge(node N, callback func(to N, weight float64) bool) {
+	if out, ok := g.nodes.Get(node); ok {
+		out.Each(callback)
+	}
+}
+
+// Clone clones the graph
+func (g *Graph[N]) Clone() *Graph[N] {
+	clone := newGraph[N](g.directed)
+	clone.AddNode(g.Nodes()...)
+	for _, edge := range g.Edges() {
+		clone.AddEdge(edge.From, edge.To, edge.Weight)
+	}
+	return clone
+}
+
+// String converts to string
+func (g *Graph[N]) String() string {
+	str := new(strings.Builder)
+	kind := "Undirected"
+	if g.directed {
+		kind = "Directed"
+	}
+	str.WriteString(fmt.Sprintf("%sGraph[%T](nodes=%d, edges=%d)", kind, *new(N), g.Count(), g.EdgeCount()))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	for index, edge := range g.Edges() {
+		if index >= 5 {
+			str.WriteString("\t...\n")
+			break
+		}
+		str.WriteByte('\t')
+		str.WriteString(nodeString(edge.From))
+		str.WriteString(" -> ")
+		str.WriteString(nodeString(edge.To))
+		str.WriteString(fmt.Sprintf(" (%v)", edge.Weight))
+		str.WriteByte(',')
+		str.WriteByte('\n')
+	}
+	str.WriteByte('}')
+	return str.String()
+}
+
+func nodeString[N any](node N) string {
+	if v, ok := any(node).(contract.Stringable); ok {
+		return v.String()
+	}
+	return fmt.Sprintf("%v", node)
+}
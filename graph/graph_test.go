@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_AddEdge(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "b", 2)
+	assert.Equal(t, int64(2), g.Count())
+	assert.Equal(t, int64(1), g.EdgeCount())
+	w, ok := g.Weight("a", "b")
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, w)
+	assert.False(t, g.HasEdge("b", "a"))
+
+	u := NewUndirected[string]()
+	u.AddEdge("a", "b", 1)
+	assert.True(t, u.HasEdge("b", "a"))
+	assert.Equal(t, int64(1), u.EdgeCount())
+}
+
+func TestGraph_RemoveEdge(t *testing.T) {
+	u := NewUndirected[string]()
+	u.AddEdge("a", "b", 1)
+	u.RemoveEdge("b", "a")
+	assert.False(t, u.HasEdge("a", "b"))
+	assert.Equal(t, int64(0), u.EdgeCount())
+	assert.Equal(t, int64(2), u.Count())
+}
+
+func TestGraph_RemoveNode(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "a", 1)
+	g.RemoveNode("b")
+	assert.Equal(t, []string{"a", "c"}, g.Nodes())
+	assert.Equal(t, []Edge[string]{{From: "c", To: "a", Weight: 1}}, g.Edges())
+}
+
+func TestGraph_Edges(t *testing.T) {
+	u := NewUndirected[string]()
+	u.AddEdge("a", "b", 1)
+	u.AddEdge("b", "c", 2)
+	u.AddEdge("c", "c", 3)
+	assert.Equal(t, []Edge[string]{
+		{From: "a", To: "b", Weight: 1},
+		{From: "b", To: "c", Weight: 2},
+		{From: "c", To: "c", Weight: 3},
+	}, u.Edges())
+	assert.Equal(t, []string{"a", "c"}, u.Neighbors("b"))
+}
+
+func TestGraph_Clone(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddNode(0)
+	g.AddEdge(1, 2, 1)
+	clone := g.Clone()
+	clone.AddEdge(2, 3, 1)
+	assert.Equal(t, []int{0, 1, 2}, g.Nodes())
+	assert.Equal(t, []int{0, 1, 2, 3}, clone.Nodes())
+	assert.True(t, clone.Directed())
+}
+
+func TestGraph_String(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2, 0.5)
+	assert.Equal(t, "DirectedGraph[int](nodes=2, edges=1){\n\t1 -> 2 (0.5),\n}", g.String())
+}
@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/queue"
+)
+
+var (
+	// ErrNegativeWeight the algorithm does not accept negative weights
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrNegativeCycle the graph contains a cycle of negative total weight reachable from the source
+	ErrNegativeCycle = errors.New("graph: negative cycle")
+	// ErrNodeNotFound the node is not in the graph
+	ErrNodeNotFound = errors.New("graph: node not found")
+)
+
+// Paths shortest paths from a source node
+type Paths[N comparable] struct {
+	source    N
+	distances *kv.LinkedMap[N, float64]
+	previous  map[N]N
+}
+
+func newPaths[N comparable](source N) *Paths[N] {
+	paths := new(Paths[N])
+	paths.source = source
+	paths.distances = kv.NewLinkedMap[N, float64]()
+	paths.previous = make(map[N]N)
+	paths.distances.Set(source, 0)
+	return paths
+}
+
+// Source returns the source node
+func (p *Paths[N]) Source() N {
+	return p.source
+}
+
+// DistanceTo returns the total weight of the shortest path to the node.
+// It returns +Inf and false when the node is unreachable.
+func (p *Paths[N]) DistanceTo(node N) (float64, bool) {
+	distance, ok := p.distances.Get(node)
+	if !ok {
+		return math.Inf(1), false
+	}
+	return distance, true
+}
+
+// PathTo returns the nodes of the shortest path from the source to the node, both included.
+// It returns nil and false when the node is unreachable.
+func (p *Paths[N]) PathTo(node N) (*list.List[N], bool) {
+	if !p.distances.ContainsKey(node) {
+		return nil, false
+	}
+	path := []N{node}
+	for node != p.source {
+		node = p.previous[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return list.NewList(path...), true
+}
+
+// Distances returns the distances of the reachable nodes in the order they were reached
+func (p *Paths[N]) Distances() *kv.LinkedMap[N, float64] {
+	distances := kv.NewLinkedMap[N, float64]()
+	p.distances.Each(func(node N, distance float64) bool {
+		distances.Set(node, distance)
+		return true
+	})
+	return distances
+}
+
+type distanceItem[N comparable] struct {
+	node     N
+	distance float64
+}
+
+type distanceComparator[N comparable] struct{}
+
+func (distanceComparator[N]) Compare(a, b distanceItem[N]) int {
+	if a.distance < b.distance {
+		return -1
+	} else if a.distance > b.distance {
+		return 1
+	}
+	return 0
+}
+
+// Dijkstra returns the shortest paths from the source by Dijkstra's algorithm.
+// It returns [ErrNegativeWeight] when the graph has an edge of negative weight.
+func (g *Graph[N]) Dijkstra(source N) (*Paths[N], error) {
+	if !g.HasNode(source) {
+		return nil, ErrNodeNotFound
+	}
+	for _, edge := range g.Edges() {
+		if edge.Weight < 0 {
+			return nil, ErrNegativeWeight
+		}
+	}
+	paths := newPaths(source)
+	done := make(map[N]struct{})
+	pending := queue.NewPriorityQueue[distanceItem[N]](distanceComparator[N]{}, distanceItem[N]{node: source})
+	for item, ok := pending.Dequeue(); ok; item, ok = pending.Dequeue() {
+		if _, ok := done[item.node]; ok {
+			continue
+		}
+		done[item.node] = struct{}{}
+		g.EachEdge(item.node, func(to N, weight float64) bool {
+			distance := item.distance + weight
+			if current, ok := paths.distances.Get(to); !ok || distance < current {
+				paths.distances.Set(to, distance)
+				paths.previous[to] = item.node
+				pending.Enqueue(distanceItem[N]{node: to, distance: distance})
+			}
+			return true
+		})
+	}
+	return paths, nil
+}
+
+// BellmanFord returns the shortest paths from the source by the Bellman-Ford algorithm, which accepts negative weights.
+// It returns [ErrNegativeCycle] when a cycle of negative total weight is reachable from the source.
+func (g *Graph[N]) BellmanFord(source N) (*Paths[N], error) {
+	if !g.HasNode(source) {
+		return nil, ErrNodeNotFound
+	}
+	paths := newPaths(source)
+	relax := func() bool {
+		changed := false
+		g.nodes.Each(func(from N, out *kv.LinkedMap[N, float64]) bool {
+			distance, ok := paths.distances.Get(from)
+			if !ok {
+				return true
+			}
+			out.Each(func(to N, weight float64) bool {
+				if current, ok := paths.distances.Get(to); !ok || distance+weight < current {
+					paths.distances.Set(to, distance+weight)
+					paths.previous[to] = from
+					changed = true
+				}
+				return true
+			})
+			return true
+		})
+		return changed
+	}
+	for i := int64(1); i < g.Count(); i++ {
+		if !relax() {
+			return paths, nil
+		}
+	}
+	if relax() {
+		return nil, ErrNegativeCycle
+	}
+	return paths, nil
+}
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRoads() *Graph[string] {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddNode("e")
+	return g
+}
+
+func TestGraph_Dijkstra(t *testing.T) {
+	paths, err := newRoads().Dijkstra("a")
+	assert.Nil(t, err)
+	distance, ok := paths.DistanceTo("d")
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, distance)
+	path, ok := paths.PathTo("d")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "c", "b", "d"}, path.ToArray())
+	distance, ok = paths.DistanceTo("e")
+	assert.False(t, ok)
+	assert.True(t, math.IsInf(distance, 1))
+	_, ok = paths.PathTo("e")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]float64{"a": 0, "b": 3, "c": 1, "d": 4}, paths.Distances().ToMap())
+
+	g := newRoads()
+	g.AddEdge("d", "a", -1)
+	_, err = g.Dijkstra("a")
+	assert.ErrorIs(t, err, ErrNegativeWeight)
+	_, err = g.Dijkstra("z")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_BellmanFord(t *testing.T) {
+	g := newRoads()
+	g.AddEdge("d", "e", -3)
+	paths, err := g.BellmanFord("a")
+	assert.Nil(t, err)
+	distance, _ := paths.DistanceTo("e")
+	assert.Equal(t, 1.0, distance)
+	path, _ := paths.PathTo("e")
+	assert.Equal(t, []string{"a", "c", "b", "d", "e"}, path.ToArray())
+
+	g.AddEdge("d", "e", -5)
+	g.AddEdge("e", "c", 1)
+	_, err = g.BellmanFord("a")
+	assert.ErrorIs(t, err, ErrNegativeCycle)
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/queue"
+)
+
+type edgeComparator[N comparable] struct{}
+
+func (edgeComparator[N]) Compare(a, b Edge[N]) int {
+	return cmp.Compare(a.Weight, b.Weight)
+}
+
+// Kruskal returns the edges of a minimum spanning forest by Kruskal's algorithm,
+// the edges are ordered by weight. The direction of edges is ignored.
+func (g *Graph[N]) Kruskal() *list.List[Edge[N]] {
+	edges := g.Edges()
+	slices.SortStableFunc(edges, edgeComparator[N]{}.Compare)
+	parents := make(map[N]N)
+	var find func(node N) N
+	find = func(node N) N {
+		parent, ok := parents[node]
+		if !ok || parent == node {
+			return node
+		}
+		root := find(parent)
+		parents[node] = root
+		return root
+	}
+	forest := list.NewList[Edge[N]]()
+	for _, edge := range edges {
+		from, to := find(edge.From), find(edge.To)
+		if from == to {
+			continue
+		}
+		parents[from] = to
+		forest.Push(edge)
+	}
+	return forest
+}
+
+// Prim returns the edges of a minimum spanning forest by Prim's algorithm,
+// each tree is grown from its first node in insertion order. The direction of edges is ignored.
+func (g *Graph[N]) Prim() *list.List[Edge[N]] {
+	adjacent := g.adjacency()
+	visited := make(map[N]struct{})
+	forest := list.NewList[Edge[N]]()
+	for _, root := range g.Nodes() {
+		if _, ok := visited[root]; ok {
+			continue
+		}
+		visited[root] = struct{}{}
+		pending := queue.NewPriorityQueue[Edge[N]](edgeComparator[N]{}, adjacent[root]...)
+		for edge, ok := pending.Dequeue(); ok; edge, ok = pending.Dequeue() {
+			if _, ok := visited[edge.To]; ok {
+				continue
+			}
+			visited[edge.To] = struct{}{}
+			forest.Push(edge)
+			for _, next := range adjacent[edge.To] {
+				if _, ok := visited[next.To]; !ok {
+					pending.Enqueue(next)
+				}
+			}
+		}
+	}
+	return forest
+}
+
+// adjacency returns the edges from every node regardless of direction
+func (g *Graph[N]) adjacency() map[N][]Edge[N] {
+	adjacent := make(map[N][]Edge[N])
+	for _, edge := range g.Edges() {
+		adjacent[edge.From] = append(adjacent[edge.From], edge)
+		if edge.From != edge.To {
+			adjacent[edge.To] = append(adjacent[edge.To], Edge[N]{From: edge.To, To: edge.From, Weight: edge.Weight})
+		}
+	}
+	return adjacent
+}
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newNetwork() *Graph[string] {
+	g := NewUndirected[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("b", "d", 5)
+	g.AddEdge("c", "d", 8)
+	g.AddEdge("x", "y", 3)
+	return g
+}
+
+func totalWeight(edges []Edge[string]) float64 {
+	total := 0.0
+	for _, edge := range edges {
+		total += edge.Weight
+	}
+	return total
+}
+
+func TestGraph_Kruskal(t *testing.T) {
+	forest := newNetwork().Kruskal()
+	assert.Equal(t, []Edge[string]{
+		{From: "a", To: "c", Weight: 1},
+		{From: "b", To: "c", Weight: 2},
+		{From: "x", To: "y", Weight: 3},
+		{From: "b", To: "d", Weight: 5},
+	}, forest.ToArray())
+}
+
+func TestGraph_Prim(t *testing.T) {
+	forest := newNetwork().Prim()
+	assert.Equal(t, []Edge[string]{
+		{From: "a", To: "c", Weight: 1},
+		{From: "c", To: "b", Weight: 2},
+		{From: "b", To: "d", Weight: 5},
+		{From: "x", To: "y", Weight: 3},
+	}, forest.ToArray())
+	assert.Equal(t, totalWeight(newNetwork().Kruskal().ToArray()), totalWeight(forest.ToArray()))
+}
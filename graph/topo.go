@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+)
+
+// ErrCycle the graph contains a cycle
+var ErrCycle = errors.New("graph: cycle")
+
+// CycleError error of a graph with cycles, it matches [ErrCycle]
+type CycleError[N comparable] struct {
+	// Cycles the strongly connected components which contain a cycle, each one lists its nodes in insertion order
+	Cycles [][]N
+}
+
+// Error implements [error]
+func (e *CycleError[N]) Error() string {
+	str := new(strings.Builder)
+	str.WriteString("graph: cycle between ")
+	for index, cycle := range e.Cycles {
+		if index > 0 {
+			str.WriteString("; ")
+		}
+		for i, node := range cycle {
+			if i > 0 {
+				str.WriteString(", ")
+			}
+			str.WriteString(nodeString(node))
+		}
+	}
+	return str.String()
+}
+
+// Unwrap returns [ErrCycle]
+func (e *CycleError[N]) Unwrap() error {
+	return ErrCycle
+}
+
+// TopoBatches returns the nodes in layers, an edge from a node to another node means the former must come first.
+// Every layer only depends on the previous layers, so the nodes of a layer can be processed in parallel.
+// Nodes of a layer are in insertion order.
+// It returns a [*CycleError] naming the nodes of every cycle when the graph is not acyclic.
+func (g *Graph[N]) TopoBatches() (*list.List[*list.List[N]], error) {
+	degrees := make(map[N]int, g.Count())
+	g.nodes.Each(func(_ N, out *kv.LinkedMap[N, float64]) bool {
+		for _, to := range out.Keys() {
+			degrees[to]++
+		}
+		return true
+	})
+	order := g.insertionOrder()
+	var layer []N
+	for _, node := range g.Nodes() {
+		if degrees[node] == 0 {
+			layer = append(layer, node)
+		}
+	}
+	batches := list.NewList[*list.List[N]]()
+	visited := 0
+	for len(layer) > 0 {
+		batches.Push(list.NewList(layer...))
+		visited += len(layer)
+		var ready []N
+		for _, node := range layer {
+			g.EachEdge(node, func(to N, _ float64) bool {
+				degrees[to]--
+				if degrees[to] == 0 {
+					ready = append(ready, to)
+				}
+				return true
+			})
+		}
+		slices.SortFunc(ready, func(a, b N) int {
+			return order[a] - order[b]
+		})
+		layer = ready
+	}
+	if int64(visited) < g.Count() {
+		return nil, g.cycleError(order)
+	}
+	return batches, nil
+}
+
+// insertionOrder returns the insertion index of every node
+func (g *Graph[N]) insertionOrder() map[N]int {
+	order := make(map[N]int, g.Count())
+	for index, node := range g.Nodes() {
+		order[node] = index
+	}
+	return order
+}
+
+func (g *Graph[N]) cycleError(order map[N]int) *CycleError[N] {
+	err := new(CycleError[N])
+	g.StronglyConnectedComponents().Each(func(_ int, component *list.List[N]) bool {
+		nodes := component.ToArray()
+		if len(nodes) == 1 && !g.HasEdge(nodes[0], nodes[0]) {
+			return true
+		}
+		slices.SortFunc(nodes, func(a, b N) int {
+			return order[a] - order[b]
+		})
+		err.Cycles = append(err.Cycles, nodes)
+		return true
+	})
+	slices.SortFunc(err.Cycles, func(a, b []N) int {
+		return order[a[0]] - order[b[0]]
+	})
+	return err
+}
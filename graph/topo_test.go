@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchesOf[N comparable](batches *list.List[*list.List[N]]) [][]N {
+	var items [][]N
+	batches.Each(func(_ int, batch *list.List[N]) bool {
+		items = append(items, batch.ToArray())
+		return true
+	})
+	return items
+}
+
+func TestGraph_TopoBatches(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddNode("deploy", "test", "build", "lint", "fetch")
+	g.AddEdge("fetch", "build", 0)
+	g.AddEdge("fetch", "lint", 0)
+	g.AddEdge("build", "test", 0)
+	g.AddEdge("lint", "deploy", 0)
+	g.AddEdge("test", "deploy", 0)
+	batches, err := g.TopoBatches()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"fetch"}, {"build", "lint"}, {"test"}, {"deploy"}}, batchesOf(batches))
+
+	batches, err = NewDirected[string]().TopoBatches()
+	assert.Nil(t, err)
+	assert.True(t, batches.IsEmpty())
+
+	g = NewDirected[string]()
+	g.AddNode("root", "c", "b", "a")
+	g.AddEdge("root", "a", 0)
+	g.AddEdge("root", "b", 0)
+	g.AddEdge("root", "c", 0)
+	batches, err = g.TopoBatches()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"root"}, {"c", "b", "a"}}, batchesOf(batches))
+}
+
+func TestGraph_TopoBatches_Chain(t *testing.T) {
+	const n = 1 << 16
+	g := NewDirected[int]()
+	for i := 1; i < n; i++ {
+		g.AddEdge(i-1, i, 0)
+	}
+	batches, err := g.TopoBatches()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(n), batches.Count())
+	last, _ := batches.Last()
+	assert.Equal(t, []int{n - 1}, last.ToArray())
+}
+
+func TestGraph_TopoBatches_Cycle(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 0)
+	g.AddEdge("b", "c", 0)
+	g.AddEdge("c", "a", 0)
+	g.AddEdge("c", "d", 0)
+	g.AddEdge("e", "e", 0)
+	batches, err := g.TopoBatches()
+	assert.Nil(t, batches)
+	assert.ErrorIs(t, err, ErrCycle)
+	var cycleErr *CycleError[string]
+	assert.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"e"}}, cycleErr.Cycles)
+	assert.Equal(t, "graph: cycle between a, b, c; e", err.Error())
+}
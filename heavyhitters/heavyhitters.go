@@ -0,0 +1,210 @@
+// Package heavyhitters tracks the approximate most frequent keys of a stream in bounded memory.
+package heavyhitters
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+// Item key tracked by a [Sketch]
+type Item[K comparable] struct {
+	Key K `json:"key"`
+	// Count estimated count of the key, it never underestimates the true count
+	Count int64 `json:"count"`
+	// Error maximum overestimation of the count, the true count is at least Count - Error
+	Error int64 `json:"error"`
+}
+
+// String formats the item as `key: count±error`
+func (i Item[K]) String() string {
+	str := new(strings.Builder)
+	format.Value(str, i.Key)
+	str.WriteString(fmt.Sprintf(": %d±%d", i.Count, i.Error))
+	return str.String()
+}
+
+// Guaranteed returns the count the key is guaranteed to have reached
+func (i Item[K]) Guaranteed() int64 {
+	return i.Count - i.Error
+}
+
+// New new sketch tracking at most capacity keys.
+// Any key occurring more than total/capacity times is guaranteed to be tracked.
+func New[K comparable](capacity int) *Sketch[K] {
+	s := new(Sketch[K])
+	s.capacity = max(capacity, 1)
+	s.index = make(map[K]int, s.capacity)
+	return s
+}
+
+// Sketch Space-Saving sketch of the most frequent keys of a stream.
+// It tracks a fixed number of keys, an untracked key replaces the key with the smallest count
+// and inherits that count as its error bound.
+// It is safe for concurrent use.
+type Sketch[K comparable] struct {
+	lock     sync.Mutex
+	capacity int
+	// items min-heap by count
+	items []Item[K]
+	index map[K]int
+	total int64
+}
+
+func (s *Sketch[K]) swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.index[s.items[i].Key] = i
+	s.index[s.items[j].Key] = j
+}
+
+func (s *Sketch[K]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if s.items[parent].Count <= s.items[i].Count {
+			break
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+func (s *Sketch[K]) down(i int) {
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(s.items) && s.items[child].Count < s.items[smallest].Count {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		s.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// Capacity returns the number of keys the sketch tracks at most
+func (s *Sketch[K]) Capacity() int {
+	return s.capacity
+}
+
+// Total returns the total weight offered to the sketch
+func (s *Sketch[K]) Total() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.total
+}
+
+// Count returns the number of keys tracked
+func (s *Sketch[K]) Count() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return int64(len(s.items))
+}
+
+// ElementType returns int64, the type of the estimated counts
+func (s *Sketch[K]) ElementType() string {
+	return collection.TypeName[int64]()
+}
+
+// KeyType returns the name of the key type
+func (s *Sketch[K]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (s *Sketch[K]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Offer counts one occurrence of the key
+func (s *Sketch[K]) Offer(key K) {
+	s.Add(key, 1)
+}
+
+// Add counts weight occurrences of the key, non-positive weights are ignored
+func (s *Sketch[K]) Add(key K, weight int64) {
+	if weight <= 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.total += weight
+	if i, ok := s.index[key]; ok {
+		s.items[i].Count += weight
+		s.down(i)
+		return
+	}
+	if len(s.items) < s.capacity {
+		s.items = append(s.items, Item[K]{Key: key, Count: weight})
+		s.index[key] = len(s.items) - 1
+		s.up(len(s.items) - 1)
+		return
+	}
+	evicted := s.items[0]
+	delete(s.index, evicted.Key)
+	s.items[0] = Item[K]{Key: key, Count: evicted.Count + weight, Error: evicted.Count}
+	s.index[key] = 0
+	s.down(0)
+}
+
+// AddCounts counts the occurrences of every key of the map,
+// such as the counts drained from a counters.Map at each flush
+func (s *Sketch[K]) AddCounts(counts map[K]int64) {
+	for key, count := range counts {
+		s.Add(key, count)
+	}
+}
+
+// Get returns the tracked item of the key
+func (s *Sketch[K]) Get(key K) (Item[K], bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	i, ok := s.index[key]
+	if !ok {
+		return Item[K]{}, false
+	}
+	return s.items[i], true
+}
+
+// Top returns the n items with the highest estimated counts, highest first,
+// ties are ordered by the guaranteed count
+func (s *Sketch[K]) Top(n int) []Item[K] {
+	s.lock.Lock()
+	items := slices.Clone(s.items)
+	s.lock.Unlock()
+	slices.SortStableFunc(items, func(a, b Item[K]) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(b.Guaranteed(), a.Guaranteed())
+	})
+	return items[:min(max(n, 0), len(items))]
+}
+
+// Reset forgets all keys
+func (s *Sketch[K]) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.items = nil
+	clear(s.index)
+	s.total = 0
+}
+
+// MarshalJSON implements [json.Marshaller], it encodes all tracked items highest first
+func (s *Sketch[K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Top(s.capacity))
+}
+
+// String converts to string
+func (s *Sketch[K]) String() string {
+	items := s.Top(s.capacity)
+	return format.ValuesOf[K, Item[K]]("Sketch", int64(len(items)), format.Slice(items))
+}
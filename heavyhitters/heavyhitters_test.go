@@ -0,0 +1,94 @@
+package heavyhitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch_Offer(t *testing.T) {
+	s := New[string](3)
+	for _, key := range []string{"a", "b", "a", "c", "a", "b"} {
+		s.Offer(key)
+	}
+	assert.Equal(t, []Item[string]{{Key: "a", Count: 3}, {Key: "b", Count: 2}, {Key: "c", Count: 1}}, s.Top(5))
+	assert.Equal(t, int64(6), s.Total())
+}
+
+func TestSketch_Add(t *testing.T) {
+	s := New[string](2)
+	s.Add("a", 5)
+	s.Add("b", 2)
+	s.Add("c", 1)
+	s.Add("d", 0)
+	item, ok := s.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, Item[string]{Key: "c", Count: 3, Error: 2}, item)
+	assert.Equal(t, int64(1), item.Guaranteed())
+	_, ok = s.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, []Item[string]{{Key: "a", Count: 5}}, s.Top(1))
+}
+
+func TestSketch_AddCounts(t *testing.T) {
+	s := New[string](2)
+	s.AddCounts(map[string]int64{"a": 3, "b": 1})
+	s.AddCounts(map[string]int64{"a": 1})
+	item, _ := s.Get("a")
+	assert.Equal(t, int64(4), item.Count)
+	assert.Equal(t, int64(5), s.Total())
+}
+
+func TestSketch_Top(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := New[int](20)
+	counts := make(map[int]int64)
+	for i := 0; i < 20000; i++ {
+		// keys 0-4 are heavy, the others are noise
+		key := r.Intn(5)
+		if r.Intn(2) == 0 {
+			key = 5 + r.Intn(1000)
+		}
+		counts[key]++
+		s.Offer(key)
+	}
+	top := s.Top(5)
+	var keys []int
+	for _, item := range top {
+		keys = append(keys, item.Key)
+		assert.GreaterOrEqual(t, item.Count, counts[item.Key])
+		assert.LessOrEqual(t, item.Guaranteed(), counts[item.Key])
+	}
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, keys)
+	assert.Empty(t, s.Top(-1))
+}
+
+func TestSketch_Reset(t *testing.T) {
+	s := New[string](2)
+	s.Offer("a")
+	s.Reset()
+	assert.Empty(t, s.Top(2))
+	assert.Equal(t, int64(0), s.Total())
+	s.Offer("b")
+	assert.Len(t, s.Top(2), 1)
+}
+
+func TestSketch_MarshalJSON(t *testing.T) {
+	s := New[string](2)
+	s.Add("a", 2)
+	s.Offer("b")
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"key":"a","count":2,"error":0},{"key":"b","count":1,"error":0}]`, string(data))
+}
+
+func TestSketch_String(t *testing.T) {
+	s := New[string](2)
+	s.Add("a", 2)
+	pattern := regexp.MustCompile(fmt.Sprintf(`Sketch\[string\]\(len=%d\)\{\n\ta:\s2±0,\n\}`, 1))
+	assert.True(t, pattern.MatchString(s.String()))
+}
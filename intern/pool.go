@@ -0,0 +1,131 @@
+// Package intern provides pools of canonical strings.
+package intern
+
+import (
+	listlib "container/list"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Stats counters of a pool
+type Stats struct {
+	// Hits the lookups which returned an existing string
+	Hits int64 `json:"hits"`
+	// Misses the lookups which added a new string
+	Misses int64 `json:"misses"`
+	// Evictions the strings evicted to stay within the capacity
+	Evictions int64 `json:"evictions"`
+}
+
+// NewPool new pool which holds at most capacity strings, the least recently used string is evicted first.
+// A capacity of zero or less makes the pool unbounded.
+func NewPool(capacity int) *Pool {
+	pool := new(Pool)
+	pool.capacity = capacity
+	pool.items = make(map[string]*listlib.Element)
+	pool.recent = listlib.New()
+	return pool
+}
+
+// Pool deduplicates equal strings so that repeated values share a single canonical instance.
+// Evicting a string only stops later lookups from sharing it, the strings already returned stay valid.
+// Strings are evicted by the capacity rather than weakly once they are no longer used,
+// since the weak and unique packages need a newer Go than the module supports.
+// It is safe for concurrent use.
+type Pool struct {
+	lock     sync.Mutex
+	capacity int
+	items    map[string]*listlib.Element
+	recent   *listlib.List
+	bytes    int64
+	stats    Stats
+}
+
+// Intern returns the canonical instance of the string.
+// A new string is copied before it is added, so a substring does not keep the larger string it was sliced from alive.
+func (p *Pool) Intern(value string) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if e, ok := p.items[value]; ok {
+		return p.hit(e)
+	}
+	return p.add(strings.Clone(value))
+}
+
+// InternBytes returns the canonical string of the bytes, the lookup of an existing string does not allocate
+func (p *Pool) InternBytes(value []byte) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if e, ok := p.items[string(value)]; ok {
+		return p.hit(e)
+	}
+	return p.add(string(value))
+}
+
+func (p *Pool) hit(e *listlib.Element) string {
+	p.stats.Hits++
+	p.recent.MoveToFront(e)
+	return e.Value.(string)
+}
+
+func (p *Pool) add(value string) string {
+	p.stats.Misses++
+	p.items[value] = p.recent.PushFront(value)
+	p.bytes += int64(len(value))
+	for p.capacity > 0 && p.recent.Len() > p.capacity {
+		oldest := p.recent.Back()
+		p.recent.Remove(oldest)
+		delete(p.items, oldest.Value.(string))
+		p.bytes -= int64(len(oldest.Value.(string)))
+		p.stats.Evictions++
+	}
+	return value
+}
+
+// Contains returns whether the pool holds the string, it does not count as a lookup
+func (p *Pool) Contains(value string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.items[value]
+	return ok
+}
+
+// Count returns the number of strings in the pool
+func (p *Pool) Count() int64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return int64(len(p.items))
+}
+
+// Bytes returns the total length of the strings in the pool
+func (p *Pool) Bytes() int64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.bytes
+}
+
+// Stats returns the counters of the pool
+func (p *Pool) Stats() Stats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.stats
+}
+
+// Clear removes all strings and resets the counters
+func (p *Pool) Clear() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.items = make(map[string]*listlib.Element)
+	p.recent.Init()
+	p.bytes = 0
+	p.stats = Stats{}
+}
+
+// String converts to string
+func (p *Pool) String() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return fmt.Sprintf("Pool(len=%d, bytes=%d, hits=%d, misses=%d, evictions=%d)",
+		len(p.items), p.bytes, p.stats.Hits, p.stats.Misses, p.stats.Evictions)
+}
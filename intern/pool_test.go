@@ -0,0 +1,76 @@
+package intern
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/gopi-frame/collection/internal/stress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_Intern(t *testing.T) {
+	pool := NewPool(0)
+	a := pool.Intern(strings.Repeat("ab", 2))
+	b := pool.Intern(strings.Repeat("a", 1) + "bab")
+	assert.Equal(t, "abab", b)
+	assert.Equal(t, unsafe.StringData(a), unsafe.StringData(b))
+	assert.Equal(t, int64(1), pool.Count())
+	assert.Equal(t, int64(4), pool.Bytes())
+	assert.Equal(t, Stats{Hits: 1, Misses: 1}, pool.Stats())
+
+	line := strings.Repeat("x", 1024)
+	c := pool.Intern(line[:3])
+	assert.Equal(t, "xxx", c)
+	assert.True(t, unsafe.StringData(line) != unsafe.StringData(c))
+}
+
+func TestPool_InternBytes(t *testing.T) {
+	pool := NewPool(0)
+	a := pool.Intern("key")
+	buf := []byte("key")
+	b := pool.InternBytes(buf)
+	assert.Equal(t, unsafe.StringData(a), unsafe.StringData(b))
+	c := pool.InternBytes([]byte("other"))
+	buf[0] = 'x'
+	assert.Equal(t, "key", b)
+	assert.Equal(t, "other", c)
+	allocs := testing.AllocsPerRun(100, func() {
+		pool.InternBytes(buf[:0:0])
+		pool.InternBytes([]byte("other"))
+	})
+	assert.LessOrEqual(t, allocs, 1.0)
+}
+
+func TestPool_Eviction(t *testing.T) {
+	pool := NewPool(2)
+	pool.Intern("a")
+	pool.Intern("b")
+	pool.Intern("a")
+	pool.Intern("c")
+	assert.True(t, pool.Contains("a"))
+	assert.False(t, pool.Contains("b"))
+	assert.True(t, pool.Contains("c"))
+	assert.Equal(t, Stats{Hits: 1, Misses: 3, Evictions: 1}, pool.Stats())
+	assert.Equal(t, int64(2), pool.Bytes())
+}
+
+func TestPool_Clear(t *testing.T) {
+	pool := NewPool(0)
+	pool.Intern("a")
+	pool.Clear()
+	assert.Equal(t, int64(0), pool.Count())
+	assert.Equal(t, Stats{}, pool.Stats())
+	assert.Equal(t, "Pool(len=0, bytes=0, hits=0, misses=0, evictions=0)", pool.String())
+}
+
+func TestPool_Stress(t *testing.T) {
+	pool := NewPool(64)
+	stress.Run(t, 8, 1000, func(worker, iteration int) {
+		value := strings.Repeat("x", iteration%100)
+		assert.Equal(t, value, pool.Intern(value))
+	})
+	assert.Equal(t, int64(64), pool.Count())
+	stats := pool.Stats()
+	assert.Equal(t, int64(8000), stats.Hits+stats.Misses)
+}
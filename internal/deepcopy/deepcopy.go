@@ -0,0 +1,177 @@
+//go:build !tinygo && !collection_noreflect
+
+package deepcopy
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Reflect reports whether the deep copy based on reflect is used
+const Reflect = true
+
+// Copy returns a deep copy of the value.
+// Values with a Copy method returning their own type copy themselves.
+// Unexported struct fields are shared with the original unless unexported is true,
+// in which case they are copied too through unsafe.
+func Copy[E any](value E, unexported bool) E {
+	src := reflect.ValueOf(&value).Elem()
+	if self, ok := any(value).(interface{ Copy() E }); ok && !(src.Kind() == reflect.Pointer && src.IsNil()) {
+		return self.Copy()
+	}
+	if !hasPointers(src.Type()) {
+		return value
+	}
+	c := &copier{unexported: unexported, visited: make(map[visit]reflect.Value)}
+	dst := reflect.New(src.Type())
+	c.copy(dst.Elem(), src)
+	return *dst.Interface().(*E)
+}
+
+type visit struct {
+	pointer unsafe.Pointer
+	typ     reflect.Type
+}
+
+type copier struct {
+	unexported bool
+	visited    map[visit]reflect.Value
+}
+
+var pointerTypes sync.Map
+
+// hasPointers reports whether values of the type reference memory which a deep copy has to copy,
+// values of other types are deeply copied by assignment
+func hasPointers(t reflect.Type) bool {
+	if cached, ok := pointerTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	var result bool
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		result = true
+	case reflect.Array:
+		result = t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				result = true
+				break
+			}
+		}
+	}
+	pointerTypes.Store(t, result)
+	return result
+}
+
+var noArgs []reflect.Value
+
+// copySelf copies the value with its Copy method, it returns false when the value has none
+func (c *copier) copySelf(dst, src reflect.Value) bool {
+	if !src.CanInterface() {
+		return false
+	}
+	method, ok := src.Type().MethodByName("Copy")
+	if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != src.Type() {
+		return false
+	}
+	if src.Kind() == reflect.Pointer && src.IsNil() {
+		return false
+	}
+	dst.Set(src.Method(method.Index).Call(noArgs)[0])
+	return true
+}
+
+func (c *copier) copy(dst, src reflect.Value) {
+	if !hasPointers(src.Type()) {
+		dst.Set(src)
+		return
+	}
+	if c.copySelf(dst, src) {
+		return
+	}
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := visit{src.UnsafePointer(), src.Type()}
+		if copied, ok := c.visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		c.visited[key] = copied
+		c.copy(copied.Elem(), src.Elem())
+		dst.Set(copied)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := src.Elem()
+		copied := reflect.New(elem.Type()).Elem()
+		c.copy(copied, elem)
+		dst.Set(copied)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			c.copy(copied.Index(i), src.Index(i))
+		}
+		dst.Set(copied)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := visit{src.UnsafePointer(), src.Type()}
+		if copied, ok := c.visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.visited[key] = copied
+		value := reflect.New(src.Type().Elem()).Elem()
+		for iter := src.MapRange(); iter.Next(); {
+			value.SetZero()
+			c.copy(value, iter.Value())
+			copied.SetMapIndex(iter.Key(), value)
+		}
+		dst.Set(copied)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Struct:
+		c.copyStruct(dst, src)
+	default:
+		dst.Set(src)
+	}
+}
+
+func (c *copier) copyStruct(dst, src reflect.Value) {
+	dst.Set(src)
+	if c.unexported && !src.CanAddr() {
+		addressable := reflect.New(src.Type()).Elem()
+		addressable.Set(src)
+		src = addressable
+	}
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		if !hasPointers(field.Type) {
+			continue
+		}
+		if field.IsExported() {
+			c.copy(dst.Field(i), src.Field(i))
+		} else if c.unexported {
+			c.copy(exposed(dst.Field(i)), exposed(src.Field(i)))
+		}
+	}
+}
+
+// exposed returns the addressable unexported field as a value which can be read and set
+func exposed(field reflect.Value) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,25 @@
+//go:build tinygo || collection_noreflect
+
+package deepcopy
+
+// Reflect reports whether the deep copy based on reflect is used
+const Reflect = false
+
+// Copy returns a copy of the value by assignment, values with a Copy method returning their own type copy themselves
+// unless they are zero, so that a nil pointer is not dereferenced
+func Copy[E any](value E, _ bool) E {
+	if c, ok := any(value).(interface{ Copy() E }); ok && !isZero(value) {
+		return c.Copy()
+	}
+	return value
+}
+
+// isZero reports whether the value is the zero value of its type, values of types which are not comparable are not
+func isZero[E any](value E) (zero bool) {
+	defer func() {
+		if recover() != nil {
+			zero = false
+		}
+	}()
+	return any(value) == any(*new(E))
+}
@@ -0,0 +1,70 @@
+//go:build !tinygo && !collection_noreflect
+
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	Name     string
+	Children []*node
+	Parent   *node
+	Tags     map[string][]int
+	Any      any
+	secret   *int
+}
+
+type versioned struct {
+	Version int
+	Data    []int
+}
+
+func (v versioned) Copy() versioned {
+	return versioned{Version: v.Version + 1, Data: append([]int(nil), v.Data...)}
+}
+
+func TestCopy(t *testing.T) {
+	secret := 1
+	root := &node{Name: "root", Tags: map[string][]int{"a": {1}}, Any: []int{2}, secret: &secret}
+	root.Children = []*node{{Name: "child", Parent: root}}
+	copied := Copy(root, false)
+	assert.Equal(t, root.Name, copied.Name)
+	assert.True(t, root != copied)
+	assert.True(t, root.Children[0] != copied.Children[0])
+	assert.Same(t, copied, copied.Children[0].Parent)
+	assert.Same(t, root.secret, copied.secret)
+	copied.Tags["a"][0] = 10
+	copied.Any.([]int)[0] = 20
+	assert.Equal(t, 1, root.Tags["a"][0])
+	assert.Equal(t, 2, root.Any.([]int)[0])
+}
+
+func TestCopy_Unexported(t *testing.T) {
+	secret := 1
+	root := node{secret: &secret, Any: node{secret: &secret}}
+	copied := Copy(root, true)
+	assert.True(t, root.secret != copied.secret)
+	assert.Equal(t, 1, *copied.secret)
+	assert.True(t, root.secret != copied.Any.(node).secret)
+}
+
+func TestCopy_Copier(t *testing.T) {
+	values := []versioned{{Version: 1, Data: []int{1}}}
+	copied := Copy(values, false)
+	assert.Equal(t, 2, copied[0].Version)
+	copied[0].Data[0] = 2
+	assert.Equal(t, 1, values[0].Data[0])
+}
+
+func TestCopy_NoPointers(t *testing.T) {
+	type point struct{ X, Y int }
+	assert.Equal(t, [2]point{{1, 2}, {3, 4}}, Copy([2]point{{1, 2}, {3, 4}}, false))
+	assert.Equal(t, "a", Copy("a", false))
+	var nilSlice []int
+	assert.Nil(t, Copy(nilSlice, false))
+	var nilAny any
+	assert.Nil(t, Copy(nilAny, false))
+}
@@ -0,0 +1,6 @@
+// Package deepcopy provides the deep copies used by the collections.
+//
+// By default values are copied by reflection. When built with TinyGo or with the
+// collection_noreflect build tag, values are copied with assignment instead,
+// so pointers, slices and maps are shared with the original.
+package deepcopy
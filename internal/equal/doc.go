@@ -0,0 +1,7 @@
+// Package equal provides the element equality used by the collections.
+//
+// By default elements are compared with [reflect.DeepEqual]. When built with TinyGo
+// or with the collection_noreflect build tag, elements are compared with == instead,
+// which avoids reflect: pointers, channels and interfaces are compared by identity,
+// and values of non-comparable types such as slices, maps and funcs are never equal.
+package equal
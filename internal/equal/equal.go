@@ -0,0 +1,13 @@
+//go:build !tinygo && !collection_noreflect
+
+package equal
+
+import "reflect"
+
+// Reflect reports whether the deep equality based on reflect is used
+const Reflect = true
+
+// Equal reports whether a and b are deeply equal
+func Equal[E any](a, b E) bool {
+	return reflect.DeepEqual(a, b)
+}
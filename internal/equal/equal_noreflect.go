@@ -0,0 +1,16 @@
+//go:build tinygo || collection_noreflect
+
+package equal
+
+// Reflect reports whether the deep equality based on reflect is used
+const Reflect = false
+
+// Equal reports whether a and b are equal with ==, values of non-comparable types are never equal
+func Equal[E any](a, b E) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = false
+		}
+	}()
+	return any(a) == any(b)
+}
@@ -0,0 +1,27 @@
+package equal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	type point struct {
+		x, y int
+	}
+	assert.True(t, Equal(1, 1))
+	assert.False(t, Equal(1, 2))
+	assert.True(t, Equal("a", "a"))
+	assert.True(t, Equal(point{1, 2}, point{1, 2}))
+	assert.False(t, Equal(point{1, 2}, point{2, 1}))
+	assert.True(t, Equal[any](1, 1))
+	assert.False(t, Equal[any](1, "1"))
+}
+
+func TestEqual_NonComparable(t *testing.T) {
+	a, b := []int{1, 2}, []int{1, 2}
+	assert.Equal(t, Reflect, Equal(a, b))
+	assert.Equal(t, Reflect, Equal(map[string]int{"a": 1}, map[string]int{"a": 1}))
+	assert.False(t, Equal[func()](nil, func() {}))
+}
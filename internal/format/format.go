@@ -0,0 +1,102 @@
+// Package format formats the collections for their String methods, so they share one style:
+//
+//	Name[E](len=n){
+//		value,
+//		...
+//	}
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gopi-frame/contract"
+)
+
+// Limit the number of elements or entries listed, the rest are elided by "..."
+const Limit = 5
+
+// Values formats the elements visited by each as `name[E](len=count){...}`
+func Values[E any](name string, count int64, each func(yield func(value E) bool)) string {
+	return ValuesOf[E, E](name, count, each)
+}
+
+// ValuesOf formats the elements of a collection as values W derived from E, such as counted keys,
+// the header names the type parameter E of the collection
+func ValuesOf[E, W any](name string, count int64, each func(yield func(value W) bool)) string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("%s[%T](len=%d)", name, *new(E), count))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	listed := 0
+	each(func(value W) bool {
+		if listed == Limit {
+			return false
+		}
+		listed++
+		str.WriteByte('\t')
+		Value(str, value)
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		return true
+	})
+	if count > Limit {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
+
+// Entries formats the entries visited by each as `name[K, V](len=count){key: value, ...}`
+func Entries[K, V any](name string, count int64, each func(yield func(key K, value V) bool)) string {
+	return EntriesOf[K, V, V](name, count, each)
+}
+
+// EntriesOf formats the entries of a map with values W derived from V, such as a multimap of []V,
+// the header names the type parameters K and V of the map
+func EntriesOf[K, V, W any](name string, count int64, each func(yield func(key K, value W) bool)) string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("%s[%T, %T](len=%d)", name, *new(K), *new(V), count))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	listed := 0
+	each(func(key K, value W) bool {
+		if listed == Limit {
+			return false
+		}
+		listed++
+		str.WriteByte('\t')
+		Value(str, key)
+		str.WriteByte(':')
+		str.WriteByte(' ')
+		Value(str, value)
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		return true
+	})
+	if count > Limit {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
+
+// Slice returns a sequence of the elements of the slice
+func Slice[E any](items []E) func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Value writes the value with its String method when it is [contract.Stringable], otherwise with %v
+func Value(str *strings.Builder, value any) {
+	if v, ok := value.(contract.Stringable); ok {
+		str.WriteString(v.String())
+		return
+	}
+	str.WriteString(fmt.Sprintf("%v", value))
+}
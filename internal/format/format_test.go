@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type named string
+
+func (n named) String() string {
+	return "<" + string(n) + ">"
+}
+
+func values[E any](items ...E) func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	assert.Equal(t, "List[int](len=0){\n}", Values("List", 0, values[int]()))
+	assert.Equal(t, "Set[format.named](len=2){\n\t<a>,\n\t<b>,\n}", Values("Set", 2, values[named]("a", "b")))
+	assert.Equal(t,
+		"List[int](len=6){\n\t1,\n\t2,\n\t3,\n\t4,\n\t5,\n\t...\n}",
+		Values("List", 6, values(1, 2, 3, 4, 5, 6)))
+}
+
+func TestEntries(t *testing.T) {
+	each := func(yield func(key string, value int) bool) {
+		for i := 0; i < 7; i++ {
+			if !yield(string(rune('a'+i)), i) {
+				return
+			}
+		}
+	}
+	assert.Equal(t,
+		"Map[string, int](len=7){\n\ta: 0,\n\tb: 1,\n\tc: 2,\n\td: 3,\n\te: 4,\n\t...\n}",
+		Entries("Map", 7, each))
+}
+
+func TestEntriesOf(t *testing.T) {
+	each := func(yield func(key string, values []int) bool) {
+		yield("a", []int{1, 2})
+	}
+	assert.Equal(t, "QuotaMap[string, int](len=1){\n\ta: [1 2],\n}", EntriesOf[string, int, []int]("QuotaMap", 1, each))
+}
@@ -0,0 +1,48 @@
+// Package jsonx implements the JSON conventions shared by the collections.
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/gopi-frame/collection"
+)
+
+var null = []byte("null")
+
+// IsNull returns whether the data is the JSON null literal
+func IsNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), null)
+}
+
+// Empty returns the literal of an empty collection, null when [collection.MarshalEmptyAsNull] is set
+func Empty(literal string) []byte {
+	if collection.MarshalEmptyAsNull() {
+		return []byte("null")
+	}
+	return []byte(literal)
+}
+
+// Array marshals the elements as an array
+func Array[E any](items []E) ([]byte, error) {
+	if len(items) == 0 {
+		return Empty("[]"), nil
+	}
+	return json.Marshal(items)
+}
+
+// SortedArray marshals the elements as an array sorted by the callback, the items are not modified
+func SortedArray[E any](items []E, callback func(a, b E) int) ([]byte, error) {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, callback)
+	return Array(sorted)
+}
+
+// Object marshals the map as an object
+func Object[K comparable, V any](items map[K]V) ([]byte, error) {
+	if len(items) == 0 {
+		return Empty("{}"), nil
+	}
+	return json.Marshal(items)
+}
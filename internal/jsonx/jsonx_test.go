@@ -0,0 +1,55 @@
+package jsonx
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNull(t *testing.T) {
+	assert.True(t, IsNull([]byte("null")))
+	assert.True(t, IsNull([]byte(" null\n")))
+	assert.False(t, IsNull([]byte("[]")))
+	assert.False(t, IsNull([]byte(`"null"`)))
+}
+
+func TestArray(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	collection.SetMarshalEmptyAsNull(false)
+	data, err := Array[int](nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+	data, err = Array([]int{1})
+	assert.Nil(t, err)
+	assert.Equal(t, "[1]", string(data))
+
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = Array([]int{})
+	assert.Equal(t, "null", string(data))
+}
+
+func TestObject(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	collection.SetMarshalEmptyAsNull(false)
+	data, err := Object[string, int](nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(data))
+
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = Object(map[string]int{})
+	assert.Equal(t, "null", string(data))
+	data, _ = Object(map[string]int{"a": 1})
+	assert.Equal(t, `{"a":1}`, string(data))
+}
+
+func TestSortedArray(t *testing.T) {
+	items := []int{3, 1, 2}
+	data, err := SortedArray(items, func(a, b int) int { return b - a })
+	assert.Nil(t, err)
+	assert.Equal(t, "[3,2,1]", string(data))
+	data, err = SortedArray(items, func(a, b int) int { return a - b })
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(data))
+	assert.Equal(t, []int{3, 1, 2}, items)
+}
@@ -0,0 +1,60 @@
+// Package memory estimates the memory footprint of collections.
+//
+// Estimates count the inline size of the elements, the structure overhead of the collection
+// and the memory reported by an optional deep size hook. They ignore allocator size classes and padding,
+// and assume values stored in an interface are boxed.
+package memory
+
+import "unsafe"
+
+// Word size of a pointer
+const Word = int64(unsafe.Sizeof(uintptr(0)))
+
+// Of returns the inline size of a value of the type
+func Of[E any]() int64 {
+	return int64(unsafe.Sizeof(*new(E)))
+}
+
+// Boxed returns the size of a value of the type stored in an interface, including the interface
+func Boxed[E any]() int64 {
+	return 2*Word + Of[E]()
+}
+
+// Deep returns the sum of the deep sizes of the values, it returns 0 when deep is nil
+func Deep[E any](deep func(value E) int64, each func(callback func(index int, value E) bool)) int64 {
+	if deep == nil {
+		return 0
+	}
+	var size int64
+	each(func(_ int, value E) bool {
+		size += deep(value)
+		return true
+	})
+	return size
+}
+
+// Slice returns the footprint of the backing array of the slice and the deep sizes of its elements
+func Slice[E any](items []E, deep func(value E) int64) int64 {
+	size := int64(cap(items)) * Of[E]()
+	if deep != nil {
+		for _, item := range items {
+			size += deep(item)
+		}
+	}
+	return size
+}
+
+// Map returns the footprint of a built-in map of the given length, excluding deep sizes.
+// It models buckets of 8 entries grown at a load factor of 6.5.
+func Map[K comparable, V any](length int) int64 {
+	buckets := int64(1)
+	for float64(length) > 6.5*float64(buckets) {
+		buckets *= 2
+	}
+	return 6*Word + buckets*(8+8*(Of[K]()+Of[V]())+Word)
+}
+
+// LinkedElement returns the footprint of an element of container/list holding a value of the type
+func LinkedElement[E any]() int64 {
+	return 3*Word + Boxed[E]()
+}
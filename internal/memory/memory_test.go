@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	assert.Equal(t, int64(8), Of[int64]())
+	assert.Equal(t, 2*Word, Of[string]())
+	assert.Equal(t, int64(0), Of[struct{}]())
+}
+
+func TestSlice(t *testing.T) {
+	items := make([]string, 2, 4)
+	items[0], items[1] = "ab", "cde"
+	assert.Equal(t, 4*2*Word, Slice(items, nil))
+	assert.Equal(t, 4*2*Word+5, Slice(items, func(value string) int64 {
+		return int64(len(value))
+	}))
+}
+
+func TestDeep(t *testing.T) {
+	each := func(callback func(int, string) bool) {
+		for index, value := range []string{"a", "bc"} {
+			callback(index, value)
+		}
+	}
+	assert.Equal(t, int64(0), Deep(nil, each))
+	assert.Equal(t, int64(3), Deep(func(value string) int64 { return int64(len(value)) }, each))
+}
+
+func TestMap(t *testing.T) {
+	assert.Less(t, Map[int, int](0), Map[int, int](100))
+	assert.Equal(t, Map[int, int](6), Map[int, int](1))
+	assert.Less(t, Map[int, int](100), Map[string, string](100))
+}
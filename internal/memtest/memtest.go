@@ -0,0 +1,38 @@
+// Package memtest provides the shared checks of the MemoryFootprint tests of the collections.
+package memtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Case collection of ints whose footprint is checked
+type Case struct {
+	Name string
+	// Count the number of elements the collection holds, equal elements stored once count once
+	Count int64
+	// Footprint returns the footprint of the collection, deep may be nil
+	Footprint func(deep func(value int) int64) int64
+	// Add adds the value to the collection
+	Add func(value int)
+}
+
+// Run checks every case: the shallow footprint is positive, deep is added once per element
+// and adding elements increases the footprint
+func Run(t *testing.T, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			shallow := c.Footprint(nil)
+			assert.Greater(t, shallow, int64(0))
+			assert.Equal(t, shallow+c.Count, c.Footprint(func(int) int64 {
+				return 1
+			}))
+			for i := 0; i < 100; i++ {
+				c.Add(1000 + i)
+			}
+			assert.Greater(t, c.Footprint(nil), shallow)
+		})
+	}
+}
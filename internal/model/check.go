@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Op operation which is applied to both the subject and the reference.
+// Run decodes its operands from the reader and returns the observations of the subject and the reference,
+// which must be equal.
+type Op[S, R any] struct {
+	Name string
+	Run  func(subject S, reference R, r *Reader) (got, want any)
+}
+
+// Check replays the operations decoded from data against the subject and the reference,
+// the observations of every operation and of the observe function after every operation must be equal.
+// A failure reports the replayed operations.
+func Check[S, R any](tb testing.TB, subject S, reference R, data []byte, ops []Op[S, R], observe func(subject S, reference R) (got, want any)) {
+	tb.Helper()
+	var trace []string
+	r := NewReader(data)
+	for r.More() {
+		op := ops[r.Intn(len(ops))]
+		trace = append(trace, op.Name)
+		if got, want := op.Run(subject, reference, r); !Equal(got, want) {
+			tb.Fatalf("%s: got %v, want %v\noperations: %s", op.Name, got, want, strings.Join(trace, ", "))
+		}
+		if observe == nil {
+			continue
+		}
+		if got, want := observe(subject, reference); !Equal(got, want) {
+			tb.Fatalf("after %s: got %v, want %v\noperations: %s", op.Name, got, want, strings.Join(trace, ", "))
+		}
+	}
+}
+
+// Replay runs fn with random inputs generated from the seeds 0 to runs-1,
+// a failing seed can be replayed with [Random].
+func Replay(t *testing.T, runs, size int, fn func(t *testing.T, data []byte)) {
+	t.Helper()
+	for seed := int64(0); seed < int64(runs); seed++ {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			fn(t, Random(seed, size))
+		})
+	}
+}
+
+// Random returns size pseudo random bytes generated from the seed
+func Random(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+// Equal reports whether the observations are deeply equal, nil and empty slices or maps are equal
+func Equal(a, b any) bool {
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if value := b.MapIndex(key); !value.IsValid() || !equal(a.MapIndex(key), value) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				return reflect.DeepEqual(a.Interface(), b.Interface())
+			}
+			if !equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		return equal(a.Elem(), b.Elem())
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+// Pair groups two observations of an operation, such as the value and the ok flag
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// P creates a pair, it accepts the results of a two-valued call directly
+func P[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
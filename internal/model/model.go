@@ -0,0 +1,156 @@
+// Package model provides naive reference implementations which the collections are checked against in tests.
+package model
+
+// List reference list over a plain slice
+type List[E any] struct {
+	Items []E
+}
+
+// Len returns the size of the list
+func (l *List[E]) Len() int {
+	return len(l.Items)
+}
+
+// Push appends the value
+func (l *List[E]) Push(value E) {
+	l.Items = append(l.Items, value)
+}
+
+// Unshift prepends the value
+func (l *List[E]) Unshift(value E) {
+	l.Items = append([]E{value}, l.Items...)
+}
+
+// Pop removes the last element
+func (l *List[E]) Pop() (E, bool) {
+	if len(l.Items) == 0 {
+		return *new(E), false
+	}
+	value := l.Items[len(l.Items)-1]
+	l.Items = l.Items[:len(l.Items)-1]
+	return value, true
+}
+
+// Shift removes the first element
+func (l *List[E]) Shift() (E, bool) {
+	if len(l.Items) == 0 {
+		return *new(E), false
+	}
+	value := l.Items[0]
+	l.Items = l.Items[1:]
+	return value, true
+}
+
+// Get returns the element on the index
+func (l *List[E]) Get(index int) (E, bool) {
+	if index < 0 || index >= len(l.Items) {
+		return *new(E), false
+	}
+	return l.Items[index], true
+}
+
+// Set sets the element on the index
+func (l *List[E]) Set(index int, value E) bool {
+	if index < 0 || index >= len(l.Items) {
+		return false
+	}
+	l.Items[index] = value
+	return true
+}
+
+// RemoveAt removes the element on the index
+func (l *List[E]) RemoveAt(index int) bool {
+	if index < 0 || index >= len(l.Items) {
+		return false
+	}
+	items := make([]E, 0, len(l.Items)-1)
+	items = append(items, l.Items[:index]...)
+	l.Items = append(items, l.Items[index+1:]...)
+	return true
+}
+
+// Clear removes all elements
+func (l *List[E]) Clear() {
+	l.Items = nil
+}
+
+// Map reference map which remembers the insertion order of keys
+type Map[K comparable, V any] struct {
+	Keys   []K
+	Values map[K]V
+}
+
+// Len returns the size of the map
+func (m *Map[K, V]) Len() int {
+	return len(m.Keys)
+}
+
+// Get returns the value of the key
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	value, ok := m.Values[key]
+	return value, ok
+}
+
+// Set sets the value of the key, a new key is appended to the keys
+func (m *Map[K, V]) Set(key K, value V) {
+	if m.Values == nil {
+		m.Values = make(map[K]V)
+	}
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = value
+}
+
+// Remove removes the key
+func (m *Map[K, V]) Remove(key K) {
+	if _, ok := m.Values[key]; !ok {
+		return
+	}
+	delete(m.Values, key)
+	for i, k := range m.Keys {
+		if k == key {
+			m.Keys = append(m.Keys[:i:i], m.Keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Clear removes all keys
+func (m *Map[K, V]) Clear() {
+	m.Keys = nil
+	m.Values = nil
+}
+
+// Reader decodes fuzz input into operations and operands
+type Reader struct {
+	data []byte
+}
+
+// NewReader new reader over the fuzz input
+func NewReader(data []byte) *Reader {
+	return &Reader{data: data}
+}
+
+// More returns whether there is input left
+func (r *Reader) More() bool {
+	return len(r.data) > 0
+}
+
+// Byte consumes a byte, it returns zero when the input is exhausted
+func (r *Reader) Byte() byte {
+	if len(r.data) == 0 {
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+// Intn consumes a byte and returns it as an int in [0, n)
+func (r *Reader) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(r.Byte()) % n
+}
@@ -0,0 +1,98 @@
+// Package stress provides a goroutine storm harness for the concurrency tests of the thread-safe collections.
+package stress
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Run starts the workers at the same time, every worker calls fn with its worker id and iteration number,
+// it waits until all workers are done. Run the tests with -race to detect data races.
+func Run(tb testing.TB, workers, iterations int, fn func(worker, iteration int)) {
+	tb.Helper()
+	start := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < iterations; i++ {
+				fn(worker, i)
+			}
+		}(w)
+	}
+	close(start)
+	wg.Wait()
+}
+
+// Watch checks the invariant repeatedly in background until the returned stop function is called,
+// every violation is reported as a test error.
+func Watch(tb testing.TB, invariant func() error) (stop func()) {
+	tb.Helper()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			if err := invariant(); err != nil {
+				tb.Errorf("invariant violated: %v", err)
+			}
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Microsecond):
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// Exactly returns a recorder which checks that every value is recorded exactly once
+func Exactly[E comparable]() *Recorder[E] {
+	return &Recorder[E]{seen: make(map[E]int)}
+}
+
+// Recorder records values from concurrent workers
+type Recorder[E comparable] struct {
+	mu   sync.Mutex
+	seen map[E]int
+}
+
+// Record records the value
+func (r *Recorder[E]) Record(value E) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen[value]++
+}
+
+// Verify reports an error for every expected value which was not recorded exactly once
+func (r *Recorder[E]) Verify(tb testing.TB, expected ...E) {
+	tb.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.seen) != len(expected) {
+		tb.Errorf("recorded %d distinct values, expected %d", len(r.seen), len(expected))
+	}
+	for _, value := range expected {
+		if count := r.seen[value]; count != 1 {
+			tb.Errorf("value %v recorded %d times", value, count)
+		}
+	}
+}
+
+// VerifyUnique reports an error for every value which was recorded more than once
+func (r *Recorder[E]) VerifyUnique(tb testing.TB) {
+	tb.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for value, count := range r.seen {
+		if count > 1 {
+			tb.Errorf("value %v recorded %d times", value, count)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Package intervals provides sets of disjoint intervals.
+package intervals
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+// Number numeric types the intervals are made of
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Interval half-open interval [Start, End)
+type Interval[E Number] struct {
+	Start E `json:"start"`
+	End   E `json:"end"`
+}
+
+// Len returns the length of the interval
+func (i Interval[E]) Len() E {
+	if i.End <= i.Start {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// IsEmpty returns whether the interval contains no point
+func (i Interval[E]) IsEmpty() bool {
+	return i.End <= i.Start
+}
+
+// Contains returns whether the interval contains the point
+func (i Interval[E]) Contains(point E) bool {
+	return i.Start <= point && point < i.End
+}
+
+// String converts to string
+func (i Interval[E]) String() string {
+	return fmt.Sprintf("[%v, %v)", i.Start, i.End)
+}
+
+// NewSet new interval set
+func NewSet[E Number](intervals ...Interval[E]) *Set[E] {
+	set := new(Set[E])
+	for _, interval := range intervals {
+		set.Add(interval.Start, interval.End)
+	}
+	return set
+}
+
+// Set set of points stored as sorted disjoint intervals, overlapping and adjacent intervals are merged
+type Set[E Number] struct {
+	sync.RWMutex
+	items []Interval[E]
+}
+
+// search returns the index of the first interval which ends after the point,
+// or at the point when inclusive is true
+func (s *Set[E]) search(point E, inclusive bool) int {
+	index, _ := slices.BinarySearchFunc(s.items, point, func(interval Interval[E], point E) int {
+		if interval.End < point || (!inclusive && interval.End == point) {
+			return -1
+		}
+		return 1
+	})
+	return index
+}
+
+// Count returns the number of disjoint intervals
+func (s *Set[E]) Count() int64 {
+	return int64(len(s.items))
+}
+
+// IsEmpty returns whether the set is empty
+func (s *Set[E]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// IsNotEmpty returns whether the set is not empty
+func (s *Set[E]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// ElementType returns the name of the type of the interval bounds
+func (s *Set[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *Set[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
+// Add adds the interval [start, end), merging the intervals it overlaps or touches.
+// Empty intervals are ignored.
+func (s *Set[E]) Add(start, end E) {
+	if end <= start {
+		return
+	}
+	from := s.search(start, true)
+	to := from
+	for to < len(s.items) && s.items[to].Start <= end {
+		to++
+	}
+	if from < to {
+		start = min(start, s.items[from].Start)
+		end = max(end, s.items[to-1].End)
+	}
+	s.items = slices.Replace(s.items, from, to, Interval[E]{Start: start, End: end})
+}
+
+// Remove removes the points of the interval [start, end), splitting the intervals it cuts through
+func (s *Set[E]) Remove(start, end E) {
+	if end <= start {
+		return
+	}
+	from := s.search(start, false)
+	to := from
+	for to < len(s.items) && s.items[to].Start < end {
+		to++
+	}
+	if from == to {
+		return
+	}
+	var pieces []Interval[E]
+	if first := s.items[from]; first.Start < start {
+		pieces = append(pieces, Interval[E]{Start: first.Start, End: start})
+	}
+	if last := s.items[to-1]; last.End > end {
+		pieces = append(pieces, Interval[E]{Start: end, End: last.End})
+	}
+	s.items = slices.Replace(s.items, from, to, pieces...)
+}
+
+// Contains returns whether the set contains the point
+func (s *Set[E]) Contains(point E) bool {
+	index := s.search(point, false)
+	return index < len(s.items) && s.items[index].Start <= point
+}
+
+// ContainsInterval returns whether the set contains every point of the interval [start, end)
+func (s *Set[E]) ContainsInterval(start, end E) bool {
+	if end <= start {
+		return true
+	}
+	index := s.search(start, false)
+	return index < len(s.items) && s.items[index].Start <= start && end <= s.items[index].End
+}
+
+// Find returns the interval containing the point.
+// It returns an empty interval and false when no interval contains the point.
+func (s *Set[E]) Find(point E) (Interval[E], bool) {
+	index := s.search(point, false)
+	if index < len(s.items) && s.items[index].Start <= point {
+		return s.items[index], true
+	}
+	return Interval[E]{}, false
+}
+
+// Length returns the total length covered by the set
+func (s *Set[E]) Length() E {
+	var length E
+	for _, interval := range s.items {
+		length += interval.Len()
+	}
+	return length
+}
+
+// Bounds returns the smallest interval containing the set.
+// It returns an empty interval and false when the set is empty.
+func (s *Set[E]) Bounds() (Interval[E], bool) {
+	if len(s.items) == 0 {
+		return Interval[E]{}, false
+	}
+	return Interval[E]{Start: s.items[0].Start, End: s.items[len(s.items)-1].End}, true
+}
+
+// Gaps returns the intervals between the intervals of the set
+func (s *Set[E]) Gaps() []Interval[E] {
+	var gaps []Interval[E]
+	for index := 1; index < len(s.items); index++ {
+		gaps = append(gaps, Interval[E]{Start: s.items[index-1].End, End: s.items[index].Start})
+	}
+	return gaps
+}
+
+// Invert returns the complement of the set within the interval [start, end)
+func (s *Set[E]) Invert(start, end E) *Set[E] {
+	inverted := NewSet[E]()
+	cursor := start
+	for _, interval := range s.items {
+		if interval.End <= cursor {
+			continue
+		}
+		if interval.Start >= end {
+			break
+		}
+		if cursor < interval.Start {
+			inverted.items = append(inverted.items, Interval[E]{Start: cursor, End: interval.Start})
+		}
+		cursor = interval.End
+	}
+	if cursor < end {
+		inverted.items = append(inverted.items, Interval[E]{Start: cursor, End: end})
+	}
+	return inverted
+}
+
+// Each runs callback for each interval in ascending order, it breaks when callback false
+func (s *Set[E]) Each(callback func(index int, interval Interval[E]) bool) {
+	for index, interval := range s.items {
+		if !callback(index, interval) {
+			break
+		}
+	}
+}
+
+// Clear clears the set
+func (s *Set[E]) Clear() {
+	s.items = nil
+}
+
+// Clone clones the set
+func (s *Set[E]) Clone() *Set[E] {
+	return &Set[E]{items: slices.Clone(s.items)}
+}
+
+// ToArray converts to array of the intervals in ascending order
+func (s *Set[E]) ToArray() []Interval[E] {
+	return slices.Clone(s.items)
+}
+
+// ToJSON converts to json
+func (s *Set[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(s.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *Set[E]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (s *Set[E]) UnmarshalJSON(data []byte) error {
+	var items []Interval[E]
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	for _, item := range items {
+		s.Add(item.Start, item.End)
+	}
+	return nil
+}
+
+// String converts to string
+func (s *Set[E]) String() string {
+	return format.ValuesOf[E, Interval[E]]("IntervalSet", s.Count(), format.Slice(s.items))
+}
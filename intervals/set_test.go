@@ -0,0 +1,110 @@
+package intervals
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_Add(t *testing.T) {
+	s := NewSet[int]()
+	s.Add(10, 20)
+	s.Add(30, 40)
+	s.Add(5, 5)
+	assert.Equal(t, []Interval[int]{{10, 20}, {30, 40}}, s.ToArray())
+	s.Add(20, 25)
+	assert.Equal(t, []Interval[int]{{10, 25}, {30, 40}}, s.ToArray())
+	s.Add(0, 35)
+	assert.Equal(t, []Interval[int]{{0, 40}}, s.ToArray())
+}
+
+func TestSet_Remove(t *testing.T) {
+	s := NewSet(Interval[int]{0, 10}, Interval[int]{20, 30})
+	s.Remove(5, 25)
+	assert.Equal(t, []Interval[int]{{0, 5}, {25, 30}}, s.ToArray())
+	s.Remove(2, 3)
+	assert.Equal(t, []Interval[int]{{0, 2}, {3, 5}, {25, 30}}, s.ToArray())
+	s.Remove(10, 20)
+	assert.Equal(t, int64(3), s.Count())
+	s.Remove(0, 100)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSet_Contains(t *testing.T) {
+	s := NewSet(Interval[float64]{0, 1.5}, Interval[float64]{2, 3})
+	assert.True(t, s.Contains(0))
+	assert.True(t, s.Contains(1.4))
+	assert.False(t, s.Contains(1.5))
+	assert.False(t, s.Contains(-1))
+	assert.True(t, s.ContainsInterval(2, 3))
+	assert.False(t, s.ContainsInterval(1, 2.5))
+	interval, ok := s.Find(2.5)
+	assert.True(t, ok)
+	assert.Equal(t, Interval[float64]{2, 3}, interval)
+}
+
+func TestSet_Length(t *testing.T) {
+	s := NewSet(Interval[int]{0, 10}, Interval[int]{5, 15}, Interval[int]{20, 25})
+	assert.Equal(t, 20, s.Length())
+	bounds, ok := s.Bounds()
+	assert.True(t, ok)
+	assert.Equal(t, Interval[int]{0, 25}, bounds)
+}
+
+func TestSet_Gaps(t *testing.T) {
+	s := NewSet(Interval[int]{9, 12}, Interval[int]{13, 17}, Interval[int]{18, 19})
+	assert.Equal(t, []Interval[int]{{12, 13}, {17, 18}}, s.Gaps())
+	assert.Empty(t, NewSet(Interval[int]{0, 1}).Gaps())
+}
+
+func TestSet_Invert(t *testing.T) {
+	s := NewSet(Interval[int]{9, 12}, Interval[int]{13, 17})
+	assert.Equal(t, []Interval[int]{{8, 9}, {12, 13}, {17, 20}}, s.Invert(8, 20).ToArray())
+	assert.Equal(t, []Interval[int]{{12, 13}}, s.Invert(10, 15).ToArray())
+	assert.Equal(t, []Interval[int]{{0, 5}}, NewSet[int]().Invert(0, 5).ToArray())
+}
+
+func TestSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewSet[int]()
+	var points [100]bool
+	for i := 0; i < 1000; i++ {
+		start, end := r.Intn(100), r.Intn(100)
+		add := r.Intn(2) == 0
+		if add {
+			s.Add(start, end)
+		} else {
+			s.Remove(start, end)
+		}
+		for p := start; p < end; p++ {
+			points[p] = add
+		}
+		length := 0
+		for p, covered := range points {
+			assert.Equal(t, covered, s.Contains(p))
+			if covered {
+				length++
+			}
+		}
+		assert.Equal(t, length, s.Length())
+		for _, gap := range s.Gaps() {
+			assert.False(t, gap.IsEmpty())
+		}
+	}
+}
+
+func TestSet_UnmarshalJSON(t *testing.T) {
+	s := NewSet[int]()
+	assert.Nil(t, json.Unmarshal([]byte(`[{"start":5,"end":8},{"start":0,"end":6}]`), s))
+	assert.Equal(t, []Interval[int]{{0, 8}}, s.ToArray())
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"start":0,"end":8}]`, string(data))
+}
+
+func TestSet_String(t *testing.T) {
+	s := NewSet(Interval[int]{0, 1}, Interval[int]{2, 3})
+	assert.Equal(t, "IntervalSet[int](len=2){\n\t[0, 1),\n\t[2, 3),\n}", s.String())
+}
@@ -0,0 +1,67 @@
+package collection
+
+import "fmt"
+
+// CollectionKind kind of a collection
+type CollectionKind uint8
+
+const (
+	// KindUnknown the kind is not reported
+	KindUnknown CollectionKind = iota
+	// KindList ordered elements addressed by index
+	KindList
+	// KindQueue elements taken out in the order of the queue
+	KindQueue
+	// KindStack elements taken out last in first out
+	KindStack
+	// KindSet distinct elements
+	KindSet
+	// KindMap values addressed by key
+	KindMap
+	// KindTree elements held in a tree
+	KindTree
+)
+
+// String returns the name of the kind
+func (k CollectionKind) String() string {
+	switch k {
+	case KindList:
+		return "list"
+	case KindQueue:
+		return "queue"
+	case KindStack:
+		return "stack"
+	case KindSet:
+		return "set"
+	case KindMap:
+		return "map"
+	case KindTree:
+		return "tree"
+	default:
+		return "unknown"
+	}
+}
+
+// Introspectable collection which describes itself,
+// so tools such as debuggers and admin endpoints can report a collection without a type switch over its instantiations
+type Introspectable interface {
+	// ElementType returns the name of the element type, the value type for maps
+	ElementType() string
+	// Kind returns the kind of the collection
+	Kind() CollectionKind
+	// Count returns the size of the collection
+	Count() int64
+}
+
+// KeyedIntrospectable map which also reports its key type
+type KeyedIntrospectable interface {
+	Introspectable
+	// KeyType returns the name of the key type
+	KeyType() string
+}
+
+// TypeName returns the name of the type as printed by %T, interface types are named rather than printed as <nil>.
+// It relies on fmt even with the collection_noreflect build tag.
+func TypeName[E any]() string {
+	return fmt.Sprintf("%T", new(E))[1:]
+}
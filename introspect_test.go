@@ -0,0 +1,93 @@
+package collection_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/bktree"
+	"github.com/gopi-frame/collection/crdt"
+	"github.com/gopi-frame/collection/heavyhitters"
+	"github.com/gopi-frame/collection/intervals"
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/queue"
+	"github.com/gopi-frame/collection/set"
+	"github.com/gopi-frame/collection/slotmap"
+	"github.com/gopi-frame/collection/spatial"
+	"github.com/gopi-frame/collection/stack"
+	"github.com/gopi-frame/collection/tree"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ collection.Introspectable      = (*list.List[any])(nil)
+	_ collection.Introspectable      = (*list.LinkedList[any])(nil)
+	_ collection.Introspectable      = (*list.SyncList[any])(nil)
+	_ collection.Introspectable      = (*list.HandleList[any])(nil)
+	_ collection.Introspectable      = (*list.UnrolledList[any])(nil)
+	_ collection.Introspectable      = (*list.SortedList[any])(nil)
+	_ collection.Introspectable      = (*queue.Queue[any])(nil)
+	_ collection.Introspectable      = (*queue.LinkedQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.Deque[any])(nil)
+	_ collection.Introspectable      = (*queue.PriorityQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.StablePriorityQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.BlockingQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.LinkedBlockingQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.PriorityBlockingQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.ChanQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.LeaseQueue[any])(nil)
+	_ collection.Introspectable      = (*queue.MultiQueue[any])(nil)
+	_ collection.Introspectable      = (*stack.Stack[any])(nil)
+	_ collection.Introspectable      = (*set.Set[int])(nil)
+	_ collection.Introspectable      = (*set.HashSet[any])(nil)
+	_ collection.Introspectable      = (*set.LinkedSet[int])(nil)
+	_ collection.Introspectable      = (*set.SortedSet[any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.Map[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.LinkedMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.OrderedMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.ConcurrentMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.RCUMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.ExpiringMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.EnumMap[int, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.QuotaMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.HashMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.FlatMap[string, any])(nil)
+	_ collection.KeyedIntrospectable = (*kv.LRUCache[string, any])(nil)
+	_ collection.Introspectable      = (*tree.AVLTree[any])(nil)
+	_ collection.Introspectable      = (*tree.RBTree[any])(nil)
+	_ collection.Introspectable      = (*tree.Tree[any])(nil)
+	_ collection.Introspectable      = (*crdt.ORSet[int])(nil)
+	_ collection.KeyedIntrospectable = (*crdt.LWWMap[string, any])(nil)
+	_ collection.Introspectable      = (*intervals.Set[int])(nil)
+	_ collection.Introspectable      = (*bktree.Tree[any])(nil)
+	_ collection.Introspectable      = (*spatial.KDTree[any])(nil)
+	_ collection.KeyedIntrospectable = (*slotmap.SlotMap[any])(nil)
+	_ collection.KeyedIntrospectable = (*heavyhitters.Sketch[string])(nil)
+)
+
+func TestTypeName(t *testing.T) {
+	assert.Equal(t, "int", collection.TypeName[int]())
+	assert.Equal(t, "interface {}", collection.TypeName[any]())
+	assert.Equal(t, "fmt.Stringer", collection.TypeName[fmt.Stringer]())
+	assert.Equal(t, "[]string", collection.TypeName[[]string]())
+}
+
+func TestIntrospectable(t *testing.T) {
+	var collections []collection.Introspectable = []collection.Introspectable{
+		list.NewList(1, 2),
+		queue.NewLinkedQueue("a"),
+		stack.NewStack[*testing.T](),
+		set.NewSet(1.5),
+		kv.NewMap[string, []byte](),
+	}
+	var described []string
+	for _, c := range collections {
+		described = append(described, fmt.Sprintf("%s<%s>(%d)", c.Kind(), c.ElementType(), c.Count()))
+	}
+	assert.Equal(t, []string{"list<int>(2)", "queue<string>(1)", "stack<*testing.T>(0)", "set<float64>(1)", "map<[]uint8>(0)"}, described)
+	m, ok := collections[4].(collection.KeyedIntrospectable)
+	assert.True(t, ok)
+	assert.Equal(t, "string", m.KeyType())
+	assert.Equal(t, "unknown", collection.KindUnknown.String())
+}
@@ -0,0 +1,17 @@
+package collection
+
+import "sync/atomic"
+
+var emptyAsNull atomic.Bool
+
+// SetMarshalEmptyAsNull sets whether empty collections are marshaled as null.
+// Empty collections are marshaled as [] and empty maps as {} by default.
+// Unmarshaling null always produces an empty collection.
+func SetMarshalEmptyAsNull(null bool) {
+	emptyAsNull.Store(null)
+}
+
+// MarshalEmptyAsNull returns whether empty collections are marshaled as null
+func MarshalEmptyAsNull() bool {
+	return emptyAsNull.Load()
+}
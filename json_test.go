@@ -0,0 +1,15 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMarshalEmptyAsNull(t *testing.T) {
+	defer SetMarshalEmptyAsNull(MarshalEmptyAsNull())
+	SetMarshalEmptyAsNull(true)
+	assert.True(t, MarshalEmptyAsNull())
+	SetMarshalEmptyAsNull(false)
+	assert.False(t, MarshalEmptyAsNull())
+}
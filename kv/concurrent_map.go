@@ -0,0 +1,337 @@
+package kv
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"maps"
+	"runtime"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/equality"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+type concurrentShard[K comparable, V any] struct {
+	lock  sync.RWMutex
+	items map[K]V
+	// shared whether items is referenced by a snapshot, so it must be copied before it is written
+	shared bool
+}
+
+// writable returns the items of the shard to write, it must be called while holding the lock of the shard
+func (s *concurrentShard[K, V]) writable() map[K]V {
+	if s.shared {
+		s.items = maps.Clone(s.items)
+		s.shared = false
+	}
+	return s.items
+}
+
+// NewConcurrentMap new concurrent map split into shards which are locked separately,
+// a non-positive number of shards defaults to GOMAXPROCS
+func NewConcurrentMap[K comparable, V any](shards int) *ConcurrentMap[K, V] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	m := new(ConcurrentMap[K, V])
+	m.seed = maphash.MakeSeed()
+	m.hasher = equality.Comparable[K]()
+	m.shards = make([]*concurrentShard[K, V], shards)
+	for i := range m.shards {
+		m.shards[i] = &concurrentShard[K, V]{items: make(map[K]V)}
+	}
+	return m
+}
+
+// ConcurrentMap map which is safe for concurrent use, keys are spread over shards so writers of different keys rarely contend.
+// It offers two ways to iterate:
+//   - Each is weakly consistent, it visits the shards one after another,
+//     so it may miss or see writes made during the iteration, like ranging a [sync.Map].
+//   - Snapshot captures all shards at a single point in time in O(shards),
+//     writers copy a shard the first time they write it after a snapshot, so the snapshot stays coherent while writers continue.
+type ConcurrentMap[K comparable, V any] struct {
+	shards []*concurrentShard[K, V]
+	seed   maphash.Seed
+	hasher equality.Hasher[K]
+}
+
+func (m *ConcurrentMap[K, V]) index(key K) int {
+	return int(equality.Sum(m.hasher, m.seed, key) % uint64(len(m.shards)))
+}
+
+func (m *ConcurrentMap[K, V]) shard(key K) *concurrentShard[K, V] {
+	return m.shards[m.index(key)]
+}
+
+// Count returns the size of the map, it is weakly consistent with concurrent writes
+func (m *ConcurrentMap[K, V]) Count() int64 {
+	var count int64
+	for _, shard := range m.shards {
+		shard.lock.RLock()
+		count += int64(len(shard.items))
+		shard.lock.RUnlock()
+	}
+	return count
+}
+
+// IsEmpty returns whether the map is empty
+func (m *ConcurrentMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *ConcurrentMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *ConcurrentMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *ConcurrentMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *ConcurrentMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get returns the value of the key
+func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	value, ok := shard.items[key]
+	return value, ok
+}
+
+// ContainsKey returns whether the map contains the key
+func (m *ConcurrentMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set sets the value of the key
+func (m *ConcurrentMap[K, V]) Set(key K, value V) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	shard.writable()[key] = value
+}
+
+// GetOrSet returns the value of the key, it sets the value when the key does not exist.
+// The returned bool is true when the value existed.
+func (m *ConcurrentMap[K, V]) GetOrSet(key K, value V) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if v, ok := shard.items[key]; ok {
+		return v, true
+	}
+	shard.writable()[key] = value
+	return value, false
+}
+
+// Remove removes the key
+func (m *ConcurrentMap[K, V]) Remove(key K) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if _, ok := shard.items[key]; ok {
+		delete(shard.writable(), key)
+	}
+}
+
+// Clear clears the map, shard by shard
+func (m *ConcurrentMap[K, V]) Clear() {
+	for _, shard := range m.shards {
+		shard.lock.Lock()
+		shard.items = make(map[K]V)
+		shard.shared = false
+		shard.lock.Unlock()
+	}
+}
+
+// Each ranges the map shard by shard, it will break the loop when the callback returns false.
+// It is weakly consistent: every shard is read at a different time, so writes made during the iteration may or may not be seen.
+// The callback is called without holding any lock and may write to the map.
+func (m *ConcurrentMap[K, V]) Each(callback func(key K, value V) bool) {
+	for _, shard := range m.shards {
+		shard.lock.RLock()
+		entries := make([]Entry[K, V], 0, len(shard.items))
+		for key, value := range shard.items {
+			entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		}
+		shard.lock.RUnlock()
+		for _, entry := range entries {
+			if !callback(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *ConcurrentMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Keys returns all keys, it is weakly consistent with concurrent writes
+func (m *ConcurrentMap[K, V]) Keys() []K {
+	var keys []K
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Snapshot captures the entries of the map at a single point in time.
+// It locks every shard at once for O(shards), the entries are copied lazily by the next writer of each shard.
+func (m *ConcurrentMap[K, V]) Snapshot() *MapSnapshot[K, V] {
+	for _, shard := range m.shards {
+		shard.lock.Lock()
+	}
+	snapshot := &MapSnapshot[K, V]{shards: make([]map[K]V, len(m.shards)), index: m.index}
+	for i, shard := range m.shards {
+		shard.shared = true
+		snapshot.shards[i] = shard.items
+		snapshot.size += int64(len(shard.items))
+	}
+	for _, shard := range m.shards {
+		shard.lock.Unlock()
+	}
+	return snapshot
+}
+
+// ToMap converts to map at a single point in time
+func (m *ConcurrentMap[K, V]) ToMap() map[K]V {
+	return m.Snapshot().ToMap()
+}
+
+// ToJSON converts to json at a single point in time
+func (m *ConcurrentMap[K, V]) ToJSON() ([]byte, error) {
+	return m.Snapshot().ToJSON()
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *ConcurrentMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], it sets the entries of the object without removing the other keys
+func (m *ConcurrentMap[K, V]) UnmarshalJSON(data []byte) error {
+	var items map[K]V
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	for key, value := range items {
+		m.Set(key, value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *ConcurrentMap[K, V]) String() string {
+	return m.Snapshot().format("ConcurrentMap")
+}
+
+// MapSnapshot immutable view of the entries of a concurrent map at a single point in time,
+// it is safe for concurrent use
+type MapSnapshot[K comparable, V any] struct {
+	shards []map[K]V
+	// index returns the shard of a key, nil when there is a single shard
+	index func(key K) int
+	size  int64
+}
+
+// Count returns the size of the snapshot
+func (s *MapSnapshot[K, V]) Count() int64 {
+	return s.size
+}
+
+// IsEmpty returns whether the snapshot is empty
+func (s *MapSnapshot[K, V]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// IsNotEmpty returns whether the snapshot is not empty
+func (s *MapSnapshot[K, V]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// Get returns the value of the key when the snapshot was taken
+func (s *MapSnapshot[K, V]) Get(key K) (V, bool) {
+	if s.index == nil {
+		value, ok := s.shards[0][key]
+		return value, ok
+	}
+	value, ok := s.shards[s.index(key)][key]
+	return value, ok
+}
+
+// ContainsKey returns whether the snapshot contains the key
+func (s *MapSnapshot[K, V]) ContainsKey(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Each ranges the snapshot, it will break the loop when the callback returns false
+func (s *MapSnapshot[K, V]) Each(callback func(key K, value V) bool) {
+	for _, items := range s.shards {
+		for key, value := range items {
+			if !callback(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (s *MapSnapshot[K, V]) All() func(yield func(key K, value V) bool) {
+	return s.Each
+}
+
+// Keys returns all keys of the snapshot
+func (s *MapSnapshot[K, V]) Keys() []K {
+	keys := make([]K, 0, s.size)
+	s.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ToMap converts to map
+func (s *MapSnapshot[K, V]) ToMap() map[K]V {
+	items := make(map[K]V, s.size)
+	s.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (s *MapSnapshot[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(s.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *MapSnapshot[K, V]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// String converts to string
+func (s *MapSnapshot[K, V]) String() string {
+	return s.format("MapSnapshot")
+}
+
+func (s *MapSnapshot[K, V]) format(name string) string {
+	return format.Entries[K, V](name, s.Count(), s.Each)
+}
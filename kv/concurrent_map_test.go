@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMap_Set(t *testing.T) {
+	m := NewConcurrentMap[int, int](4)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Set(i*100+j, j)
+				m.Get(j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(800), m.Count())
+	value, ok := m.Get(101)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	m.Remove(101)
+	assert.False(t, m.ContainsKey(101))
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestConcurrentMap_GetOrSet(t *testing.T) {
+	m := NewConcurrentMap[string, int](0)
+	value, ok := m.GetOrSet("a", 1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = m.GetOrSet("a", 2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestConcurrentMap_Each(t *testing.T) {
+	m := NewConcurrentMap[int, int](4)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i)
+	}
+	count := 0
+	m.Each(func(key int, value int) bool {
+		m.Remove(key)
+		count++
+		return true
+	})
+	assert.Equal(t, 10, count)
+	assert.True(t, m.IsEmpty())
+}
+
+func TestConcurrentMap_Snapshot(t *testing.T) {
+	m := NewConcurrentMap[int, int](4)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i)
+	}
+	snapshot := m.Snapshot()
+	m.Set(0, 100)
+	m.Set(10, 10)
+	m.Remove(1)
+	m.Clear()
+	assert.Equal(t, int64(10), snapshot.Count())
+	value, ok := snapshot.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+	assert.True(t, snapshot.ContainsKey(1))
+	assert.False(t, snapshot.ContainsKey(10))
+	assert.Len(t, snapshot.Keys(), 10)
+}
+
+func TestConcurrentMap_SnapshotWhileWriting(t *testing.T) {
+	m := NewConcurrentMap[int, int](4)
+	for i := 0; i < 100; i++ {
+		m.Set(i, 0)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			m.Set(i%100, i)
+			m.Remove(i%100 + 100)
+			m.Set(i%100+100, i)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		snapshot := m.Snapshot()
+		count := snapshot.Count()
+		assert.Len(t, snapshot.ToMap(), int(count))
+	}
+	<-done
+}
+
+func TestConcurrentMap_MarshalJSON(t *testing.T) {
+	m := NewConcurrentMap[string, int](2)
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":1,"b":2}`), m))
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.ToMap())
+}
+
+func TestConcurrentMap_String(t *testing.T) {
+	m := NewConcurrentMap[string, int](2)
+	m.Set("a", 1)
+	pattern := regexp.MustCompile(fmt.Sprintf(`ConcurrentMap\[string,\sint\]\(len=%d\)\{\n\ta:\s1,\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+	pattern = regexp.MustCompile(`MapSnapshot\[string,\sint\]\(len=1\)\{\n\ta:\s1,\n\}`)
+	assert.True(t, pattern.MatchString(m.Snapshot().String()))
+}
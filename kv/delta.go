@@ -0,0 +1,135 @@
+package kv
+
+import "encoding/json"
+
+// DeltaOp kind of the mutation recorded in a delta
+type DeltaOp string
+
+const (
+	// DeltaSet a key is set
+	DeltaSet DeltaOp = "set"
+	// DeltaRemove a key is removed
+	DeltaRemove DeltaOp = "remove"
+	// DeltaClear the map is cleared
+	DeltaClear DeltaOp = "clear"
+)
+
+// DeltaEntry a mutation recorded in a delta
+type DeltaEntry[K comparable, V any] struct {
+	Op    DeltaOp `json:"op"`
+	Key   K       `json:"key,omitempty"`
+	Value V       `json:"value,omitempty"`
+}
+
+// NewDelta new delta
+func NewDelta[K comparable, V any]() *Delta[K, V] {
+	delta := new(Delta[K, V])
+	delta.index = make(map[K]*deltaSlots)
+	return delta
+}
+
+// deltaSlots positions of the mutations kept for a key, -1 when there is none,
+// a removal is always recorded before the set which follows it
+type deltaSlots struct {
+	remove int
+	set    int
+}
+
+// Delta compacted log of mutations since a checkpoint, a clear drops all the mutations before it.
+// Each key keeps at most a removal followed by a set, both at the position of their first occurrence,
+// so applying the delta to an ordered map, such as [LinkedMap], reproduces the order of the keys:
+// updating a key keeps its position while removing and setting it again moves it to the end.
+type Delta[K comparable, V any] struct {
+	entries []*DeltaEntry[K, V]
+	index   map[K]*deltaSlots
+	count   int
+}
+
+func (d *Delta[K, V]) append(entry DeltaEntry[K, V]) int {
+	d.entries = append(d.entries, &entry)
+	d.count++
+	return len(d.entries) - 1
+}
+
+func (d *Delta[K, V]) record(entry DeltaEntry[K, V]) {
+	if entry.Op == DeltaClear {
+		d.entries = nil
+		d.index = make(map[K]*deltaSlots)
+		d.count = 0
+		d.append(entry)
+		return
+	}
+	slots, ok := d.index[entry.Key]
+	if !ok {
+		slots = &deltaSlots{remove: -1, set: -1}
+		d.index[entry.Key] = slots
+	}
+	switch entry.Op {
+	case DeltaSet:
+		if slots.set >= 0 {
+			d.entries[slots.set] = &entry
+		} else {
+			slots.set = d.append(entry)
+		}
+	case DeltaRemove:
+		if slots.set >= 0 {
+			d.entries[slots.set] = nil
+			d.count--
+			slots.set = -1
+		}
+		if slots.remove < 0 {
+			slots.remove = d.append(entry)
+		}
+	}
+}
+
+// Count returns the number of recorded mutations
+func (d *Delta[K, V]) Count() int64 {
+	return int64(d.count)
+}
+
+// IsEmpty returns whether the delta is empty
+func (d *Delta[K, V]) IsEmpty() bool {
+	return d.Count() == 0
+}
+
+// IsNotEmpty returns whether the delta is not empty
+func (d *Delta[K, V]) IsNotEmpty() bool {
+	return !d.IsEmpty()
+}
+
+// Entries returns recorded mutations in order
+func (d *Delta[K, V]) Entries() []DeltaEntry[K, V] {
+	entries := make([]DeltaEntry[K, V], 0, d.count)
+	for _, entry := range d.entries {
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries
+}
+
+// ToJSON converts to json
+func (d *Delta[K, V]) ToJSON() ([]byte, error) {
+	return json.Marshal(d.Entries())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (d *Delta[K, V]) MarshalJSON() ([]byte, error) {
+	return d.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (d *Delta[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []DeltaEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	d.entries = nil
+	d.index = make(map[K]*deltaSlots)
+	d.count = 0
+	for _, entry := range entries {
+		d.record(entry)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelta_Entries(t *testing.T) {
+	t.Run("compact", func(t *testing.T) {
+		delta := NewDelta[string, int]()
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 1})
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "b", Value: 2})
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 3})
+		assert.Equal(t, int64(2), delta.Count())
+		assert.Equal(t, []DeltaEntry[string, int]{
+			{Op: DeltaSet, Key: "a", Value: 3},
+			{Op: DeltaSet, Key: "b", Value: 2},
+		}, delta.Entries())
+	})
+
+	t.Run("remove and set again", func(t *testing.T) {
+		delta := NewDelta[string, int]()
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 1})
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "b", Value: 2})
+		delta.record(DeltaEntry[string, int]{Op: DeltaRemove, Key: "a"})
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 3})
+		delta.record(DeltaEntry[string, int]{Op: DeltaRemove, Key: "a"})
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 4})
+		assert.Equal(t, int64(3), delta.Count())
+		assert.Equal(t, []DeltaEntry[string, int]{
+			{Op: DeltaSet, Key: "b", Value: 2},
+			{Op: DeltaRemove, Key: "a"},
+			{Op: DeltaSet, Key: "a", Value: 4},
+		}, delta.Entries())
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		delta := NewDelta[string, int]()
+		delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 1})
+		delta.record(DeltaEntry[string, int]{Op: DeltaClear})
+		delta.record(DeltaEntry[string, int]{Op: DeltaRemove, Key: "b"})
+		assert.Equal(t, []DeltaEntry[string, int]{
+			{Op: DeltaClear},
+			{Op: DeltaRemove, Key: "b"},
+		}, delta.Entries())
+	})
+}
+
+func TestDelta_MarshalJSON(t *testing.T) {
+	delta := NewDelta[string, int]()
+	delta.record(DeltaEntry[string, int]{Op: DeltaSet, Key: "a", Value: 1})
+	delta.record(DeltaEntry[string, int]{Op: DeltaRemove, Key: "b"})
+	jsonBytes, err := json.Marshal(delta)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `[{"op":"set","key":"a","value":1},{"op":"remove","key":"b"}]`, string(jsonBytes))
+}
+
+func TestDelta_UnmarshalJSON(t *testing.T) {
+	delta := NewDelta[string, int]()
+	err := json.Unmarshal([]byte(`[{"op":"set","key":"a","value":1},{"op":"set","key":"a","value":2}]`), delta)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	m := NewMap[string, int]()
+	m.Apply(delta)
+	assert.Equal(t, map[string]int{"a": 2}, m.ToMap())
+}
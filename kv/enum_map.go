@@ -0,0 +1,209 @@
+package kv
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// NewEnumMap new enum map for the keys in range [min, max], the bounds are swapped when max is less than min
+func NewEnumMap[K ~int, V any](min, max K) *EnumMap[K, V] {
+	if max < min {
+		min, max = max, min
+	}
+	m := new(EnumMap[K, V])
+	m.min = min
+	m.values = make([]V, max-min+1)
+	m.present = make([]bool, max-min+1)
+	return m
+}
+
+// EnumMap map for small integer keys backed by a dense slice,
+// it gives O(1) access without hashing and iterates in ascending key order.
+type EnumMap[K ~int, V any] struct {
+	sync.RWMutex
+	min     K
+	values  []V
+	present []bool
+	size    int
+}
+
+func (m *EnumMap[K, V]) index(key K) (int, bool) {
+	index := int(key - m.min)
+	return index, index >= 0 && index < len(m.values)
+}
+
+// Count returns the size of map
+func (m *EnumMap[K, V]) Count() int64 {
+	return int64(m.size)
+}
+
+// IsEmpty returns whether the map is empty
+func (m *EnumMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *EnumMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *EnumMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *EnumMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *EnumMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get gets element by specific key.
+// A zero value and false will be returned when the given key is not exist or out of range
+func (m *EnumMap[K, V]) Get(key K) (V, bool) {
+	index, ok := m.index(key)
+	if !ok || !m.present[index] {
+		return *new(V), false
+	}
+	return m.values[index], true
+}
+
+// GetOr gets element by specific key, the default value will be returned when the given key is not exist
+func (m *EnumMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// ContainsKey returns whether the map contains the specific key
+func (m *EnumMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set sets element to the specific key, it panics when the key is out of the range of the map
+func (m *EnumMap[K, V]) Set(key K, value V) {
+	index, ok := m.index(key)
+	if !ok {
+		panic(collection.NewRangeError(index, len(m.values)))
+	}
+	if !m.present[index] {
+		m.present[index] = true
+		m.size++
+	}
+	m.values[index] = value
+}
+
+// Remove removes the element of specific key
+func (m *EnumMap[K, V]) Remove(key K) {
+	index, ok := m.index(key)
+	if !ok || !m.present[index] {
+		return
+	}
+	m.present[index] = false
+	m.values[index] = *new(V)
+	m.size--
+}
+
+// Clear clears the map
+func (m *EnumMap[K, V]) Clear() {
+	clear(m.values)
+	clear(m.present)
+	m.size = 0
+}
+
+// Keys returns all keys in ascending order
+func (m *EnumMap[K, V]) Keys() []K {
+	var keys []K
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in ascending order of keys
+func (m *EnumMap[K, V]) Values() []V {
+	var values []V
+	m.Each(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Each ranges the map in ascending order of keys, it will break the loop when the callback returns false
+func (m *EnumMap[K, V]) Each(callback func(key K, value V) bool) {
+	for index, present := range m.present {
+		if present && !callback(m.min+K(index), m.values[index]) {
+			break
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *EnumMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Clone clones the map
+func (m *EnumMap[K, V]) Clone() *EnumMap[K, V] {
+	clone := new(EnumMap[K, V])
+	clone.min = m.min
+	clone.values = append([]V(nil), m.values...)
+	clone.present = append([]bool(nil), m.present...)
+	clone.size = m.size
+	return clone
+}
+
+// ToMap converts to map
+func (m *EnumMap[K, V]) ToMap() map[K]V {
+	items := make(map[K]V, m.size)
+	m.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (m *EnumMap[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(m.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *EnumMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], it returns [collection.ErrIndexOutOfRange] for keys out of the range of the map
+func (m *EnumMap[K, V]) UnmarshalJSON(data []byte) error {
+	items := map[K]V{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	for key := range items {
+		if index, ok := m.index(key); !ok {
+			return collection.NewRangeError(index, len(m.values))
+		}
+	}
+	m.Clear()
+	for key, value := range items {
+		m.Set(key, value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *EnumMap[K, V]) String() string {
+	return format.Entries[K, V]("EnumMap", m.Count(), m.Each)
+}
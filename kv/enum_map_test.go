@@ -0,0 +1,92 @@
+package kv
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+type weekday int
+
+const (
+	sunday weekday = iota
+	monday
+	tuesday
+	wednesday
+)
+
+func TestNewEnumMap(t *testing.T) {
+	m := NewEnumMap[weekday, string](wednesday, sunday)
+	m.Set(sunday, "sun")
+	m.Set(wednesday, "wed")
+	assert.Equal(t, []weekday{sunday, wednesday}, m.Keys())
+}
+
+func TestEnumMap_Get(t *testing.T) {
+	m := NewEnumMap[weekday, string](sunday, wednesday)
+	m.Set(monday, "mon")
+	value, ok := m.Get(monday)
+	assert.True(t, ok)
+	assert.Equal(t, "mon", value)
+	_, ok = m.Get(sunday)
+	assert.False(t, ok)
+	_, ok = m.Get(weekday(10))
+	assert.False(t, ok)
+	assert.Equal(t, "none", m.GetOr(weekday(-1), "none"))
+}
+
+func TestEnumMap_Set(t *testing.T) {
+	m := NewEnumMap[weekday, string](monday, tuesday)
+	m.Set(monday, "mon")
+	m.Set(monday, "Mon")
+	assert.Equal(t, int64(1), m.Count())
+	defer func() {
+		err, ok := recover().(error)
+		assert.True(t, ok)
+		assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	}()
+	m.Set(sunday, "sun")
+}
+
+func TestEnumMap_Remove(t *testing.T) {
+	m := NewEnumMap[weekday, string](sunday, wednesday)
+	m.Set(monday, "mon")
+	m.Remove(monday)
+	m.Remove(tuesday)
+	m.Remove(weekday(10))
+	assert.True(t, m.IsEmpty())
+	assert.False(t, m.ContainsKey(monday))
+}
+
+func TestEnumMap_Each(t *testing.T) {
+	m := NewEnumMap[weekday, string](sunday, wednesday)
+	m.Set(wednesday, "wed")
+	m.Set(sunday, "sun")
+	m.Set(tuesday, "tue")
+	assert.Equal(t, []weekday{sunday, tuesday, wednesday}, m.Keys())
+	assert.Equal(t, []string{"sun", "tue", "wed"}, m.Values())
+}
+
+func TestEnumMap_Clone(t *testing.T) {
+	m := NewEnumMap[weekday, string](sunday, wednesday)
+	m.Set(monday, "mon")
+	clone := m.Clone()
+	clone.Set(tuesday, "tue")
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, map[weekday]string{monday: "mon", tuesday: "tue"}, clone.ToMap())
+}
+
+func TestEnumMap_UnmarshalJSON(t *testing.T) {
+	m := NewEnumMap[weekday, string](sunday, wednesday)
+	err := json.Unmarshal([]byte(`{"1":"mon","3":"wed"}`), m)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"mon", "wed"}, m.Values())
+	jsonBytes, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"1":"mon","3":"wed"}`, string(jsonBytes))
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"9":"x"}`), m), collection.ErrIndexOutOfRange)
+	assert.Equal(t, "EnumMap[kv.weekday, string](len=2){\n\t1: mon,\n\t3: wed,\n}", m.String())
+}
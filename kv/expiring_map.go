@@ -0,0 +1,304 @@
+package kv
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// Entry key-value pair of a map
+type Entry[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+type expiringItem[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func (item expiringItem[V]) expired(now time.Time) bool {
+	return !item.expiresAt.IsZero() && !now.Before(item.expiresAt)
+}
+
+// NewExpiringMap new expiring map, entries expire after ttl by default,
+// a non-positive ttl means entries never expire
+func NewExpiringMap[K comparable, V any](ttl time.Duration) *ExpiringMap[K, V] {
+	m := new(ExpiringMap[K, V])
+	m.ttl = ttl
+	m.items = make(map[K]expiringItem[V])
+	m.now = time.Now
+	return m
+}
+
+// ExpiringMap map whose entries expire after a time to live.
+// Reads skip expired entries without changing the map, so they may share RLock.
+// Expired entries are removed by Purge, the Janitor and the writes to their keys,
+// and every expiration is reported to the expiration callbacks.
+type ExpiringMap[K comparable, V any] struct {
+	sync.RWMutex
+	ttl      time.Duration
+	items    map[K]expiringItem[V]
+	handlers []func(entry Entry[K, V])
+	onRemove []func(entry Entry[K, V])
+	now      func() time.Time
+}
+
+func (m *ExpiringMap[K, V]) expire(key K, item expiringItem[V]) {
+	delete(m.items, key)
+	entry := Entry[K, V]{Key: key, Value: item.value}
+	for _, handler := range m.handlers {
+		handler(entry)
+	}
+	m.removed(entry)
+}
+
+func (m *ExpiringMap[K, V]) removed(entry Entry[K, V]) {
+	for _, handler := range m.onRemove {
+		handler(entry)
+	}
+}
+
+// lookup returns the entry of the key unless it is expired, it does not change the map
+func (m *ExpiringMap[K, V]) lookup(key K) (expiringItem[V], bool) {
+	item, ok := m.items[key]
+	if !ok || item.expired(m.now()) {
+		return item, false
+	}
+	return item, true
+}
+
+// OnExpire registers a callback which is called with every expired entry.
+// Callbacks are called synchronously by Purge or by the write which removes the expired entry.
+func (m *ExpiringMap[K, V]) OnExpire(callback func(entry Entry[K, V])) {
+	m.handlers = append(m.handlers, callback)
+}
+
+// OnRemove registers a callback which is called with every entry leaving the map,
+// such as to close the resources held by cached values.
+// It covers expirations, Remove and Clear, and the previous value of a key is reported when Set replaces it.
+// Callbacks are called synchronously once the entry is removed.
+func (m *ExpiringMap[K, V]) OnRemove(callback func(entry Entry[K, V])) {
+	m.onRemove = append(m.onRemove, callback)
+}
+
+// Expired returns a channel receiving expired entries,
+// expirations are dropped when the buffer of the channel is full.
+func (m *ExpiringMap[K, V]) Expired(size int) <-chan Entry[K, V] {
+	ch := make(chan Entry[K, V], size)
+	m.OnExpire(func(entry Entry[K, V]) {
+		select {
+		case ch <- entry:
+		default:
+		}
+	})
+	return ch
+}
+
+// Count returns the number of entries which are not expired
+func (m *ExpiringMap[K, V]) Count() int64 {
+	now := m.now()
+	var count int64
+	for _, item := range m.items {
+		if !item.expired(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty returns whether the map is empty
+func (m *ExpiringMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *ExpiringMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *ExpiringMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *ExpiringMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *ExpiringMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get gets element by specific key.
+// A zero value and false will be returned when the given key is not exist or expired
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	item, ok := m.lookup(key)
+	if !ok {
+		return *new(V), false
+	}
+	return item.value, true
+}
+
+// GetOr gets element by specific key, the default value will be returned when the given key is not exist or expired
+func (m *ExpiringMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// ContainsKey returns whether the map contains the specific key
+func (m *ExpiringMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.lookup(key)
+	return ok
+}
+
+// TTL returns the remaining time to live of the key.
+// It returns zero and false when the given key is not exist or never expires
+func (m *ExpiringMap[K, V]) TTL(key K) (time.Duration, bool) {
+	item, ok := m.lookup(key)
+	if !ok || item.expiresAt.IsZero() {
+		return 0, false
+	}
+	return item.expiresAt.Sub(m.now()), true
+}
+
+// Set sets element to the specific key with the default ttl
+func (m *ExpiringMap[K, V]) Set(key K, value V) {
+	m.SetWithTTL(key, value, m.ttl)
+}
+
+// SetWithTTL sets element to the specific key which expires after ttl,
+// a non-positive ttl means the entry never expires
+func (m *ExpiringMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	item := expiringItem[V]{value: value}
+	if ttl > 0 {
+		item.expiresAt = m.now().Add(ttl)
+	}
+	previous, replaced := m.items[key]
+	if replaced && previous.expired(m.now()) {
+		m.expire(key, previous)
+		replaced = false
+	}
+	m.items[key] = item
+	if replaced {
+		m.removed(Entry[K, V]{Key: key, Value: previous.value})
+	}
+}
+
+// Remove removes the element of specific key, removals are not reported as expirations
+// unless the entry is already expired
+func (m *ExpiringMap[K, V]) Remove(key K) {
+	item, ok := m.items[key]
+	if !ok {
+		return
+	}
+	if item.expired(m.now()) {
+		m.expire(key, item)
+		return
+	}
+	delete(m.items, key)
+	m.removed(Entry[K, V]{Key: key, Value: item.value})
+}
+
+// Purge removes all expired entries and returns the number of them
+func (m *ExpiringMap[K, V]) Purge() int {
+	now := m.now()
+	count := 0
+	for key, item := range m.items {
+		if item.expired(now) {
+			m.expire(key, item)
+			count++
+		}
+	}
+	return count
+}
+
+// Janitor purges expired entries periodically until the context is done,
+// it holds the lock of the map while purging.
+func (m *ExpiringMap[K, V]) Janitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Lock()
+				m.Purge()
+				m.Unlock()
+			}
+		}
+	}()
+}
+
+// Clear clears the map
+func (m *ExpiringMap[K, V]) Clear() {
+	items := m.items
+	m.items = make(map[K]expiringItem[V])
+	for key, item := range items {
+		m.removed(Entry[K, V]{Key: key, Value: item.value})
+	}
+}
+
+// Keys returns all keys which are not expired
+func (m *ExpiringMap[K, V]) Keys() []K {
+	var keys []K
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Each ranges the map by callback, it will break the loop when the callback returns false.
+// Expired entries are skipped.
+func (m *ExpiringMap[K, V]) Each(callback func(key K, value V) bool) {
+	now := m.now()
+	for key, item := range m.items {
+		if item.expired(now) {
+			continue
+		}
+		if !callback(key, item.value) {
+			break
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *ExpiringMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// ToMap converts to map
+func (m *ExpiringMap[K, V]) ToMap() map[K]V {
+	items := make(map[K]V)
+	m.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (m *ExpiringMap[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(m.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *ExpiringMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// String converts to string
+func (m *ExpiringMap[K, V]) String() string {
+	return format.Entries[K, V]("ExpiringMap", m.Count(), m.Each)
+}
@@ -0,0 +1,159 @@
+package kv
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestExpiringMap(ttl time.Duration, now *time.Time) *ExpiringMap[string, int] {
+	m := NewExpiringMap[string, int](ttl)
+	m.now = func() time.Time {
+		return *now
+	}
+	return m
+}
+
+func TestExpiringMap_Get(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 0)
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	now = now.Add(time.Second)
+	_, ok = m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.GetOr("b", 0))
+	assert.Equal(t, int64(1), m.Count())
+}
+
+func TestExpiringMap_TTL(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 0)
+	now = now.Add(400 * time.Millisecond)
+	ttl, ok := m.TTL("a")
+	assert.True(t, ok)
+	assert.Equal(t, 600*time.Millisecond, ttl)
+	_, ok = m.TTL("b")
+	assert.False(t, ok)
+}
+
+func TestExpiringMap_OnExpire(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	var expired []Entry[string, int]
+	m.OnExpire(func(entry Entry[string, int]) {
+		expired = append(expired, entry)
+	})
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Remove("b")
+	now = now.Add(time.Second)
+	assert.False(t, m.ContainsKey("a"))
+	assert.Empty(t, expired)
+	assert.Equal(t, 1, m.Purge())
+	assert.Equal(t, []Entry[string, int]{{Key: "a", Value: 1}}, expired)
+
+	expired = nil
+	m.Set("c", 3)
+	now = now.Add(time.Second)
+	m.Set("c", 4)
+	assert.Equal(t, []Entry[string, int]{{Key: "c", Value: 3}}, expired)
+}
+
+func TestExpiringMap_ConcurrentReads(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 0)
+	now = now.Add(time.Second)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.RLock()
+			defer m.RUnlock()
+			_, ok := m.Get("a")
+			assert.False(t, ok)
+			assert.Equal(t, int64(1), m.Count())
+			assert.Equal(t, []string{"b"}, m.Keys())
+			assert.Equal(t, "ExpiringMap[string, int](len=1){\n\tb: 2,\n}", m.String())
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, m.items, 2)
+}
+
+func TestExpiringMap_OnRemove(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	var removed []Entry[string, int]
+	m.OnRemove(func(entry Entry[string, int]) {
+		removed = append(removed, entry)
+	})
+	m.Set("a", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Remove("b")
+	m.Remove("c")
+	assert.Equal(t, []Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 3}}, removed)
+
+	removed = nil
+	m.Set("c", 4)
+	now = now.Add(time.Second)
+	m.Purge()
+	assert.ElementsMatch(t, []Entry[string, int]{{Key: "a", Value: 2}, {Key: "c", Value: 4}}, removed)
+
+	removed = nil
+	m.SetWithTTL("d", 5, 0)
+	m.Clear()
+	assert.Equal(t, []Entry[string, int]{{Key: "d", Value: 5}}, removed)
+}
+
+func TestExpiringMap_Expired(t *testing.T) {
+	now := time.Now()
+	m := newTestExpiringMap(time.Second, &now)
+	expired := m.Expired(10)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	now = now.Add(time.Second)
+	assert.Equal(t, 2, m.Purge())
+	var keys []string
+	keys = append(keys, (<-expired).Key, (<-expired).Key)
+	assert.ElementsMatch(t, []string{"a", "b"}, keys)
+}
+
+func TestExpiringMap_Janitor(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Millisecond)
+	expired := m.Expired(1)
+	m.Set("a", 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Janitor(ctx, time.Millisecond)
+	select {
+	case entry := <-expired:
+		assert.Equal(t, Entry[string, int]{Key: "a", Value: 1}, entry)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "entry is not expired")
+	}
+}
+
+func TestExpiringMap_ToJSON(t *testing.T) {
+	m := NewExpiringMap[string, int](0)
+	m.Set("a", 1)
+	jsonBytes, err := m.ToJSON()
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `{"a":1}`, string(jsonBytes))
+	assert.Equal(t, "ExpiringMap[string, int](len=1){\n\ta: 1,\n}", m.String())
+}
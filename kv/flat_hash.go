@@ -0,0 +1,42 @@
+//go:build !tinygo && !collection_noreflect
+
+package kv
+
+import (
+	"hash/maphash"
+	"math/rand/v2"
+	"reflect"
+	"unsafe"
+
+	"github.com/gopi-frame/collection/equality"
+)
+
+// flatHash returns a hash function with a random seed for the key type,
+// strings and integers are hashed without boxing, other keys are hashed by [equality.Comparable]
+func flatHash[K comparable]() func(key K) uint64 {
+	seed := maphash.MakeSeed()
+	mix := rand.Uint64()
+	t := reflect.TypeFor[K]()
+	switch t.Kind() {
+	case reflect.String:
+		return func(key K) uint64 {
+			return maphash.String(seed, *(*string)(unsafe.Pointer(&key)))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch t.Size() {
+		case 8:
+			return func(key K) uint64 { return mix64(*(*uint64)(unsafe.Pointer(&key)) ^ mix) }
+		case 4:
+			return func(key K) uint64 { return mix64(uint64(*(*uint32)(unsafe.Pointer(&key))) ^ mix) }
+		case 2:
+			return func(key K) uint64 { return mix64(uint64(*(*uint16)(unsafe.Pointer(&key))) ^ mix) }
+		case 1:
+			return func(key K) uint64 { return mix64(uint64(*(*uint8)(unsafe.Pointer(&key))) ^ mix) }
+		}
+	}
+	hasher := equality.Comparable[K]()
+	return func(key K) uint64 {
+		return equality.Sum(hasher, seed, key)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build tinygo || collection_noreflect
+
+package kv
+
+import (
+	"hash/maphash"
+	"math/rand/v2"
+	"unsafe"
+
+	"github.com/gopi-frame/collection/equality"
+)
+
+// flatHash returns a hash function with a random seed for the key type,
+// keys of the predeclared string and integer types are hashed without boxing, other keys are hashed by [equality.Comparable]
+func flatHash[K comparable]() func(key K) uint64 {
+	seed := maphash.MakeSeed()
+	mix := rand.Uint64()
+	switch any(*new(K)).(type) {
+	case string:
+		return func(key K) uint64 {
+			return maphash.String(seed, *(*string)(unsafe.Pointer(&key)))
+		}
+	case int, int64, uint, uint64, uintptr:
+		if unsafe.Sizeof(*new(K)) == 8 {
+			return func(key K) uint64 { return mix64(*(*uint64)(unsafe.Pointer(&key)) ^ mix) }
+		}
+		return func(key K) uint64 { return mix64(uint64(*(*uint32)(unsafe.Pointer(&key))) ^ mix) }
+	case int32, uint32:
+		return func(key K) uint64 { return mix64(uint64(*(*uint32)(unsafe.Pointer(&key))) ^ mix) }
+	case int16, uint16:
+		return func(key K) uint64 { return mix64(uint64(*(*uint16)(unsafe.Pointer(&key))) ^ mix) }
+	case int8, uint8:
+		return func(key K) uint64 { return mix64(uint64(*(*uint8)(unsafe.Pointer(&key))) ^ mix) }
+	}
+	hasher := equality.Comparable[K]()
+	return func(key K) uint64 {
+		return equality.Sum(hasher, seed, key)
+	}
+}
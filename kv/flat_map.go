@@ -0,0 +1,277 @@
+package kv
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// flatMaxLoad the load factor in eighths above which the slots grow
+const flatMaxLoad = 7
+
+type flatSlot[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	// dist the probe distance from the home slot plus one, zero when the slot is empty
+	dist uint32
+}
+
+// NewFlatMap new open-addressing map
+func NewFlatMap[K comparable, V any]() *FlatMap[K, V] {
+	m := new(FlatMap[K, V])
+	m.hash = flatHash[K]()
+	return m
+}
+
+// FlatMap open-addressing map using robin hood hashing.
+// Entries are stored inline in a single slice of slots and probed linearly,
+// an entry which probed farther from its home slot takes the slot of an entry which probed less,
+// so probe sequences stay short and a lookup touches few adjacent cache lines.
+// Removal shifts the following entries back instead of leaving tombstones.
+// It suits hot lookups of small keys and values, benchmark it against [Map] for the workload before switching.
+type FlatMap[K comparable, V any] struct {
+	sync.RWMutex
+	slots []flatSlot[K, V]
+	size  int
+	hash  func(key K) uint64
+}
+
+// mix64 the finalizer of splitmix64, it spreads every bit of the input over the output
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// find returns the slot of the key, -1 when the key does not exist
+func (m *FlatMap[K, V]) find(key K) int {
+	if m.size == 0 {
+		return -1
+	}
+	hash := m.hash(key)
+	slots := m.slots
+	mask := len(slots) - 1
+	index := int(hash) & mask
+	for dist := uint32(1); ; dist++ {
+		slot := &slots[index]
+		if slot.dist < dist {
+			return -1
+		}
+		if slot.hash == hash && slot.key == key {
+			return index
+		}
+		index = (index + 1) & mask
+	}
+}
+
+// insert inserts the entry which does not exist yet, the slots must have room for it
+func (m *FlatMap[K, V]) insert(entry flatSlot[K, V]) {
+	mask := len(m.slots) - 1
+	entry.dist = 1
+	for index := int(entry.hash) & mask; ; index = (index + 1) & mask {
+		slot := &m.slots[index]
+		if slot.dist == 0 {
+			*slot = entry
+			m.size++
+			return
+		}
+		if slot.dist < entry.dist {
+			entry, *slot = *slot, entry
+		}
+		entry.dist++
+	}
+}
+
+// grow doubles the slots and reinserts the entries with their stored hashes
+func (m *FlatMap[K, V]) grow() {
+	slots := m.slots
+	m.slots = make([]flatSlot[K, V], max(2*len(slots), 8))
+	m.size = 0
+	for _, slot := range slots {
+		if slot.dist != 0 {
+			m.insert(slot)
+		}
+	}
+}
+
+// Count returns the size of map
+func (m *FlatMap[K, V]) Count() int64 {
+	return int64(m.size)
+}
+
+// IsEmpty returns whether the map is empty
+func (m *FlatMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *FlatMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *FlatMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *FlatMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *FlatMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get returns the value of the key
+func (m *FlatMap[K, V]) Get(key K) (V, bool) {
+	if index := m.find(key); index >= 0 {
+		return m.slots[index].value, true
+	}
+	return *new(V), false
+}
+
+// GetOr returns the value of the key or the default value when the key does not exist
+func (m *FlatMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// TryGet returns the value of the key.
+// It returns [collection.ErrKeyNotFound] when the key does not exist.
+func (m *FlatMap[K, V]) TryGet(key K) (V, error) {
+	if v, ok := m.Get(key); ok {
+		return v, nil
+	}
+	return *new(V), collection.NewKeyError(key)
+}
+
+// ContainsKey returns whether the map contains the key
+func (m *FlatMap[K, V]) ContainsKey(key K) bool {
+	return m.find(key) >= 0
+}
+
+// Set sets the value of the key
+func (m *FlatMap[K, V]) Set(key K, value V) {
+	if index := m.find(key); index >= 0 {
+		m.slots[index].value = value
+		return
+	}
+	if (m.size+1)*8 > len(m.slots)*flatMaxLoad {
+		m.grow()
+	}
+	m.insert(flatSlot[K, V]{key: key, value: value, hash: m.hash(key)})
+}
+
+// Remove removes the key
+func (m *FlatMap[K, V]) Remove(key K) {
+	index := m.find(key)
+	if index < 0 {
+		return
+	}
+	mask := len(m.slots) - 1
+	for next := (index + 1) & mask; m.slots[next].dist > 1; index, next = next, (next+1)&mask {
+		m.slots[index] = m.slots[next]
+		m.slots[index].dist--
+	}
+	m.slots[index] = flatSlot[K, V]{}
+	m.size--
+}
+
+// Clear clears the map, the slots are kept for reuse
+func (m *FlatMap[K, V]) Clear() {
+	clear(m.slots)
+	m.size = 0
+}
+
+// Each ranges the map in slot order, it will break the loop when the callback returns false
+func (m *FlatMap[K, V]) Each(callback func(key K, value V) bool) {
+	for _, slot := range m.slots {
+		if slot.dist != 0 && !callback(slot.key, slot.value) {
+			return
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *FlatMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Keys returns all keys
+func (m *FlatMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values
+func (m *FlatMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.Each(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Entries returns all entries
+func (m *FlatMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.size)
+	m.Each(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	return entries
+}
+
+// ToMap converts to map
+func (m *FlatMap[K, V]) ToMap() map[K]V {
+	items := make(map[K]V, m.size)
+	m.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (m *FlatMap[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(m.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *FlatMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (m *FlatMap[K, V]) UnmarshalJSON(data []byte) error {
+	var items map[K]V
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	m.Clear()
+	for key, value := range items {
+		m.Set(key, value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *FlatMap[K, V]) String() string {
+	return format.Entries[K, V]("FlatMap", m.Count(), m.Each)
+}
@@ -0,0 +1,162 @@
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatMap_Set(t *testing.T) {
+	m := NewFlatMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 3)
+	assert.Equal(t, int64(2), m.Count())
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, 4, m.GetOr("c", 4))
+	_, err := m.TryGet("c")
+	assert.True(t, errors.Is(err, collection.ErrKeyNotFound))
+}
+
+func TestFlatMap_Remove(t *testing.T) {
+	m := NewFlatMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+	for i := 0; i < 100; i += 2 {
+		m.Remove(i)
+	}
+	m.Remove(1000)
+	assert.Equal(t, int64(50), m.Count())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i%2 == 1, m.ContainsKey(i))
+	}
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+}
+
+func TestFlatMap_Random(t *testing.T) {
+	m := NewFlatMap[uint16, int]()
+	expected := make(map[uint16]int)
+	for i := 0; i < 10000; i++ {
+		key := uint16(rand.IntN(512))
+		if rand.IntN(3) == 0 {
+			m.Remove(key)
+			delete(expected, key)
+		} else {
+			m.Set(key, i)
+			expected[key] = i
+		}
+	}
+	assert.Equal(t, int64(len(expected)), m.Count())
+	assert.Equal(t, expected, m.ToMap())
+	for key, value := range expected {
+		v, ok := m.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, value, v)
+	}
+}
+
+func TestFlatMap_Keys(t *testing.T) {
+	type point struct{ X, Y int }
+	m := NewFlatMap[point, string]()
+	m.Set(point{1, 2}, "a")
+	m.Set(point{2, 1}, "b")
+	assert.ElementsMatch(t, []point{{1, 2}, {2, 1}}, m.Keys())
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Values())
+	assert.Len(t, m.Entries(), 2)
+}
+
+func TestFlatMap_MarshalJSON(t *testing.T) {
+	m := NewFlatMap[string, int]()
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":1,"b":2}`), m))
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+}
+
+func TestFlatMap_String(t *testing.T) {
+	m := NewFlatMap[string, int]()
+	m.Set("a", 1)
+	pattern := regexp.MustCompile(fmt.Sprintf(`FlatMap\[string,\sint\]\(len=%d\)\{\n\ta:\s1,\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+}
+
+const benchmarkMapSize = 1 << 12
+
+func BenchmarkFlatMap_Get(b *testing.B) {
+	m := NewFlatMap[int, int]()
+	for i := 0; i < benchmarkMapSize; i++ {
+		m.Set(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i % (2 * benchmarkMapSize))
+	}
+}
+
+func BenchmarkBuiltinMap_Get(b *testing.B) {
+	m := make(map[int]int)
+	for i := 0; i < benchmarkMapSize; i++ {
+		m[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[i%(2*benchmarkMapSize)]
+	}
+}
+
+func BenchmarkFlatMap_GetString(b *testing.B) {
+	m := NewFlatMap[string, int]()
+	keys := make([]string, 2*benchmarkMapSize)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		if i < benchmarkMapSize {
+			m.Set(keys[i], i)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkBuiltinMap_GetString(b *testing.B) {
+	m := make(map[string]int)
+	keys := make([]string, 2*benchmarkMapSize)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		if i < benchmarkMapSize {
+			m[keys[i]] = i
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[keys[i%len(keys)]]
+	}
+}
+
+func BenchmarkFlatMap_Set(b *testing.B) {
+	m := NewFlatMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Set(i%benchmarkMapSize, i)
+	}
+}
+
+func BenchmarkBuiltinMap_Set(b *testing.B) {
+	m := make(map[int]int)
+	for i := 0; i < b.N; i++ {
+		m[i%benchmarkMapSize] = i
+	}
+}
@@ -0,0 +1,89 @@
+package kv
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var linkedMapOps = []model.Op[*LinkedMap[int, int], *model.Map[int, int]]{
+	{Name: "Set", Run: func(m *LinkedMap[int, int], reference *model.Map[int, int], r *model.Reader) (any, any) {
+		key, value := r.Intn(8), int(r.Byte())
+		m.Set(key, value)
+		reference.Set(key, value)
+		return nil, nil
+	}},
+	{Name: "Remove", Run: func(m *LinkedMap[int, int], reference *model.Map[int, int], r *model.Reader) (any, any) {
+		key := r.Intn(8)
+		m.Remove(key)
+		reference.Remove(key)
+		return nil, nil
+	}},
+	{Name: "Get", Run: func(m *LinkedMap[int, int], reference *model.Map[int, int], r *model.Reader) (any, any) {
+		key := r.Intn(8)
+		return model.P(m.Get(key)), model.P(reference.Get(key))
+	}},
+	{Name: "Clear", Run: func(m *LinkedMap[int, int], reference *model.Map[int, int], r *model.Reader) (any, any) {
+		if r.Intn(4) == 0 {
+			m.Clear()
+			reference.Clear()
+		}
+		return nil, nil
+	}},
+}
+
+func observeLinkedMap(m *LinkedMap[int, int], reference *model.Map[int, int]) (any, any) {
+	return model.P(m.Count(), m.Keys()), model.P(int64(reference.Len()), reference.Keys)
+}
+
+func checkLinkedMapOperations(t *testing.T, data []byte) {
+	model.Check(t, NewLinkedMap[int, int](), new(model.Map[int, int]), data, linkedMapOps, observeLinkedMap)
+}
+
+func FuzzMap_UnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`{"a":1,"b":2}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[1]`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m := NewMap[string, int]()
+		if err := json.Unmarshal(data, m); err != nil {
+			return
+		}
+		encoded, err := json.Marshal(m)
+		assert.Nil(t, err)
+		decoded := NewMap[string, int]()
+		assert.Nil(t, json.Unmarshal(encoded, decoded))
+		assert.True(t, model.Equal(m.ToMap(), decoded.ToMap()))
+	})
+}
+
+func FuzzLinkedMap_UnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`{"entries":{"0":0,"1":1},"keys":[1,0]}`))
+	f.Add([]byte(`{"entries":{"0":0},"keys":[0,0,1]}`))
+	f.Add([]byte(`{}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m := NewLinkedMap[int, int]()
+		if err := json.Unmarshal(data, m); err != nil {
+			return
+		}
+		assert.Equal(t, int(m.Count()), len(m.Keys()))
+		encoded, err := json.Marshal(m)
+		assert.Nil(t, err)
+		decoded := NewLinkedMap[int, int]()
+		assert.Nil(t, json.Unmarshal(encoded, decoded))
+		assert.True(t, model.Equal(m.Keys(), decoded.Keys()))
+		assert.True(t, model.Equal(m.Values(), decoded.Values()))
+	})
+}
+
+func FuzzLinkedMap_Operations(f *testing.F) {
+	f.Add([]byte{0, 1, 2, 0, 2, 3, 1, 1, 2, 2, 0, 1, 5, 3})
+	f.Fuzz(checkLinkedMapOperations)
+}
+
+func TestLinkedMap_Model(t *testing.T) {
+	model.Replay(t, 50, 256, checkLinkedMapOperations)
+}
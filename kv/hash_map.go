@@ -0,0 +1,225 @@
+package kv
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/equality"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// NewHashMap new hash map whose keys are compared and hashed by the hasher.
+// Keys are hashed with [maphash] under a random seed of the map,
+// so attacker controlled keys can not be crafted to collide in every map.
+func NewHashMap[K, V any](hasher equality.Hasher[K]) *HashMap[K, V] {
+	seed := maphash.MakeSeed()
+	return newHashMap[K, V](hasher.Equal, func(key K) uint64 {
+		return equality.Sum(hasher, seed, key)
+	})
+}
+
+// NewSeededHashMap new hash map whose keys are compared by equal and hashed by hash with the seed,
+// e.g. to hash with xxhash or to reproduce the layout of a map with a fixed seed.
+// Use a random seed, such as from [math/rand/v2.Uint64], when keys are attacker controlled.
+func NewSeededHashMap[K, V any](equal func(a, b K) bool, hash func(seed uint64, key K) uint64, seed uint64) *HashMap[K, V] {
+	return newHashMap[K, V](equal, func(key K) uint64 {
+		return hash(seed, key)
+	})
+}
+
+func newHashMap[K, V any](equal func(a, b K) bool, sum func(key K) uint64) *HashMap[K, V] {
+	m := new(HashMap[K, V])
+	m.equal = equal
+	m.sum = sum
+	m.buckets = make(map[uint64][]Entry[K, V])
+	return m
+}
+
+// HashMap map with custom key equality and hashing, it holds keys of any type including non-comparable ones
+type HashMap[K, V any] struct {
+	sync.RWMutex
+	equal   func(a, b K) bool
+	sum     func(key K) uint64
+	buckets map[uint64][]Entry[K, V]
+	size    int64
+}
+
+func (m *HashMap[K, V]) find(key K) (uint64, int) {
+	sum := m.sum(key)
+	for index, entry := range m.buckets[sum] {
+		if m.equal(entry.Key, key) {
+			return sum, index
+		}
+	}
+	return sum, -1
+}
+
+// Count returns the size of the map
+func (m *HashMap[K, V]) Count() int64 {
+	return m.size
+}
+
+// IsEmpty returns whether the map is empty
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *HashMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *HashMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *HashMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *HashMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// ContainsKey returns whether the map contains a key equal to the key
+func (m *HashMap[K, V]) ContainsKey(key K) bool {
+	_, index := m.find(key)
+	return index >= 0
+}
+
+// Get returns the value of the key
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	sum, index := m.find(key)
+	if index < 0 {
+		return *new(V), false
+	}
+	return m.buckets[sum][index].Value, true
+}
+
+// GetOr returns the value of the key or the default value when the key does not exist
+func (m *HashMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// TryGet returns the value of the key.
+// It returns [collection.ErrKeyNotFound] when the key does not exist.
+func (m *HashMap[K, V]) TryGet(key K) (V, error) {
+	if v, ok := m.Get(key); ok {
+		return v, nil
+	}
+	return *new(V), collection.NewKeyError(key)
+}
+
+// Set sets the value of the key, the existing key is kept when an equal key exists
+func (m *HashMap[K, V]) Set(key K, value V) {
+	sum, index := m.find(key)
+	if index >= 0 {
+		m.buckets[sum][index].Value = value
+		return
+	}
+	m.buckets[sum] = append(m.buckets[sum], Entry[K, V]{Key: key, Value: value})
+	m.size++
+}
+
+// Remove removes the key
+func (m *HashMap[K, V]) Remove(key K) {
+	sum, index := m.find(key)
+	if index < 0 {
+		return
+	}
+	bucket := m.buckets[sum]
+	if len(bucket) == 1 {
+		delete(m.buckets, sum)
+	} else {
+		m.buckets[sum] = append(bucket[:index:index], bucket[index+1:]...)
+	}
+	m.size--
+}
+
+// Clear clears the map
+func (m *HashMap[K, V]) Clear() {
+	m.buckets = make(map[uint64][]Entry[K, V])
+	m.size = 0
+}
+
+// Each ranges the map, it will break the loop when the callback returns false
+func (m *HashMap[K, V]) Each(callback func(key K, value V) bool) {
+	for _, bucket := range m.buckets {
+		for _, entry := range bucket {
+			if !callback(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *HashMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Keys returns all keys
+func (m *HashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values
+func (m *HashMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.Each(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Entries returns all entries
+func (m *HashMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.size)
+	for _, bucket := range m.buckets {
+		entries = append(entries, bucket...)
+	}
+	return entries
+}
+
+// ToJSON converts to a JSON array of entries, keys may be of any type so they are not encoded as object keys
+func (m *HashMap[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Array(m.Entries())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *HashMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (m *HashMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *HashMap[K, V]) String() string {
+	return format.Entries[K, V]("HashMap", m.Count(), m.Each)
+}
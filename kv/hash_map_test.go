@@ -0,0 +1,106 @@
+package kv
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/equality"
+	"github.com/stretchr/testify/assert"
+)
+
+func sliceHasher() equality.Hasher[[]int] {
+	return equality.New(slices.Equal[[]int], func(hash *maphash.Hash, value []int) {
+		for _, v := range value {
+			_ = binary.Write(hash, binary.LittleEndian, int64(v))
+		}
+	})
+}
+
+func TestHashMap_Set(t *testing.T) {
+	m := NewHashMap[[]int, string](sliceHasher())
+	m.Set([]int{1, 2}, "a")
+	m.Set([]int{2, 1}, "b")
+	m.Set([]int{1, 2}, "c")
+	assert.Equal(t, int64(2), m.Count())
+	value, ok := m.Get([]int{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, "c", value)
+	assert.Equal(t, "d", m.GetOr([]int{3}, "d"))
+	_, err := m.TryGet([]int{3})
+	assert.True(t, errors.Is(err, collection.ErrKeyNotFound))
+}
+
+func TestHashMap_Remove(t *testing.T) {
+	m := NewHashMap[string, int](equality.FoldCase())
+	m.Set("Go", 1)
+	m.Set("rust", 2)
+	m.Remove("GO")
+	assert.False(t, m.ContainsKey("go"))
+	assert.True(t, m.ContainsKey("RUST"))
+	assert.Equal(t, []string{"rust"}, m.Keys())
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestHashMap_Collisions(t *testing.T) {
+	m := NewSeededHashMap[int, int](func(a, b int) bool { return a == b }, func(uint64, int) uint64 { return 0 }, 0)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i*i)
+	}
+	assert.Equal(t, int64(10), m.Count())
+	m.Remove(5)
+	for i := 0; i < 10; i++ {
+		value, ok := m.Get(i)
+		assert.Equal(t, i != 5, ok)
+		if ok {
+			assert.Equal(t, i*i, value)
+		}
+	}
+	assert.ElementsMatch(t, []int{0, 1, 4, 9, 16, 36, 49, 64, 81}, m.Values())
+}
+
+func TestHashMap_Seed(t *testing.T) {
+	var seeds []uint64
+	hash := func(seed uint64, key string) uint64 {
+		seeds = append(seeds, seed)
+		return seed ^ uint64(len(key))
+	}
+	m := NewSeededHashMap[string, int](func(a, b string) bool { return a == b }, hash, 42)
+	m.Set("a", 1)
+	assert.Equal(t, []uint64{42}, seeds)
+
+	hasher := equality.Comparable[string]()
+	a := NewHashMap[string, int](hasher)
+	b := NewHashMap[string, int](hasher)
+	differ := false
+	for i := 0; i < 8 && !differ; i++ {
+		key := fmt.Sprint(i)
+		differ = a.sum(key) != b.sum(key)
+	}
+	assert.True(t, differ)
+}
+
+func TestHashMap_MarshalJSON(t *testing.T) {
+	m := NewHashMap[[]int, string](sliceHasher())
+	assert.Nil(t, json.Unmarshal([]byte(`[{"key":[1,2],"value":"a"},{"key":[3],"value":"b"}]`), m))
+	assert.Equal(t, "a", m.GetOr([]int{1, 2}, ""))
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	var entries []Entry[[]int, string]
+	assert.Nil(t, json.Unmarshal(data, &entries))
+	assert.ElementsMatch(t, m.Entries(), entries)
+}
+
+func TestHashMap_String(t *testing.T) {
+	m := NewHashMap[[]int, int](sliceHasher())
+	m.Set([]int{1}, 1)
+	pattern := regexp.MustCompile(`HashMap\[\[\]int,\sint\]\(len=1\)\{\n\t\[1\]:\s1,\n\}`)
+	assert.True(t, pattern.MatchString(m.String()))
+}
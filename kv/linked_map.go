@@ -1,13 +1,18 @@
 package kv
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"io"
 	"sync"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/collection/list"
-	"github.com/gopi-frame/contract"
+	"github.com/gopi-frame/collection/view"
 )
 
 type jsonObject[K comparable, V any] struct {
@@ -32,8 +37,10 @@ type LinkedMap[K comparable, V any] struct {
 
 // Set sets value to specific key.
 func (m *LinkedMap[K, V]) Set(key K, value V) {
+	if _, ok := m.items[key]; !ok {
+		m.keys.Push(key)
+	}
 	m.Map.Set(key, value)
-	m.keys.Push(key)
 }
 
 // Remove removes specific key.
@@ -42,6 +49,49 @@ func (m *LinkedMap[K, V]) Remove(key K) {
 	m.keys.Remove(key)
 }
 
+// SetMany sets the elements of the entries in order, new keys are appended in the order of the entries.
+func (m *LinkedMap[K, V]) SetMany(entries ...Entry[K, V]) {
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+}
+
+// DeleteMany removes the elements of the given keys, the keys are unlinked in a single pass.
+func (m *LinkedMap[K, V]) DeleteMany(keys ...K) {
+	removed := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := m.items[key]; ok {
+			removed[key] = struct{}{}
+			m.Map.Remove(key)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	m.keys.RemoveWhere(func(key K) bool {
+		_, ok := removed[key]
+		return ok
+	})
+}
+
+// RemoveWhere removes the entries which match the callback, the keys are unlinked in a single pass.
+func (m *LinkedMap[K, V]) RemoveWhere(callback func(key K, value V) bool) {
+	var keys []K
+	for key, value := range m.items {
+		if callback(key, value) {
+			keys = append(keys, key)
+		}
+	}
+	m.DeleteMany(keys...)
+}
+
+// RetainWhere removes the entries which do not match the callback, the keys are unlinked in a single pass.
+func (m *LinkedMap[K, V]) RetainWhere(callback func(key K, value V) bool) {
+	m.RemoveWhere(func(key K, value V) bool {
+		return !callback(key, value)
+	})
+}
+
 // First returns the first value of the map.
 // It will return zero value and false if the map is empty
 func (m *LinkedMap[K, V]) First() (V, bool) {
@@ -102,18 +152,34 @@ func (m *LinkedMap[K, V]) Values() []V {
 
 // Clear clears map.
 func (m *LinkedMap[K, V]) Clear() {
-	m.items = make(map[K]V)
+	m.Map.Clear()
 	m.keys.Clear()
 }
 
-// ContainsKey returns whether the map contains specific key.
-func (m *LinkedMap[K, V]) ContainsKey(key K) bool {
-	for k := range m.items {
-		if k == key {
-			return true
+// Apply applies the mutations of the delta to the map
+func (m *LinkedMap[K, V]) Apply(delta *Delta[K, V]) {
+	for _, entry := range delta.Entries() {
+		switch entry.Op {
+		case DeltaSet:
+			m.Set(entry.Key, entry.Value)
+		case DeltaRemove:
+			m.Remove(entry.Key)
+		case DeltaClear:
+			m.Clear()
 		}
 	}
-	return false
+}
+
+// FilteredView returns a live view of the entries which match the predicate in the order of keys,
+// reads re-evaluate the predicate against the current entries of the map.
+func (m *LinkedMap[K, V]) FilteredView(predicate func(key K, value V) bool) *view.MapView[K, V] {
+	return view.FilterMap[K, V](m, predicate)
+}
+
+// ContainsKey returns whether the map contains specific key.
+func (m *LinkedMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.items[key]
+	return ok
 }
 
 // Reverse reverses the map
@@ -129,8 +195,75 @@ func (m *LinkedMap[K, V]) Each(callback func(key K, value V) bool) {
 	})
 }
 
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *LinkedMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// MemoryFootprint estimates the memory used by the map in bytes,
+// deep returns the memory referenced by an entry beyond its inline size and may be nil
+func (m *LinkedMap[K, V]) MemoryFootprint(deep func(key K, value V) int64) int64 {
+	return memory.Of[LinkedMap[K, V]]() + m.Map.MemoryFootprint(deep) + m.keys.MemoryFootprint(nil)
+}
+
+// Entries returns all entries in the order of keys
+func (m *LinkedMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.Count())
+	m.Each(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	return entries
+}
+
+// Encode writes the map to the writer in the format,
+// JSON writes the object of [LinkedMap.ToJSON] and the other formats write the entries in the order of keys.
+func (m *LinkedMap[K, V]) Encode(w io.Writer, format codec.Format) error {
+	if format == codec.JSON {
+		return json.NewEncoder(w).Encode(m)
+	}
+	return codec.Encode(w, m.Entries(), format)
+}
+
+// Decode replaces the entries of the map with the entries read from the reader in the format
+func (m *LinkedMap[K, V]) Decode(r io.Reader, format codec.Format) error {
+	if format == codec.JSON {
+		return json.NewDecoder(r).Decode(m)
+	}
+	entries, err := codec.Decode[Entry[K, V]](r, format)
+	if err != nil {
+		return err
+	}
+	if m.Map == nil {
+		m.Map = NewMap[K, V]()
+		m.keys = list.NewLinkedList[K]()
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (m *LinkedMap[K, V]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := m.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (m *LinkedMap[K, V]) GobDecode(data []byte) error {
+	return m.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (m *LinkedMap[K, V]) ToJSON() ([]byte, error) {
+	if m.IsEmpty() {
+		return jsonx.Empty(`{"entries":{},"keys":[]}`), nil
+	}
 	return json.Marshal(jsonObject[K, V]{
 		Entries: m.ToMap(),
 		Keys:    m.keys.ToArray(),
@@ -149,12 +282,17 @@ func (m *LinkedMap[K, V]) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	m.Map = NewMap[K, V]()
-	m.keys = list.NewLinkedList(container.Keys...)
-	m.keys.Each(func(index int, value K) bool {
-		m.Map.Set(value, container.Entries[value])
-		return true
-	})
+	if m.Map == nil {
+		m.Map = NewMap[K, V]()
+		m.keys = list.NewLinkedList[K]()
+	}
+	m.Clear()
+	for _, key := range container.Keys {
+		if m.Map.ContainsKey(key) {
+			continue
+		}
+		m.Set(key, container.Entries[key])
+	}
 	return nil
 }
 
@@ -165,31 +303,7 @@ func (m *LinkedMap[K, V]) ToMap() map[K]V {
 
 // String converts to string
 func (m *LinkedMap[K, V]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("LinkedMap[%T, %T](len=%d)", *new(K), *new(V), m.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	keys := m.Keys()
-	for _, key := range keys {
-		str.WriteByte('\t')
-		if k, ok := any(key).(contract.Stringable); ok {
-			str.WriteString(k.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", key))
-		}
-		str.WriteByte(':')
-		str.WriteByte(' ')
-		value, _ := m.Map.Get(key)
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Entries[K, V]("LinkedMap", m.Count(), m.Each)
 }
 
 // Clone clones the map
@@ -201,3 +315,13 @@ func (m *LinkedMap[K, V]) Clone() *LinkedMap[K, V] {
 	})
 	return m
 }
+
+// DeepClone clones the map with a deep copy of every value in order, see [collection.DeepCopy], keys are not copied
+func (m *LinkedMap[K, V]) DeepClone() *LinkedMap[K, V] {
+	mm := NewLinkedMap[K, V]()
+	m.keys.Each(func(_ int, key K) bool {
+		mm.Set(key, collection.DeepCopy(m.items[key]))
+		return true
+	})
+	return mm
+}
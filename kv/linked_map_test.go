@@ -1,11 +1,16 @@
 package kv
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/deepcopy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,6 +98,22 @@ func TestLinkedMap_Keys(t *testing.T) {
 	assert.Equal(t, []int{0, 1, 2}, m.Keys())
 }
 
+func TestLinkedMap_RemoveWhere(t *testing.T) {
+	m := NewLinkedMap[int, int]()
+	for i := 0; i < 6; i++ {
+		m.Set(i, i*10)
+	}
+	m.RemoveWhere(func(key int, _ int) bool {
+		return key%2 == 0
+	})
+	assert.Equal(t, []int{1, 3, 5}, m.Keys())
+	m.RetainWhere(func(_ int, value int) bool {
+		return value > 10
+	})
+	assert.Equal(t, []int{3, 5}, m.Keys())
+	assert.Equal(t, int64(2), m.Count())
+}
+
 func TestLinkedMap_Values(t *testing.T) {
 	m := NewLinkedMap[int, int]()
 	m.Set(0, 0)
@@ -152,6 +173,22 @@ func TestLinkedMap_Each(t *testing.T) {
 	assert.Equal(t, []int{0, 1}, items)
 }
 
+func TestLinkedMap_All(t *testing.T) {
+	m := NewLinkedMap[int, string]()
+	m.Set(2, "b")
+	m.Set(0, "a")
+	m.Set(1, "c")
+	var keys []int
+	var values []string
+	m.All()(func(key int, value string) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return key != 0
+	})
+	assert.Equal(t, []int{2, 0}, keys)
+	assert.Equal(t, []string{"b", "a"}, values)
+}
+
 func TestLinkedMap_ToJSON(t *testing.T) {
 	m := NewLinkedMap[int, int]()
 	m.Set(0, 0)
@@ -212,3 +249,99 @@ func TestLinkedMap_Reverse(t *testing.T) {
 	values := m.Values()
 	assert.Equal(t, []int{2, 1, 0}, values)
 }
+
+func TestLinkedMap_Apply(t *testing.T) {
+	leader := NewLinkedMap[string, int]()
+	follower := NewLinkedMap[string, int]()
+	leader.Track()
+	leader.Set("b", 2)
+	leader.Set("a", 1)
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, []string{"b", "a"}, follower.Keys())
+
+	leader.Set("c", 3)
+	leader.Set("d", 4)
+	leader.Set("c", 5)
+	leader.Remove("b")
+	leader.Set("b", 6)
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, leader.Keys(), follower.Keys())
+	assert.Equal(t, leader.Values(), follower.Values())
+
+	assert.Nil(t, leader.UnmarshalJSON([]byte(`{"entries":{"x":1,"y":2},"keys":["y","x"]}`)))
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, []string{"y", "x"}, follower.Keys())
+
+	leader.Clear()
+	follower.Apply(leader.Checkpoint())
+	assert.True(t, follower.IsEmpty())
+}
+
+func TestLinkedMap_SetMany(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("b", 0)
+	m.SetMany(Entry[string, int]{Key: "a", Value: 1}, Entry[string, int]{Key: "b", Value: 2}, Entry[string, int]{Key: "c", Value: 3})
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, []int{2, 1, 3}, m.Values())
+}
+
+func TestLinkedMap_DeleteMany(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.DeleteMany("c", "a", "d")
+	assert.Equal(t, []string{"b"}, m.Keys())
+	assert.Equal(t, int64(1), m.Count())
+}
+
+func TestLinkedMap_FilteredView(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	active := m.FilteredView(func(_ string, value int) bool {
+		return value > 1
+	})
+	assert.Equal(t, []string{"c", "b"}, active.Keys())
+}
+
+func TestLinkedMap_Encode(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, m.Encode(buf, codec.NDJSON))
+	assert.Equal(t, "{\"key\":\"b\",\"value\":2}\n{\"key\":\"a\",\"value\":1}\n", buf.String())
+}
+
+func TestLinkedMap_Decode(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("z", 0)
+	assert.Nil(t, m.Decode(strings.NewReader(`{"key":"b","value":2}`+"\n"+`{"key":"a","value":1}`+"\n"), codec.NDJSON))
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+	assert.Equal(t, []int{2, 1}, m.Values())
+}
+
+func TestLinkedMap_JSONNull(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	m := NewLinkedMap[string, int]()
+	m.Set("a", 1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), m))
+	assert.True(t, m.IsEmpty())
+	data, _ := json.Marshal(m)
+	assert.JSONEq(t, `{"entries":{},"keys":[]}`, string(data))
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = json.Marshal(m)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestLinkedMap_DeepClone(t *testing.T) {
+	m := NewLinkedMap[string, []int]()
+	m.Set("b", []int{2})
+	m.Set("a", []int{1})
+	clone := m.DeepClone()
+	clone.GetOr("a", nil)[0] = 10
+	assert.Equal(t, []string{"b", "a"}, clone.Keys())
+	assert.Equal(t, deepcopy.Reflect, m.GetOr("a", nil)[0] == 1)
+}
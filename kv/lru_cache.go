@@ -0,0 +1,309 @@
+package kv
+
+import (
+	listlib "container/list"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// CacheStats counters of a cache
+type CacheStats struct {
+	// Hits the lookups which found a live entry
+	Hits int64 `json:"hits"`
+	// Misses the lookups which found no entry or an expired one
+	Misses int64 `json:"misses"`
+	// Evictions the entries evicted to stay within the capacity
+	Evictions int64 `json:"evictions"`
+	// Expirations the entries removed because their time to live elapsed
+	Expirations int64 `json:"expirations"`
+}
+
+type lruItem[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func (item *lruItem[K, V]) expired(now time.Time) bool {
+	return !item.expiresAt.IsZero() && !now.Before(item.expiresAt)
+}
+
+// NewLRUCache new cache which holds at most capacity entries, the least recently used entry is evicted first.
+// A capacity of zero or less makes the cache unbounded.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	c := new(LRUCache[K, V])
+	c.capacity = capacity
+	c.items = make(map[K]*listlib.Element)
+	c.recent = listlib.New()
+	c.now = time.Now
+	return c
+}
+
+// LRUCache cache bounded by a capacity which evicts the least recently used entries,
+// entries may also expire after a time to live.
+// Expired entries are skipped by reads and removed by Get, Put and Purge.
+// Get updates the recency of the entry and the statistics, so lock the cache with Lock rather than RLock around it,
+// the other reads, such as Peek, Count, Each and ToJSON, do not change the cache and may share RLock.
+type LRUCache[K comparable, V any] struct {
+	sync.RWMutex
+	capacity int
+	ttl      time.Duration
+	items    map[K]*listlib.Element
+	recent   *listlib.List
+	onEvict  []func(entry Entry[K, V])
+	stats    CacheStats
+	now      func() time.Time
+}
+
+// TTL sets the default time to live of the entries put afterwards, a non-positive ttl means entries never expire
+func (c *LRUCache[K, V]) TTL(ttl time.Duration) *LRUCache[K, V] {
+	c.ttl = ttl
+	return c
+}
+
+// OnEvict registers a callback which is called with every entry evicted for the capacity or expired,
+// entries removed by Delete or Clear and values replaced by Put are not reported.
+// Callbacks are called synchronously once the entry is removed.
+func (c *LRUCache[K, V]) OnEvict(callback func(entry Entry[K, V])) {
+	c.onEvict = append(c.onEvict, callback)
+}
+
+func (c *LRUCache[K, V]) evict(e *listlib.Element) {
+	item := c.recent.Remove(e).(*lruItem[K, V])
+	delete(c.items, item.key)
+	entry := Entry[K, V]{Key: item.key, Value: item.value}
+	for _, handler := range c.onEvict {
+		handler(entry)
+	}
+}
+
+// live returns the entry of the key unless it is expired, it does not change the cache
+func (c *LRUCache[K, V]) live(key K) (*listlib.Element, bool) {
+	e, ok := c.items[key]
+	if !ok || e.Value.(*lruItem[K, V]).expired(c.now()) {
+		return nil, false
+	}
+	return e, true
+}
+
+// lookup returns the live entry of the key, an expired entry is removed
+func (c *LRUCache[K, V]) lookup(key K) (*listlib.Element, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if e.Value.(*lruItem[K, V]).expired(c.now()) {
+		c.stats.Expirations++
+		c.evict(e)
+		return nil, false
+	}
+	return e, true
+}
+
+// Capacity returns the capacity of the cache
+func (c *LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Count returns the number of entries which are not expired
+func (c *LRUCache[K, V]) Count() int64 {
+	now := c.now()
+	var count int64
+	for _, e := range c.items {
+		if !e.Value.(*lruItem[K, V]).expired(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty returns whether the cache is empty
+func (c *LRUCache[K, V]) IsEmpty() bool {
+	return c.Count() == 0
+}
+
+// IsNotEmpty returns whether the cache is not empty
+func (c *LRUCache[K, V]) IsNotEmpty() bool {
+	return !c.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (c *LRUCache[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (c *LRUCache[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (c *LRUCache[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get gets the value of the key and marks the entry as the most recently used.
+// A zero value and false will be returned when the given key is not exist or expired
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		c.stats.Misses++
+		return *new(V), false
+	}
+	c.stats.Hits++
+	c.recent.MoveToFront(e)
+	return e.Value.(*lruItem[K, V]).value, true
+}
+
+// Peek gets the value of the key without updating its recency or the statistics
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.live(key)
+	if !ok {
+		return *new(V), false
+	}
+	return e.Value.(*lruItem[K, V]).value, true
+}
+
+// ContainsKey returns whether the cache contains the key, it does not update the recency of the entry
+func (c *LRUCache[K, V]) ContainsKey(key K) bool {
+	_, ok := c.live(key)
+	return ok
+}
+
+// Put puts the value of the key with the default ttl and marks the entry as the most recently used,
+// the least recently used entry is evicted when the cache is full
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL puts the value of the key which expires after ttl,
+// a non-positive ttl means the entry never expires
+func (c *LRUCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	item := &lruItem[K, V]{key: key, value: value}
+	if ttl > 0 {
+		item.expiresAt = c.now().Add(ttl)
+	}
+	if e, ok := c.items[key]; ok {
+		e.Value = item
+		c.recent.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.recent.PushFront(item)
+	for c.capacity > 0 && c.recent.Len() > c.capacity {
+		c.stats.Evictions++
+		c.evict(c.recent.Back())
+	}
+}
+
+// Delete removes the entry of the key and returns whether it was present
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.recent.Remove(e)
+	delete(c.items, key)
+	return true
+}
+
+// Purge removes all expired entries and returns the number of them
+func (c *LRUCache[K, V]) Purge() int {
+	now := c.now()
+	count := 0
+	for e := c.recent.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(*lruItem[K, V]).expired(now) {
+			c.stats.Expirations++
+			c.evict(e)
+			count++
+		}
+		e = next
+	}
+	return count
+}
+
+// Clear clears the cache, the statistics are kept
+func (c *LRUCache[K, V]) Clear() {
+	c.items = make(map[K]*listlib.Element)
+	c.recent.Init()
+}
+
+// Stats returns the counters of the cache
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	return c.stats
+}
+
+// ResetStats resets the counters of the cache
+func (c *LRUCache[K, V]) ResetStats() {
+	c.stats = CacheStats{}
+}
+
+// DebugStats returns the counters and the capacity of the cache, see the debug package
+func (c *LRUCache[K, V]) DebugStats() map[string]any {
+	return map[string]any{
+		"capacity":    c.capacity,
+		"hits":        c.stats.Hits,
+		"misses":      c.stats.Misses,
+		"evictions":   c.stats.Evictions,
+		"expirations": c.stats.Expirations,
+	}
+}
+
+// Keys returns the keys which are not expired from the most to the least recently used
+func (c *LRUCache[K, V]) Keys() []K {
+	var keys []K
+	c.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Each ranges the cache from the most to the least recently used entry without updating the recency,
+// it will break the loop when the callback returns false. Expired entries are skipped.
+func (c *LRUCache[K, V]) Each(callback func(key K, value V) bool) {
+	now := c.now()
+	for e := c.recent.Front(); e != nil; e = e.Next() {
+		item := e.Value.(*lruItem[K, V])
+		if item.expired(now) {
+			continue
+		}
+		if !callback(item.key, item.value) {
+			break
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (c *LRUCache[K, V]) All() func(yield func(key K, value V) bool) {
+	return c.Each
+}
+
+// ToMap converts to map
+func (c *LRUCache[K, V]) ToMap() map[K]V {
+	items := make(map[K]V)
+	c.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (c *LRUCache[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(c.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (c *LRUCache[K, V]) MarshalJSON() ([]byte, error) {
+	return c.ToJSON()
+}
+
+// String converts to string
+func (c *LRUCache[K, V]) String() string {
+	return format.Entries[K, V]("LRUCache", c.Count(), c.Each)
+}
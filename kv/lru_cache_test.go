@@ -0,0 +1,133 @@
+package kv
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLRUCache(capacity int, now *time.Time) *LRUCache[string, int] {
+	c := NewLRUCache[string, int](capacity)
+	c.now = func() time.Time {
+		return *now
+	}
+	return c
+}
+
+func TestLRUCache_Put(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(2, &now)
+	var evicted []Entry[string, int]
+	c.OnEvict(func(entry Entry[string, int]) {
+		evicted = append(evicted, entry)
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	c.Put("c", 3)
+	assert.Equal(t, []Entry[string, int]{{Key: "b", Value: 2}}, evicted)
+	assert.Equal(t, []string{"c", "a"}, c.Keys())
+
+	c.Put("a", 10)
+	c.Put("d", 4)
+	assert.Equal(t, []string{"d", "a"}, c.Keys())
+	value, _ := c.Peek("a")
+	assert.Equal(t, 10, value)
+	assert.Equal(t, CacheStats{Hits: 1, Evictions: 2}, c.Stats())
+}
+
+func TestLRUCache_Get(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(0, &now)
+	c.Put("a", 1)
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, c.Stats())
+	c.ResetStats()
+	assert.Equal(t, CacheStats{}, c.Stats())
+}
+
+func TestLRUCache_TTL(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(10, &now).TTL(time.Second)
+	var evicted []string
+	c.OnEvict(func(entry Entry[string, int]) {
+		evicted = append(evicted, entry.Key)
+	})
+	c.Put("a", 1)
+	c.PutWithTTL("b", 2, 0)
+	c.PutWithTTL("c", 3, 2*time.Second)
+
+	now = now.Add(time.Second)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.True(t, c.ContainsKey("c"))
+	assert.Equal(t, int64(2), c.Count())
+
+	now = now.Add(time.Second)
+	assert.Equal(t, 1, c.Purge())
+	assert.Equal(t, []string{"a", "c"}, evicted)
+	assert.Equal(t, CacheStats{Misses: 1, Expirations: 2}, c.Stats())
+}
+
+func TestLRUCache_Delete(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(2, &now)
+	evicted := 0
+	c.OnEvict(func(Entry[string, int]) { evicted++ })
+	c.Put("a", 1)
+	c.Put("b", 2)
+	assert.True(t, c.Delete("a"))
+	assert.False(t, c.Delete("a"))
+	c.Put("c", 3)
+	assert.Equal(t, int64(2), c.Count())
+	c.Clear()
+	assert.True(t, c.IsEmpty())
+	assert.Equal(t, 0, evicted)
+	assert.Equal(t, 2, c.Capacity())
+}
+
+func TestLRUCache_ToJSON(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(2, &now)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	data, err := c.MarshalJSON()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+	assert.Equal(t, "LRUCache[string, int](len=2){\n\tb: 2,\n\ta: 1,\n}", c.String())
+	assert.Equal(t, map[string]any{"capacity": 2, "hits": int64(0), "misses": int64(0), "evictions": int64(0), "expirations": int64(0)}, c.DebugStats())
+}
+
+func TestLRUCache_ConcurrentReads(t *testing.T) {
+	now := time.Now()
+	c := newTestLRUCache(10, &now)
+	c.PutWithTTL("a", 1, time.Second)
+	c.Put("b", 2)
+	now = now.Add(time.Second)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.RLock()
+			defer c.RUnlock()
+			assert.Equal(t, int64(1), c.Count())
+			assert.False(t, c.ContainsKey("a"))
+			_, ok := c.Peek("a")
+			assert.False(t, ok)
+			data, err := c.ToJSON()
+			assert.Nil(t, err)
+			assert.JSONEq(t, `{"b":2}`, string(data))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, CacheStats{}, c.Stats())
+	assert.Equal(t, 1, c.Purge())
+}
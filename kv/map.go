@@ -1,13 +1,43 @@
 package kv
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"reflect"
-	"strings"
+	"io"
+	"slices"
 	"sync"
 
-	"github.com/gopi-frame/contract"
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+	"github.com/gopi-frame/collection/view"
+)
+
+// Interface operations shared by the maps, so one implementation can be swapped for another
+type Interface[K, V any] interface {
+	Count() int64
+	IsEmpty() bool
+	IsNotEmpty() bool
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Remove(key K)
+	ContainsKey(key K) bool
+	Keys() []K
+	Each(callback func(key K, value V) bool)
+}
+
+var (
+	_ Interface[string, any] = (*Map[string, any])(nil)
+	_ Interface[string, any] = (*LinkedMap[string, any])(nil)
+	_ Interface[string, any] = (*OrderedMap[string, any])(nil)
+	_ Interface[string, any] = (*ConcurrentMap[string, any])(nil)
+	_ Interface[string, any] = (*ExpiringMap[string, any])(nil)
+	_ Interface[string, any] = (*HashMap[string, any])(nil)
+	_ Interface[string, any] = (*FlatMap[string, any])(nil)
+	_ Interface[int, any]    = (*EnumMap[int, any])(nil)
 )
 
 // NewMap new map
@@ -17,10 +47,45 @@ func NewMap[K comparable, V any]() *Map[K, V] {
 	return m
 }
 
+// Collect new map of the entries of the sequence, such as an [iter.Seq2], later entries replace earlier ones of the same key
+func Collect[K comparable, V any](seq func(yield func(key K, value V) bool)) *Map[K, V] {
+	m := NewMap[K, V]()
+	seq(func(key K, value V) bool {
+		m.items[key] = value
+		return true
+	})
+	return m
+}
+
 // Map map
 type Map[K comparable, V any] struct {
 	sync.RWMutex
-	items map[K]V
+	items    map[K]V
+	delta    *Delta[K, V]
+	watchers watchList[K, V]
+	order    func(a, b K) int
+}
+
+// OrderBy makes the map iterate in the order of keys sorted by the callback,
+// which makes iteration reproducible at the cost of sorting keys on each iteration.
+// A nil callback restores the hash order.
+// Use [LinkedMap] to iterate in insertion order.
+func (m *Map[K, V]) OrderBy(callback func(a, b K) int) *Map[K, V] {
+	m.order = callback
+	return m
+}
+
+// sortedKeys returns the keys sorted by the order, it returns nil when the map is not ordered
+func (m *Map[K, V]) sortedKeys() []K {
+	if m.order == nil {
+		return nil
+	}
+	keys := make([]K, 0, len(m.items))
+	for key := range m.items {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, m.order)
+	return keys
 }
 
 // Count returns the size of map
@@ -38,6 +103,21 @@ func (m *Map[K, V]) IsNotEmpty() bool {
 	return !m.IsEmpty()
 }
 
+// ElementType returns the name of the value type
+func (m *Map[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *Map[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *Map[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
 // Get gets element by specific key.
 // A zero value and false will be returned when the given key is not exist
 func (m *Map[K, V]) Get(key K) (V, bool) {
@@ -55,18 +135,93 @@ func (m *Map[K, V]) GetOr(key K, value V) V {
 	return value
 }
 
+// TryGet gets element by specific key.
+// It returns [collection.ErrKeyNotFound] when the given key is not exist
+func (m *Map[K, V]) TryGet(key K) (V, error) {
+	v, ok := m.items[key]
+	if !ok {
+		return v, collection.NewKeyError(key)
+	}
+	return v, nil
+}
+
 // Set sets element to the specific key
 func (m *Map[K, V]) Set(key K, value V) {
 	m.items[key] = value
+	m.record(DeltaEntry[K, V]{Op: DeltaSet, Key: key, Value: value})
 }
 
 // Remove removes the element of specific key
 func (m *Map[K, V]) Remove(key K) {
 	delete(m.items, key)
+	m.record(DeltaEntry[K, V]{Op: DeltaRemove, Key: key})
+}
+
+// GetMany gets elements by the given keys, keys which are not exist are absent from the result.
+// Lock the map once around the call instead of once per key.
+func (m *Map[K, V]) GetMany(keys ...K) map[K]V {
+	values := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := m.items[key]; ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// SetMany sets the elements of the entries in order
+func (m *Map[K, V]) SetMany(entries ...Entry[K, V]) {
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+}
+
+// DeleteMany removes the elements of the given keys
+func (m *Map[K, V]) DeleteMany(keys ...K) {
+	for _, key := range keys {
+		m.Remove(key)
+	}
+}
+
+// RemoveWhere removes the entries which match the callback in a single pass.
+// Lock the map once around the call instead of once per entry.
+func (m *Map[K, V]) RemoveWhere(callback func(key K, value V) bool) {
+	for key, value := range m.items {
+		if callback(key, value) {
+			m.Remove(key)
+		}
+	}
+}
+
+// RetainWhere removes the entries which do not match the callback in a single pass
+func (m *Map[K, V]) RetainWhere(callback func(key K, value V) bool) {
+	m.RemoveWhere(func(key K, value V) bool {
+		return !callback(key, value)
+	})
+}
+
+// CountWhere returns the number of entries which match the callback
+func (m *Map[K, V]) CountWhere(callback func(key K, value V) bool) int64 {
+	var count int64
+	for key, value := range m.items {
+		if callback(key, value) {
+			count++
+		}
+	}
+	return count
+}
+
+// FilteredView returns a live view of the entries which match the predicate,
+// reads re-evaluate the predicate against the current entries of the map.
+func (m *Map[K, V]) FilteredView(predicate func(key K, value V) bool) *view.MapView[K, V] {
+	return view.FilterMap[K, V](m, predicate)
 }
 
 // Keys returns all keys
 func (m *Map[K, V]) Keys() []K {
+	if m.order != nil {
+		return m.sortedKeys()
+	}
 	var keys []K
 	for key := range m.items {
 		keys = append(keys, key)
@@ -77,6 +232,12 @@ func (m *Map[K, V]) Keys() []K {
 // Values returns all values
 func (m *Map[K, V]) Values() []V {
 	var values []V
+	if m.order != nil {
+		for _, key := range m.sortedKeys() {
+			values = append(values, m.items[key])
+		}
+		return values
+	}
 	for _, value := range m.items {
 		values = append(values, value)
 	}
@@ -86,22 +247,58 @@ func (m *Map[K, V]) Values() []V {
 // Clear clears the map
 func (m *Map[K, V]) Clear() {
 	m.items = make(map[K]V)
+	m.record(DeltaEntry[K, V]{Op: DeltaClear})
 }
 
-// ContainsKey returns whether the map contains the specific key
-func (m *Map[K, V]) ContainsKey(key K) bool {
-	for k := range m.items {
-		if k == key {
-			return true
+func (m *Map[K, V]) record(entry DeltaEntry[K, V]) {
+	if m.delta != nil {
+		m.delta.record(entry)
+	}
+	m.watchers.notify(entry)
+}
+
+// Track starts recording mutations of the map (Set, Remove and Clear),
+// Decode and UnmarshalJSON are recorded as a clear followed by the decoded entries,
+// the recorded mutations can be exported by Checkpoint
+func (m *Map[K, V]) Track() {
+	m.delta = NewDelta[K, V]()
+}
+
+// Checkpoint returns the mutations recorded since the last checkpoint and starts a new one.
+// It returns nil when the map is not tracked.
+func (m *Map[K, V]) Checkpoint() *Delta[K, V] {
+	if m.delta == nil {
+		return nil
+	}
+	delta := m.delta
+	m.delta = NewDelta[K, V]()
+	return delta
+}
+
+// Apply applies the mutations of the delta to the map
+func (m *Map[K, V]) Apply(delta *Delta[K, V]) {
+	for _, entry := range delta.Entries() {
+		switch entry.Op {
+		case DeltaSet:
+			m.Set(entry.Key, entry.Value)
+		case DeltaRemove:
+			m.Remove(entry.Key)
+		case DeltaClear:
+			m.Clear()
 		}
 	}
-	return false
+}
+
+// ContainsKey returns whether the map contains the specific key
+func (m *Map[K, V]) ContainsKey(key K) bool {
+	_, ok := m.items[key]
+	return ok
 }
 
 // Contains returns whether the map contains the specific value
 func (m *Map[K, V]) Contains(value V) bool {
 	return m.ContainsWhere(func(v V) bool {
-		return reflect.DeepEqual(v, value)
+		return equal.Equal(v, value)
 	})
 }
 
@@ -115,8 +312,36 @@ func (m *Map[K, V]) ContainsWhere(callback func(value V) bool) bool {
 	return false
 }
 
+// ContainsKeyWhere returns whether the map contains keys which match the callback
+func (m *Map[K, V]) ContainsKeyWhere(callback func(key K) bool) bool {
+	for k := range m.items {
+		if callback(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllWhere returns whether all values match the callback, it returns true when the map is empty
+func (m *Map[K, V]) AllWhere(callback func(value V) bool) bool {
+	for _, v := range m.items {
+		if !callback(v) {
+			return false
+		}
+	}
+	return true
+}
+
 // Each ranges the map by callback, it will break the loop when the callback returns false
 func (m *Map[K, V]) Each(callback func(key K, value V) bool) {
+	if m.order != nil {
+		for _, key := range m.sortedKeys() {
+			if !callback(key, m.items[key]) {
+				break
+			}
+		}
+		return
+	}
 	for key, value := range m.items {
 		if !callback(key, value) {
 			break
@@ -124,9 +349,76 @@ func (m *Map[K, V]) Each(callback func(key K, value V) bool) {
 	}
 }
 
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *Map[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// MemoryFootprint estimates the memory used by the map in bytes,
+// deep returns the memory referenced by an entry beyond its inline size and may be nil
+func (m *Map[K, V]) MemoryFootprint(deep func(key K, value V) int64) int64 {
+	size := memory.Of[Map[K, V]]() + memory.Map[K, V](len(m.items))
+	if deep != nil {
+		for key, value := range m.items {
+			size += deep(key, value)
+		}
+	}
+	return size
+}
+
+// Entries returns all entries in the order of Each, so they are sorted when the map is ordered by OrderBy
+func (m *Map[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m.items))
+	m.Each(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	return entries
+}
+
+// Encode writes the map to the writer in the format,
+// JSON writes the object of [Map.ToJSON] and the other formats write the entries in the order of Each.
+// Order the map with OrderBy for a deterministic output.
+func (m *Map[K, V]) Encode(w io.Writer, format codec.Format) error {
+	if format == codec.JSON {
+		return json.NewEncoder(w).Encode(m)
+	}
+	return codec.Encode(w, m.Entries(), format)
+}
+
+// Decode replaces the entries of the map with the entries read from the reader in the format
+func (m *Map[K, V]) Decode(r io.Reader, format codec.Format) error {
+	if format == codec.JSON {
+		return json.NewDecoder(r).Decode(m)
+	}
+	entries, err := codec.Decode[Entry[K, V]](r, format)
+	if err != nil {
+		return err
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := m.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	return m.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts the map to json bytes
 func (m *Map[K, V]) ToJSON() ([]byte, error) {
-	return json.Marshal(m.items)
+	return jsonx.Object(m.items)
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -140,7 +432,10 @@ func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &values); err != nil {
 		return err
 	}
-	m.items = values
+	m.Clear()
+	for key, value := range values {
+		m.Set(key, value)
+	}
 	return nil
 }
 
@@ -151,36 +446,25 @@ func (m *Map[K, V]) ToMap() map[K]V {
 
 // String converts to string
 func (m *Map[K, V]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("Map[%T, %T](len=%d)", *new(K), *new(V), m.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	for k, v := range m.items {
-		str.WriteByte('\t')
-		if key, ok := any(k).(contract.Stringable); ok {
-			str.WriteString(key.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", k))
-		}
-		str.WriteByte(':')
-		str.WriteByte(' ')
-		if value, ok := any(v).(contract.Stringable); ok {
-			str.WriteString(value.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", v))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Entries[K, V]("Map", m.Count(), m.Each)
 }
 
 // Clone clone a new map
 func (m *Map[K, V]) Clone() *Map[K, V] {
 	newMap := NewMap[K, V]()
+	newMap.order = m.order
 	for key, value := range m.items {
 		newMap.Set(key, value)
 	}
 	return newMap
 }
+
+// DeepClone clones the map with a deep copy of every value, see [collection.DeepCopy], keys are not copied
+func (m *Map[K, V]) DeepClone() *Map[K, V] {
+	newMap := NewMap[K, V]()
+	newMap.order = m.order
+	for key, value := range m.items {
+		newMap.Set(key, collection.DeepCopy(value))
+	}
+	return newMap
+}
@@ -0,0 +1,93 @@
+package kv
+
+type entryOwner[K comparable, V any] interface {
+	Set(key K, value V)
+	Remove(key K)
+}
+
+func newMapEntry[K comparable, V any](owner entryOwner[K, V], items map[K]V, key K) *MapEntry[K, V] {
+	entry := new(MapEntry[K, V])
+	entry.owner = owner
+	entry.key = key
+	entry.value, entry.exists = items[key]
+	return entry
+}
+
+// MapEntry reference to an entry of the map, the key is looked up once when the entry is created
+// and the cached value is used by the following reads, so compound read-modify-write sequences
+// cost one lookup plus at most one write.
+// The entry should not be used after the map is modified by other means.
+type MapEntry[K comparable, V any] struct {
+	owner  entryOwner[K, V]
+	key    K
+	value  V
+	exists bool
+}
+
+// Key returns the key of the entry
+func (e *MapEntry[K, V]) Key() K {
+	return e.key
+}
+
+// Exists returns whether the key exists in the map
+func (e *MapEntry[K, V]) Exists() bool {
+	return e.exists
+}
+
+// Get returns the value of the entry.
+// A zero value and false will be returned when the key is not exist
+func (e *MapEntry[K, V]) Get() (V, bool) {
+	return e.value, e.exists
+}
+
+// Set sets the value of the entry
+func (e *MapEntry[K, V]) Set(value V) {
+	e.owner.Set(e.key, value)
+	e.value = value
+	e.exists = true
+}
+
+// Remove removes the entry from the map
+func (e *MapEntry[K, V]) Remove() {
+	if !e.exists {
+		return
+	}
+	e.owner.Remove(e.key)
+	e.value = *new(V)
+	e.exists = false
+}
+
+// OrInsert sets the value when the key is not exist and returns the value of the entry
+func (e *MapEntry[K, V]) OrInsert(value V) V {
+	if !e.exists {
+		e.Set(value)
+	}
+	return e.value
+}
+
+// OrInsertWith sets the value returned by the callback when the key is not exist and returns the value of the entry,
+// the callback is only called when the key is not exist
+func (e *MapEntry[K, V]) OrInsertWith(callback func() V) V {
+	if !e.exists {
+		e.Set(callback())
+	}
+	return e.value
+}
+
+// Update replaces the value with the result of the callback when the key exists
+func (e *MapEntry[K, V]) Update(callback func(value V) V) *MapEntry[K, V] {
+	if e.exists {
+		e.Set(callback(e.value))
+	}
+	return e
+}
+
+// Entry returns the reference to the entry of the specific key
+func (m *Map[K, V]) Entry(key K) *MapEntry[K, V] {
+	return newMapEntry[K, V](m, m.items, key)
+}
+
+// Entry returns the reference to the entry of the specific key
+func (m *LinkedMap[K, V]) Entry(key K) *MapEntry[K, V] {
+	return newMapEntry[K, V](m, m.items, key)
+}
@@ -0,0 +1,68 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapEntry_OrInsert(t *testing.T) {
+	m := NewMap[string, int]()
+	assert.Equal(t, 1, m.Entry("a").OrInsert(1))
+	assert.Equal(t, 1, m.Entry("a").OrInsert(2))
+	assert.Equal(t, 1, m.GetOr("a", 0))
+}
+
+func TestMapEntry_OrInsertWith(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	called := false
+	value := m.Entry("a").OrInsertWith(func() int {
+		called = true
+		return 2
+	})
+	assert.Equal(t, 1, value)
+	assert.False(t, called)
+}
+
+func TestMapEntry_Update(t *testing.T) {
+	m := NewMap[string, int]()
+	inc := func(value int) int {
+		return value + 1
+	}
+	for i := 0; i < 3; i++ {
+		m.Entry("a").Update(inc).OrInsert(1)
+	}
+	assert.Equal(t, 3, m.GetOr("a", 0))
+}
+
+func TestMapEntry_Remove(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	entry := m.Entry("a")
+	value, ok := entry.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	entry.Remove()
+	assert.False(t, entry.Exists())
+	assert.False(t, m.ContainsKey("a"))
+}
+
+func TestLinkedMap_Entry(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Entry("b").OrInsert(2)
+	m.Entry("a").OrInsert(1)
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+	m.Entry("b").Remove()
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestLinkedMap_EntryUpdate(t *testing.T) {
+	m := NewLinkedMap[string, int]()
+	m.Set("a", 1)
+	m.Entry("a").Update(func(value int) int {
+		return value + 1
+	})
+	assert.Equal(t, []string{"a"}, m.Keys())
+	assert.Equal(t, []int{2}, m.Values())
+}
@@ -1,11 +1,18 @@
 package kv
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/deepcopy"
+	"github.com/gopi-frame/collection/internal/equal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,6 +111,31 @@ func TestMap_ContainsWhere(t *testing.T) {
 	}))
 }
 
+func TestCollect(t *testing.T) {
+	source := NewMap[string, int]()
+	source.Set("a", 1)
+	source.Set("b", 2)
+	m := Collect(source.Each)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.ToMap())
+}
+
+func TestMap_All(t *testing.T) {
+	m := NewMap[string, int]()
+	m.OrderBy(cmp.Compare[string])
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	var keys []string
+	var values []int
+	m.All()(func(key string, value int) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
 func TestMap_Each(t *testing.T) {
 	m := NewMap[int, int]()
 	m.Set(0, 0)
@@ -166,6 +198,11 @@ func TestMap_String(t *testing.T) {
 	str := m.String()
 	pattern := regexp.MustCompile(fmt.Sprintf(`Map\[int, int\]\(len=%d\)\{\n(\t\d+:\s\d+,\n)+\}`, m.Count()))
 	assert.True(t, pattern.Match([]byte(str)))
+	for i := 3; i < 10; i++ {
+		m.Set(i, i)
+	}
+	pattern = regexp.MustCompile(`Map\[int, int\]\(len=10\)\{\n(\t\d+:\s\d+,\n){5}\t\.\.\.\n\}`)
+	assert.True(t, pattern.MatchString(m.String()))
 }
 
 func TestMap_Clone(t *testing.T) {
@@ -178,3 +215,216 @@ func TestMap_Clone(t *testing.T) {
 		0: 0, 1: 1, 2: 2,
 	}, m2.ToMap())
 }
+
+func TestMap_Checkpoint(t *testing.T) {
+	t.Run("not tracked", func(t *testing.T) {
+		m := NewMap[string, int]()
+		m.Set("a", 1)
+		assert.Nil(t, m.Checkpoint())
+	})
+
+	t.Run("tracked", func(t *testing.T) {
+		m := NewMap[string, int]()
+		m.Set("a", 1)
+		m.Track()
+		m.Set("b", 2)
+		m.Remove("a")
+		delta := m.Checkpoint()
+		assert.Equal(t, []DeltaEntry[string, int]{
+			{Op: DeltaSet, Key: "b", Value: 2},
+			{Op: DeltaRemove, Key: "a"},
+		}, delta.Entries())
+		assert.True(t, m.Checkpoint().IsEmpty())
+	})
+}
+
+func TestMap_Apply(t *testing.T) {
+	leader := NewMap[string, int]()
+	follower := NewMap[string, int]()
+	leader.Track()
+	leader.Set("a", 1)
+	leader.Set("b", 2)
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, leader.ToMap(), follower.ToMap())
+
+	leader.Clear()
+	leader.Set("c", 3)
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, map[string]int{"c": 3}, follower.ToMap())
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"d":4}`), leader))
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, map[string]int{"d": 4}, follower.ToMap())
+	assert.Nil(t, leader.Decode(strings.NewReader(`{"e":5}`), codec.JSON))
+	follower.Apply(leader.Checkpoint())
+	assert.Equal(t, map[string]int{"e": 5}, follower.ToMap())
+}
+
+func TestMap_TryGet(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	value, err := m.TryGet("a")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+	_, err = m.TryGet("b")
+	assert.ErrorIs(t, err, collection.ErrKeyNotFound)
+}
+
+func TestMap_OrderBy(t *testing.T) {
+	m := NewMap[string, int]().OrderBy(strings.Compare)
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keys())
+	assert.Equal(t, []int{1, 2, 3}, m.Values())
+	var keys []string
+	m.Each(func(key string, value int) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, "Map[string, int](len=3){\n\ta: 1,\n\tb: 2,\n\tc: 3,\n}", m.String())
+	assert.Equal(t, []string{"a", "b", "c"}, m.Clone().Keys())
+}
+
+func TestMap_GetMany(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	assert.Equal(t, map[string]int{"a": 1}, m.GetMany("a", "c"))
+}
+
+func TestMap_SetMany(t *testing.T) {
+	m := NewMap[string, int]()
+	m.SetMany(Entry[string, int]{Key: "a", Value: 1}, Entry[string, int]{Key: "b", Value: 2}, Entry[string, int]{Key: "a", Value: 3})
+	assert.Equal(t, map[string]int{"a": 3, "b": 2}, m.ToMap())
+}
+
+func TestMap_DeleteMany(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.DeleteMany("a", "c", "d")
+	assert.Equal(t, map[string]int{"b": 2}, m.ToMap())
+}
+
+func TestMap_RemoveWhere(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Track()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Checkpoint()
+	m.RemoveWhere(func(key string, value int) bool {
+		return key == "a" || value == 3
+	})
+	assert.Equal(t, map[string]int{"b": 2}, m.ToMap())
+	assert.Len(t, m.Checkpoint().Entries(), 2)
+}
+
+func TestMap_RetainWhere(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.RetainWhere(func(_ string, value int) bool {
+		return value > 1
+	})
+	assert.Equal(t, map[string]int{"b": 2, "c": 3}, m.ToMap())
+}
+
+func TestMap_CountWhere(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	assert.Equal(t, int64(2), m.CountWhere(func(key string, value int) bool {
+		return key != "b"
+	}))
+}
+
+func TestMap_ContainsKeyWhere(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("apple", 1)
+	assert.True(t, m.ContainsKeyWhere(func(key string) bool { return key[0] == 'a' }))
+	assert.False(t, m.ContainsKeyWhere(func(key string) bool { return key[0] == 'b' }))
+}
+
+func TestMap_AllWhere(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	assert.True(t, m.AllWhere(func(value int) bool { return value > 0 }))
+	assert.False(t, m.AllWhere(func(value int) bool { return value > 1 }))
+}
+
+func TestMap_FilteredView(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	active := m.FilteredView(func(_ string, value int) bool {
+		return value > 1
+	})
+	_, ok := active.Get("a")
+	assert.False(t, ok)
+	m.Set("a", 3)
+	assert.Equal(t, 3, active.GetOr("a", 0))
+	assert.Equal(t, map[string]int{"a": 3, "b": 2}, active.ToMap())
+}
+
+func TestMap_Encode(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, m.Encode(buf, codec.JSON))
+	assert.Equal(t, "{\"a\":1}\n", buf.String())
+	if equal.Reflect {
+		buf.Reset()
+		assert.Nil(t, m.Encode(buf, codec.CSV))
+		assert.Equal(t, "Key,Value\na,1\n", buf.String())
+	}
+
+	m.OrderBy(cmp.Compare[string])
+	for _, key := range []string{"c", "b"} {
+		m.Set(key, 0)
+	}
+	for range 10 {
+		buf.Reset()
+		assert.Nil(t, m.Encode(buf, codec.NDJSON))
+		assert.Equal(t, `{"key":"a","value":1}`+"\n"+`{"key":"b","value":0}`+"\n"+`{"key":"c","value":0}`+"\n", buf.String())
+	}
+}
+
+func TestMap_Decode(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Set("z", 0)
+	assert.Nil(t, m.Decode(strings.NewReader("{\"key\":\"a\",\"value\":1}\n{\"key\":\"b\",\"value\":2}\n"), codec.NDJSON))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.ToMap())
+	assert.Nil(t, m.Decode(strings.NewReader(`{"c":3}`), codec.JSON))
+	assert.Equal(t, map[string]int{"c": 3}, m.ToMap())
+}
+
+func TestMap_JSONNull(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), m))
+	assert.True(t, m.IsEmpty())
+	m.Set("b", 2)
+	assert.Equal(t, map[string]int{"b": 2}, m.ToMap())
+	m.Clear()
+	data, _ := json.Marshal(m)
+	assert.Equal(t, "{}", string(data))
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = json.Marshal(m)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestMap_DeepClone(t *testing.T) {
+	m := NewMap[string, []int]()
+	m.Set("a", []int{1})
+	clone := m.DeepClone()
+	clone.GetOr("a", nil)[0] = 10
+	assert.Equal(t, deepcopy.Reflect, m.GetOr("a", nil)[0] == 1)
+}
@@ -0,0 +1,30 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+// byKey adapts the footprint of a map of int keys to the element footprint checked by memtest
+func byKey(footprint func(deep func(key, value int) int64) int64) func(deep func(value int) int64) int64 {
+	return func(deep func(value int) int64) int64 {
+		if deep == nil {
+			return footprint(nil)
+		}
+		return footprint(func(key, _ int) int64 { return deep(key) })
+	}
+}
+
+func TestMemoryFootprint(t *testing.T) {
+	m := NewMap[int, int]()
+	linked := NewLinkedMap[int, int]()
+	for _, key := range []int{1, 2} {
+		m.Set(key, key)
+		linked.Set(key, key)
+	}
+	memtest.Run(t, []memtest.Case{
+		{Name: "Map", Count: 2, Footprint: byKey(m.MemoryFootprint), Add: func(value int) { m.Set(value, value) }},
+		{Name: "LinkedMap", Count: 2, Footprint: byKey(linked.MemoryFootprint), Add: func(value int) { linked.Set(value, value) }},
+	})
+}
@@ -0,0 +1,275 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/list"
+)
+
+// NewOrderedMap new ordered map with the entries in order
+func NewOrderedMap[K comparable, V any](entries ...Entry[K, V]) *OrderedMap[K, V] {
+	m := new(OrderedMap[K, V])
+	m.index = make(map[K]list.Handle)
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return m
+}
+
+// OrderedMap map which iterates in insertion order, setting an existing key keeps its position.
+// Unlike [LinkedMap], it removes keys in O(1) and encodes to a plain JSON object whose keys follow the order,
+// e.g. to build API responses with a stable field order.
+type OrderedMap[K comparable, V any] struct {
+	sync.RWMutex
+	index   map[K]list.Handle
+	entries list.HandleList[Entry[K, V]]
+}
+
+// Count returns the size of the map
+func (m *OrderedMap[K, V]) Count() int64 {
+	return int64(len(m.index))
+}
+
+// IsEmpty returns whether the map is empty
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *OrderedMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *OrderedMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *OrderedMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *OrderedMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// ContainsKey returns whether the map contains the key
+func (m *OrderedMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.index[key]
+	return ok
+}
+
+// Get returns the value of the key
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	h, ok := m.index[key]
+	if !ok {
+		return *new(V), false
+	}
+	entry, _ := m.entries.Get(h)
+	return entry.Value, true
+}
+
+// GetOr returns the value of the key or the default value when the key does not exist
+func (m *OrderedMap[K, V]) GetOr(key K, value V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return value
+}
+
+// TryGet returns the value of the key.
+// It returns [collection.ErrKeyNotFound] when the key does not exist.
+func (m *OrderedMap[K, V]) TryGet(key K) (V, error) {
+	if v, ok := m.Get(key); ok {
+		return v, nil
+	}
+	return *new(V), collection.NewKeyError(key)
+}
+
+// Set sets the value of the key, a new key is appended to the end
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if h, ok := m.index[key]; ok {
+		m.entries.Set(h, Entry[K, V]{Key: key, Value: value})
+		return
+	}
+	m.index[key] = m.entries.Push(Entry[K, V]{Key: key, Value: value})[0]
+}
+
+// Remove removes the key
+func (m *OrderedMap[K, V]) Remove(key K) {
+	if h, ok := m.index[key]; ok {
+		delete(m.index, key)
+		m.entries.Remove(h)
+	}
+}
+
+// First returns the first entry of the map.
+// It will return a zero entry and false when the map is empty.
+func (m *OrderedMap[K, V]) First() (Entry[K, V], bool) {
+	h, ok := m.entries.First()
+	if !ok {
+		return Entry[K, V]{}, false
+	}
+	return m.entries.Get(h)
+}
+
+// Last returns the last entry of the map.
+// It will return a zero entry and false when the map is empty.
+func (m *OrderedMap[K, V]) Last() (Entry[K, V], bool) {
+	h, ok := m.entries.Last()
+	if !ok {
+		return Entry[K, V]{}, false
+	}
+	return m.entries.Get(h)
+}
+
+// Keys returns all keys in order
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.index))
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in order
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.index))
+	m.Each(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Entries returns all entries in order
+func (m *OrderedMap[K, V]) Entries() []Entry[K, V] {
+	return m.entries.ToArray()
+}
+
+// Each ranges the entries in order, it will break the loop when the callback returns false.
+// Removing the key of the given entry in the callback is allowed.
+func (m *OrderedMap[K, V]) Each(callback func(key K, value V) bool) {
+	m.entries.Each(func(_ list.Handle, entry Entry[K, V]) bool {
+		return callback(entry.Key, entry.Value)
+	})
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *OrderedMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Clear clears the map
+func (m *OrderedMap[K, V]) Clear() {
+	clear(m.index)
+	m.entries.Clear()
+}
+
+// ToMap converts to map
+func (m *OrderedMap[K, V]) ToMap() map[K]V {
+	items := make(map[K]V, len(m.index))
+	m.Each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to a JSON object whose keys follow the order of the map,
+// keys are encoded like the keys of a Go map by [json.Marshal]
+func (m *OrderedMap[K, V]) ToJSON() ([]byte, error) {
+	if m.IsEmpty() {
+		return jsonx.Empty(`{}`), nil
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	var err error
+	m.Each(func(key K, value V) bool {
+		var member []byte
+		if member, err = json.Marshal(map[K]V{key: value}); err != nil {
+			return false
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(member[1 : len(member)-1])
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the entries are set in the order of the keys of the object
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if jsonx.IsNull(data) {
+		m.Clear()
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		// decoding the value into a map reports the same type error as encoding/json
+		var items map[K]V
+		return json.Unmarshal(data, &items)
+	}
+	var entries []Entry[K, V]
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		// decode the member as an object of a single entry to convert the name into a key like a Go map does
+		name, _ := json.Marshal(token)
+		object := new(bytes.Buffer)
+		object.WriteByte('{')
+		object.Write(name)
+		object.WriteByte(':')
+		object.Write(value)
+		object.WriteByte('}')
+		member := map[K]V{}
+		if err := json.Unmarshal(object.Bytes(), &member); err != nil {
+			return err
+		}
+		for key, v := range member {
+			entries = append(entries, Entry[K, V]{Key: key, Value: v})
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	if m.index == nil {
+		m.index = make(map[K]list.Handle)
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// String converts to string
+func (m *OrderedMap[K, V]) String() string {
+	return format.Entries[K, V]("OrderedMap", m.Count(), m.Each)
+}
@@ -0,0 +1,106 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap_Set(t *testing.T) {
+	m := NewOrderedMap(Entry[string, int]{Key: "b", Value: 1}, Entry[string, int]{Key: "a", Value: 2})
+	m.Set("c", 3)
+	m.Set("b", 4)
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, []int{4, 2, 3}, m.Values())
+	value, ok := m.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+	assert.Equal(t, 5, m.GetOr("d", 5))
+	_, err := m.TryGet("d")
+	assert.ErrorIs(t, err, collection.ErrKeyNotFound)
+}
+
+func TestOrderedMap_Remove(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Remove("b")
+	m.Remove("d")
+	assert.Equal(t, []Entry[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}}, m.Entries())
+	assert.False(t, m.ContainsKey("b"))
+	m.Set("b", 2)
+	assert.Equal(t, []string{"a", "c", "b"}, m.Keys())
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestOrderedMap_First(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	_, ok := m.First()
+	assert.False(t, ok)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	first, ok := m.First()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[string, int]{Key: "a", Value: 1}, first)
+	last, ok := m.Last()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[string, int]{Key: "b", Value: 2}, last)
+}
+
+func TestOrderedMap_Each(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Each(func(key string, value int) bool {
+		if value%2 == 1 {
+			m.Remove(key)
+		}
+		return true
+	})
+	assert.Equal(t, map[string]int{"b": 2}, m.ToMap())
+}
+
+func TestOrderedMap_MarshalJSON(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{}`, string(data))
+	m.Set("z", 1)
+	m.Set("a", 2)
+	data, err = json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"z":1,"a":2}`, string(data))
+
+	ints := NewOrderedMap[int, string]()
+	ints.Set(2, "b")
+	ints.Set(1, "a")
+	data, err = json.Marshal(ints)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"2":"b","1":"a"}`, string(data))
+}
+
+func TestOrderedMap_UnmarshalJSON(t *testing.T) {
+	m := new(OrderedMap[int, []int])
+	assert.Nil(t, json.Unmarshal([]byte(`{"3":[1],"1":[2,3],"2":null}`), m))
+	assert.Equal(t, []int{3, 1, 2}, m.Keys())
+	assert.Equal(t, []int{2, 3}, m.GetOr(1, nil))
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, json.Unmarshal([]byte(`[1]`), m), &typeErr)
+	assert.Equal(t, "map[int][]int", typeErr.Type.String())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":[1]}`), m))
+}
+
+func TestOrderedMap_String(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("b", 1)
+	m.Set("a", 2)
+	pattern := regexp.MustCompile(fmt.Sprintf(`OrderedMap\[string,\sint\]\(len=%d\)\{\n\tb:\s1,\n\ta:\s2,\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+}
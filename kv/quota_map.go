@@ -0,0 +1,254 @@
+package kv
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// Quota limits of the values of a key, a non-positive limit means unlimited
+type Quota struct {
+	// MaxValues maximum number of values of a key
+	MaxValues int
+	// MaxBytes maximum total size of the values of a key
+	MaxBytes int64
+}
+
+type quotaBucket[V any] struct {
+	values []V
+	bytes  int64
+}
+
+// NewQuotaMap new quota map, every key is limited by the default quota.
+// size returns the size of a value in bytes, it may be nil when no quota has a byte limit.
+// It panics when the quota has a byte limit and size is nil.
+func NewQuotaMap[K comparable, V any](quota Quota, size func(value V) int64) *QuotaMap[K, V] {
+	m := new(QuotaMap[K, V])
+	m.size = size
+	m.validate(quota)
+	m.quota = quota
+	m.items = make(map[K]*quotaBucket[V])
+	m.quotas = make(map[K]Quota)
+	return m
+}
+
+// QuotaMap multimap enforcing a quota on the values of each key,
+// so a single key, such as a tenant of a multi-tenant store, cannot take all the memory.
+// Writes exceeding the quota fail with a [collection.QuotaError] and leave the key unchanged.
+type QuotaMap[K comparable, V any] struct {
+	sync.RWMutex
+	quota  Quota
+	quotas map[K]Quota
+	size   func(value V) int64
+	items  map[K]*quotaBucket[V]
+}
+
+// SetQuota overrides the default quota for the key, the values already stored are kept even if they exceed it.
+// It panics when the quota has a byte limit and the map has no size func.
+func (m *QuotaMap[K, V]) SetQuota(key K, quota Quota) {
+	m.validate(quota)
+	m.quotas[key] = quota
+}
+
+// validate panics when the byte limit of the quota can not be enforced
+func (m *QuotaMap[K, V]) validate(quota Quota) {
+	if quota.MaxBytes > 0 && m.size == nil {
+		panic(fmt.Sprintf("kv: quota of %d bytes needs a size func", quota.MaxBytes))
+	}
+}
+
+// QuotaOf returns the quota of the key
+func (m *QuotaMap[K, V]) QuotaOf(key K) Quota {
+	if quota, ok := m.quotas[key]; ok {
+		return quota
+	}
+	return m.quota
+}
+
+func (m *QuotaMap[K, V]) sizeOf(values []V) int64 {
+	if m.size == nil {
+		return 0
+	}
+	var size int64
+	for _, value := range values {
+		size += m.size(value)
+	}
+	return size
+}
+
+func (m *QuotaMap[K, V]) check(key K, count int, bytes int64) error {
+	quota := m.QuotaOf(key)
+	if quota.MaxValues > 0 && count > quota.MaxValues {
+		return collection.NewQuotaError(key, "values", int64(quota.MaxValues), int64(count))
+	}
+	if quota.MaxBytes > 0 && bytes > quota.MaxBytes {
+		return collection.NewQuotaError(key, "bytes", quota.MaxBytes, bytes)
+	}
+	return nil
+}
+
+// Count returns the number of keys
+func (m *QuotaMap[K, V]) Count() int64 {
+	return int64(len(m.items))
+}
+
+// IsEmpty returns whether the map is empty
+func (m *QuotaMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *QuotaMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *QuotaMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *QuotaMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *QuotaMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// ContainsKey returns whether the map contains the specific key
+func (m *QuotaMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.items[key]
+	return ok
+}
+
+// Get returns the values of the key
+func (m *QuotaMap[K, V]) Get(key K) []V {
+	bucket, ok := m.items[key]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(bucket.values)
+}
+
+// Usage returns the number and the total size of the values of the key
+func (m *QuotaMap[K, V]) Usage(key K) (int, int64) {
+	bucket, ok := m.items[key]
+	if !ok {
+		return 0, 0
+	}
+	return len(bucket.values), bucket.bytes
+}
+
+// Add appends the values to the key, either all values are added
+// or none when they exceed the quota of the key. Adding no values does nothing.
+func (m *QuotaMap[K, V]) Add(key K, values ...V) error {
+	if len(values) == 0 {
+		return nil
+	}
+	bucket, ok := m.items[key]
+	if !ok {
+		bucket = new(quotaBucket[V])
+	}
+	bytes := bucket.bytes + m.sizeOf(values)
+	if err := m.check(key, len(bucket.values)+len(values), bytes); err != nil {
+		return err
+	}
+	bucket.values = append(bucket.values, values...)
+	bucket.bytes = bytes
+	m.items[key] = bucket
+	return nil
+}
+
+// Set replaces the values of the key, the key is unchanged when the values exceed its quota.
+// Setting no values removes the key.
+func (m *QuotaMap[K, V]) Set(key K, values ...V) error {
+	if len(values) == 0 {
+		m.Remove(key)
+		return nil
+	}
+	bytes := m.sizeOf(values)
+	if err := m.check(key, len(values), bytes); err != nil {
+		return err
+	}
+	m.items[key] = &quotaBucket[V]{values: slices.Clone(values), bytes: bytes}
+	return nil
+}
+
+// Remove removes the key and all its values
+func (m *QuotaMap[K, V]) Remove(key K) {
+	delete(m.items, key)
+}
+
+// RemoveWhere removes the values of the key which match the callback and returns the number of them,
+// the key is removed with its last value
+func (m *QuotaMap[K, V]) RemoveWhere(key K, callback func(value V) bool) int {
+	bucket, ok := m.items[key]
+	if !ok {
+		return 0
+	}
+	count := len(bucket.values)
+	bucket.values = slices.DeleteFunc(bucket.values, callback)
+	bucket.bytes = m.sizeOf(bucket.values)
+	if len(bucket.values) == 0 {
+		delete(m.items, key)
+	}
+	return count - len(bucket.values)
+}
+
+// Clear clears the map, the quotas of keys are kept
+func (m *QuotaMap[K, V]) Clear() {
+	m.items = make(map[K]*quotaBucket[V])
+}
+
+// Keys returns all keys
+func (m *QuotaMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.items))
+	for key := range m.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Each ranges the map by callback, it will break the loop when the callback returns false
+func (m *QuotaMap[K, V]) Each(callback func(key K, values []V) bool) {
+	for key, bucket := range m.items {
+		if !callback(key, bucket.values) {
+			break
+		}
+	}
+}
+
+// All returns a sequence of the keys and valuess in the order of Each, it stops when yield returns false
+func (m *QuotaMap[K, V]) All() func(yield func(key K, values []V) bool) {
+	return m.Each
+}
+
+// ToMap converts to map
+func (m *QuotaMap[K, V]) ToMap() map[K][]V {
+	items := make(map[K][]V, len(m.items))
+	for key, bucket := range m.items {
+		items[key] = slices.Clone(bucket.values)
+	}
+	return items
+}
+
+// ToJSON converts to json
+func (m *QuotaMap[K, V]) ToJSON() ([]byte, error) {
+	return jsonx.Object(m.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *QuotaMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// String converts to string
+func (m *QuotaMap[K, V]) String() string {
+	return format.EntriesOf[K, V, []V]("QuotaMap", m.Count(), m.Each)
+}
@@ -0,0 +1,95 @@
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func _bytes(value string) int64 {
+	return int64(len(value))
+}
+
+func TestQuotaMap_Add(t *testing.T) {
+	m := NewQuotaMap[string, string](Quota{MaxValues: 3}, nil)
+	assert.Nil(t, m.Add("a", "x", "y"))
+	err := m.Add("a", "z", "w")
+	assert.ErrorIs(t, err, collection.ErrQuotaExceeded)
+	var quotaErr *collection.QuotaError
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, "values", quotaErr.Resource)
+	assert.Equal(t, int64(4), quotaErr.Usage)
+	assert.Equal(t, []string{"x", "y"}, m.Get("a"))
+	assert.Nil(t, m.Add("b", "x", "y", "z"))
+	assert.Equal(t, int64(2), m.Count())
+	assert.Nil(t, m.Add("c"))
+	assert.False(t, m.ContainsKey("c"))
+}
+
+func TestQuotaMap_MaxBytes(t *testing.T) {
+	m := NewQuotaMap[string, string](Quota{MaxBytes: 5}, _bytes)
+	assert.Nil(t, m.Add("a", "abc"))
+	assert.ErrorIs(t, m.Add("a", "def"), collection.ErrQuotaExceeded)
+	assert.Nil(t, m.Add("a", "de"))
+	count, bytes := m.Usage("a")
+	assert.Equal(t, 2, count)
+	assert.Equal(t, int64(5), bytes)
+	assert.ErrorIs(t, m.Add("b", "abcdef"), collection.ErrQuotaExceeded)
+	assert.False(t, m.ContainsKey("b"))
+	assert.Panics(t, func() { NewQuotaMap[string, string](Quota{MaxBytes: 5}, nil) })
+	assert.Panics(t, func() { NewQuotaMap[string, string](Quota{}, nil).SetQuota("a", Quota{MaxBytes: 5}) })
+}
+
+func TestQuotaMap_SetQuota(t *testing.T) {
+	m := NewQuotaMap[string, string](Quota{MaxValues: 1}, nil)
+	m.SetQuota("premium", Quota{MaxValues: 2})
+	assert.Nil(t, m.Add("premium", "a", "b"))
+	assert.ErrorIs(t, m.Add("free", "a", "b"), collection.ErrQuotaExceeded)
+	assert.Equal(t, Quota{MaxValues: 1}, m.QuotaOf("free"))
+}
+
+func TestQuotaMap_Set(t *testing.T) {
+	m := NewQuotaMap[string, string](Quota{MaxValues: 2, MaxBytes: 4}, _bytes)
+	assert.Nil(t, m.Add("a", "ab"))
+	assert.ErrorIs(t, m.Set("a", "abc", "de"), collection.ErrQuotaExceeded)
+	assert.Equal(t, []string{"ab"}, m.Get("a"))
+	assert.Nil(t, m.Set("a", "c", "de"))
+	_, bytes := m.Usage("a")
+	assert.Equal(t, int64(3), bytes)
+	assert.Nil(t, m.Set("a"))
+	assert.False(t, m.ContainsKey("a"))
+}
+
+func TestQuotaMap_RemoveWhere(t *testing.T) {
+	m := NewQuotaMap[string, string](Quota{MaxBytes: 4}, _bytes)
+	assert.Nil(t, m.Add("a", "ab", "cd"))
+	assert.Equal(t, 1, m.RemoveWhere("a", func(value string) bool { return value == "ab" }))
+	assert.Nil(t, m.Add("a", "ef"))
+	assert.Equal(t, 2, m.RemoveWhere("a", func(string) bool { return true }))
+	assert.False(t, m.ContainsKey("a"))
+	m.Add("b", "x")
+	m.Remove("b")
+	assert.True(t, m.IsEmpty())
+}
+
+func TestQuotaMap_MarshalJSON(t *testing.T) {
+	m := NewQuotaMap[string, int](Quota{}, nil)
+	assert.Nil(t, m.Add("a", 1, 2))
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":[1,2]}`, string(data))
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestQuotaMap_String(t *testing.T) {
+	m := NewQuotaMap[string, int](Quota{}, nil)
+	assert.Nil(t, m.Add("a", 1, 2))
+	pattern := regexp.MustCompile(fmt.Sprintf(`QuotaMap\[string,\sint\]\(len=%d\)\{\n\ta:\s\[1 2\],\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+}
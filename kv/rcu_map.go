@@ -0,0 +1,188 @@
+package kv
+
+import (
+	"encoding/json"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gopi-frame/collection"
+)
+
+// NewRCUMap new read-copy-update map
+func NewRCUMap[K comparable, V any]() *RCUMap[K, V] {
+	m := new(RCUMap[K, V])
+	items := make(map[K]V)
+	m.items.Store(&items)
+	return m
+}
+
+// RCUMap map for read-mostly workloads, it is safe for concurrent use.
+// Readers load an immutable index published atomically and never lock,
+// so reads scale with the number of cores however many readers run at once.
+// Writers copy the index, update the copy and publish it, which costs O(n) per write,
+// so batch writes with Update when the map is large. Prefer [ConcurrentMap] unless reads outnumber writes by far.
+type RCUMap[K comparable, V any] struct {
+	// lock serializes the writers
+	lock  sync.Mutex
+	items atomic.Pointer[map[K]V]
+}
+
+func (m *RCUMap[K, V]) load() map[K]V {
+	if items := m.items.Load(); items != nil {
+		return *items
+	}
+	return nil
+}
+
+// Count returns the size of the map
+func (m *RCUMap[K, V]) Count() int64 {
+	return int64(len(m.load()))
+}
+
+// IsEmpty returns whether the map is empty
+func (m *RCUMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// IsNotEmpty returns whether the map is not empty
+func (m *RCUMap[K, V]) IsNotEmpty() bool {
+	return !m.IsEmpty()
+}
+
+// ElementType returns the name of the value type
+func (m *RCUMap[K, V]) ElementType() string {
+	return collection.TypeName[V]()
+}
+
+// KeyType returns the name of the key type
+func (m *RCUMap[K, V]) KeyType() string {
+	return collection.TypeName[K]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *RCUMap[K, V]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// Get returns the value of the key without locking
+func (m *RCUMap[K, V]) Get(key K) (V, bool) {
+	value, ok := m.load()[key]
+	return value, ok
+}
+
+// ContainsKey returns whether the map contains the key
+func (m *RCUMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Update calls the callback with a copy of the entries and publishes the copy once the callback returns,
+// so any number of writes are published at once. Readers see either none or all of them.
+// The callback must not retain the entries.
+func (m *RCUMap[K, V]) Update(callback func(items map[K]V)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	items := maps.Clone(m.load())
+	if items == nil {
+		items = make(map[K]V)
+	}
+	callback(items)
+	m.items.Store(&items)
+}
+
+// Set sets the value of the key and publishes it
+func (m *RCUMap[K, V]) Set(key K, value V) {
+	m.Update(func(items map[K]V) {
+		items[key] = value
+	})
+}
+
+// SetMany sets the values of the entries and publishes them at once
+func (m *RCUMap[K, V]) SetMany(entries ...Entry[K, V]) {
+	m.Update(func(items map[K]V) {
+		for _, entry := range entries {
+			items[entry.Key] = entry.Value
+		}
+	})
+}
+
+// Remove removes the keys and publishes the removal at once
+func (m *RCUMap[K, V]) Remove(keys ...K) {
+	m.Update(func(items map[K]V) {
+		for _, key := range keys {
+			delete(items, key)
+		}
+	})
+}
+
+// Clear clears the map
+func (m *RCUMap[K, V]) Clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	items := make(map[K]V)
+	m.items.Store(&items)
+}
+
+// Each ranges the published index, it will break the loop when the callback returns false.
+// Writes published during the iteration are not seen.
+func (m *RCUMap[K, V]) Each(callback func(key K, value V) bool) {
+	for key, value := range m.load() {
+		if !callback(key, value) {
+			return
+		}
+	}
+}
+
+// All returns a sequence of the keys and values in the order of Each, it stops when yield returns false
+func (m *RCUMap[K, V]) All() func(yield func(key K, value V) bool) {
+	return m.Each
+}
+
+// Keys returns all keys
+func (m *RCUMap[K, V]) Keys() []K {
+	items := m.load()
+	keys := make([]K, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Snapshot returns the published index in O(1), it never changes
+func (m *RCUMap[K, V]) Snapshot() *MapSnapshot[K, V] {
+	items := m.load()
+	return &MapSnapshot[K, V]{shards: []map[K]V{items}, size: int64(len(items))}
+}
+
+// ToMap converts to map
+func (m *RCUMap[K, V]) ToMap() map[K]V {
+	return maps.Clone(m.load())
+}
+
+// ToJSON converts to json
+func (m *RCUMap[K, V]) ToJSON() ([]byte, error) {
+	return m.Snapshot().ToJSON()
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *RCUMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], it sets the entries of the object and publishes them at once
+func (m *RCUMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	m.Update(func(items map[K]V) {
+		maps.Copy(items, entries)
+	})
+	return nil
+}
+
+// String converts to string
+func (m *RCUMap[K, V]) String() string {
+	return m.Snapshot().format("RCUMap")
+}
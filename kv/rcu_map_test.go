@@ -0,0 +1,121 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRCUMap_Set(t *testing.T) {
+	m := NewRCUMap[string, int]()
+	m.Set("a", 1)
+	m.SetMany(Entry[string, int]{Key: "b", Value: 2}, Entry[string, int]{Key: "c", Value: 3})
+	value, ok := m.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	m.Remove("a", "c")
+	assert.Equal(t, []string{"b"}, m.Keys())
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestRCUMap_Update(t *testing.T) {
+	m := NewRCUMap[int, int]()
+	m.Set(0, 0)
+	snapshot := m.Snapshot()
+	m.Update(func(items map[int]int) {
+		for i := 1; i < 10; i++ {
+			items[i] = i
+		}
+	})
+	assert.Equal(t, int64(10), m.Count())
+	assert.Equal(t, int64(1), snapshot.Count())
+	assert.False(t, snapshot.ContainsKey(1))
+}
+
+func TestRCUMap_Concurrent(t *testing.T) {
+	m := new(RCUMap[int, int])
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Set(i*100+j, j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.Get(j)
+				m.Each(func(int, int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(400), m.Count())
+}
+
+func TestRCUMap_MarshalJSON(t *testing.T) {
+	m := new(RCUMap[string, int])
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":1,"b":2}`), m))
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.ToMap())
+}
+
+func TestRCUMap_String(t *testing.T) {
+	m := NewRCUMap[string, int]()
+	m.Set("a", 1)
+	pattern := regexp.MustCompile(fmt.Sprintf(`RCUMap\[string,\sint\]\(len=%d\)\{\n\ta:\s1,\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+}
+
+const benchmarkKeys = 1024
+
+// benchmarkReadMostly runs 10000 reads per write on all cores, run it with -cpu to compare the read scalability
+func benchmarkReadMostly(b *testing.B, get func(key int) (int, bool), set func(key, value int)) {
+	for i := 0; i < benchmarkKeys; i++ {
+		set(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10000 == 0 {
+				set(i%benchmarkKeys, i)
+			} else {
+				get(i % benchmarkKeys)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkRCUMap_ReadMostly(b *testing.B) {
+	m := NewRCUMap[int, int]()
+	benchmarkReadMostly(b, m.Get, m.Set)
+}
+
+func BenchmarkConcurrentMap_ReadMostly(b *testing.B) {
+	m := NewConcurrentMap[int, int](0)
+	benchmarkReadMostly(b, m.Get, m.Set)
+}
+
+func BenchmarkRWMutexMap_ReadMostly(b *testing.B) {
+	m := NewMap[int, int]()
+	benchmarkReadMostly(b, func(key int) (int, bool) {
+		m.RLock()
+		defer m.RUnlock()
+		return m.Get(key)
+	}, func(key, value int) {
+		m.Lock()
+		defer m.Unlock()
+		m.Set(key, value)
+	})
+}
@@ -0,0 +1,47 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/stress"
+)
+
+func TestConcurrentMap_Stress(t *testing.T) {
+	const workers, keys = 8, 200
+	m := NewConcurrentMap[int, int](4)
+	recorder := stress.Exactly[int]()
+	stop := stress.Watch(t, func() error {
+		if count := m.Count(); count < 0 || count > keys {
+			return fmt.Errorf("count %d out of [0, %d]", count, keys)
+		}
+		return nil
+	})
+	stress.Run(t, workers, keys, func(worker, key int) {
+		if _, loaded := m.GetOrSet(key, worker); !loaded {
+			recorder.Record(key)
+		}
+		if value, ok := m.Get(key); !ok || value < 0 || value >= workers {
+			t.Errorf("key %d has value %d, %v", key, value, ok)
+		}
+		m.Each(func(int, int) bool { return true })
+	})
+	stop()
+	var expected []int
+	for key := 0; key < keys; key++ {
+		expected = append(expected, key)
+	}
+	recorder.Verify(t, expected...)
+	snapshot := m.Snapshot()
+	if snapshot.Count() != keys {
+		t.Errorf("snapshot count %d, expected %d", snapshot.Count(), keys)
+	}
+
+	stress.Run(t, workers, keys, func(worker, key int) {
+		m.Set(key, worker)
+		m.Remove(key)
+	})
+	if m.IsNotEmpty() {
+		t.Errorf("map is not empty, count %d", m.Count())
+	}
+}
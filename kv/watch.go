@@ -0,0 +1,103 @@
+package kv
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// HasPrefix returns a key matcher which matches string keys with the prefix
+func HasPrefix(prefix string) func(key string) bool {
+	return func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}
+}
+
+type watcher[K comparable, V any] struct {
+	match func(entry DeltaEntry[K, V]) bool
+	ch    chan DeltaEntry[K, V]
+}
+
+type watchList[K comparable, V any] struct {
+	lock     sync.Mutex
+	count    atomic.Int64
+	watchers map[*watcher[K, V]]struct{}
+}
+
+func (w *watchList[K, V]) add(size int, match func(entry DeltaEntry[K, V]) bool) *watcher[K, V] {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[*watcher[K, V]]struct{})
+	}
+	wt := &watcher[K, V]{match: match, ch: make(chan DeltaEntry[K, V], size)}
+	w.watchers[wt] = struct{}{}
+	w.count.Add(1)
+	return wt
+}
+
+func (w *watchList[K, V]) remove(wt *watcher[K, V], closeChan bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.watchers[wt]; !ok {
+		return
+	}
+	delete(w.watchers, wt)
+	w.count.Add(-1)
+	if closeChan {
+		close(wt.ch)
+	}
+}
+
+func (w *watchList[K, V]) notify(entry DeltaEntry[K, V]) {
+	if w.count.Load() == 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for wt := range w.watchers {
+		if !wt.match(entry) {
+			continue
+		}
+		select {
+		case wt.ch <- entry:
+		default:
+		}
+	}
+}
+
+// WaitFor returns the value of the key, it blocks until the key is set or the context is done.
+// It acquires the read lock of the map, so writers running concurrently should hold the write lock while setting keys.
+func (m *Map[K, V]) WaitFor(ctx context.Context, key K) (V, error) {
+	m.RLock()
+	if value, ok := m.items[key]; ok {
+		m.RUnlock()
+		return value, nil
+	}
+	wt := m.watchers.add(1, func(entry DeltaEntry[K, V]) bool {
+		return entry.Op == DeltaSet && entry.Key == key
+	})
+	m.RUnlock()
+	defer m.watchers.remove(wt, false)
+	select {
+	case entry := <-wt.ch:
+		return entry.Value, nil
+	case <-ctx.Done():
+		return *new(V), ctx.Err()
+	}
+}
+
+// Watch returns a channel receiving mutations of the keys which match the callback,
+// clears of the map are always received. The channel is closed when the context is done.
+// Mutations are dropped when the buffer of the channel is full.
+func (m *Map[K, V]) Watch(ctx context.Context, size int, match func(key K) bool) <-chan DeltaEntry[K, V] {
+	wt := m.watchers.add(size, func(entry DeltaEntry[K, V]) bool {
+		return entry.Op == DeltaClear || match(entry.Key)
+	})
+	go func() {
+		<-ctx.Done()
+		m.watchers.remove(wt, true)
+	}()
+	return wt.ch
+}
@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap_WaitFor(t *testing.T) {
+	t.Run("existing key", func(t *testing.T) {
+		m := NewMap[string, int]()
+		m.Set("a", 1)
+		value, err := m.WaitFor(context.Background(), "a")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("key set later", func(t *testing.T) {
+		m := NewMap[string, int]()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			m.Lock()
+			defer m.Unlock()
+			m.Set("b", 0)
+			m.Remove("a")
+			m.Set("a", 1)
+		}()
+		value, err := m.WaitFor(context.Background(), "a")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		m := NewMap[string, int]()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		value, err := m.WaitFor(ctx, "a")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, value)
+		assert.Equal(t, int64(0), m.watchers.count.Load())
+	})
+}
+
+func TestMap_Watch(t *testing.T) {
+	m := NewMap[string, int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := m.Watch(ctx, 10, HasPrefix("user/"))
+	m.Set("user/1", 1)
+	m.Set("order/1", 1)
+	m.Remove("user/1")
+	m.Clear()
+	assert.Equal(t, DeltaEntry[string, int]{Op: DeltaSet, Key: "user/1", Value: 1}, <-events)
+	assert.Equal(t, DeltaEntry[string, int]{Op: DeltaRemove, Key: "user/1"}, <-events)
+	assert.Equal(t, DeltaEntry[string, int]{Op: DeltaClear}, <-events)
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
@@ -0,0 +1,195 @@
+package list
+
+import "math"
+
+// GroupBy groups the elements by key, the elements of each group keep their order
+func GroupBy[E any, K comparable](l *List[E], key func(value E) K) map[K]*List[E] {
+	groups := make(map[K]*List[E])
+	for _, item := range l.items {
+		k := key(item)
+		group, ok := groups[k]
+		if !ok {
+			group = NewList[E]()
+			groups[k] = group
+		}
+		group.items = append(group.items, item)
+	}
+	return groups
+}
+
+type aggregateKind uint8
+
+const (
+	groupKey aggregateKind = iota
+	sumAggregate
+	countAggregate
+	minAggregate
+	maxAggregate
+	avgAggregate
+)
+
+// Column column of an aggregation, either a group key made by [GroupKey]
+// or an aggregate made by [Sum], [Count], [Min], [Max] or [Avg]
+type Column[E any] struct {
+	// Name name of the column
+	Name  string
+	kind  aggregateKind
+	key   func(value E) any
+	value func(value E) float64
+}
+
+// GroupKey groups the elements by the key, which must return comparable values
+func GroupKey[E any](name string, key func(value E) any) Column[E] {
+	return Column[E]{Name: name, kind: groupKey, key: key}
+}
+
+// Sum sums the values of the elements of each group
+func Sum[E any](name string, value func(value E) float64) Column[E] {
+	return Column[E]{Name: name, kind: sumAggregate, value: value}
+}
+
+// Count counts the elements of each group
+func Count[E any](name string) Column[E] {
+	return Column[E]{Name: name, kind: countAggregate}
+}
+
+// Min returns the minimum value of the elements of each group
+func Min[E any](name string, value func(value E) float64) Column[E] {
+	return Column[E]{Name: name, kind: minAggregate, value: value}
+}
+
+// Max returns the maximum value of the elements of each group
+func Max[E any](name string, value func(value E) float64) Column[E] {
+	return Column[E]{Name: name, kind: maxAggregate, value: value}
+}
+
+// Avg returns the average value of the elements of each group
+func Avg[E any](name string, value func(value E) float64) Column[E] {
+	return Column[E]{Name: name, kind: avgAggregate, value: value}
+}
+
+// Row aggregated group
+type Row struct {
+	// Keys values of the group keys in the order of the key columns
+	Keys []any `json:"keys"`
+	// Values aggregated values in the order of the aggregate columns
+	Values []float64 `json:"values"`
+}
+
+// Table result of an aggregation, one row per group in the order of the first element of each group
+type Table struct {
+	// Keys names of the key columns
+	Keys []string `json:"keys"`
+	// Values names of the aggregate columns
+	Values []string `json:"values"`
+	// Rows aggregated groups
+	Rows []Row `json:"rows"`
+}
+
+// Nested returns the rows as maps nested by the group keys,
+// the innermost maps hold the aggregated values by column name
+func (t *Table) Nested() map[any]any {
+	root := make(map[any]any)
+	for _, row := range t.Rows {
+		node := root
+		for _, key := range row.Keys {
+			child, ok := node[key].(map[any]any)
+			if !ok {
+				child = make(map[any]any)
+				node[key] = child
+			}
+			node = child
+		}
+		for i, value := range row.Values {
+			node[t.Values[i]] = value
+		}
+	}
+	return root
+}
+
+type accumulator struct {
+	sum, min, max float64
+	count         int64
+}
+
+func (a *accumulator) add(value float64) {
+	if a.count == 0 {
+		a.min, a.max = value, value
+	}
+	a.sum += value
+	a.min = math.Min(a.min, value)
+	a.max = math.Max(a.max, value)
+	a.count++
+}
+
+type aggregateGroup struct {
+	row          int
+	children     map[any]*aggregateGroup
+	accumulators []accumulator
+}
+
+// Aggregate groups the elements by the key columns and aggregates each group with the aggregate columns,
+// like GROUP BY in SQL. Without key columns the whole list is one group.
+// Columns may be given in any order, the table lists keys and aggregates in the order they are given.
+func Aggregate[E any](l *List[E], columns ...Column[E]) *Table {
+	var keys, aggregates []Column[E]
+	table := new(Table)
+	for _, column := range columns {
+		if column.kind == groupKey {
+			keys = append(keys, column)
+			table.Keys = append(table.Keys, column.Name)
+		} else {
+			aggregates = append(aggregates, column)
+			table.Values = append(table.Values, column.Name)
+		}
+	}
+	var groups []*aggregateGroup
+	root := &aggregateGroup{row: -1, children: make(map[any]*aggregateGroup)}
+	for _, item := range l.items {
+		group := root
+		var values []any
+		for _, key := range keys {
+			value := key.key(item)
+			values = append(values, value)
+			child, ok := group.children[value]
+			if !ok {
+				child = &aggregateGroup{row: -1, children: make(map[any]*aggregateGroup)}
+				group.children[value] = child
+			}
+			group = child
+		}
+		if group.row < 0 {
+			group.row = len(groups)
+			group.accumulators = make([]accumulator, len(aggregates))
+			groups = append(groups, group)
+			table.Rows = append(table.Rows, Row{Keys: values})
+		}
+		for i, aggregate := range aggregates {
+			value := 0.0
+			if aggregate.value != nil {
+				value = aggregate.value(item)
+			}
+			group.accumulators[i].add(value)
+		}
+	}
+	for _, group := range groups {
+		values := make([]float64, len(aggregates))
+		for i, aggregate := range aggregates {
+			a := group.accumulators[i]
+			switch aggregate.kind {
+			case sumAggregate:
+				values[i] = a.sum
+			case countAggregate:
+				values[i] = float64(a.count)
+			case minAggregate:
+				values[i] = a.min
+			case maxAggregate:
+				values[i] = a.max
+			case avgAggregate:
+				values[i] = a.sum / float64(a.count)
+			}
+		}
+		table.Rows[group.row].Values = values
+	}
+	return table
+}
@@ -0,0 +1,71 @@
+package list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type _sale struct {
+	Region  string
+	Product string
+	Amount  float64
+}
+
+var _sales = NewList(
+	_sale{"eu", "a", 10},
+	_sale{"us", "a", 5},
+	_sale{"eu", "b", 20},
+	_sale{"eu", "a", 30},
+)
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(_sales, func(s _sale) string { return s.Region })
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []_sale{{"eu", "a", 10}, {"eu", "b", 20}, {"eu", "a", 30}}, groups["eu"].ToArray())
+	assert.Equal(t, int64(1), groups["us"].Count())
+}
+
+func TestAggregate(t *testing.T) {
+	amount := func(s _sale) float64 { return s.Amount }
+	table := Aggregate(_sales,
+		GroupKey("region", func(s _sale) any { return s.Region }),
+		Sum("total", amount),
+		GroupKey("product", func(s _sale) any { return s.Product }),
+		Count[_sale]("count"),
+		Min("min", amount),
+		Max("max", amount),
+		Avg("avg", amount),
+	)
+	assert.Equal(t, []string{"region", "product"}, table.Keys)
+	assert.Equal(t, []string{"total", "count", "min", "max", "avg"}, table.Values)
+	assert.Equal(t, []Row{
+		{Keys: []any{"eu", "a"}, Values: []float64{40, 2, 10, 30, 20}},
+		{Keys: []any{"us", "a"}, Values: []float64{5, 1, 5, 5, 5}},
+		{Keys: []any{"eu", "b"}, Values: []float64{20, 1, 20, 20, 20}},
+	}, table.Rows)
+	assert.Equal(t, map[any]any{
+		"eu": map[any]any{
+			"a": map[any]any{"total": 40.0, "count": 2.0, "min": 10.0, "max": 30.0, "avg": 20.0},
+			"b": map[any]any{"total": 20.0, "count": 1.0, "min": 20.0, "max": 20.0, "avg": 20.0},
+		},
+		"us": map[any]any{
+			"a": map[any]any{"total": 5.0, "count": 1.0, "min": 5.0, "max": 5.0, "avg": 5.0},
+		},
+	}, table.Nested())
+}
+
+func TestAggregate_GroupBy(t *testing.T) {
+	totals := make(map[string]float64)
+	for region, group := range GroupBy(_sales, func(s _sale) string { return s.Region }) {
+		table := Aggregate(group, Sum("total", func(s _sale) float64 { return s.Amount }))
+		assert.Empty(t, table.Rows[0].Keys)
+		totals[region] = table.Rows[0].Values[0]
+	}
+	assert.Equal(t, map[string]float64{"eu": 60, "us": 5}, totals)
+	assert.Empty(t, Aggregate(NewList[_sale](), Count[_sale]("count")).Rows)
+	data, err := json.Marshal(Aggregate(_sales, Count[_sale]("count")))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"keys":null,"values":["count"],"rows":[{"keys":null,"values":[4]}]}`, string(data))
+}
@@ -0,0 +1,96 @@
+package list
+
+import "slices"
+
+// NewComparableList new list of comparable elements
+func NewComparableList[E comparable](values ...E) *ComparableList[E] {
+	instance := new(ComparableList[E])
+	instance.Push(values...)
+	return instance
+}
+
+// ComparableList list of comparable elements.
+// Contains, Remove, IndexOf and Compact compare elements with == instead of [reflect.DeepEqual],
+// so lists of non-comparable elements such as funcs fail to compile instead of silently never matching.
+type ComparableList[E comparable] struct {
+	List[E]
+}
+
+// Contains returns whether the list contains the specific element.
+func (list *ComparableList[E]) Contains(value E) bool {
+	return slices.Contains(list.items, value)
+}
+
+// Remove removes the specific element.
+func (list *ComparableList[E]) Remove(value E) {
+	list.RemoveWhere(func(item E) bool {
+		return item == value
+	})
+}
+
+// IndexOf returns the index of the specific element.
+func (list *ComparableList[E]) IndexOf(value E) int {
+	return slices.Index(list.items, value)
+}
+
+// Compact makes the list more compact, equal neighbours are compared with == when the callback is nil
+func (list *ComparableList[E]) Compact(callback func(a, b E) bool) {
+	if callback == nil {
+		callback = func(a, b E) bool {
+			return a == b
+		}
+	}
+	list.List.Compact(callback)
+}
+
+// Clone clones the list
+func (list *ComparableList[E]) Clone() *ComparableList[E] {
+	return NewComparableList(list.items...)
+}
+
+// NewComparableLinkedList new linked list of comparable elements
+func NewComparableLinkedList[E comparable](values ...E) *ComparableLinkedList[E] {
+	instance := new(ComparableLinkedList[E])
+	instance.Push(values...)
+	return instance
+}
+
+// ComparableLinkedList linked list of comparable elements.
+// Contains, Remove, IndexOf and Compact compare elements with == instead of [reflect.DeepEqual].
+type ComparableLinkedList[E comparable] struct {
+	LinkedList[E]
+}
+
+// Contains returns whether the list contains the specific element.
+func (l *ComparableLinkedList[E]) Contains(value E) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// Remove removes the specific element.
+func (l *ComparableLinkedList[E]) Remove(value E) {
+	l.RemoveWhere(func(item E) bool {
+		return item == value
+	})
+}
+
+// IndexOf returns the index of the specific element.
+func (l *ComparableLinkedList[E]) IndexOf(value E) int {
+	return l.IndexOfWhere(func(item E) bool {
+		return item == value
+	})
+}
+
+// Compact makes the list more compact, equal neighbours are compared with == when the callback is nil
+func (l *ComparableLinkedList[E]) Compact(callback func(a, b E) bool) {
+	if callback == nil {
+		callback = func(a, b E) bool {
+			return a == b
+		}
+	}
+	l.LinkedList.Compact(callback)
+}
+
+// Clone clones the list
+func (l *ComparableLinkedList[E]) Clone() *ComparableLinkedList[E] {
+	return NewComparableLinkedList(l.ToArray()...)
+}
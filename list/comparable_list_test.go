@@ -0,0 +1,75 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	x, y int
+}
+
+func TestComparableList_Contains(t *testing.T) {
+	list := NewComparableList(point{1, 2}, point{3, 4})
+	assert.True(t, list.Contains(point{3, 4}))
+	assert.False(t, list.Contains(point{5, 6}))
+}
+
+func TestComparableList_Remove(t *testing.T) {
+	list := NewComparableList(1, 2, 3, 2)
+	list.Remove(2)
+	assert.Equal(t, []int{1, 3}, list.ToArray())
+}
+
+func TestComparableList_IndexOf(t *testing.T) {
+	list := NewComparableList("a", "b", "c")
+	assert.Equal(t, 1, list.IndexOf("b"))
+	assert.Equal(t, -1, list.IndexOf("d"))
+}
+
+func TestComparableList_Compact(t *testing.T) {
+	list := NewComparableList(1, 1, 2, 2, 1)
+	list.Compact(nil)
+	assert.Equal(t, []int{1, 2, 1}, list.ToArray())
+}
+
+func TestComparableList_Clone(t *testing.T) {
+	list := NewComparableList(1, 2)
+	clone := list.Clone()
+	clone.Push(3)
+	assert.Equal(t, []int{1, 2}, list.ToArray())
+	assert.Equal(t, []int{1, 2, 3}, clone.ToArray())
+}
+
+func TestComparableLinkedList_Contains(t *testing.T) {
+	list := NewComparableLinkedList(point{1, 2}, point{3, 4})
+	assert.True(t, list.Contains(point{3, 4}))
+	assert.False(t, list.Contains(point{5, 6}))
+}
+
+func TestComparableLinkedList_Remove(t *testing.T) {
+	list := NewComparableLinkedList(1, 2, 3, 2)
+	list.Remove(2)
+	assert.Equal(t, []int{1, 3}, list.ToArray())
+}
+
+func TestComparableLinkedList_IndexOf(t *testing.T) {
+	list := NewComparableLinkedList("a", "b", "c")
+	assert.Equal(t, 2, list.IndexOf("c"))
+	assert.Equal(t, -1, list.IndexOf("d"))
+}
+
+func TestComparableLinkedList_Compact(t *testing.T) {
+	list := NewComparableLinkedList(1, 1, 2, 2, 1)
+	list.Compact(nil)
+	assert.Equal(t, []int{1, 2, 1}, list.ToArray())
+}
+
+func TestComparableLinkedList_Clone(t *testing.T) {
+	list := NewComparableLinkedList(1, 2)
+	clone := list.Clone()
+	clone.Push(3)
+	assert.Equal(t, []int{1, 2}, list.ToArray())
+	assert.Equal(t, []int{1, 2, 3}, clone.ToArray())
+}
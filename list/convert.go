@@ -0,0 +1,36 @@
+package list
+
+// MapTo converts the elements of the list with the mapper into a slice allocated once with the length of the list,
+// e.g. to fill a repeated field of a generated protobuf message.
+func MapTo[E, T any](l *List[E], mapper func(value E) T) []T {
+	result := make([]T, len(l.items))
+	for index, item := range l.items {
+		result[index] = mapper(item)
+	}
+	return result
+}
+
+// MapToRefs converts the elements of the list with the mapper, which fills a zero target, into pointers.
+// The targets are allocated in a single batch instead of one allocation per element,
+// e.g. to fill a repeated message field of a generated protobuf message.
+// The batch is released once none of the pointers is reachable.
+func MapToRefs[E, T any](l *List[E], mapper func(value E, target *T)) []*T {
+	batch := make([]T, len(l.items))
+	result := make([]*T, len(l.items))
+	for index, item := range l.items {
+		mapper(item, &batch[index])
+		result[index] = &batch[index]
+	}
+	return result
+}
+
+// MapFrom new list of the elements of the slice converted with the mapper,
+// e.g. from a repeated field of a generated protobuf message
+func MapFrom[T, E any](items []T, mapper func(value T) E) *List[E] {
+	l := new(List[E])
+	l.items = make([]E, len(items))
+	for index, item := range items {
+		l.items[index] = mapper(item)
+	}
+	return l
+}
@@ -0,0 +1,43 @@
+package list
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type _message struct {
+	ID   int64
+	Name string
+}
+
+func TestMapTo(t *testing.T) {
+	assert.Equal(t, []string{"1", "2"}, MapTo(NewList(1, 2), strconv.Itoa))
+	assert.Equal(t, []string{}, MapTo(NewList[int](), strconv.Itoa))
+}
+
+func TestMapToRefs(t *testing.T) {
+	l := NewList(1, 2, 3)
+	messages := MapToRefs(l, func(value int, target *_message) {
+		target.ID = int64(value)
+		target.Name = strconv.Itoa(value)
+	})
+	assert.Equal(t, []*_message{{1, "1"}, {2, "2"}, {3, "3"}}, messages)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		MapToRefs(l, func(value int, target *_message) {
+			target.ID = int64(value)
+		})
+	})
+	assert.LessOrEqual(t, allocs, float64(2))
+}
+
+func TestMapFrom(t *testing.T) {
+	l := MapFrom([]*_message{{1, "a"}, {2, "b"}}, func(value *_message) string {
+		return value.Name
+	})
+	assert.Equal(t, []string{"a", "b"}, l.ToArray())
+	l.Push("c")
+	assert.Equal(t, int64(3), l.Count())
+}
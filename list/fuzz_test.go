@@ -0,0 +1,114 @@
+package list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type listSubject interface {
+	Count() int64
+	Push(values ...int)
+	Unshift(values ...int)
+	Pop() (int, bool)
+	Shift() (int, bool)
+	TryGet(index int) (int, error)
+	TrySet(index int, value int) error
+	TryRemoveAt(index int) error
+	At(index int) (int, bool)
+	ToArray() []int
+}
+
+// index returns an index from -1 to the size of the list, so that out of range indexes are covered
+func index(reference *model.List[int], r *model.Reader) int {
+	return r.Intn(reference.Len()+2) - 1
+}
+
+var listOps = []model.Op[listSubject, *model.List[int]]{
+	{Name: "Push", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		value := int(r.Byte())
+		l.Push(value)
+		reference.Push(value)
+		return nil, nil
+	}},
+	{Name: "Unshift", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		value := int(r.Byte())
+		l.Unshift(value)
+		reference.Unshift(value)
+		return nil, nil
+	}},
+	{Name: "Pop", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		return model.P(l.Pop()), model.P(reference.Pop())
+	}},
+	{Name: "Shift", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		return model.P(l.Shift()), model.P(reference.Shift())
+	}},
+	{Name: "TryGet", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		i := index(reference, r)
+		value, err := l.TryGet(i)
+		return model.P(value, err == nil), model.P(reference.Get(i))
+	}},
+	{Name: "TrySet", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		i, value := index(reference, r), int(r.Byte())
+		return l.TrySet(i, value) == nil, reference.Set(i, value)
+	}},
+	{Name: "TryRemoveAt", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		i := index(reference, r)
+		return l.TryRemoveAt(i) == nil, reference.RemoveAt(i)
+	}},
+	{Name: "At", Run: func(l listSubject, reference *model.List[int], r *model.Reader) (any, any) {
+		i := r.Intn(2*reference.Len()+3) - reference.Len() - 1
+		position := i
+		if position < 0 {
+			position += reference.Len()
+		}
+		return model.P(l.At(i)), model.P(reference.Get(position))
+	}},
+}
+
+func observeList(l listSubject, reference *model.List[int]) (any, any) {
+	return model.P(l.Count(), l.ToArray()), model.P(int64(reference.Len()), reference.Items)
+}
+
+func checkListOperations(t *testing.T, data []byte) {
+	// the model returns false where strict mode panics
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	model.Check(t, listSubject(NewList[int]()), new(model.List[int]), data, listOps, observeList)
+	model.Check(t, listSubject(NewLinkedList[int]()), new(model.List[int]), data, listOps, observeList)
+}
+
+func FuzzList_UnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`[1,2,3]`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[1,`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, l := range []interface {
+			json.Unmarshaler
+			ToArray() []int
+		}{NewList[int](), NewLinkedList[int]()} {
+			if err := json.Unmarshal(data, l); err != nil {
+				continue
+			}
+			encoded, err := json.Marshal(l)
+			assert.Nil(t, err)
+			decoded := NewList[int]()
+			assert.Nil(t, json.Unmarshal(encoded, decoded))
+			assert.True(t, model.Equal(l.ToArray(), decoded.ToArray()))
+		}
+	})
+}
+
+func FuzzList_Operations(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 1, 3, 2, 3, 4, 0, 5, 1, 9, 6, 0})
+	f.Add([]byte{3, 2, 4, 5, 6, 7, 0, 255, 7, 1})
+	f.Fuzz(checkListOperations)
+}
+
+func TestList_Model(t *testing.T) {
+	model.Replay(t, 50, 256, checkListOperations)
+}
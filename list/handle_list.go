@@ -0,0 +1,266 @@
+package list
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/slotmap"
+)
+
+// Handle stable reference to an element of a [HandleList].
+// It stays valid while the element is in the list and never refers to another element once it is removed,
+// the zero value refers to no element.
+type Handle = slotmap.Key
+
+type handleNode[E any] struct {
+	value      E
+	prev, next Handle
+}
+
+// NewHandleList new handle list
+func NewHandleList[E any](values ...E) *HandleList[E] {
+	l := new(HandleList[E])
+	l.Push(values...)
+	return l
+}
+
+// HandleList ordered list whose Push returns stable handles.
+// A handle gets, sets and removes its element in O(1) however the list is mutated in the meantime,
+// unlike an index which refers to another element as soon as an element before it is inserted or removed.
+// Elements are kept in a [slotmap.SlotMap] chained in list order, so the handles of removed elements
+// are rejected even after their slots are reused.
+type HandleList[E any] struct {
+	sync.RWMutex
+	nodes      slotmap.SlotMap[handleNode[E]]
+	head, tail Handle
+}
+
+// link inserts the element in front of next, at the end when next is zero
+func (l *HandleList[E]) link(value E, next Handle) Handle {
+	prev := l.tail
+	if !next.IsZero() {
+		n, _ := l.nodes.GetRef(next)
+		prev = n.prev
+	}
+	h := l.nodes.Insert(handleNode[E]{value: value, prev: prev, next: next})
+	if next.IsZero() {
+		l.tail = h
+	} else {
+		n, _ := l.nodes.GetRef(next)
+		n.prev = h
+	}
+	if prev.IsZero() {
+		l.head = h
+	} else {
+		n, _ := l.nodes.GetRef(prev)
+		n.next = h
+	}
+	return h
+}
+
+func (l *HandleList[E]) unlink(h Handle) (E, bool) {
+	node, ok := l.nodes.Delete(h)
+	if !ok {
+		return node.value, false
+	}
+	if node.prev.IsZero() {
+		l.head = node.next
+	} else {
+		n, _ := l.nodes.GetRef(node.prev)
+		n.next = node.next
+	}
+	if node.next.IsZero() {
+		l.tail = node.prev
+	} else {
+		n, _ := l.nodes.GetRef(node.next)
+		n.prev = node.prev
+	}
+	return node.value, true
+}
+
+// Count returns the size of the list
+func (l *HandleList[E]) Count() int64 {
+	return l.nodes.Count()
+}
+
+// IsEmpty returns whether the list is empty
+func (l *HandleList[E]) IsEmpty() bool {
+	return l.nodes.IsEmpty()
+}
+
+// IsNotEmpty returns whether the list is not empty
+func (l *HandleList[E]) IsNotEmpty() bool {
+	return l.nodes.IsNotEmpty()
+}
+
+// ElementType returns the name of the element type
+func (l *HandleList[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (l *HandleList[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
+// Push pushes elements to the end of the list and returns their handles
+func (l *HandleList[E]) Push(values ...E) []Handle {
+	handles := make([]Handle, len(values))
+	for i, value := range values {
+		handles[i] = l.link(value, Handle{})
+	}
+	return handles
+}
+
+// Unshift inserts an element at the start of the list and returns its handle
+func (l *HandleList[E]) Unshift(value E) Handle {
+	return l.link(value, l.head)
+}
+
+// InsertBefore inserts an element in front of the element of the handle,
+// it returns false when the handle is stale
+func (l *HandleList[E]) InsertBefore(h Handle, value E) (Handle, bool) {
+	if !l.nodes.ContainsKey(h) {
+		return Handle{}, false
+	}
+	return l.link(value, h), true
+}
+
+// InsertAfter inserts an element behind the element of the handle,
+// it returns false when the handle is stale
+func (l *HandleList[E]) InsertAfter(h Handle, value E) (Handle, bool) {
+	node, ok := l.nodes.Get(h)
+	if !ok {
+		return Handle{}, false
+	}
+	return l.link(value, node.next), true
+}
+
+// Valid returns whether the handle refers to an element of the list
+func (l *HandleList[E]) Valid(h Handle) bool {
+	return l.nodes.ContainsKey(h)
+}
+
+// Get returns the element of the handle, it returns false when the handle is stale
+func (l *HandleList[E]) Get(h Handle) (E, bool) {
+	node, ok := l.nodes.Get(h)
+	return node.value, ok
+}
+
+// Set replaces the element of the handle, it returns false when the handle is stale
+func (l *HandleList[E]) Set(h Handle, value E) bool {
+	node, ok := l.nodes.GetRef(h)
+	if !ok {
+		return false
+	}
+	node.value = value
+	return true
+}
+
+// Remove removes the element of the handle, it returns false when the handle is stale
+func (l *HandleList[E]) Remove(h Handle) (E, bool) {
+	return l.unlink(h)
+}
+
+// First returns the handle of the first element
+func (l *HandleList[E]) First() (Handle, bool) {
+	return l.head, !l.head.IsZero()
+}
+
+// Last returns the handle of the last element
+func (l *HandleList[E]) Last() (Handle, bool) {
+	return l.tail, !l.tail.IsZero()
+}
+
+// Next returns the handle of the element behind the element of the handle
+func (l *HandleList[E]) Next(h Handle) (Handle, bool) {
+	node, ok := l.nodes.Get(h)
+	return node.next, ok && !node.next.IsZero()
+}
+
+// Prev returns the handle of the element in front of the element of the handle
+func (l *HandleList[E]) Prev(h Handle) (Handle, bool) {
+	node, ok := l.nodes.Get(h)
+	return node.prev, ok && !node.prev.IsZero()
+}
+
+// Clear clears the list, all handles become stale
+func (l *HandleList[E]) Clear() {
+	l.nodes.Clear()
+	l.head, l.tail = Handle{}, Handle{}
+}
+
+// Each ranges the list in order, it will break the loop when the callback returns false.
+// Removing the element of the given handle in the callback is allowed.
+func (l *HandleList[E]) Each(callback func(h Handle, value E) bool) {
+	for h := l.head; !h.IsZero(); {
+		node, _ := l.nodes.Get(h)
+		if !callback(h, node.value) {
+			break
+		}
+		h = node.next
+	}
+}
+
+// Handles returns the handles of the elements in order
+func (l *HandleList[E]) Handles() []Handle {
+	handles := make([]Handle, 0, l.nodes.Count())
+	l.Each(func(h Handle, _ E) bool {
+		handles = append(handles, h)
+		return true
+	})
+	return handles
+}
+
+// ToArray converts to array
+func (l *HandleList[E]) ToArray() []E {
+	items := make([]E, 0, l.nodes.Count())
+	l.Each(func(_ Handle, value E) bool {
+		items = append(items, value)
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (l *HandleList[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(l.ToArray())
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the list is not changed
+func (l *HandleList[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(l.ToArray(), callback)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (l *HandleList[E]) MarshalJSON() ([]byte, error) {
+	return l.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], it replaces the elements so all handles become stale
+func (l *HandleList[E]) UnmarshalJSON(data []byte) error {
+	if jsonx.IsNull(data) {
+		l.Clear()
+		return nil
+	}
+	items := []E{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	l.Clear()
+	l.Push(items...)
+	return nil
+}
+
+// String converts to string
+func (l *HandleList[E]) String() string {
+	return format.Values("HandleList", l.Count(), func(yield func(value E) bool) {
+		l.Each(func(_ Handle, value E) bool {
+			return yield(value)
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleList_Push(t *testing.T) {
+	l := NewHandleList[string]()
+	handles := l.Push("a", "b", "c")
+	assert.Len(t, handles, 3)
+	l.Unshift("z")
+	value, ok := l.Get(handles[2])
+	assert.True(t, ok)
+	assert.Equal(t, "c", value)
+	assert.Equal(t, []string{"z", "a", "b", "c"}, l.ToArray())
+	assert.Equal(t, int64(4), l.Count())
+}
+
+func TestHandleList_Remove(t *testing.T) {
+	l := NewHandleList[string]()
+	handles := l.Push("a", "b", "c")
+	value, ok := l.Remove(handles[0])
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+	_, ok = l.Remove(handles[0])
+	assert.False(t, ok)
+	assert.False(t, l.Valid(handles[0]))
+
+	// the freed slot is reused, the stale handle must not see the new element
+	d := l.Push("d")[0]
+	_, ok = l.Get(handles[0])
+	assert.False(t, ok)
+	assert.False(t, l.Set(handles[0], "x"))
+	value, _ = l.Get(d)
+	assert.Equal(t, "d", value)
+	assert.Equal(t, []string{"b", "c", "d"}, l.ToArray())
+
+	value, _ = l.Get(handles[2])
+	assert.Equal(t, "c", value)
+	_, ok = l.Get(Handle{})
+	assert.False(t, ok)
+}
+
+func TestHandleList_Insert(t *testing.T) {
+	l := NewHandleList[int]()
+	handles := l.Push(1, 3)
+	two, ok := l.InsertBefore(handles[1], 2)
+	assert.True(t, ok)
+	_, ok = l.InsertAfter(handles[1], 4)
+	assert.True(t, ok)
+	_, ok = l.InsertBefore(handles[0], 0)
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, l.ToArray())
+	assert.True(t, l.Set(two, 20))
+	assert.Equal(t, []int{0, 1, 20, 3, 4}, l.ToArray())
+
+	l.Remove(handles[1])
+	_, ok = l.InsertAfter(handles[1], 5)
+	assert.False(t, ok)
+}
+
+func TestHandleList_Navigate(t *testing.T) {
+	l := NewHandleList(1, 2, 3)
+	first, ok := l.First()
+	assert.True(t, ok)
+	last, _ := l.Last()
+	next, _ := l.Next(first)
+	value, _ := l.Get(next)
+	assert.Equal(t, 2, value)
+	prev, _ := l.Prev(last)
+	assert.Equal(t, next, prev)
+	_, ok = l.Next(last)
+	assert.False(t, ok)
+	assert.Equal(t, []Handle{first, next, last}, l.Handles())
+}
+
+func TestHandleList_Each(t *testing.T) {
+	l := NewHandleList(1, 2, 3, 4)
+	l.Each(func(h Handle, value int) bool {
+		if value%2 == 0 {
+			l.Remove(h)
+		}
+		return true
+	})
+	assert.Equal(t, []int{1, 3}, l.ToArray())
+}
+
+func TestHandleList_Clear(t *testing.T) {
+	l := NewHandleList(1, 2)
+	handles := l.Handles()
+	l.Clear()
+	assert.True(t, l.IsEmpty())
+	assert.False(t, l.Valid(handles[1]))
+	_, ok := l.First()
+	assert.False(t, ok)
+	l.Push(3)
+	assert.Equal(t, []int{3}, l.ToArray())
+}
+
+func TestHandleList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := NewHandleList[int]()
+	var expected []int
+	var handles []Handle
+	for i := range 2000 {
+		if len(expected) > 0 && r.Intn(3) == 0 {
+			index := r.Intn(len(expected))
+			value, ok := l.Remove(handles[index])
+			assert.True(t, ok)
+			assert.Equal(t, expected[index], value)
+			expected = append(expected[:index], expected[index+1:]...)
+			handles = append(handles[:index], handles[index+1:]...)
+			continue
+		}
+		h := l.Push(i)[0]
+		expected = append(expected, i)
+		handles = append(handles, h)
+	}
+	assert.Equal(t, expected, l.ToArray())
+	assert.Equal(t, handles, l.Handles())
+}
+
+func TestHandleList_MarshalJSON(t *testing.T) {
+	l := NewHandleList(1, 2, 3)
+	data, err := json.Marshal(l)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(data))
+}
+
+func TestHandleList_UnmarshalJSON(t *testing.T) {
+	l := NewHandleList(1)
+	assert.Nil(t, json.Unmarshal([]byte("[2,3]"), l))
+	assert.Equal(t, []int{2, 3}, l.ToArray())
+	assert.Nil(t, json.Unmarshal([]byte("null"), l))
+	assert.True(t, l.IsEmpty())
+}
+
+func TestHandleList_String(t *testing.T) {
+	l := NewHandleList(1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`HandleList\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, l.Count()))
+	assert.True(t, pattern.MatchString(l.String()))
+}
@@ -0,0 +1,85 @@
+package list
+
+// InnerJoin joins the elements of a and b with equal keys by hashing b.
+// Pairs are projected in the order of a, and in the order of b for the same element of a.
+func InnerJoin[A, B any, K comparable, R any](a *List[A], b *List[B], keyA func(A) K, keyB func(B) K, project func(A, B) R) *List[R] {
+	index := hashIndex(b, keyB)
+	result := NewList[R]()
+	for _, left := range a.items {
+		for _, right := range index[keyA(left)] {
+			result.items = append(result.items, project(left, right))
+		}
+	}
+	return result
+}
+
+// LeftJoin joins the elements of a and b with equal keys by hashing b, keeping the elements of a without a match.
+// An element of a without a match is projected once with a zero value of B and false.
+func LeftJoin[A, B any, K comparable, R any](a *List[A], b *List[B], keyA func(A) K, keyB func(B) K, project func(A, B, bool) R) *List[R] {
+	index := hashIndex(b, keyB)
+	result := NewList[R]()
+	for _, left := range a.items {
+		matches := index[keyA(left)]
+		if len(matches) == 0 {
+			result.items = append(result.items, project(left, *new(B), false))
+			continue
+		}
+		for _, right := range matches {
+			result.items = append(result.items, project(left, right, true))
+		}
+	}
+	return result
+}
+
+func hashIndex[B any, K comparable](b *List[B], keyB func(B) K) map[K][]B {
+	index := make(map[K][]B, len(b.items))
+	for _, right := range b.items {
+		k := keyB(right)
+		index[k] = append(index[k], right)
+	}
+	return index
+}
+
+// InnerMergeJoin joins the elements of a and b with equal keys, both sorted by key in the order of compare.
+// It walks the lists once without building an index, runs of equal keys are joined pairwise.
+func InnerMergeJoin[A, B, K, R any](a *List[A], b *List[B], keyA func(A) K, keyB func(B) K, compare func(x, y K) int, project func(A, B) R) *List[R] {
+	result := NewList[R]()
+	mergeJoin(a.items, b.items, keyA, keyB, compare, func(left A, rights []B) {
+		for _, right := range rights {
+			result.items = append(result.items, project(left, right))
+		}
+	})
+	return result
+}
+
+// LeftMergeJoin joins the elements of a and b with equal keys like [InnerMergeJoin], keeping the elements of a without a match.
+// An element of a without a match is projected once with a zero value of B and false.
+func LeftMergeJoin[A, B, K, R any](a *List[A], b *List[B], keyA func(A) K, keyB func(B) K, compare func(x, y K) int, project func(A, B, bool) R) *List[R] {
+	result := NewList[R]()
+	mergeJoin(a.items, b.items, keyA, keyB, compare, func(left A, rights []B) {
+		if len(rights) == 0 {
+			result.items = append(result.items, project(left, *new(B), false))
+			return
+		}
+		for _, right := range rights {
+			result.items = append(result.items, project(left, right, true))
+		}
+	})
+	return result
+}
+
+// mergeJoin calls match with every element of a and the run of elements of b with an equal key
+func mergeJoin[A, B, K any](a []A, b []B, keyA func(A) K, keyB func(B) K, compare func(x, y K) int, match func(A, []B)) {
+	j := 0
+	for _, left := range a {
+		k := keyA(left)
+		for j < len(b) && compare(keyB(b[j]), k) < 0 {
+			j++
+		}
+		end := j
+		for end < len(b) && compare(keyB(b[end]), k) == 0 {
+			end++
+		}
+		match(left, b[j:end])
+	}
+}
@@ -0,0 +1,53 @@
+package list
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type _user struct {
+	ID   int
+	Name string
+}
+
+type _order struct {
+	UserID int
+	Total  int
+}
+
+func TestInnerJoin(t *testing.T) {
+	users := NewList(_user{1, "a"}, _user{2, "b"}, _user{3, "c"})
+	orders := NewList(_order{2, 20}, _order{1, 10}, _order{2, 21}, _order{4, 40})
+	joined := InnerJoin(users, orders, func(u _user) int { return u.ID }, func(o _order) int { return o.UserID },
+		func(u _user, o _order) string { return fmt.Sprint(u.Name, o.Total) })
+	assert.Equal(t, []string{"a10", "b20", "b21"}, joined.ToArray())
+}
+
+func TestLeftJoin(t *testing.T) {
+	users := NewList(_user{1, "a"}, _user{2, "b"}, _user{3, "c"})
+	orders := NewList(_order{2, 20}, _order{1, 10}, _order{2, 21})
+	joined := LeftJoin(users, orders, func(u _user) int { return u.ID }, func(o _order) int { return o.UserID },
+		func(u _user, o _order, ok bool) string { return fmt.Sprintf("%s%d%t", u.Name, o.Total, ok) })
+	assert.Equal(t, []string{"a10true", "b20true", "b21true", "c0false"}, joined.ToArray())
+}
+
+func TestInnerMergeJoin(t *testing.T) {
+	users := NewList(_user{1, "a"}, _user{2, "b"}, _user{2, "bb"}, _user{3, "c"}, _user{5, "e"})
+	orders := NewList(_order{0, 0}, _order{2, 20}, _order{2, 21}, _order{3, 30}, _order{4, 40})
+	joined := InnerMergeJoin(users, orders, func(u _user) int { return u.ID }, func(o _order) int { return o.UserID }, cmp.Compare[int],
+		func(u _user, o _order) string { return fmt.Sprint(u.Name, o.Total) })
+	assert.Equal(t, []string{"b20", "b21", "bb20", "bb21", "c30"}, joined.ToArray())
+}
+
+func TestLeftMergeJoin(t *testing.T) {
+	users := NewList(_user{1, "a"}, _user{2, "b"}, _user{5, "e"})
+	orders := NewList(_order{2, 20}, _order{3, 30})
+	joined := LeftMergeJoin(users, orders, func(u _user) int { return u.ID }, func(o _order) int { return o.UserID }, cmp.Compare[int],
+		func(u _user, o _order, ok bool) string { return fmt.Sprintf("%s%d%t", u.Name, o.Total, ok) })
+	assert.Equal(t, []string{"a0false", "b20true", "e0false"}, joined.ToArray())
+	assert.True(t, LeftMergeJoin(NewList[_user](), orders, func(u _user) int { return u.ID }, func(o _order) int { return o.UserID }, cmp.Compare[int],
+		func(u _user, o _order, ok bool) string { return "" }).IsEmpty())
+}
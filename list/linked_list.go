@@ -1,15 +1,20 @@
 package list
 
 import (
+	"bytes"
 	listlib "container/list"
 	"encoding/json"
-	"fmt"
-	"reflect"
+	"io"
 	"slices"
-	"strings"
 	"sync"
 
-	"github.com/gopi-frame/contract"
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+	"github.com/gopi-frame/collection/view"
 	"github.com/gopi-frame/exception"
 )
 
@@ -50,11 +55,22 @@ func (l *LinkedList[E]) IsNotEmpty() bool {
 	return !l.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (l *LinkedList[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (l *LinkedList[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
 // Contains returns whether the list contains the specific element.
+// Elements are compared with [reflect.DeepEqual], use [ComparableLinkedList] or ContainsWhere to compare otherwise.
 func (l *LinkedList[E]) Contains(value E) bool {
 	l.init()
 	return l.ContainsWhere(func(item E) bool {
-		return reflect.DeepEqual(item, value)
+		return equal.Equal(item, value)
 	})
 }
 
@@ -77,10 +93,10 @@ func (l *LinkedList[E]) Push(values ...E) {
 	}
 }
 
-// Remove removes the specific element.
+// Remove removes the specific element, elements are compared with [reflect.DeepEqual].
 func (l *LinkedList[E]) Remove(value E) {
 	l.RemoveWhere(func(item E) bool {
-		return reflect.DeepEqual(item, value)
+		return equal.Equal(item, value)
 	})
 }
 
@@ -139,11 +155,96 @@ func (l *LinkedList[E]) Set(index int, value E) {
 	}
 }
 
+func (l *LinkedList[E]) elementAt(index int) *listlib.Element {
+	if index < 0 || index >= l.list.Len() {
+		return nil
+	}
+	for i, e := 0, l.list.Front(); e != nil; i, e = i+1, e.Next() {
+		if i == index {
+			return e
+		}
+	}
+	return nil
+}
+
+// At returns the element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It will return a zero value and false when the index is out of range.
+func (l *LinkedList[E]) At(index int) (E, bool) {
+	l.init()
+	e := l.elementFromEnd(index)
+	if e == nil {
+		collection.Fail(collection.NewRangeError(index, l.list.Len()))
+		return *new(E), false
+	}
+	return e.Value.(E), true
+}
+
+// SetAt sets element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It returns false when the index is out of range.
+func (l *LinkedList[E]) SetAt(index int, value E) bool {
+	l.init()
+	e := l.elementFromEnd(index)
+	if e == nil {
+		collection.Fail(collection.NewRangeError(index, l.list.Len()))
+		return false
+	}
+	e.Value = value
+	return true
+}
+
+func (l *LinkedList[E]) elementFromEnd(index int) *listlib.Element {
+	if index >= 0 {
+		return l.elementAt(index)
+	}
+	for i, e := -1, l.list.Back(); e != nil; i, e = i-1, e.Prev() {
+		if i == index {
+			return e
+		}
+	}
+	return nil
+}
+
+// TryGet returns the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *LinkedList[E]) TryGet(index int) (E, error) {
+	l.init()
+	e := l.elementAt(index)
+	if e == nil {
+		return *new(E), collection.NewRangeError(index, l.list.Len())
+	}
+	return e.Value.(E), nil
+}
+
+// TrySet sets element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *LinkedList[E]) TrySet(index int, value E) error {
+	l.init()
+	e := l.elementAt(index)
+	if e == nil {
+		return collection.NewRangeError(index, l.list.Len())
+	}
+	e.Value = value
+	return nil
+}
+
+// TryRemoveAt removes the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *LinkedList[E]) TryRemoveAt(index int) error {
+	l.init()
+	e := l.elementAt(index)
+	if e == nil {
+		return collection.NewRangeError(index, l.list.Len())
+	}
+	l.list.Remove(e)
+	return nil
+}
+
 // First returns the first element of the list.
 // it will return a zero value and false when the list is empty.
 func (l *LinkedList[E]) First() (E, bool) {
 	l.init()
 	if l.list.Len() == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	return l.list.Front().Value.(E), true
@@ -187,6 +288,7 @@ func (l *LinkedList[E]) FirstWhereOr(callback func(item E) bool, value E) E {
 func (l *LinkedList[E]) Last() (E, bool) {
 	l.init()
 	if l.list.Len() == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	return l.list.Back().Value.(E), true
@@ -229,6 +331,7 @@ func (l *LinkedList[E]) LastWhereOr(callback func(item E) bool, value E) E {
 func (l *LinkedList[E]) Pop() (E, bool) {
 	l.init()
 	if l.list.Len() == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	item := l.list.Back()
@@ -241,6 +344,7 @@ func (l *LinkedList[E]) Pop() (E, bool) {
 func (l *LinkedList[E]) Shift() (E, bool) {
 	l.init()
 	if l.list.Len() == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	item := l.list.Front()
@@ -256,11 +360,11 @@ func (l *LinkedList[E]) Unshift(values ...E) {
 	}
 }
 
-// IndexOf returns the index of the specific element.
+// IndexOf returns the index of the specific element, elements are compared with [reflect.DeepEqual].
 func (l *LinkedList[E]) IndexOf(value E) int {
 	l.init()
 	return l.IndexOfWhere(func(item E) bool {
-		return reflect.DeepEqual(item, value)
+		return equal.Equal(item, value)
 	})
 }
 
@@ -291,6 +395,29 @@ func (l *LinkedList[E]) Sub(from, to int) *LinkedList[E] {
 	return linked
 }
 
+// Take returns a new list with the first n elements, n is clamped to the bounds of the list.
+func (l *LinkedList[E]) Take(n int) *LinkedList[E] {
+	return l.Sub(0, n)
+}
+
+// Skip returns a new list without the first n elements, n is clamped to the bounds of the list.
+func (l *LinkedList[E]) Skip(n int) *LinkedList[E] {
+	l.init()
+	return l.Sub(n, l.list.Len())
+}
+
+// TakeLast returns a new list with the last n elements, n is clamped to the bounds of the list.
+func (l *LinkedList[E]) TakeLast(n int) *LinkedList[E] {
+	l.init()
+	return l.Skip(l.list.Len() - n)
+}
+
+// SkipLast returns a new list without the last n elements, n is clamped to the bounds of the list.
+func (l *LinkedList[E]) SkipLast(n int) *LinkedList[E] {
+	l.init()
+	return l.Take(l.list.Len() - n)
+}
+
 // Where returns the sub list with elements which matches the callback
 func (l *LinkedList[E]) Where(callback func(item E) bool) *LinkedList[E] {
 	l.init()
@@ -303,6 +430,12 @@ func (l *LinkedList[E]) Where(callback func(item E) bool) *LinkedList[E] {
 	return linked
 }
 
+// FilteredView returns a live view of the elements which match the predicate,
+// reads re-evaluate the predicate against the current elements of the list.
+func (l *LinkedList[E]) FilteredView(predicate func(item E) bool) *view.View[E] {
+	return view.Filter[E](l, predicate)
+}
+
 // Compact makes the list more compact
 func (l *LinkedList[E]) Compact(callback func(a, b E) bool) {
 	l.init()
@@ -311,7 +444,7 @@ func (l *LinkedList[E]) Compact(callback func(a, b E) bool) {
 	}
 	if callback == nil {
 		callback = func(a, b E) bool {
-			return reflect.DeepEqual(a, b)
+			return equal.Equal(a, b)
 		}
 	}
 	var next *listlib.Element
@@ -335,6 +468,26 @@ func (l *LinkedList[E]) Max(callback func(a, b E) int) E {
 	return slices.MaxFunc(l.ToArray(), callback)
 }
 
+// TryMin returns the min element.
+// It returns [collection.ErrEmptyCollection] when the list is empty.
+func (l *LinkedList[E]) TryMin(callback func(a, b E) int) (E, error) {
+	l.init()
+	if l.list.Len() == 0 {
+		return *new(E), collection.ErrEmptyCollection
+	}
+	return l.Min(callback), nil
+}
+
+// TryMax returns the max element.
+// It returns [collection.ErrEmptyCollection] when the list is empty.
+func (l *LinkedList[E]) TryMax(callback func(a, b E) int) (E, error) {
+	l.init()
+	if l.list.Len() == 0 {
+		return *new(E), collection.ErrEmptyCollection
+	}
+	return l.Max(callback), nil
+}
+
 // Sort sorts the list
 func (l *LinkedList[E]) Sort(callback func(a, b E) int) {
 	l.init()
@@ -372,6 +525,24 @@ func (l *LinkedList[E]) Chunk(size int) *LinkedList[*LinkedList[any]] {
 	return chunks
 }
 
+// Fill sets every element in range [from, to) to the value in place, the range is clamped to the bounds of the list.
+func (l *LinkedList[E]) Fill(value E, from, to int) {
+	l.init()
+	for i, e := 0, l.list.Front(); e != nil && i < to; i, e = i+1, e.Next() {
+		if i >= from {
+			e.Value = value
+		}
+	}
+}
+
+// ReplaceAll replaces every element with the result of the mapper in place.
+func (l *LinkedList[E]) ReplaceAll(mapper func(item E) E) {
+	l.init()
+	for e := l.list.Front(); e != nil; e = e.Next() {
+		e.Value = mapper(e.Value.(E))
+	}
+}
+
 // Each travers the list, if the callback returns false then break
 func (l *LinkedList[E]) Each(callback func(index int, value E) bool) {
 	l.init()
@@ -382,6 +553,76 @@ func (l *LinkedList[E]) Each(callback func(index int, value E) bool) {
 	}
 }
 
+// All returns a sequence of the indexes and elements from front to back which walks the nodes without copying them,
+// it stops when yield returns false
+func (l *LinkedList[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		l.init()
+		for e, i := l.list.Front(), 0; e != nil; e, i = e.Next(), i+1 {
+			if !yield(i, e.Value.(E)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the elements from front to back which walks the nodes without copying them,
+// it stops when yield returns false
+func (l *LinkedList[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		l.init()
+		for e := l.list.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value.(E)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence of the indexes and elements from back to front which walks the nodes without copying them,
+// it stops when yield returns false
+func (l *LinkedList[E]) Backward() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		l.init()
+		for e, i := l.list.Back(), l.list.Len()-1; e != nil; e, i = e.Prev(), i-1 {
+			if !yield(i, e.Value.(E)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterator returns an iterator over the elements from front to back, which walks the nodes without copying them.
+// The list must not be modified while it is iterated.
+func (l *LinkedList[E]) Iterator() *LinkedIterator[E] {
+	l.init()
+	return &LinkedIterator[E]{next: l.list.Front()}
+}
+
+// LinkedIterator iterator over the elements of a [LinkedList]
+type LinkedIterator[E any] struct {
+	next    *listlib.Element
+	current *listlib.Element
+}
+
+// Next advances to the next element, it returns false once the elements are exhausted
+func (it *LinkedIterator[E]) Next() bool {
+	if it.next == nil {
+		it.current = nil
+		return false
+	}
+	it.current, it.next = it.next, it.next.Next()
+	return true
+}
+
+// Value returns the current element
+func (it *LinkedIterator[E]) Value() E {
+	if it.current == nil {
+		return *new(E)
+	}
+	return it.current.Value.(E)
+}
+
 // Reverse reverses the list
 func (l *LinkedList[E]) Reverse() {
 	l.init()
@@ -403,35 +644,80 @@ func (l *LinkedList[E]) Clone() *LinkedList[E] {
 	return linked
 }
 
+// DeepClone clones the list with a deep copy of every element, see [collection.DeepCopy]
+func (l *LinkedList[E]) DeepClone() *LinkedList[E] {
+	l.init()
+	linked := &LinkedList[E]{}
+	for e := l.list.Front(); e != nil; e = e.Next() {
+		linked.Push(collection.DeepCopy(e.Value.(E)))
+	}
+	return linked
+}
+
 // String convert to string
 func (l *LinkedList[E]) String() string {
 	l.init()
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("LinkedList[%T](len=%d)", *new(E), l.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	l.Each(func(index int, value E) bool {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		return index < 4
+	return format.Values("LinkedList", l.Count(), l.Values())
+}
+
+// MemoryFootprint estimates the memory used by the list in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (l *LinkedList[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[LinkedList[E]]() + memory.Of[listlib.List]() + l.Count()*memory.LinkedElement[E]() + memory.Deep(deep, l.Each)
+}
+
+// AppendNDJSON writes the elements of the list to the writer one JSON element per line without copying the list
+func (l *LinkedList[E]) AppendNDJSON(w io.Writer) error {
+	return codec.WriteNDJSON(w, func(yield func(value E) bool) {
+		l.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
 	})
-	if l.list.Len() > 5 {
-		str.WriteString("\t...\n")
+}
+
+// ReadNDJSON pushes the JSON elements read from the reader one per line as they are decoded,
+// elements decoded before an error are kept
+func (l *LinkedList[E]) ReadNDJSON(r io.Reader) error {
+	return codec.ReadNDJSON(r, func(value E) bool {
+		l.Push(value)
+		return true
+	})
+}
+
+// Encode writes the elements of the list to the writer in the format
+func (l *LinkedList[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, l.ToArray(), format)
+}
+
+// Decode replaces the elements of the list with the elements read from the reader in the format
+func (l *LinkedList[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	l.Clear()
+	l.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (l *LinkedList[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := l.Encode(buf, codec.Gob); err != nil {
+		return nil, err
 	}
-	str.WriteByte('}')
-	return str.String()
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (l *LinkedList[E]) GobDecode(data []byte) error {
+	return l.Decode(bytes.NewReader(data), codec.Gob)
 }
 
 // ToJSON converts to json
 func (l *LinkedList[E]) ToJSON() ([]byte, error) {
 	l.init()
-	return json.Marshal(l.ToArray())
+	return jsonx.Array(l.ToArray())
 }
 
 // ToArray converts to array
@@ -444,6 +730,12 @@ func (l *LinkedList[E]) ToArray() []E {
 	return items
 }
 
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the list is not changed
+func (l *LinkedList[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(l.ToArray(), callback)
+}
+
 // MarshalJSON implements [json.Marshaller]
 func (l *LinkedList[E]) MarshalJSON() ([]byte, error) {
 	l.init()
@@ -453,6 +745,10 @@ func (l *LinkedList[E]) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements [json.Unmarshaller]
 func (l *LinkedList[E]) UnmarshalJSON(data []byte) error {
 	l.init()
+	if jsonx.IsNull(data) {
+		l.Clear()
+		return nil
+	}
 	items := []E{}
 	err := json.Unmarshal(data, &items)
 	if err != nil {
@@ -1,12 +1,18 @@
 package list
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
-	"github.com/gopi-frame/exception"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/deepcopy"
+	"github.com/gopi-frame/exception"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,6 +59,8 @@ func TestLinkedList_Set(t *testing.T) {
 }
 
 func TestLinkedList_First(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewLinkedList[int]()
 	value, ok := list.First()
 	assert.Equal(t, 0, value)
@@ -99,6 +107,8 @@ func TestLinkedList_FirstWhereOr(t *testing.T) {
 }
 
 func TestLinkedList_Last(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewLinkedList[int]()
 	value, ok := list.Last()
 	assert.Equal(t, 0, value)
@@ -145,6 +155,8 @@ func TestLinkedList_LastWhereOr(t *testing.T) {
 }
 
 func TestLinkedList_Pop(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewLinkedList[int]()
 	value, ok := list.Pop()
 	assert.Equal(t, 0, value)
@@ -158,6 +170,8 @@ func TestLinkedList_Pop(t *testing.T) {
 }
 
 func TestLinkedList_Shift(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewLinkedList[int]()
 	value, ok := list.Shift()
 	assert.Equal(t, 0, value)
@@ -236,6 +250,20 @@ func TestLinkedList_Max(t *testing.T) {
 	}))
 }
 
+func TestLinkedList_Strict(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(true)
+	list := NewLinkedList[int]()
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { list.Shift() })
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { list.Last() })
+	assert.Panics(t, func() { list.SetAt(-1, 1) })
+	assert.Equal(t, 1, list.LastOr(1))
+	list.Push(1)
+	value, ok := list.At(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
 func TestLinkedList_Sort(t *testing.T) {
 	list := NewLinkedList(0, 3, 1, 2)
 	list.Sort(func(a, b int) int {
@@ -267,6 +295,52 @@ func TestLinkedList_Each(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, items)
 }
 
+func TestLinkedList_All(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4)
+	var indexes, items []int
+	list.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestLinkedList_Values(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4)
+	items := []int{}
+	list.Values()(func(value int) bool {
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestLinkedList_Backward(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4)
+	var indexes, items []int
+	list.Backward()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return value > 3
+	})
+	assert.Equal(t, []int{3, 2}, indexes)
+	assert.Equal(t, []int{4, 3}, items)
+}
+
+func TestLinkedList_Iterator(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	items := []int{}
+	for it := list.Iterator(); it.Next(); {
+		items = append(items, it.Value())
+	}
+	assert.Equal(t, []int{1, 2, 3}, items)
+	it := NewLinkedList[int]().Iterator()
+	assert.False(t, it.Next())
+	assert.Equal(t, 0, it.Value())
+}
+
 func TestLinkedList_Reverse(t *testing.T) {
 	list := NewLinkedList(1, 2, 3)
 	list.Reverse()
@@ -305,3 +379,164 @@ func TestLinkedList_UnmarshalJSON(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
 	assert.Nil(t, err)
 }
+
+func TestLinkedList_TryGet(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	value, err := list.TryGet(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+	_, err = list.TryGet(3)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+}
+
+func TestLinkedList_TrySet(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	assert.Nil(t, list.TrySet(0, 10))
+	assert.Equal(t, 10, list.Get(0))
+	assert.ErrorIs(t, list.TrySet(-1, 10), collection.ErrIndexOutOfRange)
+}
+
+func TestLinkedList_TryRemoveAt(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	assert.Nil(t, list.TryRemoveAt(1))
+	assert.Equal(t, []int{1, 3}, list.ToArray())
+	assert.ErrorIs(t, list.TryRemoveAt(2), collection.ErrIndexOutOfRange)
+}
+
+func TestLinkedList_TryMin(t *testing.T) {
+	_, err := NewLinkedList[int]().TryMin(cmp.Compare[int])
+	assert.ErrorIs(t, err, collection.ErrEmptyCollection)
+	value, err := NewLinkedList(2, 1, 3).TryMax(cmp.Compare[int])
+	assert.Nil(t, err)
+	assert.Equal(t, 3, value)
+}
+
+func TestLinkedList_At(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewLinkedList(1, 2, 3)
+	value, ok := list.At(0)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = list.At(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	value, ok = list.At(-3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	_, ok = list.At(-4)
+	assert.False(t, ok)
+	_, ok = list.At(3)
+	assert.False(t, ok)
+}
+
+func TestLinkedList_SetAt(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewLinkedList(1, 2, 3)
+	assert.True(t, list.SetAt(-1, 4))
+	assert.True(t, list.SetAt(0, 5))
+	assert.False(t, list.SetAt(-4, 6))
+	assert.False(t, list.SetAt(3, 6))
+	assert.Equal(t, []int{5, 2, 4}, list.ToArray())
+}
+
+func TestLinkedList_Fill(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4, 5)
+	list.Fill(0, 1, 3)
+	assert.Equal(t, []int{1, 0, 0, 4, 5}, list.ToArray())
+	list.Fill(9, -1, 10)
+	assert.Equal(t, []int{9, 9, 9, 9, 9}, list.ToArray())
+}
+
+func TestLinkedList_ReplaceAll(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	list.ReplaceAll(func(item int) int {
+		return item * 2
+	})
+	assert.Equal(t, []int{2, 4, 6}, list.ToArray())
+}
+
+func TestLinkedList_Take(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{1, 2}, list.Take(2).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.Take(10).ToArray())
+	assert.Empty(t, list.Take(-1).ToArray())
+}
+
+func TestLinkedList_Skip(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{3, 4, 5}, list.Skip(2).ToArray())
+	assert.Empty(t, list.Skip(10).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.Skip(-1).ToArray())
+}
+
+func TestLinkedList_TakeLast(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{4, 5}, list.TakeLast(2).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.TakeLast(10).ToArray())
+	assert.Empty(t, list.TakeLast(-1).ToArray())
+}
+
+func TestLinkedList_SkipLast(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3}, list.SkipLast(2).ToArray())
+	assert.Empty(t, list.SkipLast(10).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.SkipLast(-1).ToArray())
+}
+
+func TestLinkedList_FilteredView(t *testing.T) {
+	list := NewLinkedList(1, 2, 3, 4)
+	even := list.FilteredView(func(item int) bool {
+		return item%2 == 0
+	})
+	list.Unshift(0)
+	assert.Equal(t, []int{0, 2, 4}, even.ToArray())
+}
+
+func TestLinkedList_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewLinkedList(1, 2, 3).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestLinkedList_Decode(t *testing.T) {
+	x := NewLinkedList(9)
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+}
+
+func TestLinkedList_AppendNDJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewLinkedList("a", "b").AppendNDJSON(buf))
+	assert.Equal(t, "\"a\"\n\"b\"\n", buf.String())
+}
+
+func TestLinkedList_ReadNDJSON(t *testing.T) {
+	l := NewLinkedList(0)
+	assert.Nil(t, l.ReadNDJSON(strings.NewReader("1\n2\n")))
+	assert.Equal(t, []int{0, 1, 2}, l.ToArray())
+}
+
+func TestLinkedList_JSONNull(t *testing.T) {
+	l := NewLinkedList(1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), l))
+	assert.True(t, l.IsEmpty())
+	data, _ := json.Marshal(l)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestLinkedList_DeepClone(t *testing.T) {
+	list := NewLinkedList(&_message{Name: "a"})
+	clone := list.DeepClone()
+	clone.Get(0).Name = "b"
+	assert.Equal(t, deepcopy.Reflect, list.Get(0).Name == "a")
+}
+
+func TestLinkedList_ToJSONSorted(t *testing.T) {
+	l := NewLinkedList("b", "c", "a")
+	data, err := l.ToJSONSorted(cmp.Compare[string])
+	assert.Nil(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(data))
+	assert.Equal(t, []string{"b", "c", "a"}, l.ToArray())
+}
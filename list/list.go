@@ -1,14 +1,19 @@
 package list
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"reflect"
+	"io"
 	"slices"
-	"strings"
 	"sync"
 
-	"github.com/gopi-frame/contract"
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+	"github.com/gopi-frame/collection/view"
 )
 
 // NewList new list
@@ -18,10 +23,38 @@ func NewList[E any](values ...E) *List[E] {
 	return instance
 }
 
-// List list
+// Collect new list of the elements of the sequence in order, such as an [iter.Seq]
+func Collect[E any](seq func(yield func(value E) bool)) *List[E] {
+	instance := new(List[E])
+	seq(func(value E) bool {
+		instance.items = append(instance.items, value)
+		return true
+	})
+	return instance
+}
+
+// List list, its methods do not take the embedded lock, lock it around the calls or use [SyncList]
 type List[E any] struct {
 	sync.RWMutex
-	items []E
+	items    []E
+	onRemove []func(value E)
+}
+
+// OnRemove registers a callback which is called with every element leaving the list,
+// such as to close the resources held by the elements.
+// It covers Remove, RemoveWhere, RemoveAt, Splice, Compact, Clear, Pop and Shift,
+// elements overwritten by Set or replaced by Decode are not reported.
+// Callbacks are called synchronously once the list is updated.
+func (list *List[E]) OnRemove(callback func(value E)) {
+	list.onRemove = append(list.onRemove, callback)
+}
+
+func (list *List[E]) removed(values ...E) {
+	for _, value := range values {
+		for _, handler := range list.onRemove {
+			handler(value)
+		}
+	}
 }
 
 // Count returns the size of the list
@@ -39,10 +72,21 @@ func (list *List[E]) IsNotEmpty() bool {
 	return !list.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (list *List[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (list *List[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
 // Contains returns whether the list contains the specific element.
+// Elements are compared with [reflect.DeepEqual], use [ComparableList] or ContainsWhere to compare otherwise.
 func (list *List[E]) Contains(value E) bool {
 	return list.ContainsWhere(func(e E) bool {
-		return reflect.DeepEqual(e, value)
+		return equal.Equal(e, value)
 	})
 }
 
@@ -56,26 +100,48 @@ func (list *List[E]) Push(values ...E) {
 	list.items = append(list.items, values...)
 }
 
-// Remove removes the specific element.
+// Remove removes the specific element, elements are compared with [reflect.DeepEqual].
 func (list *List[E]) Remove(value E) {
 	list.RemoveWhere(func(item E) bool {
-		return reflect.DeepEqual(value, item)
+		return equal.Equal(value, item)
 	})
 }
 
 // RemoveWhere removes specific elements by callback.
 func (list *List[E]) RemoveWhere(callback func(item E) bool) {
-	list.items = slices.DeleteFunc(list.items, callback)
+	var removed []E
+	list.items = slices.DeleteFunc(list.items, func(item E) bool {
+		if !callback(item) {
+			return false
+		}
+		if len(list.onRemove) > 0 {
+			removed = append(removed, item)
+		}
+		return true
+	})
+	list.removed(removed...)
 }
 
 // RemoveAt removes the element on the specific index.
 func (list *List[E]) RemoveAt(index int) {
+	value := list.items[index]
 	list.items = slices.Delete(list.items, index, index+1)
+	list.removed(value)
 }
 
 // Clear clears the list.
 func (list *List[E]) Clear() {
+	items := list.items
 	list.items = []E{}
+	list.removed(items...)
+}
+
+// Repack reallocates the backing array to fit the elements.
+// It releases the capacity left over by removals and shifts, which keeps long-lived lists from holding on to a peak-sized array.
+func (list *List[E]) Repack() {
+	items := make([]E, len(list.items))
+	copy(items, list.items)
+	list.items = items
 }
 
 // Get returns the element on the specific index.
@@ -88,10 +154,67 @@ func (list *List[E]) Set(index int, value E) {
 	list.items[index] = value
 }
 
+// At returns the element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It will return a zero value and false when the index is out of range.
+func (list *List[E]) At(index int) (E, bool) {
+	if index < 0 {
+		index += len(list.items)
+	}
+	if index < 0 || index >= len(list.items) {
+		collection.Fail(collection.NewRangeError(index, len(list.items)))
+		return *new(E), false
+	}
+	return list.items[index], true
+}
+
+// SetAt sets element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It returns false when the index is out of range.
+func (list *List[E]) SetAt(index int, value E) bool {
+	if index < 0 {
+		index += len(list.items)
+	}
+	if index < 0 || index >= len(list.items) {
+		collection.Fail(collection.NewRangeError(index, len(list.items)))
+		return false
+	}
+	list.items[index] = value
+	return true
+}
+
+// TryGet returns the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *List[E]) TryGet(index int) (E, error) {
+	if index < 0 || index >= len(list.items) {
+		return *new(E), collection.NewRangeError(index, len(list.items))
+	}
+	return list.items[index], nil
+}
+
+// TrySet sets element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *List[E]) TrySet(index int, value E) error {
+	if index < 0 || index >= len(list.items) {
+		return collection.NewRangeError(index, len(list.items))
+	}
+	list.items[index] = value
+	return nil
+}
+
+// TryRemoveAt removes the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *List[E]) TryRemoveAt(index int) error {
+	if index < 0 || index >= len(list.items) {
+		return collection.NewRangeError(index, len(list.items))
+	}
+	list.RemoveAt(index)
+	return nil
+}
+
 // First returns the first element of the list.
 // it will return a zero value and false when the list is empty.
 func (list *List[E]) First() (E, bool) {
 	if len(list.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	return list.items[0], true
@@ -99,10 +222,10 @@ func (list *List[E]) First() (E, bool) {
 
 // FirstOr returns the first element of the list, it will return the default value when the list is empty.
 func (list *List[E]) FirstOr(value E) E {
-	if v, ok := list.First(); ok {
-		return v
+	if len(list.items) == 0 {
+		return value
 	}
-	return value
+	return list.items[0]
 }
 
 // FirstWhere returns the first element of the list which matches the callback.
@@ -130,6 +253,7 @@ func (list *List[E]) FirstWhereOr(callback func(item E) bool, value E) E {
 func (list *List[E]) Last() (E, bool) {
 	length := len(list.items)
 	if length == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	return list.items[length-1], true
@@ -138,10 +262,10 @@ func (list *List[E]) Last() (E, bool) {
 // LastOr returns the last element of the list.
 // It will return the default value when the list is empty.
 func (list *List[E]) LastOr(value E) E {
-	if v, ok := list.Last(); ok {
-		return v
+	if len(list.items) == 0 {
+		return value
 	}
-	return value
+	return list.items[len(list.items)-1]
 }
 
 // LastWhere returns the last element of the list which matches the callback.
@@ -170,10 +294,13 @@ func (list *List[E]) LastWhereOr(callback func(item E) bool, value E) E {
 func (list *List[E]) Pop() (E, bool) {
 	length := len(list.items)
 	if length == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	value := list.items[length-1]
+	clear(list.items[length-1:])
 	list.items = list.items[:length-1]
+	list.removed(value)
 	return value, true
 }
 
@@ -181,10 +308,13 @@ func (list *List[E]) Pop() (E, bool) {
 // It will return a zero value and false when the list is empty.
 func (list *List[E]) Shift() (E, bool) {
 	if len(list.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
 		return *new(E), false
 	}
 	value := list.items[0]
+	clear(list.items[:1])
 	list.items = list.items[1:]
+	list.removed(value)
 	return value, true
 }
 
@@ -193,10 +323,10 @@ func (list *List[E]) Unshift(values ...E) {
 	list.items = slices.Insert(list.items, 0, values...)
 }
 
-// IndexOf returns the index of the specific element.
+// IndexOf returns the index of the specific element, elements are compared with [reflect.DeepEqual].
 func (list *List[E]) IndexOf(value E) int {
 	return list.IndexOfWhere(func(item E) bool {
-		return reflect.DeepEqual(value, item)
+		return equal.Equal(value, item)
 	})
 }
 
@@ -210,6 +340,44 @@ func (list *List[E]) Sub(from, to int) *List[E] {
 	return &List[E]{items: list.items[from:to]}
 }
 
+// Take returns a new list with the first n elements, n is clamped to the bounds of the list.
+func (list *List[E]) Take(n int) *List[E] {
+	n = min(max(n, 0), len(list.items))
+	return &List[E]{items: slices.Clone(list.items[:n])}
+}
+
+// Skip returns a new list without the first n elements, n is clamped to the bounds of the list.
+func (list *List[E]) Skip(n int) *List[E] {
+	n = min(max(n, 0), len(list.items))
+	return &List[E]{items: slices.Clone(list.items[n:])}
+}
+
+// TakeLast returns a new list with the last n elements, n is clamped to the bounds of the list.
+func (list *List[E]) TakeLast(n int) *List[E] {
+	return list.Skip(len(list.items) - n)
+}
+
+// SkipLast returns a new list without the last n elements, n is clamped to the bounds of the list.
+func (list *List[E]) SkipLast(n int) *List[E] {
+	return list.Take(len(list.items) - n)
+}
+
+// Splice removes deleteCount elements from start, inserts items at start and returns the removed elements.
+// Like JavaScript's Array.prototype.splice, a negative start counts back from the end of the list,
+// start and deleteCount are clamped to the bounds of the list.
+func (list *List[E]) Splice(start, deleteCount int, items ...E) *List[E] {
+	length := len(list.items)
+	if start < 0 {
+		start = max(length+start, 0)
+	}
+	start = min(start, length)
+	deleteCount = min(max(deleteCount, 0), length-start)
+	removed := &List[E]{items: slices.Clone(list.items[start : start+deleteCount])}
+	list.items = slices.Replace(list.items, start, start+deleteCount, items...)
+	list.removed(removed.items...)
+	return removed
+}
+
 // Where returns the sub list with elements which matches the callback
 func (list *List[E]) Where(callback func(item E) bool) *List[E] {
 	l := &List[E]{}
@@ -221,14 +389,37 @@ func (list *List[E]) Where(callback func(item E) bool) *List[E] {
 	return l
 }
 
+// FilteredView returns a live view of the elements which match the predicate,
+// reads re-evaluate the predicate against the current elements of the list.
+func (list *List[E]) FilteredView(predicate func(item E) bool) *view.View[E] {
+	return view.Filter[E](list, predicate)
+}
+
 // Compact makes the list more compact
 func (list *List[E]) Compact(callback func(a, b E) bool) {
 	if callback == nil {
 		callback = func(a, b E) bool {
-			return reflect.DeepEqual(a, b)
+			return equal.Equal(a, b)
+		}
+	}
+	if len(list.items) < 2 {
+		return
+	}
+	// each element is compared with its original predecessor like slices.CompactFunc
+	var removed []E
+	kept, previous := 1, list.items[0]
+	for _, item := range list.items[1:] {
+		if !callback(item, previous) {
+			list.items[kept] = item
+			kept++
+		} else if len(list.onRemove) > 0 {
+			removed = append(removed, item)
 		}
+		previous = item
 	}
-	list.items = slices.CompactFunc(list.items, callback)
+	clear(list.items[kept:])
+	list.items = list.items[:kept]
+	list.removed(removed...)
 }
 
 // Min returns the min element
@@ -241,6 +432,24 @@ func (list *List[E]) Max(callback func(a, b E) int) E {
 	return slices.MaxFunc(list.items, callback)
 }
 
+// TryMin returns the min element.
+// It returns [collection.ErrEmptyCollection] when the list is empty.
+func (list *List[E]) TryMin(callback func(a, b E) int) (E, error) {
+	if len(list.items) == 0 {
+		return *new(E), collection.ErrEmptyCollection
+	}
+	return list.Min(callback), nil
+}
+
+// TryMax returns the max element.
+// It returns [collection.ErrEmptyCollection] when the list is empty.
+func (list *List[E]) TryMax(callback func(a, b E) int) (E, error) {
+	if len(list.items) == 0 {
+		return *new(E), collection.ErrEmptyCollection
+	}
+	return list.Max(callback), nil
+}
+
 // Sort sorts the list
 func (list *List[E]) Sort(callback func(a, b E) int) {
 	slices.SortFunc(list.items, callback)
@@ -262,6 +471,21 @@ func (list *List[E]) Chunk(size int) *List[*List[any]] {
 	return chunks
 }
 
+// Fill sets every element in range [from, to) to the value in place, the range is clamped to the bounds of the list.
+func (list *List[E]) Fill(value E, from, to int) {
+	from, to = max(from, 0), min(to, len(list.items))
+	for i := from; i < to; i++ {
+		list.items[i] = value
+	}
+}
+
+// ReplaceAll replaces every element with the result of the mapper in place.
+func (list *List[E]) ReplaceAll(mapper func(item E) E) {
+	for i, item := range list.items {
+		list.items[i] = mapper(item)
+	}
+}
+
 // Each travers the list, if the callback returns false then break
 func (list *List[E]) Each(callback func(index int, value E) bool) {
 	for index, value := range list.items {
@@ -271,6 +495,39 @@ func (list *List[E]) Each(callback func(index int, value E) bool) {
 	}
 }
 
+// All returns a sequence of the indexes and elements from first to last, it stops when yield returns false
+func (list *List[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for index, value := range list.items {
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the elements from first to last, it stops when yield returns false
+func (list *List[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, value := range list.items {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence of the indexes and elements from last to first, it stops when yield returns false
+func (list *List[E]) Backward() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for index := len(list.items) - 1; index >= 0; index-- {
+			if !yield(index, list.items[index]) {
+				return
+			}
+		}
+	}
+}
+
 // Reverse reverses the list
 func (list *List[E]) Reverse() {
 	slices.Reverse(list.items)
@@ -282,40 +539,84 @@ func (list *List[E]) Clone() *List[E] {
 	return list
 }
 
+// DeepClone clones the list with a deep copy of every element, see [collection.DeepCopy].
+// Elements are copied one by one, so a pointer shared by two elements is no longer shared in the clone.
+func (list *List[E]) DeepClone() *List[E] {
+	items := make([]E, len(list.items))
+	for i, item := range list.items {
+		items[i] = collection.DeepCopy(item)
+	}
+	return &List[E]{items: items}
+}
+
 // String convert to string
 func (list *List[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("List[%T](len=%d)", *new(E), list.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	for index, value := range list.items {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		if index >= 4 {
-			break
-		}
+	return format.Values("List", list.Count(), list.Values())
+}
+
+// MemoryFootprint estimates the memory used by the list in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (list *List[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[List[E]]() + memory.Slice(list.items, deep)
+}
+
+// AppendNDJSON writes the elements of the list to the writer one JSON element per line without copying the list
+func (list *List[E]) AppendNDJSON(w io.Writer) error {
+	return codec.Encode(w, list.items, codec.NDJSON)
+}
+
+// ReadNDJSON pushes the JSON elements read from the reader one per line as they are decoded,
+// elements decoded before an error are kept
+func (list *List[E]) ReadNDJSON(r io.Reader) error {
+	return codec.ReadNDJSON(r, func(value E) bool {
+		list.Push(value)
+		return true
+	})
+}
+
+// Encode writes the elements of the list to the writer in the format
+func (list *List[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, list.ToArray(), format)
+}
+
+// Decode replaces the elements of the list with the elements read from the reader in the format
+func (list *List[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
 	}
-	if list.Count() > 5 {
-		str.WriteString("\t...\n")
+	list.items = items
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (list *List[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := list.Encode(buf, codec.Gob); err != nil {
+		return nil, err
 	}
-	str.WriteByte('}')
-	return str.String()
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (list *List[E]) GobDecode(data []byte) error {
+	return list.Decode(bytes.NewReader(data), codec.Gob)
 }
 
 // ToJSON converts to json
 func (list *List[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(list.items)
+	return jsonx.Array(list.items)
 }
 
-// ToArray converts to array
+// ToArray converts to array, the array is a copy of the elements
 func (list *List[E]) ToArray() []E {
-	return list.items
+	return slices.Clone(list.items)
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the list is not changed
+func (list *List[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(list.items, callback)
 }
 
 // MarshalJSON implements [json.Marshaller]
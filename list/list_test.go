@@ -1,11 +1,17 @@
 package list
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/deepcopy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -132,6 +138,8 @@ func TestList_LastWhereOr(t *testing.T) {
 }
 
 func TestList_Pop(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewList[int]()
 	value, ok := list.Pop()
 	assert.Equal(t, 0, value)
@@ -146,6 +154,8 @@ func TestList_Pop(t *testing.T) {
 }
 
 func TestList_Shift(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
 	list := NewList[int]()
 	value, ok := list.Shift()
 	assert.Equal(t, 0, value)
@@ -182,6 +192,32 @@ func TestList_Sub(t *testing.T) {
 	assert.Equal(t, []int{2, 3}, subList.ToArray())
 }
 
+func TestList_Splice(t *testing.T) {
+	t.Run("remove and insert", func(t *testing.T) {
+		list := NewList(1, 2, 3, 4, 5)
+		removed := list.Splice(1, 2, 6, 7, 8)
+		assert.Equal(t, []int{2, 3}, removed.ToArray())
+		assert.Equal(t, []int{1, 6, 7, 8, 4, 5}, list.ToArray())
+	})
+
+	t.Run("negative start", func(t *testing.T) {
+		list := NewList(1, 2, 3, 4, 5)
+		removed := list.Splice(-2, 1)
+		assert.Equal(t, []int{4}, removed.ToArray())
+		assert.Equal(t, []int{1, 2, 3, 5}, list.ToArray())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		list := NewList(1, 2, 3)
+		removed := list.Splice(10, 10, 4)
+		assert.Empty(t, removed.ToArray())
+		assert.Equal(t, []int{1, 2, 3, 4}, list.ToArray())
+		removed = list.Splice(-10, 2)
+		assert.Equal(t, []int{1, 2}, removed.ToArray())
+		assert.Equal(t, []int{3, 4}, list.ToArray())
+	})
+}
+
 func TestList_Where(t *testing.T) {
 	list := NewList(1, 2, 3, 4, 5)
 	assert.Equal(t, []int{4, 5}, list.Where(func(item int) bool {
@@ -215,6 +251,21 @@ func TestList_Max(t *testing.T) {
 	}))
 }
 
+func TestList_Strict(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(true)
+	list := NewList[int]()
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { list.Pop() })
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { list.First() })
+	assert.Panics(t, func() { list.At(1) })
+	assert.Equal(t, 1, list.FirstOr(1))
+	list.Push(1)
+	assert.PanicsWithError(t, "collection: index 1 out of range [0, 1)", func() { list.SetAt(1, 2) })
+	value, ok := list.Shift()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
 func TestList_Sort(t *testing.T) {
 	list := NewList(3, 1, 2)
 	list.Sort(func(a, b int) int {
@@ -236,6 +287,14 @@ func TestList_Chunk(t *testing.T) {
 	assert.Equal(t, []any{3, 4}, chunks.Get(1).ToArray())
 }
 
+func TestList_ToArray(t *testing.T) {
+	list := NewList(1, 2, 3)
+	items := list.ToArray()
+	list.Pop()
+	list.Shift()
+	assert.Equal(t, []int{1, 2, 3}, items)
+}
+
 func TestList_Each(t *testing.T) {
 	list := NewList(1, 2, 3, 4)
 	items := []int{}
@@ -246,6 +305,47 @@ func TestList_Each(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, items)
 }
 
+func TestList_All(t *testing.T) {
+	list := NewList(1, 2, 3, 4)
+	var indexes, items []int
+	list.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestList_Values(t *testing.T) {
+	list := NewList(1, 2, 3, 4)
+	items := []int{}
+	list.Values()(func(value int) bool {
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestList_Backward(t *testing.T) {
+	list := NewList("a", "b", "c")
+	var indexes []int
+	var items []string
+	list.Backward()(func(index int, value string) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{2, 1, 0}, indexes)
+	assert.Equal(t, []string{"c", "b", "a"}, items)
+}
+
+func TestCollect(t *testing.T) {
+	list := Collect(NewList(1, 2, 3).Values())
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+	assert.True(t, Collect(NewList[int]().Values()).IsEmpty())
+}
+
 func TestList_Reverse(t *testing.T) {
 	list := NewList(1, 2, 3)
 	list.Reverse()
@@ -284,3 +384,224 @@ func TestList_UnmarshalJSON(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
 	assert.Nil(t, err)
 }
+
+func TestList_TryGet(t *testing.T) {
+	list := NewList(1, 2, 3)
+	value, err := list.TryGet(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+	_, err = list.TryGet(3)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	_, err = list.TryGet(-1)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+}
+
+func TestList_TrySet(t *testing.T) {
+	list := NewList(1, 2, 3)
+	assert.Nil(t, list.TrySet(0, 10))
+	assert.Equal(t, 10, list.Get(0))
+	assert.ErrorIs(t, list.TrySet(3, 10), collection.ErrIndexOutOfRange)
+}
+
+func TestList_TryRemoveAt(t *testing.T) {
+	list := NewList(1, 2, 3)
+	assert.Nil(t, list.TryRemoveAt(0))
+	assert.Equal(t, []int{2, 3}, list.ToArray())
+	assert.ErrorIs(t, list.TryRemoveAt(2), collection.ErrIndexOutOfRange)
+}
+
+func TestList_TryMin(t *testing.T) {
+	_, err := NewList[int]().TryMin(cmp.Compare[int])
+	assert.ErrorIs(t, err, collection.ErrEmptyCollection)
+	value, err := NewList(2, 1, 3).TryMin(cmp.Compare[int])
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestList_TryMax(t *testing.T) {
+	_, err := NewList[int]().TryMax(cmp.Compare[int])
+	assert.ErrorIs(t, err, collection.ErrEmptyCollection)
+	value, err := NewList(2, 1, 3).TryMax(cmp.Compare[int])
+	assert.Nil(t, err)
+	assert.Equal(t, 3, value)
+}
+
+func TestList_At(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewList(1, 2, 3)
+	value, ok := list.At(0)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = list.At(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	value, ok = list.At(-3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	_, ok = list.At(-4)
+	assert.False(t, ok)
+	_, ok = list.At(3)
+	assert.False(t, ok)
+}
+
+func TestList_SetAt(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewList(1, 2, 3)
+	assert.True(t, list.SetAt(-1, 4))
+	assert.True(t, list.SetAt(0, 5))
+	assert.False(t, list.SetAt(-4, 6))
+	assert.False(t, list.SetAt(3, 6))
+	assert.Equal(t, []int{5, 2, 4}, list.ToArray())
+}
+
+func TestList_Fill(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	list.Fill(0, 1, 3)
+	assert.Equal(t, []int{1, 0, 0, 4, 5}, list.ToArray())
+	list.Fill(9, -1, 10)
+	assert.Equal(t, []int{9, 9, 9, 9, 9}, list.ToArray())
+}
+
+func TestList_ReplaceAll(t *testing.T) {
+	list := NewList(1, 2, 3)
+	list.ReplaceAll(func(item int) int {
+		return item * 2
+	})
+	assert.Equal(t, []int{2, 4, 6}, list.ToArray())
+}
+
+func TestList_Take(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{1, 2}, list.Take(2).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.Take(10).ToArray())
+	assert.Empty(t, list.Take(-1).ToArray())
+}
+
+func TestList_Skip(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{3, 4, 5}, list.Skip(2).ToArray())
+	assert.Empty(t, list.Skip(10).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.Skip(-1).ToArray())
+}
+
+func TestList_TakeLast(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{4, 5}, list.TakeLast(2).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.TakeLast(10).ToArray())
+	assert.Empty(t, list.TakeLast(-1).ToArray())
+}
+
+func TestList_SkipLast(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3}, list.SkipLast(2).ToArray())
+	assert.Empty(t, list.SkipLast(10).ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.SkipLast(-1).ToArray())
+}
+
+func TestList_FilteredView(t *testing.T) {
+	list := NewList(1, 2, 3, 4)
+	even := list.FilteredView(func(item int) bool {
+		return item%2 == 0
+	})
+	assert.Equal(t, []int{2, 4}, even.ToArray())
+	list.Push(6)
+	list.Remove(2)
+	assert.Equal(t, int64(2), even.Count())
+	assert.Equal(t, []int{4, 6}, even.ToArray())
+}
+
+func TestList_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewList(1, 2, 3).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestList_Decode(t *testing.T) {
+	x := NewList[int]()
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+}
+
+func TestList_AppendNDJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewList("a", "b").AppendNDJSON(buf))
+	assert.Equal(t, "\"a\"\n\"b\"\n", buf.String())
+}
+
+func TestList_ReadNDJSON(t *testing.T) {
+	l := NewList(0)
+	assert.Nil(t, l.ReadNDJSON(strings.NewReader("1\n2\n")))
+	assert.Equal(t, []int{0, 1, 2}, l.ToArray())
+	assert.NotNil(t, l.ReadNDJSON(strings.NewReader("3\n{\n")))
+	assert.Equal(t, []int{0, 1, 2, 3}, l.ToArray())
+}
+
+func TestList_JSONNull(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	l := NewList(1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), l))
+	assert.True(t, l.IsEmpty())
+	data, _ := json.Marshal(l)
+	assert.Equal(t, "[]", string(data))
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = json.Marshal(l)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestList_EncodeEnvelope(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewList(1, 2).Encode(buf, codec.JSONEnvelope))
+	assert.JSONEq(t, `{"type":"int","version":0,"length":2,"items":[1,2]}`, buf.String())
+	l := NewList[int]()
+	assert.Nil(t, l.Decode(buf, codec.JSONEnvelope))
+	assert.Equal(t, []int{1, 2}, l.ToArray())
+}
+
+func TestList_Repack(t *testing.T) {
+	list := NewList(new(int), new(int), new(int), new(int))
+	list.Pop()
+	list.Shift()
+	assert.Nil(t, list.items[:cap(list.items)][cap(list.items)-1])
+	list.Repack()
+	assert.Equal(t, 2, cap(list.items))
+	assert.Equal(t, int64(2), list.Count())
+}
+
+func TestList_OnRemove(t *testing.T) {
+	list := NewList(1, 2, 2, 3, 4, 5, 6, 7)
+	var removed []int
+	list.OnRemove(func(value int) {
+		removed = append(removed, value)
+	})
+	list.Compact(nil)
+	list.Remove(3)
+	list.RemoveAt(0)
+	list.Pop()
+	list.Shift()
+	list.Splice(0, 1, 10)
+	assert.Equal(t, []int{2, 3, 1, 7, 2, 4}, removed)
+	assert.Equal(t, []int{10, 5, 6}, list.ToArray())
+
+	removed = nil
+	list.Set(0, 11)
+	list.Clear()
+	assert.Equal(t, []int{11, 5, 6}, removed)
+}
+
+func TestList_DeepClone(t *testing.T) {
+	list := NewList([]int{1}, []int{2})
+	clone := list.DeepClone()
+	clone.Get(0)[0] = 10
+	assert.Equal(t, deepcopy.Reflect, list.Get(0)[0] == 1)
+	assert.Equal(t, 2, len(clone.ToArray()))
+}
+
+func TestList_ToJSONSorted(t *testing.T) {
+	l := NewList(3, 1, 2)
+	data, err := l.ToJSONSorted(cmp.Compare[int])
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(data))
+	assert.Equal(t, []int{3, 1, 2}, l.ToArray())
+}
@@ -0,0 +1,18 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+func TestMemoryFootprint(t *testing.T) {
+	list := NewList(1, 2)
+	linked := NewLinkedList(1, 2)
+	unrolled := NewUnrolledList(4, 1, 2)
+	memtest.Run(t, []memtest.Case{
+		{Name: "List", Count: 2, Footprint: list.MemoryFootprint, Add: func(value int) { list.Push(value) }},
+		{Name: "LinkedList", Count: 2, Footprint: linked.MemoryFootprint, Add: func(value int) { linked.Push(value) }},
+		{Name: "UnrolledList", Count: 2, Footprint: unrolled.MemoryFootprint, Add: func(value int) { unrolled.Push(value) }},
+	})
+}
@@ -0,0 +1,58 @@
+package list
+
+import "github.com/gopi-frame/collection/internal/equal"
+
+// Reconciliation changes which turn the old elements into the new elements
+type Reconciliation[T any] struct {
+	// ToCreate elements whose keys are only in the new list, in the order of the new list
+	ToCreate *List[T]
+	// ToUpdate new elements whose keys are in both lists but differ from the old elements, in the order of the new list
+	ToUpdate *List[T]
+	// ToDelete old elements whose keys are not in the new list, in the order of the old list
+	ToDelete *List[T]
+}
+
+// IsEmpty returns whether there is nothing to change
+func (r *Reconciliation[T]) IsEmpty() bool {
+	return r.ToCreate.IsEmpty() && r.ToUpdate.IsEmpty() && r.ToDelete.IsEmpty()
+}
+
+// ReconcileBy matches the old and the new elements by key, e.g. the actual and the desired state of resources.
+// An element is updated when eq returns false for the old and the new element, a nil eq compares them deeply.
+// When a key repeats in a list, the last element of the key is used.
+func ReconcileBy[T any, K comparable](old, new *List[T], key func(value T) K, eq func(old, new T) bool) *Reconciliation[T] {
+	if eq == nil {
+		eq = equal.Equal[T]
+	}
+	result := &Reconciliation[T]{ToCreate: NewList[T](), ToUpdate: NewList[T](), ToDelete: NewList[T]()}
+	olds := make(map[K]T, len(old.items))
+	for _, item := range old.items {
+		olds[key(item)] = item
+	}
+	news := make(map[K]int, len(new.items))
+	for index, item := range new.items {
+		news[key(item)] = index
+	}
+	for index, item := range new.items {
+		k := key(item)
+		if news[k] != index {
+			continue
+		}
+		if prev, ok := olds[k]; !ok {
+			result.ToCreate.Push(item)
+		} else if !eq(prev, item) {
+			result.ToUpdate.Push(item)
+		}
+	}
+	for _, item := range old.items {
+		k := key(item)
+		if _, ok := news[k]; ok {
+			continue
+		}
+		if last, ok := olds[k]; ok {
+			result.ToDelete.Push(last)
+			delete(olds, k)
+		}
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type _resource struct {
+	Name     string
+	Replicas int
+}
+
+func _resourceName(r _resource) string {
+	return r.Name
+}
+
+func TestReconcileBy(t *testing.T) {
+	actual := NewList(_resource{"api", 2}, _resource{"web", 1}, _resource{"cron", 1}, _resource{"old", 1})
+	desired := NewList(_resource{"web", 3}, _resource{"api", 2}, _resource{"worker", 4}, _resource{"cron", 1})
+	r := ReconcileBy(actual, desired, _resourceName, nil)
+	assert.Equal(t, []_resource{{"worker", 4}}, r.ToCreate.ToArray())
+	assert.Equal(t, []_resource{{"web", 3}}, r.ToUpdate.ToArray())
+	assert.Equal(t, []_resource{{"old", 1}}, r.ToDelete.ToArray())
+	assert.False(t, r.IsEmpty())
+
+	assert.True(t, ReconcileBy(actual, actual, _resourceName, nil).IsEmpty())
+}
+
+func TestReconcileBy_Equal(t *testing.T) {
+	actual := NewList(_resource{"api", 2})
+	desired := NewList(_resource{"api", 3})
+	r := ReconcileBy(actual, desired, _resourceName, func(old, new _resource) bool {
+		return new.Replicas <= old.Replicas+1
+	})
+	assert.True(t, r.IsEmpty())
+}
+
+func TestReconcileBy_DuplicateKeys(t *testing.T) {
+	actual := NewList(_resource{"a", 1}, _resource{"gone", 1}, _resource{"a", 2}, _resource{"gone", 2})
+	desired := NewList(_resource{"a", 5}, _resource{"b", 1}, _resource{"a", 2}, _resource{"b", 2})
+	r := ReconcileBy(actual, desired, _resourceName, nil)
+	assert.Equal(t, []_resource{{"b", 2}}, r.ToCreate.ToArray())
+	assert.True(t, r.ToUpdate.IsEmpty())
+	assert.Equal(t, []_resource{{"gone", 2}}, r.ToDelete.ToArray())
+}
@@ -0,0 +1,45 @@
+package list
+
+// RollingApply computes an aggregate over every window of consecutive elements in O(n).
+// The state starts as initial and agg folds in the element entering the window; once the window is full,
+// agg also gets the element leaving it with evicted set to true, so state can be updated incrementally.
+// It returns the state of each full window, which is empty when the list is shorter than the window.
+func RollingApply[E, S any](l *List[E], window int, initial S, agg func(added, removed E, evicted bool, state S) S) *List[S] {
+	result := NewList[S]()
+	if window <= 0 || len(l.items) < window {
+		return result
+	}
+	result.items = make([]S, 0, len(l.items)-window+1)
+	state := initial
+	for i, item := range l.items {
+		if i >= window {
+			state = agg(item, l.items[i-window], true, state)
+		} else {
+			state = agg(item, *new(E), false, state)
+		}
+		if i >= window-1 {
+			result.items = append(result.items, state)
+		}
+	}
+	return result
+}
+
+// MovingSum returns the sum of the values of every window of consecutive elements
+func MovingSum[E any](l *List[E], window int, value func(item E) float64) *List[float64] {
+	return RollingApply(l, window, 0, func(added, removed E, evicted bool, sum float64) float64 {
+		sum += value(added)
+		if evicted {
+			sum -= value(removed)
+		}
+		return sum
+	})
+}
+
+// MovingAverage returns the average of the values of every window of consecutive elements
+func MovingAverage[E any](l *List[E], window int, value func(item E) float64) *List[float64] {
+	sums := MovingSum(l, window, value)
+	for i, sum := range sums.items {
+		sums.items[i] = sum / float64(window)
+	}
+	return sums
+}
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollingApply(t *testing.T) {
+	l := NewList(3, 1, 4, 1, 5, 9, 2)
+	type state struct{ count, evens int }
+	evens := RollingApply(l, 3, state{}, func(added, removed int, evicted bool, s state) state {
+		s.count++
+		if added%2 == 0 {
+			s.evens++
+		}
+		if evicted && removed%2 == 0 {
+			s.evens--
+		}
+		return s
+	})
+	var counts []int
+	evens.Each(func(_ int, s state) bool {
+		counts = append(counts, s.evens)
+		return true
+	})
+	assert.Equal(t, []int{1, 1, 1, 0, 1}, counts)
+	assert.True(t, RollingApply(l, 8, 0, func(_, _ int, _ bool, s int) int { return s }).IsEmpty())
+	assert.True(t, RollingApply(l, 0, 0, func(_, _ int, _ bool, s int) int { return s }).IsEmpty())
+}
+
+func TestMovingSum(t *testing.T) {
+	l := NewList(1, 2, 3, 4, 5)
+	value := func(v int) float64 { return float64(v) }
+	assert.Equal(t, []float64{6, 9, 12}, MovingSum(l, 3, value).ToArray())
+	assert.Equal(t, []float64{1.5, 2.5, 3.5, 4.5}, MovingAverage(l, 2, value).ToArray())
+}
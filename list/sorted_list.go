@@ -0,0 +1,239 @@
+package list
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/contract"
+)
+
+// NewSortedList new sorted list, elements are ordered by the comparator
+func NewSortedList[E any](comparator contract.Comparator[E], values ...E) *SortedList[E] {
+	instance := new(SortedList[E])
+	instance.comparator = comparator
+	instance.Push(values...)
+	return instance
+}
+
+// SortedList list which keeps its elements ordered by a comparator, equal elements keep their insertion order.
+// Lookups take O(log n) by binary search over a slice.
+// Pushing one element takes O(log n) comparisons and an O(n) copy, pushing many elements at once merges them in O(n + m log m),
+// so push in batches when loading a large list.
+type SortedList[E any] struct {
+	sync.RWMutex
+	items      []E
+	comparator contract.Comparator[E]
+}
+
+// lowerBound returns the index of the first element which is not less than the value
+func (l *SortedList[E]) lowerBound(value E) int {
+	return sort.Search(len(l.items), func(i int) bool {
+		return l.comparator.Compare(l.items[i], value) >= 0
+	})
+}
+
+// upperBound returns the index of the first element which is greater than the value
+func (l *SortedList[E]) upperBound(value E) int {
+	return sort.Search(len(l.items), func(i int) bool {
+		return l.comparator.Compare(l.items[i], value) > 0
+	})
+}
+
+// merge merges the sorted elements into the list in O(n + m)
+func (l *SortedList[E]) merge(values []E) {
+	items := make([]E, 0, len(l.items)+len(values))
+	i, j := 0, 0
+	for i < len(l.items) && j < len(values) {
+		if l.comparator.Compare(values[j], l.items[i]) < 0 {
+			items = append(items, values[j])
+			j++
+		} else {
+			items = append(items, l.items[i])
+			i++
+		}
+	}
+	items = append(items, l.items[i:]...)
+	l.items = append(items, values[j:]...)
+}
+
+// Count returns the size of the list
+func (l *SortedList[E]) Count() int64 {
+	return int64(len(l.items))
+}
+
+// IsEmpty returns whether the list is empty
+func (l *SortedList[E]) IsEmpty() bool {
+	return len(l.items) == 0
+}
+
+// IsNotEmpty returns whether the list is not empty
+func (l *SortedList[E]) IsNotEmpty() bool {
+	return !l.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (l *SortedList[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (l *SortedList[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
+// Push inserts the elements at their sorted positions, after the elements equal to them
+func (l *SortedList[E]) Push(values ...E) {
+	if len(values) == 0 {
+		return
+	}
+	if len(values) == 1 {
+		l.items = slices.Insert(l.items, l.upperBound(values[0]), values[0])
+		return
+	}
+	sorted := slices.Clone(values)
+	slices.SortStableFunc(sorted, l.comparator.Compare)
+	l.merge(sorted)
+}
+
+// IndexOf returns the index of the first element equal to the value by the comparator, or -1
+func (l *SortedList[E]) IndexOf(value E) int {
+	index := l.lowerBound(value)
+	if index < len(l.items) && l.comparator.Compare(l.items[index], value) == 0 {
+		return index
+	}
+	return -1
+}
+
+// Contains returns whether the list contains an element equal to the value by the comparator
+func (l *SortedList[E]) Contains(value E) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// Get returns the element at the index
+func (l *SortedList[E]) Get(index int) E {
+	return l.items[index]
+}
+
+// At returns the element at the index, it returns false when the index is out of range
+func (l *SortedList[E]) At(index int) (E, bool) {
+	if index < 0 || index >= len(l.items) {
+		collection.Fail(collection.NewRangeError(index, len(l.items)))
+		return *new(E), false
+	}
+	return l.items[index], true
+}
+
+// First returns the least element, it returns false when the list is empty
+func (l *SortedList[E]) First() (E, bool) {
+	if len(l.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	return l.items[0], true
+}
+
+// Last returns the greatest element, it returns false when the list is empty
+func (l *SortedList[E]) Last() (E, bool) {
+	if len(l.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	return l.items[len(l.items)-1], true
+}
+
+// Range returns a new sorted list of the elements from from inclusive to to exclusive
+func (l *SortedList[E]) Range(from, to E) *SortedList[E] {
+	start, end := l.lowerBound(from), l.lowerBound(to)
+	return &SortedList[E]{items: slices.Clone(l.items[start:max(start, end)]), comparator: l.comparator}
+}
+
+// Merge returns a new sorted list of the elements of both lists ordered by the comparator of this list,
+// the lists are merged in O(n + m) when they share the order and both lists are left unchanged
+func (l *SortedList[E]) Merge(other *SortedList[E]) *SortedList[E] {
+	merged := &SortedList[E]{items: l.items, comparator: l.comparator}
+	items := other.items
+	if !slices.IsSortedFunc(items, l.comparator.Compare) {
+		items = slices.Clone(items)
+		slices.SortStableFunc(items, l.comparator.Compare)
+	}
+	merged.merge(items)
+	return merged
+}
+
+// Remove removes the elements equal to the value by the comparator and returns the number of them
+func (l *SortedList[E]) Remove(value E) int {
+	start, end := l.lowerBound(value), l.upperBound(value)
+	l.items = slices.Delete(l.items, start, end)
+	return end - start
+}
+
+// RemoveAt removes the element at the index
+func (l *SortedList[E]) RemoveAt(index int) {
+	l.items = slices.Delete(l.items, index, index+1)
+}
+
+// Clear clears the list
+func (l *SortedList[E]) Clear() {
+	l.items = []E{}
+}
+
+// Each ranges the list in order, it will break the loop when the callback returns false
+func (l *SortedList[E]) Each(callback func(index int, value E) bool) {
+	for index, value := range l.items {
+		if !callback(index, value) {
+			return
+		}
+	}
+}
+
+// All returns a sequence of the indexes and elements in order, it stops when yield returns false
+func (l *SortedList[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		l.Each(yield)
+	}
+}
+
+// Values returns a sequence of the elements in order, it stops when yield returns false
+func (l *SortedList[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		l.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
+// ToArray converts to a sorted array, the array is a copy so it can be modified without breaking the order of the list
+func (l *SortedList[E]) ToArray() []E {
+	return slices.Clone(l.items)
+}
+
+// ToJSON converts to json
+func (l *SortedList[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(l.items)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (l *SortedList[E]) MarshalJSON() ([]byte, error) {
+	return l.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the elements are sorted by the comparator of the list
+func (l *SortedList[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	slices.SortStableFunc(items, l.comparator.Compare)
+	l.items = items
+	return nil
+}
+
+// String converts to string
+func (l *SortedList[E]) String() string {
+	return format.Values("SortedList", l.Count(), l.Values())
+}
@@ -0,0 +1,136 @@
+package list
+
+import (
+	"cmp"
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/cmpx"
+	"github.com/stretchr/testify/assert"
+)
+
+type byLength struct{}
+
+func (byLength) Compare(a, b string) int {
+	return cmp.Compare(len(a), len(b))
+}
+
+func TestSortedList_Push(t *testing.T) {
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]), 5, 1, 3)
+	l.Push(4)
+	l.Push(0, 6, 2)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, l.ToArray())
+	first, _ := l.First()
+	last, _ := l.Last()
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 6, last)
+
+	values := rand.Perm(1000)
+	l = NewSortedList[int](cmpx.Func[int](cmp.Compare[int]))
+	for _, value := range values {
+		l.Push(value)
+	}
+	assert.True(t, slices.IsSorted(l.ToArray()))
+}
+
+func TestSortedList_Push_Stable(t *testing.T) {
+	l := NewSortedList[string](byLength{}, "bb", "a", "cc")
+	l.Push("dd")
+	l.Push("e", "ff")
+	assert.Equal(t, []string{"a", "e", "bb", "cc", "dd", "ff"}, l.ToArray())
+}
+
+func TestSortedList_IndexOf(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]), 1, 3, 3, 5)
+	assert.Equal(t, 1, l.IndexOf(3))
+	assert.Equal(t, -1, l.IndexOf(4))
+	assert.Equal(t, -1, l.IndexOf(9))
+	assert.True(t, l.Contains(5))
+	assert.False(t, l.Contains(0))
+	assert.Equal(t, 2, l.Remove(3))
+	assert.Equal(t, 0, l.Remove(3))
+	l.RemoveAt(0)
+	assert.Equal(t, []int{5}, l.ToArray())
+	_, ok := l.At(1)
+	assert.False(t, ok)
+}
+
+func TestSortedList_Strict(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(true)
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]))
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { l.First() })
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { l.Last() })
+	assert.Panics(t, func() { l.At(0) })
+	l.Push(3, 1, 2)
+	first, _ := l.First()
+	last, _ := l.Last()
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 3, last)
+	assert.Panics(t, func() { l.At(-1) })
+	assert.Panics(t, func() { l.At(3) })
+	collection.SetStrict(false)
+	_, ok := l.At(3)
+	assert.False(t, ok)
+	l.Clear()
+	_, ok = l.Last()
+	assert.False(t, ok)
+}
+
+func TestSortedList_All(t *testing.T) {
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]), 3, 1, 2)
+	var indexes, items []int
+	l.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []int{1, 2}, items)
+
+	items = nil
+	l.Values()(func(value int) bool {
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, items)
+}
+
+func TestSortedList_Range(t *testing.T) {
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]), 1, 2, 4, 4, 6, 8)
+	assert.Equal(t, []int{2, 4, 4}, l.Range(2, 6).ToArray())
+	assert.Equal(t, []int{4, 4, 6, 8}, l.Range(3, 100).ToArray())
+	assert.True(t, l.Range(6, 2).IsEmpty())
+}
+
+func TestSortedList_Merge(t *testing.T) {
+	compare := cmpx.Func[int](cmp.Compare[int])
+	a := NewSortedList[int](compare, 1, 4, 7)
+	b := NewSortedList[int](compare, 2, 4, 9)
+	assert.Equal(t, []int{1, 2, 4, 4, 7, 9}, a.Merge(b).ToArray())
+	c := NewSortedList[int](compare.Reversed(), 3, 8)
+	assert.Equal(t, []int{1, 3, 4, 7, 8}, a.Merge(c).ToArray())
+	assert.Equal(t, []int{1, 4, 7}, a.ToArray())
+}
+
+func TestSortedList_ToJSON(t *testing.T) {
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]), 3, 1, 2)
+	data, err := json.Marshal(l)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(data))
+	assert.Nil(t, json.Unmarshal([]byte("[9,7,8]"), l))
+	assert.Equal(t, []int{7, 8, 9}, l.ToArray())
+	assert.Equal(t, "SortedList[int](len=3){\n\t7,\n\t8,\n\t9,\n}", l.String())
+}
+
+func BenchmarkSortedList_Push(b *testing.B) {
+	l := NewSortedList[int](cmpx.Func[int](cmp.Compare[int]))
+	for i := 0; i < b.N; i++ {
+		l.Push(rand.IntN(1 << 20))
+	}
+}
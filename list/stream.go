@@ -0,0 +1,227 @@
+package list
+
+import (
+	"slices"
+
+	"github.com/gopi-frame/collection/internal/equal"
+)
+
+// StreamOf new stream of the values
+func StreamOf[E any](values ...E) *Stream[E] {
+	return &Stream[E]{seq: func(yield func(value E) bool) {
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}}
+}
+
+// Stream returns a lazy stream of the elements of the list,
+// the list is read when a terminal operation runs rather than when the stream is built
+func (list *List[E]) Stream() *Stream[E] {
+	return &Stream[E]{seq: list.Values()}
+}
+
+// Stream lazily evaluated pipeline of operations over a sequence of elements.
+// The intermediate operations such as Filter, Map, Skip and Take only chain the stages,
+// the elements are pulled through all the stages one at a time by a terminal operation
+// such as ToList, Count, AnyMatch or First, which stop reading the source as soon as they have their result.
+// Only Sorted buffers the elements, every terminal operation evaluates the pipeline again from the source.
+type Stream[E any] struct {
+	seq func(yield func(value E) bool)
+}
+
+// then new stream which pulls the elements of the stream through the stage
+func (s *Stream[E]) then(stage func(yield func(value E) bool) func(value E) bool) *Stream[E] {
+	return &Stream[E]{seq: func(yield func(value E) bool) {
+		s.seq(stage(yield))
+	}}
+}
+
+// Filter keeps the elements which match the predicate
+func (s *Stream[E]) Filter(predicate func(value E) bool) *Stream[E] {
+	return s.then(func(yield func(value E) bool) func(value E) bool {
+		return func(value E) bool {
+			return !predicate(value) || yield(value)
+		}
+	})
+}
+
+// Map replaces the elements with the results of the mapper, use [MapStream] to change the element type
+func (s *Stream[E]) Map(mapper func(value E) E) *Stream[E] {
+	return MapStream(s, mapper)
+}
+
+// Peek calls the callback with every element which passes the stage, such as to log the elements
+func (s *Stream[E]) Peek(callback func(value E)) *Stream[E] {
+	return s.then(func(yield func(value E) bool) func(value E) bool {
+		return func(value E) bool {
+			callback(value)
+			return yield(value)
+		}
+	})
+}
+
+// Skip drops the first n elements
+func (s *Stream[E]) Skip(n int) *Stream[E] {
+	return s.then(func(yield func(value E) bool) func(value E) bool {
+		skipped := 0
+		return func(value E) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(value)
+		}
+	})
+}
+
+// Take keeps the first n elements, the source is not read further once they are taken
+func (s *Stream[E]) Take(n int) *Stream[E] {
+	return &Stream[E]{seq: func(yield func(value E) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		s.seq(func(value E) bool {
+			taken++
+			return yield(value) && taken < n
+		})
+	}}
+}
+
+// Distinct keeps the first of the equal elements, elements are compared like [List.Contains].
+// It compares every element with the ones kept so far, use [DistinctStream] with a comparable key for long streams.
+func (s *Stream[E]) Distinct() *Stream[E] {
+	return s.then(func(yield func(value E) bool) func(value E) bool {
+		var seen []E
+		return func(value E) bool {
+			if slices.ContainsFunc(seen, func(e E) bool { return equal.Equal(e, value) }) {
+				return true
+			}
+			seen = append(seen, value)
+			return yield(value)
+		}
+	})
+}
+
+// Sorted sorts the elements by the callback, it buffers all the elements of the previous stages
+func (s *Stream[E]) Sorted(callback func(a, b E) int) *Stream[E] {
+	return &Stream[E]{seq: func(yield func(value E) bool) {
+		values := s.ToArray()
+		slices.SortStableFunc(values, callback)
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}}
+}
+
+// All returns a sequence of the indexes and elements of the stream, it stops when yield returns false
+func (s *Stream[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		index := 0
+		s.seq(func(value E) bool {
+			if !yield(index, value) {
+				return false
+			}
+			index++
+			return true
+		})
+	}
+}
+
+// Values returns a sequence of the elements of the stream, it stops when yield returns false
+func (s *Stream[E]) Values() func(yield func(value E) bool) {
+	return s.seq
+}
+
+// Each calls the callback with every element, it will break the loop when the callback returns false
+func (s *Stream[E]) Each(callback func(value E) bool) {
+	s.seq(callback)
+}
+
+// ToArray collects the elements into an array
+func (s *Stream[E]) ToArray() []E {
+	values := []E{}
+	s.seq(func(value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// ToList collects the elements into a new list
+func (s *Stream[E]) ToList() *List[E] {
+	return &List[E]{items: s.ToArray()}
+}
+
+// Count returns the number of elements
+func (s *Stream[E]) Count() int64 {
+	var count int64
+	s.seq(func(E) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// AnyMatch returns whether any element matches the predicate, it stops at the first match
+func (s *Stream[E]) AnyMatch(predicate func(value E) bool) bool {
+	_, ok := s.Filter(predicate).First()
+	return ok
+}
+
+// AllMatch returns whether every element matches the predicate, it stops at the first mismatch
+func (s *Stream[E]) AllMatch(predicate func(value E) bool) bool {
+	return !s.AnyMatch(func(value E) bool {
+		return !predicate(value)
+	})
+}
+
+// First returns the first element
+func (s *Stream[E]) First() (E, bool) {
+	var first E
+	var ok bool
+	s.seq(func(value E) bool {
+		first, ok = value, true
+		return false
+	})
+	return first, ok
+}
+
+// MapStream new stream of the elements of the stream converted with the mapper
+func MapStream[E, R any](s *Stream[E], mapper func(value E) R) *Stream[R] {
+	return &Stream[R]{seq: func(yield func(value R) bool) {
+		s.seq(func(value E) bool {
+			return yield(mapper(value))
+		})
+	}}
+}
+
+// DistinctStream new stream which keeps the first element of every key
+func DistinctStream[E any, K comparable](s *Stream[E], key func(value E) K) *Stream[E] {
+	return s.then(func(yield func(value E) bool) func(value E) bool {
+		seen := make(map[K]struct{})
+		return func(value E) bool {
+			k := key(value)
+			if _, ok := seen[k]; ok {
+				return true
+			}
+			seen[k] = struct{}{}
+			return yield(value)
+		}
+	})
+}
+
+// ReduceStream folds the elements of the stream into the accumulator, starting with the initial value
+func ReduceStream[E, R any](s *Stream[E], initial R, reducer func(acc R, value E) R) R {
+	acc := initial
+	s.seq(func(value E) bool {
+		acc = reducer(acc, value)
+		return true
+	})
+	return acc
+}
@@ -0,0 +1,70 @@
+package list
+
+import (
+	"cmp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_Stream(t *testing.T) {
+	l := NewList(5, 3, 8, 1, 3, 9, 2)
+	s := l.Stream().Filter(func(value int) bool { return value > 1 }).Distinct().Sorted(cmp.Compare[int])
+	assert.Equal(t, []int{2, 3, 5, 8, 9}, s.ToArray())
+
+	l.Push(4)
+	assert.Equal(t, []int{2, 3, 4, 5, 8, 9}, s.ToList().ToArray())
+	assert.Equal(t, int64(6), s.Count())
+}
+
+func TestStream_Lazy(t *testing.T) {
+	var pulled []int
+	s := StreamOf(1, 2, 3, 4, 5, 6).Peek(func(value int) { pulled = append(pulled, value) })
+	s = s.Skip(1).Map(func(value int) int { return value * 10 }).Take(2)
+	assert.Empty(t, pulled)
+	assert.Equal(t, []int{20, 30}, s.ToArray())
+	assert.Equal(t, []int{1, 2, 3}, pulled)
+
+	pulled = nil
+	first, ok := s.First()
+	assert.True(t, ok)
+	assert.Equal(t, 20, first)
+	assert.Equal(t, []int{1, 2}, pulled)
+}
+
+func TestStream_All(t *testing.T) {
+	s := StreamOf(5, 6, 7).Filter(func(value int) bool { return value != 6 })
+	var indexes, items []int
+	s.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []int{5, 7}, items)
+
+	items = nil
+	s.Values()(func(value int) bool {
+		items = append(items, value)
+		return false
+	})
+	assert.Equal(t, []int{5}, items)
+}
+
+func TestStream_Match(t *testing.T) {
+	s := StreamOf(2, 4, 5)
+	assert.True(t, s.AnyMatch(func(value int) bool { return value%2 == 1 }))
+	assert.False(t, s.AllMatch(func(value int) bool { return value%2 == 0 }))
+	assert.True(t, StreamOf[int]().AllMatch(func(int) bool { return false }))
+	_, ok := StreamOf[int]().First()
+	assert.False(t, ok)
+	assert.Empty(t, s.Take(0).ToArray())
+}
+
+func TestMapStream(t *testing.T) {
+	s := MapStream(StreamOf(1, 22, 3), strconv.Itoa)
+	assert.Equal(t, []string{"1", "22", "3"}, s.ToArray())
+	assert.Equal(t, []string{"1", "22"}, DistinctStream(s, func(value string) int { return len(value) }).ToArray())
+	assert.Equal(t, 26, ReduceStream(StreamOf(1, 22, 3), 0, func(acc, value int) int { return acc + value }))
+}
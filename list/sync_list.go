@@ -0,0 +1,359 @@
+package list
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+)
+
+// NewSyncList new synchronized list, the zero value is an empty list ready to use
+func NewSyncList[E any](values ...E) *SyncList[E] {
+	return &SyncList[E]{items: NewList(values...)}
+}
+
+// SyncList list which is safe for concurrent use.
+// Unlike [List], every read method takes the read lock and every mutating method takes the lock,
+// use WithLock or WithList to run several operations atomically.
+// Callbacks passed to its methods are called while holding the lock, so they must not call the list.
+type SyncList[E any] struct {
+	init  sync.Once
+	items *List[E]
+}
+
+// backing returns the underlying list, allocating it on first use of a zero value
+func (list *SyncList[E]) backing() *List[E] {
+	list.init.Do(func() {
+		if list.items == nil {
+			list.items = NewList[E]()
+		}
+	})
+	return list.items
+}
+
+// WithLock calls the callback with the backing elements while holding the lock.
+// The elements may be read and overwritten in place, they must not be retained after the callback returns.
+func (list *SyncList[E]) WithLock(callback func(items []E)) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	callback(items.items)
+}
+
+// WithList calls the callback with the underlying unsynchronized list while holding the lock,
+// so elements can be added and removed. The list must not be retained after the callback returns.
+func (list *SyncList[E]) WithList(callback func(items *List[E])) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	callback(items)
+}
+
+// OnRemove registers a callback which is called with every element leaving the list, see [List.OnRemove]
+func (list *SyncList[E]) OnRemove(callback func(value E)) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.OnRemove(callback)
+}
+
+// Count returns the size of the list
+func (list *SyncList[E]) Count() int64 {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.Count()
+}
+
+// IsEmpty returns whether the list is empty
+func (list *SyncList[E]) IsEmpty() bool {
+	return list.Count() == 0
+}
+
+// IsNotEmpty returns whether the list is not empty
+func (list *SyncList[E]) IsNotEmpty() bool {
+	return !list.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (list *SyncList[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (list *SyncList[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
+// Contains returns whether the list contains the specific element, elements are compared with [reflect.DeepEqual]
+func (list *SyncList[E]) Contains(value E) bool {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.Contains(value)
+}
+
+// ContainsWhere returns whether the list contains specific elements by callback
+func (list *SyncList[E]) ContainsWhere(callback func(value E) bool) bool {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.ContainsWhere(callback)
+}
+
+// Push pushes elements into the list
+func (list *SyncList[E]) Push(values ...E) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.Push(values...)
+}
+
+// Unshift puts elements to the head of the list
+func (list *SyncList[E]) Unshift(values ...E) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.Unshift(values...)
+}
+
+// Pop removes the last element of the list and returns it.
+// It will return a zero value and false when the list is empty.
+func (list *SyncList[E]) Pop() (E, bool) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.Pop()
+}
+
+// Shift removes the first element of the list and returns it.
+// It will return a zero value and false when the list is empty.
+func (list *SyncList[E]) Shift() (E, bool) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.Shift()
+}
+
+// Remove removes the specific element, elements are compared with [reflect.DeepEqual]
+func (list *SyncList[E]) Remove(value E) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.Remove(value)
+}
+
+// RemoveWhere removes specific elements by callback
+func (list *SyncList[E]) RemoveWhere(callback func(item E) bool) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.RemoveWhere(callback)
+}
+
+// TryRemoveAt removes the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *SyncList[E]) TryRemoveAt(index int) error {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.TryRemoveAt(index)
+}
+
+// Clear clears the list
+func (list *SyncList[E]) Clear() {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.Clear()
+}
+
+// At returns the element on the specific index, a negative index counts back from the end of the list.
+// It will return a zero value and false when the index is out of range.
+func (list *SyncList[E]) At(index int) (E, bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.At(index)
+}
+
+// SetAt sets element on the specific index, a negative index counts back from the end of the list.
+// It returns false when the index is out of range.
+func (list *SyncList[E]) SetAt(index int, value E) bool {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.SetAt(index, value)
+}
+
+// TryGet returns the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *SyncList[E]) TryGet(index int) (E, error) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.TryGet(index)
+}
+
+// TrySet sets element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (list *SyncList[E]) TrySet(index int, value E) error {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.TrySet(index, value)
+}
+
+// First returns the first element of the list.
+// It will return a zero value and false when the list is empty.
+func (list *SyncList[E]) First() (E, bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.First()
+}
+
+// Last returns the last element of the list.
+// It will return a zero value and false when the list is empty.
+func (list *SyncList[E]) Last() (E, bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.Last()
+}
+
+// FirstWhere returns the first element of the list which matches the callback.
+// It will return a zero value and false when none matches the callback.
+func (list *SyncList[E]) FirstWhere(callback func(item E) bool) (E, bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.FirstWhere(callback)
+}
+
+// LastWhere returns the last element of the list which matches the callback.
+// It will return a zero value and false when none matches the callback.
+func (list *SyncList[E]) LastWhere(callback func(item E) bool) (E, bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.LastWhere(callback)
+}
+
+// IndexOf returns the index of the specific element, elements are compared with [reflect.DeepEqual]
+func (list *SyncList[E]) IndexOf(value E) int {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.IndexOf(value)
+}
+
+// IndexOfWhere returns the index of the first element which matches the callback
+func (list *SyncList[E]) IndexOfWhere(callback func(item E) bool) int {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.IndexOfWhere(callback)
+}
+
+// Where returns an unsynchronized list of the elements which match the callback
+func (list *SyncList[E]) Where(callback func(item E) bool) *List[E] {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.Where(callback)
+}
+
+// Sort sorts the list by callback
+func (list *SyncList[E]) Sort(callback func(a, b E) int) {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	items.Sort(callback)
+}
+
+// Each ranges the list by callback while holding the read lock, it will break the loop when the callback returns false
+func (list *SyncList[E]) Each(callback func(index int, value E) bool) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	items.Each(callback)
+}
+
+// All returns a sequence of the indexes and elements of a copy taken under the read lock,
+// so the list can be modified while it is ranged
+func (list *SyncList[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for index, value := range list.ToArray() {
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the elements of a copy taken under the read lock,
+// so the list can be modified while it is ranged
+func (list *SyncList[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, value := range list.ToArray() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns an unsynchronized copy of the list
+func (list *SyncList[E]) Clone() *List[E] {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return &List[E]{items: slices.Clone(items.items)}
+}
+
+// ToArray returns a copy of the elements
+func (list *SyncList[E]) ToArray() []E {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return slices.Clone(items.items)
+}
+
+// ToJSON converts to json
+func (list *SyncList[E]) ToJSON() ([]byte, error) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.ToJSON()
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the list is not changed
+func (list *SyncList[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return items.ToJSONSorted(callback)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (list *SyncList[E]) MarshalJSON() ([]byte, error) {
+	return list.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (list *SyncList[E]) UnmarshalJSON(data []byte) error {
+	items := list.backing()
+	items.Lock()
+	defer items.Unlock()
+	return items.UnmarshalJSON(data)
+}
+
+// String converts to string
+func (list *SyncList[E]) String() string {
+	items := list.backing()
+	items.RLock()
+	defer items.RUnlock()
+	return "Sync" + items.String()
+}
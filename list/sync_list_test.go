@@ -0,0 +1,164 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncList_Push(t *testing.T) {
+	list := NewSyncList[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				list.Push(j)
+				list.Count()
+				list.Contains(j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1000), list.Count())
+}
+
+func TestSyncList_ZeroValue(t *testing.T) {
+	var list SyncList[int]
+	assert.True(t, list.IsEmpty())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list.Push(i)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(10), list.Count())
+
+	var decoded SyncList[int]
+	assert.Nil(t, json.Unmarshal([]byte(`[1,2,3]`), &decoded))
+	assert.Equal(t, []int{1, 2, 3}, decoded.ToArray())
+}
+
+func TestSyncList_Pop(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	value, ok := list.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	value, ok = list.Shift()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	list.Unshift(0)
+	assert.Equal(t, []int{0, 2}, list.ToArray())
+}
+
+func TestSyncList_RemoveWhere(t *testing.T) {
+	list := NewSyncList(1, 2, 3, 4)
+	var removed []int
+	list.OnRemove(func(value int) {
+		removed = append(removed, value)
+	})
+	list.RemoveWhere(func(item int) bool {
+		return item%2 == 0
+	})
+	assert.Equal(t, []int{1, 3}, list.ToArray())
+	assert.Equal(t, []int{2, 4}, removed)
+	assert.ErrorIs(t, list.TryRemoveAt(5), collection.ErrIndexOutOfRange)
+	list.Clear()
+	assert.True(t, list.IsEmpty())
+}
+
+func TestSyncList_TryGet(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	value, err := list.TryGet(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+	assert.Nil(t, list.TrySet(1, 5))
+	value, ok := list.At(-2)
+	assert.True(t, ok)
+	assert.Equal(t, 5, value)
+	_, err = list.TryGet(3)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+}
+
+func TestSyncList_WithLock(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list.WithLock(func(items []int) {
+				for index := range items {
+					items[index]++
+				}
+			})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []int{11, 12, 13}, list.ToArray())
+}
+
+func TestSyncList_WithList(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	list.WithList(func(items *List[int]) {
+		if items.Count() < 4 {
+			items.Push(4)
+		}
+	})
+	assert.Equal(t, int64(4), list.Count())
+}
+
+func TestSyncList_Clone(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	clone := list.Clone()
+	clone.Set(0, 5)
+	items := list.ToArray()
+	items[1] = 5
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+	assert.Equal(t, []int{2}, list.Where(func(item int) bool { return item == 2 }).ToArray())
+}
+
+func TestSyncList_All(t *testing.T) {
+	list := NewSyncList(1, 2, 3)
+	var indexes, items []int
+	list.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		list.Push(value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Equal(t, int64(6), list.Count())
+
+	items = nil
+	list.Values()(func(value int) bool {
+		items = append(items, value)
+		list.Clear()
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, items)
+}
+
+func TestSyncList_UnmarshalJSON(t *testing.T) {
+	list := NewSyncList[int]()
+	assert.Nil(t, json.Unmarshal([]byte(`[1,2,3]`), list))
+	data, err := json.Marshal(list)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[1,2,3]`, string(data))
+}
+
+func TestSyncList_String(t *testing.T) {
+	list := NewSyncList(1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`SyncList\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t(\.){3}\n\}`, list.Count()))
+	assert.True(t, pattern.MatchString(list.String()))
+}
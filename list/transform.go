@@ -0,0 +1,52 @@
+package list
+
+// Pair pair of elements zipped by [Zip]
+type Pair[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+// Map new list of the elements converted with the mapper, in the order of the list
+func Map[E, R any](l *List[E], mapper func(value E) R) *List[R] {
+	return &List[R]{items: MapTo(l, mapper)}
+}
+
+// FlatMap new list of the elements returned by the mapper for each element, in the order of the list
+func FlatMap[E, R any](l *List[E], mapper func(value E) []R) *List[R] {
+	result := new(List[R])
+	for _, item := range l.items {
+		result.items = append(result.items, mapper(item)...)
+	}
+	return result
+}
+
+// Reduce folds the elements from first to last into the accumulator, starting with the initial value
+func Reduce[E, R any](l *List[E], initial R, reducer func(acc R, value E) R) R {
+	acc := initial
+	for _, item := range l.items {
+		acc = reducer(acc, item)
+	}
+	return acc
+}
+
+// Partition splits the list into the elements which match the callback and the others, both keep their order
+func Partition[E any](l *List[E], callback func(value E) bool) (*List[E], *List[E]) {
+	matched, rest := NewList[E](), NewList[E]()
+	for _, item := range l.items {
+		if callback(item) {
+			matched.items = append(matched.items, item)
+		} else {
+			rest.items = append(rest.items, item)
+		}
+	}
+	return matched, rest
+}
+
+// Zip new list of the pairs of the elements at the same index, it is as long as the shorter list
+func Zip[A, B any](a *List[A], b *List[B]) *List[Pair[A, B]] {
+	result := &List[Pair[A, B]]{items: make([]Pair[A, B], min(len(a.items), len(b.items)))}
+	for index := range result.items {
+		result.items[index] = Pair[A, B]{First: a.items[index], Second: b.items[index]}
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	l := NewList(1, 2, 3)
+	assert.Equal(t, []string{"1", "2", "3"}, Map(l, strconv.Itoa).ToArray())
+	assert.True(t, Map(NewList[int](), strconv.Itoa).IsEmpty())
+}
+
+func TestFlatMap(t *testing.T) {
+	l := NewList("a b", "", "c")
+	assert.Equal(t, []string{"a", "b", "c"}, FlatMap(l, strings.Fields).ToArray())
+}
+
+func TestReduce(t *testing.T) {
+	l := NewList(1, 2, 3)
+	assert.Equal(t, 6, Reduce(l, 0, func(acc int, value int) int { return acc + value }))
+	assert.Equal(t, "123", Reduce(l, "", func(acc string, value int) string { return acc + strconv.Itoa(value) }))
+}
+
+func TestPartition(t *testing.T) {
+	even, odd := Partition(NewList(1, 2, 3, 4, 5), func(value int) bool { return value%2 == 0 })
+	assert.Equal(t, []int{2, 4}, even.ToArray())
+	assert.Equal(t, []int{1, 3, 5}, odd.ToArray())
+}
+
+func TestZip(t *testing.T) {
+	zipped := Zip(NewList(1, 2, 3), NewList("a", "b"))
+	assert.Equal(t, []Pair[int, string]{{1, "a"}, {2, "b"}}, zipped.ToArray())
+}
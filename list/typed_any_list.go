@@ -0,0 +1,199 @@
+//go:build !tinygo && !collection_noreflect
+
+package list
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/contract"
+)
+
+// NewTypedAnyList new list of values of the allowed types, a value is allowed when its type is assignable
+// to one of them, so an interface type allows every implementation
+func NewTypedAnyList(allowed ...reflect.Type) *TypedAnyList {
+	instance := new(TypedAnyList)
+	instance.allowed = allowed
+	return instance
+}
+
+// Allow allows the values of type T in the list
+func Allow[T any](l *TypedAnyList) {
+	l.allowed = append(l.allowed, reflect.TypeFor[T]())
+}
+
+// TypedAnyList list of mixed types checked at run time against the allowed types,
+// such as the stages of a plugin pipeline
+type TypedAnyList struct {
+	sync.RWMutex
+	allowed []reflect.Type
+	items   []any
+}
+
+// Types returns the allowed types
+func (l *TypedAnyList) Types() []reflect.Type {
+	return l.allowed
+}
+
+// Check returns a [*collection.TypeError] when the type of the value is not allowed
+func (l *TypedAnyList) Check(value any) error {
+	if value != nil {
+		t := reflect.TypeOf(value)
+		for _, allowed := range l.allowed {
+			if t.AssignableTo(allowed) {
+				return nil
+			}
+		}
+	}
+	names := make([]string, len(l.allowed))
+	for i, allowed := range l.allowed {
+		names[i] = allowed.String()
+	}
+	return collection.NewTypeError(value, "one of ["+strings.Join(names, ", ")+"]")
+}
+
+// Count returns the size of the list
+func (l *TypedAnyList) Count() int64 {
+	return int64(len(l.items))
+}
+
+// IsEmpty returns whether the list is empty.
+func (l *TypedAnyList) IsEmpty() bool {
+	return l.Count() == 0
+}
+
+// IsNotEmpty returns whether the list is not empty.
+func (l *TypedAnyList) IsNotEmpty() bool {
+	return !l.IsEmpty()
+}
+
+// Push pushes values into the list.
+// It returns a [*collection.TypeError] and pushes none of them when the type of a value is not allowed.
+func (l *TypedAnyList) Push(values ...any) error {
+	for _, value := range values {
+		if err := l.Check(value); err != nil {
+			return err
+		}
+	}
+	l.items = append(l.items, values...)
+	return nil
+}
+
+// Get returns the value on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *TypedAnyList) Get(index int) (any, error) {
+	if index < 0 || index >= len(l.items) {
+		return nil, collection.NewRangeError(index, len(l.items))
+	}
+	return l.items[index], nil
+}
+
+// GetAs returns the value on the specific index as a T.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range,
+// and a [*collection.TypeError] when the value is not a T.
+func GetAs[T any](l *TypedAnyList, index int) (T, error) {
+	value, err := l.Get(index)
+	if err != nil {
+		return *new(T), err
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return *new(T), collection.NewTypeError(value, reflect.TypeFor[T]().String())
+	}
+	return typed, nil
+}
+
+// OfType returns the values which are a T in order
+func OfType[T any](l *TypedAnyList) []T {
+	var values []T
+	for _, value := range l.items {
+		if typed, ok := value.(T); ok {
+			values = append(values, typed)
+		}
+	}
+	return values
+}
+
+// Set sets the value on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range,
+// and a [*collection.TypeError] when the type of the value is not allowed.
+func (l *TypedAnyList) Set(index int, value any) error {
+	if index < 0 || index >= len(l.items) {
+		return collection.NewRangeError(index, len(l.items))
+	}
+	if err := l.Check(value); err != nil {
+		return err
+	}
+	l.items[index] = value
+	return nil
+}
+
+// RemoveAt removes the value on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *TypedAnyList) RemoveAt(index int) error {
+	if index < 0 || index >= len(l.items) {
+		return collection.NewRangeError(index, len(l.items))
+	}
+	l.items = slices.Delete(l.items, index, index+1)
+	return nil
+}
+
+// Clear clears the list.
+func (l *TypedAnyList) Clear() {
+	l.items = nil
+}
+
+// Each travers the list, if the callback returns false then break
+func (l *TypedAnyList) Each(callback func(index int, value any) bool) {
+	for index, value := range l.items {
+		if !callback(index, value) {
+			break
+		}
+	}
+}
+
+// ToArray converts to array, the array is a copy of the elements
+func (l *TypedAnyList) ToArray() []any {
+	return slices.Clone(l.items)
+}
+
+// ToJSON converts to json, the list cannot be unmarshalled since the types of the values are lost
+func (l *TypedAnyList) ToJSON() ([]byte, error) {
+	return jsonx.Array(l.items)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (l *TypedAnyList) MarshalJSON() ([]byte, error) {
+	return l.ToJSON()
+}
+
+// String convert to string
+func (l *TypedAnyList) String() string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("TypedAnyList(len=%d)", len(l.items)))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	for index, value := range l.items {
+		str.WriteByte('\t')
+		if v, ok := value.(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		if index >= 4 {
+			break
+		}
+	}
+	if len(l.items) > 5 {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
@@ -0,0 +1,78 @@
+//go:build !tinygo && !collection_noreflect
+
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+type _stage interface {
+	Name() string
+}
+
+type _parse struct{}
+
+func (_parse) Name() string { return "parse" }
+
+func TestTypedAnyList_Push(t *testing.T) {
+	l := NewTypedAnyList(reflect.TypeFor[int]())
+	Allow[_stage](l)
+	assert.Nil(t, l.Push(1, _parse{}))
+	err := l.Push(2, "three")
+	assert.ErrorIs(t, err, collection.ErrTypeMismatch)
+	assert.Equal(t, "collection: value of type string is not one of [int, list._stage]", err.Error())
+	assert.ErrorIs(t, l.Push(nil), collection.ErrTypeMismatch)
+	assert.Equal(t, []any{1, _parse{}}, l.ToArray())
+	assert.Len(t, l.Types(), 2)
+}
+
+func TestTypedAnyList_Set(t *testing.T) {
+	l := NewTypedAnyList(reflect.TypeFor[int]())
+	assert.Nil(t, l.Push(1))
+	assert.Nil(t, l.Set(0, 2))
+	assert.ErrorIs(t, l.Set(0, "a"), collection.ErrTypeMismatch)
+	assert.ErrorIs(t, l.Set(1, 3), collection.ErrIndexOutOfRange)
+	value, err := l.Get(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestGetAs(t *testing.T) {
+	l := NewTypedAnyList(reflect.TypeFor[int](), reflect.TypeFor[_stage]())
+	assert.Nil(t, l.Push(1, _parse{}))
+	stage, err := GetAs[_stage](l, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "parse", stage.Name())
+	_, err = GetAs[string](l, 0)
+	assert.ErrorIs(t, err, collection.ErrTypeMismatch)
+	_, err = GetAs[int](l, 2)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	assert.Equal(t, []int{1}, OfType[int](l))
+}
+
+func TestTypedAnyList_RemoveAt(t *testing.T) {
+	l := NewTypedAnyList(reflect.TypeFor[int]())
+	assert.Nil(t, l.Push(1, 2, 3))
+	assert.Nil(t, l.RemoveAt(1))
+	assert.ErrorIs(t, l.RemoveAt(2), collection.ErrIndexOutOfRange)
+	assert.Equal(t, []any{1, 3}, l.ToArray())
+	l.Clear()
+	assert.True(t, l.IsEmpty())
+}
+
+func TestTypedAnyList_String(t *testing.T) {
+	l := NewTypedAnyList(reflect.TypeFor[int]())
+	assert.Nil(t, l.Push(1, 2, 3, 4, 5, 6))
+	pattern := regexp.MustCompile(fmt.Sprintf(`TypedAnyList\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, l.Count()))
+	assert.True(t, pattern.MatchString(l.String()))
+	data, err := json.Marshal(l)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3,4,5,6]", string(data))
+}
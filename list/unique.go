@@ -0,0 +1,29 @@
+package list
+
+// Merged elements merged from several sources
+type Merged[T any] struct {
+	// Unique first element of every key in the order of the sources
+	Unique *List[T]
+	// Duplicates elements of each source whose key was seen before, indexed like the sources
+	Duplicates []*List[T]
+}
+
+// UniqueAcross merges the elements of the sources, e.g. lists, queues or sets, keeping the first element of every key.
+// The elements dropped from each source are reported, which tells which provider repeated which element.
+func UniqueAcross[T any, K comparable](key func(value T) K, sources ...interface{ ToArray() []T }) *Merged[T] {
+	result := &Merged[T]{Unique: NewList[T](), Duplicates: make([]*List[T], len(sources))}
+	seen := make(map[K]struct{})
+	for index, source := range sources {
+		result.Duplicates[index] = NewList[T]()
+		for _, item := range source.ToArray() {
+			k := key(item)
+			if _, ok := seen[k]; ok {
+				result.Duplicates[index].Push(item)
+				continue
+			}
+			seen[k] = struct{}{}
+			result.Unique.Push(item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,23 @@
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueAcross(t *testing.T) {
+	first := NewList("a", "B", "c", "b")
+	second := NewLinkedList("C", "d")
+	merged := UniqueAcross(strings.ToLower, first, second, NewList[string]())
+	assert.Equal(t, []string{"a", "B", "c", "d"}, merged.Unique.ToArray())
+	assert.Len(t, merged.Duplicates, 3)
+	assert.Equal(t, []string{"b"}, merged.Duplicates[0].ToArray())
+	assert.Equal(t, []string{"C"}, merged.Duplicates[1].ToArray())
+	assert.True(t, merged.Duplicates[2].IsEmpty())
+
+	merged = UniqueAcross[string](strings.ToLower)
+	assert.True(t, merged.Unique.IsEmpty())
+	assert.Empty(t, merged.Duplicates)
+}
@@ -0,0 +1,546 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/arena"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+)
+
+// DefaultBlockSize block size of an unrolled list created with a non-positive block size
+const DefaultBlockSize = 128
+
+// NewUnrolledList new unrolled list storing at most blockSize elements per block
+func NewUnrolledList[E any](blockSize int, values ...E) *UnrolledList[E] {
+	if blockSize <= 0 {
+		blockSize = DefaultBlockSize
+	}
+	instance := new(UnrolledList[E])
+	instance.blockSize = blockSize
+	instance.Push(values...)
+	return instance
+}
+
+// NewUnrolledListIn new unrolled list whose blocks are made by the allocator, such as a request-scoped arena.
+// Free drops the list together with the allocator.
+func NewUnrolledListIn[E any](allocator arena.Allocator[E], blockSize int, values ...E) *UnrolledList[E] {
+	instance := NewUnrolledList[E](blockSize)
+	instance.allocator = allocator
+	instance.Push(values...)
+	return instance
+}
+
+// UnrolledList list storing elements in fixed-size blocks.
+// Inserting and removing in the middle only moves the elements of one block,
+// and growing never copies the whole list, which suits very long sequences.
+// Indexing walks the blocks, so it costs O(n/blockSize).
+type UnrolledList[E any] struct {
+	sync.RWMutex
+	blocks    [][]E
+	size      int
+	blockSize int
+	allocator arena.Allocator[E]
+}
+
+// BlockSize returns the maximum number of elements per block
+func (l *UnrolledList[E]) BlockSize() int {
+	return l.blockSize
+}
+
+// Blocks returns the number of blocks
+func (l *UnrolledList[E]) Blocks() int {
+	return len(l.blocks)
+}
+
+// Count returns the size of the list
+func (l *UnrolledList[E]) Count() int64 {
+	return int64(l.size)
+}
+
+// IsEmpty returns whether the list is empty.
+func (l *UnrolledList[E]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// IsNotEmpty returns whether the list is not empty.
+func (l *UnrolledList[E]) IsNotEmpty() bool {
+	return !l.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (l *UnrolledList[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindList]
+func (l *UnrolledList[E]) Kind() collection.CollectionKind {
+	return collection.KindList
+}
+
+// locate returns the block and the offset in the block of the index, the index must be in [0, size]
+func (l *UnrolledList[E]) locate(index int) (int, int) {
+	if index > l.size/2 {
+		end := l.size
+		for b := len(l.blocks) - 1; b >= 0; b-- {
+			end -= len(l.blocks[b])
+			if index >= end {
+				return b, index - end
+			}
+		}
+	}
+	for b, block := range l.blocks {
+		if index < len(block) {
+			return b, index
+		}
+		index -= len(block)
+	}
+	return len(l.blocks), 0
+}
+
+func (l *UnrolledList[E]) newBlock() []E {
+	if l.allocator != nil {
+		return l.allocator.MakeSlice(l.blockSize)[:0:l.blockSize]
+	}
+	return make([]E, 0, l.blockSize)
+}
+
+// Contains returns whether the list contains the specific element, elements are compared with [reflect.DeepEqual].
+func (l *UnrolledList[E]) Contains(value E) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// ContainsWhere returns whether the list contains specific elements by callback.
+func (l *UnrolledList[E]) ContainsWhere(callback func(value E) bool) bool {
+	return l.IndexOfWhere(callback) >= 0
+}
+
+// Push pushes elements into the list.
+func (l *UnrolledList[E]) Push(values ...E) {
+	for len(values) > 0 {
+		if len(l.blocks) == 0 || len(l.blocks[len(l.blocks)-1]) == l.blockSize {
+			l.blocks = append(l.blocks, l.newBlock())
+		}
+		last := &l.blocks[len(l.blocks)-1]
+		n := min(l.blockSize-len(*last), len(values))
+		*last = append(*last, values[:n]...)
+		l.size += n
+		values = values[n:]
+	}
+}
+
+// Insert inserts the elements before the element on the specific index, an index of Count appends them.
+// It returns false when the index is out of range.
+func (l *UnrolledList[E]) Insert(index int, values ...E) bool {
+	if index < 0 || index > l.size {
+		collection.Fail(collection.NewRangeError(index, l.size+1))
+		return false
+	}
+	if index == l.size {
+		l.Push(values...)
+		return true
+	}
+	for i, value := range values {
+		l.insert(index+i, value)
+	}
+	return true
+}
+
+func (l *UnrolledList[E]) insert(index int, value E) {
+	b, offset := l.locate(index)
+	if len(l.blocks[b]) == l.blockSize {
+		// split the full block in halves
+		half := l.blockSize / 2
+		next := append(l.newBlock(), l.blocks[b][half:]...)
+		clear(l.blocks[b][half:])
+		l.blocks[b] = l.blocks[b][:half]
+		l.blocks = slices.Insert(l.blocks, b+1, next)
+		if offset > half {
+			b, offset = b+1, offset-half
+		}
+	}
+	l.blocks[b] = slices.Insert(l.blocks[b], offset, value)
+	l.size++
+}
+
+// Remove removes the specific element, elements are compared with [reflect.DeepEqual].
+func (l *UnrolledList[E]) Remove(value E) {
+	l.RemoveWhere(func(item E) bool {
+		return equal.Equal(value, item)
+	})
+}
+
+// RemoveWhere removes specific elements by callback, the remaining elements are packed into full blocks in place.
+func (l *UnrolledList[E]) RemoveWhere(callback func(item E) bool) {
+	// the write position never passes the read position, so the blocks are reused without allocating
+	b, offset, size := 0, 0, 0
+	for _, block := range l.blocks {
+		for _, item := range block {
+			if callback(item) {
+				continue
+			}
+			if offset == l.blockSize {
+				b, offset = b+1, 0
+			}
+			l.blocks[b] = l.blocks[b][:offset+1]
+			l.blocks[b][offset] = item
+			offset++
+			size++
+		}
+	}
+	if size == 0 {
+		l.Clear()
+		return
+	}
+	clear(l.blocks[b][offset:cap(l.blocks[b])])
+	l.blocks[b] = l.blocks[b][:offset]
+	clear(l.blocks[b+1:])
+	l.blocks = l.blocks[:b+1]
+	l.size = size
+}
+
+// RemoveAt removes the element on the specific index.
+func (l *UnrolledList[E]) RemoveAt(index int) {
+	if index < 0 || index >= l.size {
+		panic(collection.NewRangeError(index, l.size))
+	}
+	b, offset := l.locate(index)
+	l.blocks[b] = slices.Delete(l.blocks[b], offset, offset+1)
+	l.size--
+	switch {
+	case len(l.blocks[b]) == 0:
+		l.blocks = slices.Delete(l.blocks, b, b+1)
+	case len(l.blocks[b]) < l.blockSize/4 && b+1 < len(l.blocks) && len(l.blocks[b])+len(l.blocks[b+1]) <= l.blockSize:
+		// merge a sparse block with its successor
+		l.blocks[b] = append(l.blocks[b], l.blocks[b+1]...)
+		l.blocks = slices.Delete(l.blocks, b+1, b+2)
+	}
+}
+
+// Clear clears the list.
+func (l *UnrolledList[E]) Clear() {
+	l.blocks = nil
+	l.size = 0
+}
+
+// Repack packs the elements into full blocks and drops the emptied blocks,
+// which undoes the fragmentation left by removals in the middle of the list.
+func (l *UnrolledList[E]) Repack() {
+	l.RemoveWhere(func(E) bool {
+		return false
+	})
+	l.blocks = slices.Clone(l.blocks)
+}
+
+// Free clears the list and frees its allocator, which releases every collection sharing the allocator.
+// Without an allocator it equals Clear.
+func (l *UnrolledList[E]) Free() {
+	l.Clear()
+	if l.allocator != nil {
+		l.allocator.Free()
+	}
+}
+
+// Get returns the element on the specific index, it panics when the index is out of range.
+func (l *UnrolledList[E]) Get(index int) E {
+	if index < 0 || index >= l.size {
+		panic(collection.NewRangeError(index, l.size))
+	}
+	b, offset := l.locate(index)
+	return l.blocks[b][offset]
+}
+
+// Set sets element on the specific index, it panics when the index is out of range.
+func (l *UnrolledList[E]) Set(index int, value E) {
+	if index < 0 || index >= l.size {
+		panic(collection.NewRangeError(index, l.size))
+	}
+	b, offset := l.locate(index)
+	l.blocks[b][offset] = value
+}
+
+// At returns the element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It will return a zero value and false when the index is out of range.
+func (l *UnrolledList[E]) At(index int) (E, bool) {
+	if index < 0 {
+		index += l.size
+	}
+	if index < 0 || index >= l.size {
+		collection.Fail(collection.NewRangeError(index, l.size))
+		return *new(E), false
+	}
+	return l.Get(index), true
+}
+
+// SetAt sets element on the specific index, a negative index counts back from the end of the list,
+// so -1 is the last element. It returns false when the index is out of range.
+func (l *UnrolledList[E]) SetAt(index int, value E) bool {
+	if index < 0 {
+		index += l.size
+	}
+	if index < 0 || index >= l.size {
+		collection.Fail(collection.NewRangeError(index, l.size))
+		return false
+	}
+	l.Set(index, value)
+	return true
+}
+
+// TryGet returns the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *UnrolledList[E]) TryGet(index int) (E, error) {
+	if index < 0 || index >= l.size {
+		return *new(E), collection.NewRangeError(index, l.size)
+	}
+	return l.Get(index), nil
+}
+
+// TrySet sets element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *UnrolledList[E]) TrySet(index int, value E) error {
+	if index < 0 || index >= l.size {
+		return collection.NewRangeError(index, l.size)
+	}
+	l.Set(index, value)
+	return nil
+}
+
+// TryRemoveAt removes the element on the specific index.
+// It returns [collection.ErrIndexOutOfRange] when the index is out of range.
+func (l *UnrolledList[E]) TryRemoveAt(index int) error {
+	if index < 0 || index >= l.size {
+		return collection.NewRangeError(index, l.size)
+	}
+	l.RemoveAt(index)
+	return nil
+}
+
+// First returns the first element of the list.
+// it will return a zero value and false when the list is empty.
+func (l *UnrolledList[E]) First() (E, bool) {
+	if l.size == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	return l.blocks[0][0], true
+}
+
+// FirstOr returns the first element of the list, it will return the default value when the list is empty.
+func (l *UnrolledList[E]) FirstOr(value E) E {
+	if l.size == 0 {
+		return value
+	}
+	return l.blocks[0][0]
+}
+
+// Last returns the last element of the list.
+// It will return a zero value and false when the list is empty.
+func (l *UnrolledList[E]) Last() (E, bool) {
+	if l.size == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	last := l.blocks[len(l.blocks)-1]
+	return last[len(last)-1], true
+}
+
+// LastOr returns the last element of the list, it will return the default value when the list is empty.
+func (l *UnrolledList[E]) LastOr(value E) E {
+	if l.size == 0 {
+		return value
+	}
+	last := l.blocks[len(l.blocks)-1]
+	return last[len(last)-1]
+}
+
+// Pop removes the last element of the list and returns it.
+// It will return a zero value and false when the list is empty.
+func (l *UnrolledList[E]) Pop() (E, bool) {
+	if l.size == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	value := l.LastOr(*new(E))
+	l.RemoveAt(l.size - 1)
+	return value, true
+}
+
+// Shift removes the first element of the list and returns it.
+// It will return a zero value and false when the list is empty.
+func (l *UnrolledList[E]) Shift() (E, bool) {
+	if l.size == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	value := l.blocks[0][0]
+	l.RemoveAt(0)
+	return value, true
+}
+
+// Unshift puts elements to the head of the list.
+func (l *UnrolledList[E]) Unshift(values ...E) {
+	l.Insert(0, values...)
+}
+
+// IndexOf returns the index of the specific element, elements are compared with [reflect.DeepEqual].
+func (l *UnrolledList[E]) IndexOf(value E) int {
+	return l.IndexOfWhere(func(item E) bool {
+		return equal.Equal(value, item)
+	})
+}
+
+// IndexOfWhere returns the index of the first element which matches the callback.
+func (l *UnrolledList[E]) IndexOfWhere(callback func(item E) bool) int {
+	index := -1
+	l.Each(func(i int, value E) bool {
+		if callback(value) {
+			index = i
+			return false
+		}
+		return true
+	})
+	return index
+}
+
+// Each travers the list, it breaks when the callback returns false.
+func (l *UnrolledList[E]) Each(callback func(index int, value E) bool) {
+	index := 0
+	for _, block := range l.blocks {
+		for _, value := range block {
+			if !callback(index, value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// All returns a sequence of the indexes and elements, it stops when yield returns false.
+func (l *UnrolledList[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		l.Each(yield)
+	}
+}
+
+// Values returns a sequence of the elements, it stops when yield returns false.
+func (l *UnrolledList[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		l.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Clone clones the list, the clone is allocated on the heap so that it outlives the allocator of the list.
+func (l *UnrolledList[E]) Clone() *UnrolledList[E] {
+	clone := NewUnrolledList[E](l.blockSize)
+	clone.size = l.size
+	clone.blocks = make([][]E, len(l.blocks))
+	for b, block := range l.blocks {
+		clone.blocks[b] = append(clone.newBlock(), block...)
+	}
+	return clone
+}
+
+// DeepClone clones the list with a deep copy of every element on the heap, see [collection.DeepCopy]
+func (l *UnrolledList[E]) DeepClone() *UnrolledList[E] {
+	clone := l.Clone()
+	for _, block := range clone.blocks {
+		for i, value := range block {
+			block[i] = collection.DeepCopy(value)
+		}
+	}
+	return clone
+}
+
+// String convert to string
+func (l *UnrolledList[E]) String() string {
+	return format.Values("UnrolledList", int64(l.size), l.Values())
+}
+
+// MemoryFootprint estimates the memory used by the list in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (l *UnrolledList[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	size := memory.Of[UnrolledList[E]]() + memory.Slice(l.blocks, nil)
+	for _, block := range l.blocks {
+		size += memory.Slice(block, deep)
+	}
+	return size
+}
+
+// Encode writes the elements of the list to the writer in the format
+func (l *UnrolledList[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, l.ToArray(), format)
+}
+
+// Decode replaces the elements of the list with the elements read from the reader in the format
+func (l *UnrolledList[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	l.Clear()
+	l.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (l *UnrolledList[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := l.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (l *UnrolledList[E]) GobDecode(data []byte) error {
+	return l.Decode(bytes.NewReader(data), codec.Gob)
+}
+
+// ToJSON converts to json
+func (l *UnrolledList[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(l.ToArray())
+}
+
+// ToArray converts to array
+func (l *UnrolledList[E]) ToArray() []E {
+	items := make([]E, 0, l.size)
+	for _, block := range l.blocks {
+		items = append(items, block...)
+	}
+	return items
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the list is not changed
+func (l *UnrolledList[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(l.ToArray(), callback)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (l *UnrolledList[E]) MarshalJSON() ([]byte, error) {
+	return l.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (l *UnrolledList[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if l.blockSize <= 0 {
+		l.blockSize = DefaultBlockSize
+	}
+	l.Clear()
+	l.Push(items...)
+	return nil
+}
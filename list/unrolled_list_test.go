@@ -0,0 +1,284 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/arena"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/deepcopy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnrolledList_Push(t *testing.T) {
+	list := NewUnrolledList(4, 1, 2, 3, 4, 5, 6)
+	assert.Equal(t, int64(6), list.Count())
+	assert.Equal(t, 2, list.Blocks())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, list.ToArray())
+	assert.Equal(t, DefaultBlockSize, NewUnrolledList[int](0).BlockSize())
+}
+
+func TestUnrolledList_Insert(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewUnrolledList(4, 1, 2, 3, 4)
+	assert.True(t, list.Insert(1, 10, 11))
+	assert.Equal(t, []int{1, 10, 11, 2, 3, 4}, list.ToArray())
+	assert.Equal(t, 2, list.Blocks())
+	assert.True(t, list.Insert(6, 5))
+	assert.Equal(t, []int{1, 10, 11, 2, 3, 4, 5}, list.ToArray())
+	assert.False(t, list.Insert(8, 0))
+}
+
+func TestUnrolledList_Remove(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 1, 3)
+	list.Remove(1)
+	assert.Equal(t, []int{2, 3}, list.ToArray())
+	assert.Equal(t, 1, list.Blocks())
+}
+
+func TestUnrolledList_RemoveWhere(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3, 4, 5, 6, 7)
+	list.RemoveAt(1)
+	list.RemoveWhere(func(value int) bool { return value%3 == 0 })
+	assert.Equal(t, []int{1, 4, 5, 7}, list.ToArray())
+	assert.Equal(t, 2, list.Blocks())
+	list.RemoveWhere(func(int) bool { return true })
+	assert.True(t, list.IsEmpty())
+	assert.Equal(t, 0, list.Blocks())
+}
+
+func TestUnrolledList_RemoveAt(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	list.RemoveAt(2)
+	assert.Equal(t, []int{1, 2}, list.ToArray())
+	assert.Equal(t, 1, list.Blocks())
+	assert.Panics(t, func() { list.RemoveAt(2) })
+}
+
+func TestUnrolledList_Free(t *testing.T) {
+	allocator := arena.NewSlab[int](8)
+	list := NewUnrolledListIn[int](allocator, 4, 1, 2, 3, 4, 5)
+	assert.Equal(t, 1, allocator.Slabs())
+	list.Insert(1, 10)
+	assert.Equal(t, []int{1, 10, 2, 3, 4, 5}, list.ToArray())
+	assert.Equal(t, 2, allocator.Slabs())
+	clone := list.Clone()
+	list.Free()
+	assert.True(t, list.IsEmpty())
+	assert.Equal(t, 0, allocator.Slabs())
+	assert.Equal(t, []int{1, 10, 2, 3, 4, 5}, clone.ToArray())
+	list.Push(1)
+	assert.Equal(t, 1, allocator.Slabs())
+}
+
+func TestUnrolledList_Clear(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	list.Clear()
+	assert.True(t, list.IsEmpty())
+	assert.Equal(t, 0, list.Blocks())
+}
+
+func TestUnrolledList_Get(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3, 4, 5)
+	assert.Equal(t, 2, list.Get(1))
+	assert.Equal(t, 5, list.Get(4))
+	assert.Panics(t, func() { list.Get(5) })
+}
+
+func TestUnrolledList_Set(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	list.Set(2, 30)
+	assert.Equal(t, []int{1, 2, 30}, list.ToArray())
+}
+
+func TestUnrolledList_At(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewUnrolledList(2, 1, 2, 3)
+	value, ok := list.At(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	_, ok = list.At(3)
+	assert.False(t, ok)
+	assert.True(t, list.SetAt(-3, 10))
+	assert.False(t, list.SetAt(-4, 10))
+	assert.Equal(t, []int{10, 2, 3}, list.ToArray())
+}
+
+func TestUnrolledList_TryGet(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	value, err := list.TryGet(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+	_, err = list.TryGet(3)
+	assert.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	assert.ErrorIs(t, list.TrySet(-1, 0), collection.ErrIndexOutOfRange)
+	assert.ErrorIs(t, list.TryRemoveAt(3), collection.ErrIndexOutOfRange)
+}
+
+func TestUnrolledList_First(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	list := NewUnrolledList[int](2)
+	_, ok := list.First()
+	assert.False(t, ok)
+	assert.Equal(t, 10, list.FirstOr(10))
+	assert.Equal(t, 10, list.LastOr(10))
+	list.Push(1, 2, 3)
+	value, _ := list.First()
+	assert.Equal(t, 1, value)
+	value, _ = list.Last()
+	assert.Equal(t, 3, value)
+}
+
+func TestUnrolledList_Pop(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	value, ok := list.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	value, ok = list.Shift()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, []int{2}, list.ToArray())
+}
+
+func TestUnrolledList_Unshift(t *testing.T) {
+	list := NewUnrolledList(2, 3)
+	list.Unshift(1, 2)
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+}
+
+func TestUnrolledList_IndexOf(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	assert.Equal(t, 2, list.IndexOf(3))
+	assert.Equal(t, -1, list.IndexOf(4))
+	assert.True(t, list.Contains(2))
+	assert.False(t, list.ContainsWhere(func(value int) bool { return value > 3 }))
+}
+
+func TestUnrolledList_Each(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	var indexes []int
+	list.Each(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		return value < 2
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+}
+
+func TestUnrolledList_All(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3, 4)
+	var indexes, items []int
+	list.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return value < 3
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	items = nil
+	list.Values()(func(value int) bool {
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3, 4}, items)
+}
+
+func TestUnrolledList_Clone(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	clone := list.Clone()
+	clone.Set(0, 10)
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+	assert.Equal(t, []int{10, 2, 3}, clone.ToArray())
+}
+
+func TestUnrolledList_String(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(`UnrolledList\[int\]\(len=6\)\{\n(\t\d+,\n){5}\t...\n\}`)
+	assert.True(t, pattern.MatchString(list.String()))
+}
+
+func TestUnrolledList_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewUnrolledList(2, 1, 2, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(data))
+}
+
+func TestUnrolledList_UnmarshalJSON(t *testing.T) {
+	list := new(UnrolledList[int])
+	assert.Nil(t, json.Unmarshal([]byte("[1,2,3]"), list))
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+	assert.Equal(t, DefaultBlockSize, list.BlockSize())
+	assert.Nil(t, json.Unmarshal([]byte("null"), list))
+	assert.True(t, list.IsEmpty())
+}
+
+func TestUnrolledList_Decode(t *testing.T) {
+	list := NewUnrolledList(2, 1, 2, 3)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, list.Encode(buf, codec.NDJSON))
+	decoded := NewUnrolledList[int](2, 9)
+	assert.Nil(t, decoded.Decode(buf, codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, decoded.ToArray())
+}
+
+func TestUnrolledList_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, blockSize := range []int{1, 2, 3, 8} {
+		t.Run(fmt.Sprint(blockSize), func(t *testing.T) {
+			list := NewUnrolledList[int](blockSize)
+			var expected []int
+			for i := 0; i < 2000; i++ {
+				switch op := random.Intn(4); {
+				case op < 2:
+					index := random.Intn(len(expected) + 1)
+					list.Insert(index, i)
+					expected = slices.Insert(expected, index, i)
+				case op == 2 && i%50 == 0:
+					list.RemoveWhere(func(value int) bool { return value%3 == 0 })
+					expected = slices.DeleteFunc(expected, func(value int) bool { return value%3 == 0 })
+				case op == 2 && len(expected) > 0:
+					index := random.Intn(len(expected))
+					list.RemoveAt(index)
+					expected = slices.Delete(expected, index, index+1)
+				default:
+					list.Push(i)
+					expected = append(expected, i)
+				}
+				if len(expected) > 0 {
+					index := random.Intn(len(expected))
+					assert.Equal(t, expected[index], list.Get(index))
+				}
+			}
+			assert.Equal(t, expected, list.ToArray())
+			assert.Equal(t, int64(len(expected)), list.Count())
+		})
+	}
+}
+
+func TestUnrolledList_Repack(t *testing.T) {
+	list := NewUnrolledList(4, 1, 2, 3, 4, 5, 6, 7, 8)
+	list.RemoveAt(0)
+	list.RemoveAt(4)
+	list.RemoveAt(0)
+	list.Insert(1, 10)
+	list.Repack()
+	assert.Equal(t, []int{3, 10, 4, 5, 7, 8}, list.ToArray())
+	assert.Equal(t, 2, list.Blocks())
+	assert.Equal(t, 0, list.blocks[1][:4][3])
+}
+
+func TestUnrolledList_DeepClone(t *testing.T) {
+	list := NewUnrolledList(1, map[string]int{"a": 1}, map[string]int{"b": 2})
+	clone := list.DeepClone()
+	clone.Get(1)["b"] = 20
+	assert.Equal(t, deepcopy.Reflect, list.Get(1)["b"] == 2)
+}
@@ -0,0 +1,51 @@
+package list
+
+import "github.com/gopi-frame/collection/variant"
+
+// MapVariant2 converts the elements of the list with the function of the held type of each element
+func MapVariant2[A, B, R any](l *List[variant.Of2[A, B]], onA func(value A) R, onB func(value B) R) *List[R] {
+	result := new(List[R])
+	result.items = make([]R, len(l.items))
+	for index, item := range l.items {
+		result.items[index] = variant.Match2(item, onA, onB)
+	}
+	return result
+}
+
+// MapVariant3 converts the elements of the list with the function of the held type of each element
+func MapVariant3[A, B, C, R any](l *List[variant.Of3[A, B, C]], onA func(value A) R, onB func(value B) R, onC func(value C) R) *List[R] {
+	result := new(List[R])
+	result.items = make([]R, len(l.items))
+	for index, item := range l.items {
+		result.items[index] = variant.Match3(item, onA, onB, onC)
+	}
+	return result
+}
+
+// PartitionByVariant2 splits the list into one list per held type, the elements keep their order
+func PartitionByVariant2[A, B any](l *List[variant.Of2[A, B]]) (*List[A], *List[B]) {
+	as, bs := NewList[A](), NewList[B]()
+	for _, item := range l.items {
+		item.Switch(func(value A) {
+			as.items = append(as.items, value)
+		}, func(value B) {
+			bs.items = append(bs.items, value)
+		})
+	}
+	return as, bs
+}
+
+// PartitionByVariant3 splits the list into one list per held type, the elements keep their order
+func PartitionByVariant3[A, B, C any](l *List[variant.Of3[A, B, C]]) (*List[A], *List[B], *List[C]) {
+	as, bs, cs := NewList[A](), NewList[B](), NewList[C]()
+	for _, item := range l.items {
+		item.Switch(func(value A) {
+			as.items = append(as.items, value)
+		}, func(value B) {
+			bs.items = append(bs.items, value)
+		}, func(value C) {
+			cs.items = append(cs.items, value)
+		})
+	}
+	return as, bs, cs
+}
@@ -0,0 +1,41 @@
+package list
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gopi-frame/collection/variant"
+	"github.com/stretchr/testify/assert"
+)
+
+type _event = variant.Of3[int, string, bool]
+
+func TestMapVariant2(t *testing.T) {
+	l := NewList(variant.Of2A[int, string](1), variant.Of2B[int, string]("a"))
+	result := MapVariant2(l, strconv.Itoa, func(value string) string { return value + value })
+	assert.Equal(t, []string{"1", "aa"}, result.ToArray())
+}
+
+func TestMapVariant3(t *testing.T) {
+	l := NewList(variant.Of3B[int, string, bool]("ab"), variant.Of3C[int, string, bool](true), variant.Of3A[int, string, bool](3))
+	result := MapVariant3(l,
+		func(value int) int { return value },
+		func(value string) int { return len(value) },
+		func(bool) int { return 1 })
+	assert.Equal(t, []int{2, 1, 3}, result.ToArray())
+}
+
+func TestPartitionByVariant2(t *testing.T) {
+	l := NewList(variant.Of2A[int, string](1), variant.Of2B[int, string]("a"), variant.Of2A[int, string](2))
+	ints, strs := PartitionByVariant2(l)
+	assert.Equal(t, []int{1, 2}, ints.ToArray())
+	assert.Equal(t, []string{"a"}, strs.ToArray())
+}
+
+func TestPartitionByVariant3(t *testing.T) {
+	l := NewList[_event](variant.Of3C[int, string, bool](true), variant.Of3A[int, string, bool](1), variant.Of3C[int, string, bool](false))
+	ints, strs, bools := PartitionByVariant3(l)
+	assert.Equal(t, []int{1}, ints.ToArray())
+	assert.True(t, strs.IsEmpty())
+	assert.Equal(t, []bool{true, false}, bools.ToArray())
+}
@@ -0,0 +1,148 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/gopi-frame/collection/list"
+)
+
+const (
+	leafPrefix byte = iota
+	nodePrefix
+)
+
+// HashFunc returns the hash of the element
+type HashFunc[E any] func(value E) []byte
+
+// ProofStep a sibling hash on the path from the leaf to the root
+type ProofStep struct {
+	Hash []byte `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+// Proof audit path of an element, ordered from the leaf to the root
+type Proof []ProofStep
+
+// NewTree builds a merkle tree over the values
+func NewTree[E any](hash HashFunc[E], values ...E) *Tree[E] {
+	tree := new(Tree[E])
+	tree.hash = hash
+	tree.build(values)
+	return tree
+}
+
+// FromList builds a merkle tree over the elements of the list
+func FromList[E any](items *list.List[E], hash HashFunc[E]) *Tree[E] {
+	return NewTree(hash, items.ToArray()...)
+}
+
+// Tree merkle tree
+type Tree[E any] struct {
+	hash   HashFunc[E]
+	levels [][][]byte
+}
+
+func leafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+func (t *Tree[E]) build(values []E) {
+	if len(values) == 0 {
+		return
+	}
+	level := make([][]byte, 0, len(values))
+	for _, value := range values {
+		level = append(level, leafHash(t.hash(value)))
+	}
+	t.levels = append(t.levels, level)
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				// the unpaired node is promoted to the next level
+				next = append(next, level[i])
+			} else {
+				next = append(next, nodeHash(level[i], level[i+1]))
+			}
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+}
+
+// Count returns the number of leaves
+func (t *Tree[E]) Count() int64 {
+	if len(t.levels) == 0 {
+		return 0
+	}
+	return int64(len(t.levels[0]))
+}
+
+// IsEmpty returns whether the tree is empty
+func (t *Tree[E]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// IsNotEmpty returns whether the tree is not empty
+func (t *Tree[E]) IsNotEmpty() bool {
+	return !t.IsEmpty()
+}
+
+// RootHash returns the root hash, it returns nil when the tree is empty
+func (t *Tree[E]) RootHash() []byte {
+	if len(t.levels) == 0 {
+		return nil
+	}
+	return bytes.Clone(t.levels[len(t.levels)-1][0])
+}
+
+// Proof returns the audit path of the element on the specific index.
+// It returns nil and false when the index is out of range.
+func (t *Tree[E]) Proof(index int) (Proof, bool) {
+	if index < 0 || int64(index) >= t.Count() {
+		return nil, false
+	}
+	proof := Proof{}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if index%2 == 1 {
+			proof = append(proof, ProofStep{Hash: bytes.Clone(level[index-1]), Left: true})
+		} else if index+1 < len(level) {
+			proof = append(proof, ProofStep{Hash: bytes.Clone(level[index+1]), Left: false})
+		}
+		index /= 2
+	}
+	return proof, true
+}
+
+// Verify returns whether the value with the proof belongs to the tree
+func (t *Tree[E]) Verify(value E, proof Proof) bool {
+	return Verify(t.RootHash(), value, proof, t.hash)
+}
+
+// Verify returns whether the value with the proof matches the root hash
+func Verify[E any](root []byte, value E, proof Proof, hash HashFunc[E]) bool {
+	if root == nil {
+		return false
+	}
+	h := leafHash(hash(value))
+	for _, step := range proof {
+		if step.Left {
+			h = nodeHash(step.Hash, h)
+		} else {
+			h = nodeHash(h, step.Hash)
+		}
+	}
+	return bytes.Equal(h, root)
+}
@@ -0,0 +1,59 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func hashString(value string) []byte {
+	return []byte(value)
+}
+
+func TestTree_RootHash(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		tree := NewTree(hashString)
+		assert.True(t, tree.IsEmpty())
+		assert.Nil(t, tree.RootHash())
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		a := NewTree(hashString, "a", "b", "c")
+		b := FromList(list.NewList("a", "b", "c"), hashString)
+		assert.Equal(t, a.RootHash(), b.RootHash())
+		assert.Equal(t, int64(3), b.Count())
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		a := NewTree(hashString, "a", "b", "c")
+		b := NewTree(hashString, "a", "x", "c")
+		assert.NotEqual(t, a.RootHash(), b.RootHash())
+	})
+}
+
+func TestTree_Proof(t *testing.T) {
+	values := []string{"a", "b", "c", "d", "e"}
+	tree := NewTree(hashString, values...)
+	for index, value := range values {
+		proof, ok := tree.Proof(index)
+		assert.True(t, ok)
+		assert.True(t, tree.Verify(value, proof))
+		assert.True(t, Verify(tree.RootHash(), value, proof, hashString))
+		assert.False(t, tree.Verify("z", proof))
+	}
+
+	_, ok := tree.Proof(5)
+	assert.False(t, ok)
+	_, ok = tree.Proof(-1)
+	assert.False(t, ok)
+}
+
+func TestTree_Verify(t *testing.T) {
+	tree := NewTree(hashString, "a")
+	proof, ok := tree.Proof(0)
+	assert.True(t, ok)
+	assert.Empty(t, proof)
+	assert.True(t, tree.Verify("a", proof))
+	assert.False(t, NewTree(hashString).Verify("a", proof))
+}
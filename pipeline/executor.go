@@ -0,0 +1,204 @@
+// Package pipeline runs dependency graphs of tasks.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection/graph"
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/queue"
+)
+
+var (
+	// ErrDuplicateTask the task is already added
+	ErrDuplicateTask = errors.New("pipeline: duplicate task")
+	// ErrUnknownTask a dependency is not added as a task
+	ErrUnknownTask = errors.New("pipeline: unknown task")
+	// ErrSkipped the task did not run because a dependency failed
+	ErrSkipped = errors.New("pipeline: skipped")
+)
+
+// Task unit of work of a pipeline
+type Task func(ctx context.Context) error
+
+// RetryPolicy retry policy of a task
+type RetryPolicy struct {
+	// Attempts the maximum number of attempts, values below 1 mean a single attempt
+	Attempts int
+	// Backoff the delay before the second attempt, it doubles after each further attempt
+	Backoff time.Duration
+}
+
+// Result result of a task
+type Result[N comparable] struct {
+	Node     N
+	Attempts int
+	Err      error
+}
+
+// NewExecutor new executor which runs at most concurrency tasks at a time
+func NewExecutor[N comparable](concurrency int) *Executor[N] {
+	e := new(Executor[N])
+	e.concurrency = max(concurrency, 1)
+	e.graph = graph.NewDirected[N]()
+	e.tasks = make(map[N]Task)
+	e.retries = make(map[N]RetryPolicy)
+	return e
+}
+
+// Executor runs a dependency graph of tasks, a task starts once all of its dependencies succeeded
+type Executor[N comparable] struct {
+	sync.RWMutex
+	concurrency int
+	graph       *graph.Graph[N]
+	tasks       map[N]Task
+	retries     map[N]RetryPolicy
+}
+
+// Add adds the task which depends on the given nodes, dependencies may be added later.
+// It returns [ErrDuplicateTask] when the node is already added.
+func (e *Executor[N]) Add(node N, task Task, dependencies ...N) error {
+	if _, ok := e.tasks[node]; ok {
+		return fmt.Errorf("%w: %v", ErrDuplicateTask, node)
+	}
+	e.tasks[node] = task
+	e.graph.AddNode(node)
+	for _, dependency := range dependencies {
+		e.graph.AddEdge(dependency, node, 0)
+	}
+	return nil
+}
+
+// Retry sets the retry policy of the task
+func (e *Executor[N]) Retry(node N, policy RetryPolicy) {
+	e.retries[node] = policy
+}
+
+// Count returns the number of tasks
+func (e *Executor[N]) Count() int64 {
+	return int64(len(e.tasks))
+}
+
+// Run runs the tasks and returns their results in the order they were added.
+// A failed task skips all tasks depending on it, which report [ErrSkipped], other tasks keep running.
+// Once the context is done no more tasks are started and the tasks which did not start report the error of the context.
+// The returned error joins the errors of the failed tasks and the context,
+// it is a [*graph.CycleError] or wraps [ErrUnknownTask] without running any task when the graph is invalid.
+func (e *Executor[N]) Run(ctx context.Context) (*kv.LinkedMap[N, Result[N]], error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	results := kv.NewLinkedMap[N, Result[N]]()
+	decided := make(map[N]struct{}, len(e.tasks))
+	degrees := make(map[N]int, len(e.tasks))
+	ready := queue.NewLinkedQueue[N]()
+	for _, edge := range e.graph.Edges() {
+		degrees[edge.To]++
+	}
+	for _, node := range e.graph.Nodes() {
+		results.Set(node, Result[N]{Node: node})
+		if degrees[node] == 0 {
+			ready.Enqueue(node)
+		}
+	}
+	finished := queue.NewChanQueue[Result[N]](e.concurrency)
+	var failures []error
+	running := 0
+	for len(decided) < len(e.tasks) {
+		for running < e.concurrency && ctx.Err() == nil {
+			node, ok := ready.Dequeue()
+			if !ok {
+				break
+			}
+			running++
+			go func() {
+				finished.Enqueue(e.attempt(ctx, node))
+			}()
+		}
+		if running == 0 {
+			break
+		}
+		result, _ := finished.Dequeue()
+		running--
+		decided[result.Node] = struct{}{}
+		results.Set(result.Node, result)
+		if result.Err != nil {
+			failures = append(failures, fmt.Errorf("pipeline: task %v: %w", result.Node, result.Err))
+			e.skip(result.Node, results, decided)
+			continue
+		}
+		e.graph.EachEdge(result.Node, func(to N, _ float64) bool {
+			degrees[to]--
+			if _, ok := decided[to]; !ok && degrees[to] == 0 {
+				ready.Enqueue(to)
+			}
+			return true
+		})
+	}
+	if len(decided) < len(e.tasks) {
+		results.Each(func(node N, result Result[N]) bool {
+			if _, ok := decided[node]; !ok {
+				result.Err = ctx.Err()
+				results.Set(node, result)
+			}
+			return true
+		})
+		failures = append(failures, ctx.Err())
+	}
+	return results, errors.Join(failures...)
+}
+
+// validate checks that every dependency is a task and the graph is acyclic
+func (e *Executor[N]) validate() error {
+	for _, node := range e.graph.Nodes() {
+		if _, ok := e.tasks[node]; !ok {
+			return fmt.Errorf("%w: %v required by %v", ErrUnknownTask, node, e.graph.Neighbors(node))
+		}
+	}
+	_, err := e.graph.TopoBatches()
+	return err
+}
+
+// attempt runs the task of the node following its retry policy
+func (e *Executor[N]) attempt(ctx context.Context, node N) Result[N] {
+	policy := e.retries[node]
+	backoff := policy.Backoff
+	result := Result[N]{Node: node}
+	for {
+		result.Attempts++
+		result.Err = e.tasks[node](ctx)
+		if result.Err == nil || result.Attempts >= policy.Attempts || ctx.Err() != nil {
+			return result
+		}
+		if backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return result
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// skip marks all tasks depending on the failed node as skipped
+func (e *Executor[N]) skip(failed N, results *kv.LinkedMap[N, Result[N]], decided map[N]struct{}) {
+	pending := queue.NewLinkedQueue(failed)
+	for node, ok := pending.Dequeue(); ok; node, ok = pending.Dequeue() {
+		e.graph.EachEdge(node, func(to N, _ float64) bool {
+			if _, ok := decided[to]; ok {
+				return true
+			}
+			decided[to] = struct{}{}
+			results.Set(to, Result[N]{Node: to, Err: fmt.Errorf("%w: %v failed", ErrSkipped, failed)})
+			pending.Enqueue(to)
+			return true
+		})
+	}
+}
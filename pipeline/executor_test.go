@@ -0,0 +1,139 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func record(mu *sync.Mutex, order *[]string, name string) Task {
+	return func(context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*order = append(*order, name)
+		return nil
+	}
+}
+
+func TestExecutor_Run(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	e := NewExecutor[string](2)
+	assert.Nil(t, e.Add("deploy", record(&mu, &order, "deploy"), "build", "lint"))
+	assert.Nil(t, e.Add("build", record(&mu, &order, "build"), "fetch"))
+	assert.Nil(t, e.Add("lint", record(&mu, &order, "lint"), "fetch"))
+	assert.Nil(t, e.Add("fetch", record(&mu, &order, "fetch")))
+	assert.ErrorIs(t, e.Add("fetch", record(&mu, &order, "fetch")), ErrDuplicateTask)
+	assert.Equal(t, int64(4), e.Count())
+
+	results, err := e.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"deploy", "build", "lint", "fetch"}, results.Keys())
+	assert.Equal(t, "fetch", order[0])
+	assert.ElementsMatch(t, []string{"build", "lint"}, order[1:3])
+	assert.Equal(t, "deploy", order[3])
+	for _, result := range results.Values() {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, 1, result.Attempts)
+	}
+}
+
+func TestExecutor_Run_Concurrency(t *testing.T) {
+	var running, peak atomic.Int64
+	e := NewExecutor[int](3)
+	for i := 0; i < 10; i++ {
+		_ = e.Add(i, func(context.Context) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	}
+	_, err := e.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), peak.Load())
+}
+
+func TestExecutor_Run_Failure(t *testing.T) {
+	boom := errors.New("boom")
+	var ran atomic.Bool
+	e := NewExecutor[string](1)
+	_ = e.Add("a", func(context.Context) error { return boom })
+	_ = e.Add("b", func(context.Context) error { ran.Store(true); return nil }, "a")
+	_ = e.Add("c", func(context.Context) error { ran.Store(true); return nil }, "b")
+	_ = e.Add("d", func(context.Context) error { return nil })
+	results, err := e.Run(context.Background())
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, ran.Load())
+	a, _ := results.Get("a")
+	assert.ErrorIs(t, a.Err, boom)
+	c, _ := results.Get("c")
+	assert.ErrorIs(t, c.Err, ErrSkipped)
+	assert.Equal(t, 0, c.Attempts)
+	d, _ := results.Get("d")
+	assert.Nil(t, d.Err)
+	assert.Equal(t, 1, d.Attempts)
+}
+
+func TestExecutor_Retry(t *testing.T) {
+	var calls atomic.Int64
+	e := NewExecutor[string](1)
+	_ = e.Add("flaky", func(context.Context) error {
+		if calls.Add(1) < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+	e.Retry("flaky", RetryPolicy{Attempts: 3, Backoff: time.Millisecond})
+	results, err := e.Run(context.Background())
+	assert.Nil(t, err)
+	result, _ := results.Get("flaky")
+	assert.Equal(t, 3, result.Attempts)
+
+	calls.Store(0)
+	e.Retry("flaky", RetryPolicy{Attempts: 2})
+	results, err = e.Run(context.Background())
+	assert.NotNil(t, err)
+	result, _ = results.Get("flaky")
+	assert.Equal(t, 2, result.Attempts)
+}
+
+func TestExecutor_Run_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := NewExecutor[string](1)
+	_ = e.Add("a", func(context.Context) error { cancel(); return nil })
+	_ = e.Add("b", func(context.Context) error { return nil }, "a")
+	results, err := e.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	a, _ := results.Get("a")
+	assert.Nil(t, a.Err)
+	b, _ := results.Get("b")
+	assert.ErrorIs(t, b.Err, context.Canceled)
+	assert.Equal(t, 0, b.Attempts)
+}
+
+func TestExecutor_Run_Invalid(t *testing.T) {
+	e := NewExecutor[string](1)
+	_ = e.Add("a", func(context.Context) error { return nil }, "missing")
+	_, err := e.Run(context.Background())
+	assert.ErrorIs(t, err, ErrUnknownTask)
+
+	e = NewExecutor[string](1)
+	_ = e.Add("a", func(context.Context) error { return nil }, "b")
+	_ = e.Add("b", func(context.Context) error { return nil }, "a")
+	results, err := e.Run(context.Background())
+	assert.Nil(t, results)
+	assert.ErrorIs(t, err, graph.ErrCycle)
+}
@@ -1,17 +1,20 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
-	"reflect"
-	"strings"
+	"io"
+	"slices"
 	"sync"
 	"time"
 
-	"github.com/gopi-frame/contract"
-	"github.com/gopi-frame/exception"
-	"github.com/gopi-frame/future"
-	"github.com/gopi-frame/util/catch"
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 )
 
 // NewBlockingQueue new blocking queue
@@ -33,13 +36,13 @@ type BlockingQueue[E any] struct {
 	takeLock *sync.Cond
 	putLock  *sync.Cond
 	lock     *sync.RWMutex
+	closed   bool
 }
 
 // Count returns the size of queue
 func (q *BlockingQueue[E]) Count() int64 {
-	if q.lock.TryRLock() {
-		defer q.lock.RUnlock()
-	}
+	q.lock.RLock()
+	defer q.lock.RUnlock()
 	return q.size
 }
 
@@ -53,20 +56,37 @@ func (q *BlockingQueue[E]) IsNotEmpty() bool {
 	return !q.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (q *BlockingQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *BlockingQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
 // Clear clears the queue
 func (q *BlockingQueue[E]) Clear() {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	q.items = nil
 	q.size = 0
 }
 
+// Repack reallocates the backing array to fit the elements, releasing the capacity left over by dequeues
+func (q *BlockingQueue[E]) Repack() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	items := make([]E, len(q.items))
+	copy(items, q.items)
+	q.items = items
+}
+
 // Peek returns the first element of the queue
 func (q *BlockingQueue[E]) Peek() (E, bool) {
-	if q.lock.TryRLock() {
-		defer q.lock.RUnlock()
-	}
+	q.lock.RLock()
+	defer q.lock.RUnlock()
 	if q.size == 0 {
 		return *new(E), false
 	}
@@ -75,10 +95,9 @@ func (q *BlockingQueue[E]) Peek() (E, bool) {
 
 // TryEnqueue enqueues a new element into the queue, it will return false if the size is up to the capacity
 func (q *BlockingQueue[E]) TryEnqueue(value E) bool {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
-	if q.cap == q.size {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed || q.cap == q.size {
 		return false
 	}
 	q.items = append(q.items, value)
@@ -90,119 +109,134 @@ func (q *BlockingQueue[E]) TryEnqueue(value E) bool {
 // TryDequeue dequeues the first element of the queue and returns it.
 // The empty value of the element type and false will be returned when the queue is empty
 func (q *BlockingQueue[E]) TryDequeue() (E, bool) {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	if q.size == 0 {
 		return *new(E), false
 	}
 	value := q.items[0]
+	clear(q.items[:1])
 	q.items = q.items[1:]
 	q.size--
 	q.putLock.Broadcast()
 	return value, true
 }
 
-// Enqueue enqueues a new element into the queue, it will block if the size is up to capacity
+// Enqueue enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns false when the queue is closed.
 func (q *BlockingQueue[E]) Enqueue(value E) bool {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
-	for q.cap == q.size {
+	return q.Put(value) == nil
+}
+
+// Dequeue dequeues the first element of queue, it will block if the queue is empty.
+// It returns a zero value and false when the queue is closed and drained.
+func (q *BlockingQueue[E]) Dequeue() (E, bool) {
+	value, err := q.Take()
+	return value, err == nil
+}
+
+// Put enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns [collection.ErrClosed] when the queue is closed, including while it is blocked.
+func (q *BlockingQueue[E]) Put(value E) error {
+	return q.EnqueueContext(context.Background(), value)
+}
+
+// EnqueueContext enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns the error of the context when the context is done before the element is enqueued,
+// or [collection.ErrClosed] when the queue is closed.
+func (q *BlockingQueue[E]) EnqueueContext(ctx context.Context, value E) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer wakeOnDone(ctx, q.putLock)()
+	for q.cap == q.size && !q.closed && ctx.Err() == nil {
 		q.putLock.Wait()
 	}
+	if q.closed {
+		return collection.ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	q.items = append(q.items, value)
 	q.size++
 	q.takeLock.Broadcast()
-	return true
+	return nil
 }
 
-// Dequeue dequeues the first element of queue, it will block if the queue is empty
-func (q *BlockingQueue[E]) Dequeue() (E, bool) {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
-	for q.size == 0 {
+// Take dequeues the first element of queue, it will block if the queue is empty.
+// The elements left when the queue is closed can still be taken,
+// it returns [collection.ErrClosed] once the queue is closed and drained.
+func (q *BlockingQueue[E]) Take() (E, error) {
+	return q.DequeueContext(context.Background())
+}
+
+// DequeueContext dequeues the first element of queue, it will block if the queue is empty.
+// It returns the error of the context when the context is done before an element is dequeued,
+// or [collection.ErrClosed] once the queue is closed and drained.
+func (q *BlockingQueue[E]) DequeueContext(ctx context.Context) (E, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer wakeOnDone(ctx, q.takeLock)()
+	for q.size == 0 && !q.closed && ctx.Err() == nil {
 		q.takeLock.Wait()
 	}
+	if err := ctx.Err(); err != nil {
+		return *new(E), err
+	}
+	if q.size == 0 {
+		return *new(E), collection.ErrClosed
+	}
 	value := q.items[0]
+	clear(q.items[:1])
 	q.items = q.items[1:]
 	q.size--
 	q.putLock.Broadcast()
-	return value, true
+	return value, nil
+}
+
+// Close closes the queue and wakes all blocked producers and consumers.
+// Elements can no longer be enqueued, the elements left can still be dequeued.
+func (q *BlockingQueue[E]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closed = true
+	q.takeLock.Broadcast()
+	q.putLock.Broadcast()
+}
+
+// IsClosed returns whether the queue is closed
+func (q *BlockingQueue[E]) IsClosed() bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.closed
 }
 
 // EnqueueTimeout enqueues element into the queue.
 // It will block when the size of queue is up to capacity.
-// It will return true if the element is successfully enqueued or false when time is out
+// It will return true if the element is successfully enqueued or false when time is out or the queue is closed
 func (q *BlockingQueue[E]) EnqueueTimeout(value E, duration time.Duration) bool {
-	var ok bool
-	catch.Try(func() {
-		done := make(chan struct{})
-		ok = future.Timeout(func() bool {
-			future.Void(func() {
-				if q.lock.TryLock() {
-					defer q.lock.Unlock()
-				}
-				for q.cap == q.size {
-					q.putLock.Wait()
-				}
-				done <- struct{}{}
-			})
-			<-done
-			q.items = append(q.items, value)
-			q.size++
-			q.takeLock.Broadcast()
-			return true
-		}, duration).Complete(func() {
-			close(done)
-		}).Await()
-	}).Catch(new(exception.TimeoutException), func(err error) {
-		ok = false
-	}).Run()
-	return ok
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return q.EnqueueContext(ctx, value) == nil
 }
 
 // DequeueTimeout removes the first element and returns it.
 // It will block when the queue is empty.
-// It will return zero value and false when time is out
+// It will return zero value and false when time is out or the queue is closed and drained
 func (q *BlockingQueue[E]) DequeueTimeout(duration time.Duration) (E, bool) {
-	var value E
-	var ok bool
-	catch.Try(func() {
-		done := make(chan struct{})
-		ok = future.Timeout(func() bool {
-			future.Void(func() {
-				if q.lock.TryLock() {
-					defer q.lock.Unlock()
-				}
-				for q.size == 0 {
-					q.takeLock.Wait()
-				}
-				done <- struct{}{}
-			})
-			<-done
-			value = q.items[0]
-			q.items = q.items[1:]
-			q.size--
-			q.putLock.Broadcast()
-			return true
-		}, duration).Complete(func() {
-			close(done)
-		}).Await()
-	}).Catch(new(exception.TimeoutException), func(err error) {
-	}).Run()
-	return value, ok
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	value, err := q.DequeueContext(ctx)
+	return value, err == nil
 }
 
 // Remove removes the specific element
 func (q *BlockingQueue[E]) Remove(value E) {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	var items []E
 	for _, item := range q.items {
-		if !reflect.DeepEqual(item, value) {
+		if !equal.Equal(item, value) {
 			items = append(items, item)
 		}
 	}
@@ -212,9 +246,8 @@ func (q *BlockingQueue[E]) Remove(value E) {
 
 // RemoveWhere removes elements which matches the callback
 func (q *BlockingQueue[E]) RemoveWhere(callback func(E) bool) {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	var items []E
 	for _, item := range q.items {
 		if !callback(item) {
@@ -225,17 +258,90 @@ func (q *BlockingQueue[E]) RemoveWhere(callback func(E) bool) {
 	q.size = int64(len(items))
 }
 
-// ToArray converts to array
+// ToArray converts to array, the array is a copy of the elements taken under the read lock
 func (q *BlockingQueue[E]) ToArray() []E {
-	if q.lock.TryRLock() {
-		defer q.lock.RUnlock()
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return slices.Clone(q.items)
+}
+
+// All returns a sequence of the indexes and elements from head to tail of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *BlockingQueue[E]) All() func(yield func(index int, value E) bool) {
+	return snapshotAll(q.ToArray)
+}
+
+// Values returns a sequence of the elements from head to tail of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *BlockingQueue[E]) Values() func(yield func(value E) bool) {
+	return snapshotValues(q.ToArray)
+}
+
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *BlockingQueue[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[BlockingQueue[E]]() + 2*memory.Of[sync.Cond]() + memory.Of[sync.RWMutex]() + memory.Slice(q.items, deep)
+}
+
+// AppendNDJSON writes the elements of the queue to the writer one JSON element per line without copying the queue
+func (q *BlockingQueue[E]) AppendNDJSON(w io.Writer) error {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return codec.Encode(w, q.items, codec.NDJSON)
+}
+
+// ReadNDJSON enqueues the JSON elements read from the reader one per line as they are decoded,
+// it blocks while the queue is full so a slow consumer throttles the reader,
+// elements decoded before an error are kept.
+// It stops reading and returns [collection.ErrClosed] once the queue is closed.
+func (q *BlockingQueue[E]) ReadNDJSON(r io.Reader) error {
+	var err error
+	if readErr := codec.ReadNDJSON(r, func(value E) bool {
+		err = q.Put(value)
+		return err == nil
+	}); readErr != nil {
+		return readErr
 	}
-	return q.items
+	return err
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *BlockingQueue[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode enqueues the elements read from the reader in the format, it blocks while the queue is full.
+// It stops and returns [collection.ErrClosed] once the queue is closed.
+func (q *BlockingQueue[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := q.Put(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (q *BlockingQueue[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := q.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (q *BlockingQueue[E]) GobDecode(data []byte) error {
+	return q.Decode(bytes.NewReader(data), codec.Gob)
 }
 
 // ToJSON converts to json
 func (q *BlockingQueue[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(q.ToArray())
+	return jsonx.Array(q.ToArray())
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -245,17 +351,23 @@ func (q *BlockingQueue[E]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements [json.Unmarshaller]
 func (q *BlockingQueue[E]) UnmarshalJSON(data []byte) error {
-	if q.lock.TryLock() {
-		defer q.lock.Unlock()
+	if jsonx.IsNull(data) {
+		q.Clear()
+		return nil
 	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	values := make([]E, 0)
 	if err := json.Unmarshal(data, &values); err != nil {
 		return err
 	}
 	for _, value := range values {
-		for q.size == q.cap {
+		for q.size == q.cap && !q.closed {
 			q.putLock.Wait()
 		}
+		if q.closed {
+			return collection.ErrClosed
+		}
 		q.items = append(q.items, value)
 		q.size++
 		q.takeLock.Broadcast()
@@ -265,29 +377,37 @@ func (q *BlockingQueue[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (q *BlockingQueue[E]) String() string {
-	if q.lock.TryRLock() {
-		defer q.lock.RUnlock()
-	}
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("BlockingQueue[%T](len=%d)", *new(E), q.size))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	for index, value := range q.items {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		if index >= 4 {
-			break
+	items := q.ToArray()
+	return format.Values("BlockingQueue", int64(len(items)), format.Slice(items))
+}
+
+// snapshotAll returns a sequence of the indexes and elements of the array returned by toArray when it is ranged
+func snapshotAll[E any](toArray func() []E) func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for index, value := range toArray() {
+			if !yield(index, value) {
+				return
+			}
 		}
 	}
-	if q.size > 5 {
-		str.WriteString("\t...\n")
+}
+
+// snapshotValues returns a sequence of the elements of the array returned by toArray when it is ranged
+func snapshotValues[E any](toArray func() []E) func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, value := range toArray() {
+			if !yield(value) {
+				return
+			}
+		}
 	}
-	str.WriteByte('}')
-	return str.String()
+}
+
+// wakeOnDone wakes the waiters of the cond once the context is done, the returned func stops waiting for the context
+func wakeOnDone(ctx context.Context, cond *sync.Cond) func() bool {
+	return context.AfterFunc(ctx, func() {
+		cond.L.Lock()
+		defer cond.L.Unlock()
+		cond.Broadcast()
+	})
 }
@@ -1,12 +1,17 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -143,7 +148,37 @@ func TestBlockingQueue_ToArray(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		queue.Enqueue(i)
 	}
-	assert.Equal(t, []int{0, 1, 2, 3, 4}, queue.ToArray())
+	items := queue.ToArray()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, items)
+	queue.Dequeue()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, items)
+}
+
+func TestBlockingQueue_All(t *testing.T) {
+	queue := NewBlockingQueue[int](5)
+	for i := 0; i < 3; i++ {
+		queue.Enqueue(i)
+	}
+	var indexes, items []int
+	queue.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		queue.Dequeue()
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{0, 1, 2}, items)
+	assert.True(t, queue.IsEmpty())
+
+	queue.Enqueue(3)
+	items = nil
+	queue.Values()(func(value int) bool {
+		items = append(items, value)
+		queue.Enqueue(4)
+		return true
+	})
+	assert.Equal(t, []int{3}, items)
+	assert.Equal(t, int64(2), queue.Count())
 }
 
 func TestBlockingQueue_ToJSON(t *testing.T) {
@@ -204,3 +239,116 @@ func TestBlockingQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(3), queue.Count())
 	assert.Equal(t, []int{0, 2, 4}, queue.ToArray())
 }
+
+func TestBlockingQueue_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	q := NewBlockingQueue[int](3)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+	assert.Nil(t, q.Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestBlockingQueue_Decode(t *testing.T) {
+	x := NewBlockingQueue[int](3)
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+
+	// a closed queue stops the decoding instead of blocking or dropping the elements silently
+	x = NewBlockingQueue[int](1)
+	x.Close()
+	assert.ErrorIs(t, x.Decode(strings.NewReader("1\n2\n"), codec.NDJSON), collection.ErrClosed)
+	assert.ErrorIs(t, x.ReadNDJSON(strings.NewReader("1\n2\n")), collection.ErrClosed)
+	assert.True(t, x.IsEmpty())
+}
+
+func TestBlockingQueue_AppendNDJSON(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	assert.Nil(t, q.ReadNDJSON(strings.NewReader("1\n2\n")))
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Equal(t, "1\n2\n", buf.String())
+}
+
+func TestBlockingQueue_JSONNull(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	q.Enqueue(1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), q))
+	assert.True(t, q.IsEmpty())
+	data, _ := json.Marshal(q)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestBlockingQueue_Repack(t *testing.T) {
+	q := NewBlockingQueue[*int](4)
+	q.Enqueue(new(int))
+	q.Enqueue(new(int))
+	q.Dequeue()
+	q.Repack()
+	assert.Equal(t, 1, cap(q.items))
+	assert.Equal(t, int64(1), q.Count())
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	queue := NewBlockingQueue[int](1)
+	taken := make(chan error)
+	go func() {
+		_, err := queue.Take()
+		taken <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-taken, collection.ErrClosed)
+	assert.True(t, queue.IsClosed())
+	assert.ErrorIs(t, queue.Put(1), collection.ErrClosed)
+	assert.False(t, queue.TryEnqueue(1))
+	assert.False(t, queue.EnqueueTimeout(1, 10*time.Millisecond))
+	queue.Close()
+
+	queue = NewBlockingQueue[int](1)
+	assert.Nil(t, queue.Put(1))
+	put := make(chan error)
+	go func() {
+		put <- queue.Put(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-put, collection.ErrClosed)
+	value, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+	_, ok = queue.DequeueTimeout(10 * time.Millisecond)
+	assert.False(t, ok)
+}
+
+func TestBlockingQueue_DequeueContext(t *testing.T) {
+	queue := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Enqueue(1)
+	}()
+	value, err := queue.DequeueContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestBlockingQueue_EnqueueContext(t *testing.T) {
+	queue := NewBlockingQueue[int](1)
+	assert.Nil(t, queue.EnqueueContext(context.Background(), 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, queue.EnqueueContext(ctx, 2), context.Canceled)
+	assert.Equal(t, int64(1), queue.Count())
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,234 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gopi-frame/collection"
+)
+
+// Interface operations shared by queues and channel queues
+type Interface[E any] interface {
+	Count() int64
+	IsEmpty() bool
+	IsNotEmpty() bool
+	Enqueue(value E) bool
+	Dequeue() (E, bool)
+}
+
+var (
+	_ Interface[any] = (*Queue[any])(nil)
+	_ Interface[any] = (*LinkedQueue[any])(nil)
+	_ Interface[any] = (*Deque[any])(nil)
+	_ Interface[any] = (*PriorityQueue[any])(nil)
+	_ Interface[any] = (*StablePriorityQueue[any])(nil)
+	_ Interface[any] = (*BlockingQueue[any])(nil)
+	_ Interface[any] = (*LinkedBlockingQueue[any])(nil)
+	_ Interface[any] = (*PriorityBlockingQueue[any])(nil)
+	_ Interface[any] = (*ChanQueue[any])(nil)
+)
+
+// pollInterval the interval to poll an empty queue in [AsChan]
+var pollInterval = time.Millisecond
+
+// NewChanQueue new channel queue with a buffered channel of the given capacity
+func NewChanQueue[E any](cap int) *ChanQueue[E] {
+	return WrapChan(make(chan E, cap))
+}
+
+// WrapChan wraps a native channel as a queue
+func WrapChan[E any](ch chan E) *ChanQueue[E] {
+	queue := new(ChanQueue[E])
+	queue.ch = ch
+	return queue
+}
+
+// ChanQueue queue over a native channel.
+// It is safe for concurrent use, Enqueue and Dequeue block like the operations of the channel.
+type ChanQueue[E any] struct {
+	ch     chan E
+	close  sync.Once
+	closed atomic.Bool
+}
+
+// Chan returns the underlying channel
+func (q *ChanQueue[E]) Chan() chan E {
+	return q.ch
+}
+
+// Count returns the number of elements buffered in the channel
+func (q *ChanQueue[E]) Count() int64 {
+	return int64(len(q.ch))
+}
+
+// IsEmpty returns whether the queue is empty
+func (q *ChanQueue[E]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// IsNotEmpty returns whether the queue is not empty
+func (q *ChanQueue[E]) IsNotEmpty() bool {
+	return !q.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (q *ChanQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *ChanQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
+// Clear drains the buffered elements
+func (q *ChanQueue[E]) Clear() {
+	for {
+		if _, ok := q.TryDequeue(); !ok {
+			return
+		}
+	}
+}
+
+// TryEnqueue enqueues a new element into the queue, it will return false if the channel is full
+func (q *ChanQueue[E]) TryEnqueue(value E) bool {
+	select {
+	case q.ch <- value:
+		return true
+	default:
+		return false
+	}
+}
+
+// TryDequeue dequeues the first element of the queue and returns it.
+// The empty value of the element type and false will be returned when the queue is empty or closed
+func (q *ChanQueue[E]) TryDequeue() (E, bool) {
+	select {
+	case value, ok := <-q.ch:
+		return value, ok
+	default:
+		return *new(E), false
+	}
+}
+
+// Enqueue enqueues a new element into the queue, it will block if the channel is full
+func (q *ChanQueue[E]) Enqueue(value E) bool {
+	q.ch <- value
+	return true
+}
+
+// Dequeue dequeues the first element of queue, it will block if the queue is empty.
+// It will return zero value and false when the queue is closed and drained
+func (q *ChanQueue[E]) Dequeue() (E, bool) {
+	value, ok := <-q.ch
+	return value, ok
+}
+
+// EnqueueTimeout enqueues element into the queue.
+// It will return true if the element is successfully enqueued or false when time is out
+func (q *ChanQueue[E]) EnqueueTimeout(value E, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case q.ch <- value:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// DequeueTimeout removes the first element and returns it.
+// It will return zero value and false when time is out or the queue is closed and drained
+func (q *ChanQueue[E]) DequeueTimeout(duration time.Duration) (E, bool) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case value, ok := <-q.ch:
+		return value, ok
+	case <-timer.C:
+		return *new(E), false
+	}
+}
+
+// Close closes the underlying channel, elements must not be enqueued after closing.
+// Closing the queue again has no effect.
+func (q *ChanQueue[E]) Close() {
+	q.close.Do(func() {
+		q.closed.Store(true)
+		close(q.ch)
+	})
+}
+
+// IsClosed returns whether the queue is closed by Close
+func (q *ChanQueue[E]) IsClosed() bool {
+	return q.closed.Load()
+}
+
+// FromChan returns a blocking queue with the given capacity which is filled with the elements received from the channel.
+// Enqueuing blocks while the queue is full and stops when the channel is closed.
+func FromChan[E any](ch <-chan E, buffer int) *BlockingQueue[E] {
+	queue := NewBlockingQueue[E](int64(buffer))
+	go func() {
+		for value := range ch {
+			queue.Enqueue(value)
+		}
+	}()
+	return queue
+}
+
+// AsChan returns a channel which receives the elements dequeued from the queue until the context is done.
+// Queues exposing TryDequeue are polled without blocking, queues implementing [sync.Locker] are locked while dequeuing.
+// An element which is dequeued but not received before the context is done is enqueued back at the tail of the queue,
+// with TryEnqueue when the queue exposes it, so it is only lost when a bounded queue is filled or closed meanwhile.
+func AsChan[E any](ctx context.Context, queue Interface[E]) <-chan E {
+	dequeue, enqueue := queue.Dequeue, queue.Enqueue
+	if q, ok := queue.(interface{ TryDequeue() (E, bool) }); ok {
+		dequeue = q.TryDequeue
+	}
+	if q, ok := queue.(interface{ TryEnqueue(value E) bool }); ok {
+		enqueue = q.TryEnqueue
+	}
+	if q, ok := queue.(interface{ IsClosed() bool }); ok {
+		next := enqueue
+		enqueue = func(value E) bool {
+			return !q.IsClosed() && next(value)
+		}
+	}
+	if locker, ok := queue.(sync.Locker); ok {
+		next, put := dequeue, enqueue
+		dequeue = func() (E, bool) {
+			locker.Lock()
+			defer locker.Unlock()
+			return next()
+		}
+		enqueue = func(value E) bool {
+			locker.Lock()
+			defer locker.Unlock()
+			return put(value)
+		}
+	}
+	ch := make(chan E)
+	go func() {
+		defer close(ch)
+		for {
+			value, ok := dequeue()
+			if !ok {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(pollInterval):
+				}
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				enqueue(value)
+				return
+			case ch <- value:
+			}
+		}
+	}()
+	return ch
+}
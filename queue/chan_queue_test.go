@@ -0,0 +1,120 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanQueue_TryEnqueue(t *testing.T) {
+	queue := NewChanQueue[int](2)
+	assert.True(t, queue.TryEnqueue(1))
+	assert.True(t, queue.TryEnqueue(2))
+	assert.False(t, queue.TryEnqueue(3))
+	assert.Equal(t, int64(2), queue.Count())
+}
+
+func TestChanQueue_TryDequeue(t *testing.T) {
+	queue := NewChanQueue[int](2)
+	_, ok := queue.TryDequeue()
+	assert.False(t, ok)
+	queue.Enqueue(1)
+	value, ok := queue.TryDequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestChanQueue_Dequeue(t *testing.T) {
+	queue := NewChanQueue[int](0)
+	go queue.Enqueue(1)
+	value, ok := queue.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	queue.Close()
+	assert.True(t, queue.IsClosed())
+	queue.Close()
+	_, ok = queue.Dequeue()
+	assert.False(t, ok)
+}
+
+func TestChanQueue_Timeout(t *testing.T) {
+	queue := NewChanQueue[int](1)
+	assert.True(t, queue.EnqueueTimeout(1, 10*time.Millisecond))
+	assert.False(t, queue.EnqueueTimeout(2, 10*time.Millisecond))
+	value, ok := queue.DequeueTimeout(10 * time.Millisecond)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	_, ok = queue.DequeueTimeout(10 * time.Millisecond)
+	assert.False(t, ok)
+}
+
+func TestChanQueue_Clear(t *testing.T) {
+	queue := NewChanQueue[int](3)
+	queue.Enqueue(1)
+	queue.Enqueue(2)
+	queue.Clear()
+	assert.True(t, queue.IsEmpty())
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int)
+	queue := FromChan(ch, 5)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	value, ok := queue.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = queue.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestAsChan(t *testing.T) {
+	t.Run("queue", func(t *testing.T) {
+		queue := NewQueue(1, 2)
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := AsChan[int](ctx, queue)
+		assert.Equal(t, 1, <-ch)
+		assert.Equal(t, 2, <-ch)
+		queue.Lock()
+		queue.Enqueue(3)
+		queue.Unlock()
+		assert.Equal(t, 3, <-ch)
+		cancel()
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+
+	t.Run("blocking queue", func(t *testing.T) {
+		queue := NewLinkedBlockingQueue[int](5)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch := AsChan[int](ctx, queue)
+		queue.Enqueue(1)
+		select {
+		case value := <-ch:
+			assert.Equal(t, 1, value)
+		case <-time.After(time.Second):
+			assert.FailNow(t, "element is not received")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		queue := NewQueue(1, 2)
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := AsChan[int](ctx, queue)
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		assert.Eventually(t, func() bool {
+			queue.Lock()
+			defer queue.Unlock()
+			return queue.Count() == 2
+		}, time.Second, time.Millisecond)
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.Equal(t, []int{2, 1}, queue.ToArray())
+	})
+}
@@ -1,13 +1,19 @@
 package queue
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
-	"strings"
+	"io"
 	"sync"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/contract"
 )
 
@@ -15,16 +21,21 @@ import (
 func NewDelayedQueue[Q contract.Delayable[T], T any]() *DelayedQueue[Q, T] {
 	queue := new(DelayedQueue[Q, T])
 	queue.items = NewPriorityQueue[Q](queue)
-	queue.takeLock = sync.NewCond(queue.items)
+	queue.lock = new(sync.RWMutex)
+	queue.takeLock = sync.NewCond(queue.lock)
 	return queue
 }
 
-// DelayedQueue delayed queue
+// DelayedQueue blocking queue which releases each element once its delay elapses, the earliest first.
+// It is safe for concurrent use like [BlockingQueue].
 type DelayedQueue[Q contract.Delayable[T], T any] struct {
 	items    *PriorityQueue[Q]
+	lock     *sync.RWMutex
 	takeLock *sync.Cond
+	closed   bool
 }
 
+// Compare orders the elements by the time their delays elapse
 func (q *DelayedQueue[Q, T]) Compare(a, b Q) int {
 	if a.Until().Before(b.Until()) {
 		return -1
@@ -35,179 +46,249 @@ func (q *DelayedQueue[Q, T]) Compare(a, b Q) int {
 	}
 }
 
+// Count returns the size of queue
 func (q *DelayedQueue[Q, T]) Count() int64 {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.lock.RLock()
+	defer q.lock.RUnlock()
 	return q.items.Count()
 }
 
+// IsEmpty returns whether the queue is empty
 func (q *DelayedQueue[Q, T]) IsEmpty() bool {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	return q.items.IsEmpty()
+	return q.Count() == 0
 }
 
+// IsNotEmpty returns whether the queue is not empty
 func (q *DelayedQueue[Q, T]) IsNotEmpty() bool {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	return q.items.IsNotEmpty()
+	return !q.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (q *DelayedQueue[Q, T]) ElementType() string {
+	return collection.TypeName[Q]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *DelayedQueue[Q, T]) Kind() collection.CollectionKind {
+	return collection.KindQueue
 }
 
+// Clear clears the queue
 func (q *DelayedQueue[Q, T]) Clear() {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	q.items.Clear()
 }
 
+// Peek returns the element whose delay elapses first, whether or not it has elapsed
 func (q *DelayedQueue[Q, T]) Peek() (Q, bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	return q.items.Peek()
 }
 
+// TryEnqueue enqueues a new element into the queue like Enqueue, the queue is unbounded so it never blocks
 func (q *DelayedQueue[Q, T]) TryEnqueue(value Q) bool {
 	return q.Enqueue(value)
 }
 
+// Enqueue enqueues a new element into the queue, it returns false when the queue is closed
 func (q *DelayedQueue[Q, T]) Enqueue(value Q) bool {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return false
 	}
 	ok := q.items.Enqueue(value)
 	q.takeLock.Broadcast()
 	return ok
 }
 
+// EnqueueTimeout enqueues a new element into the queue like Enqueue, the queue is unbounded so it never waits
 func (q *DelayedQueue[Q, T]) EnqueueTimeout(value Q, _ time.Duration) bool {
 	return q.Enqueue(value)
 }
 
+// TryDequeue dequeues the first element when its delay has elapsed, otherwise it returns a zero value and false
 func (q *DelayedQueue[Q, T]) TryDequeue() (Q, bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	if v, ok := q.items.Peek(); ok && v.Until().Before(time.Now()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if v, ok := q.items.Peek(); ok && !v.Until().After(time.Now()) {
 		return q.items.Dequeue()
 	}
 	return *new(Q), false
 }
 
+// Dequeue dequeues the first element once its delay elapses, it will block if the queue is empty.
+// It returns a zero value and false when the queue is closed and drained.
 func (q *DelayedQueue[Q, T]) Dequeue() (Q, bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	for q.items.IsEmpty() {
-		q.takeLock.Wait()
-	}
-	v, _ := q.items.Peek()
-	timer := time.NewTimer(time.Until(v.Until()))
-	defer timer.Stop()
-	<-timer.C
-	return q.items.Dequeue()
+	value, err := q.DequeueContext(context.Background())
+	return value, err == nil
 }
 
+// DequeueTimeout dequeues the first element once its delay elapses, it will block if the queue is empty.
+// It returns a zero value and false when time is out before an element is due or the queue is closed and drained.
 func (q *DelayedQueue[Q, T]) DequeueTimeout(duration time.Duration) (Q, bool) {
-	timeout := time.After(duration)
-	done := make(chan struct{})
-	go func() {
-		q.items.Lock()
-		defer q.items.Unlock()
-		for q.items.IsEmpty() {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	value, err := q.DequeueContext(ctx)
+	return value, err == nil
+}
+
+// DequeueContext dequeues the first element once its delay elapses, it will block if the queue is empty.
+// An element enqueued while waiting is taken first when its delay elapses earlier.
+// It returns the error of the context when the context is done before an element is dequeued,
+// or [collection.ErrClosed] once the queue is closed and drained.
+func (q *DelayedQueue[Q, T]) DequeueContext(ctx context.Context) (Q, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer wakeOnDone(ctx, q.takeLock)()
+	for {
+		for q.items.IsEmpty() && !q.closed && ctx.Err() == nil {
 			q.takeLock.Wait()
 		}
-		if v, ok := q.items.Peek(); ok {
-			timer := time.NewTimer(time.Until(v.Until()))
-			defer timer.Stop()
-			<-timer.C
-			close(done)
+		if err := ctx.Err(); err != nil {
+			return *new(Q), err
 		}
-
-	}()
-	select {
-	case <-timeout:
-		return *new(Q), false
-	case <-done:
-		return q.items.Dequeue()
+		v, ok := q.items.Peek()
+		if !ok {
+			return *new(Q), collection.ErrClosed
+		}
+		delay := time.Until(v.Until())
+		if delay <= 0 {
+			value, _ := q.items.Dequeue()
+			return value, nil
+		}
+		timer := time.AfterFunc(delay, func() {
+			q.lock.Lock()
+			defer q.lock.Unlock()
+			q.takeLock.Broadcast()
+		})
+		q.takeLock.Wait()
+		timer.Stop()
 	}
 }
 
+// Close closes the queue and wakes all blocked consumers.
+// Elements can no longer be enqueued, the elements left can still be dequeued once their delay elapses.
+func (q *DelayedQueue[Q, T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closed = true
+	q.takeLock.Broadcast()
+}
+
+// IsClosed returns whether the queue is closed
+func (q *DelayedQueue[Q, T]) IsClosed() bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.closed
+}
+
+// Remove removes the elements with the same value and the same time as the specific element
 func (q *DelayedQueue[Q, T]) Remove(value Q) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
 	q.RemoveWhere(func(v Q) bool {
-		return reflect.DeepEqual(v.Value(), value.Value()) && v.Until() == value.Until()
+		return equal.Equal(v.Value(), value.Value()) && v.Until().Equal(value.Until())
 	})
 }
 
+// RemoveWhere removes elements which matches the callback
 func (q *DelayedQueue[Q, T]) RemoveWhere(callback func(value Q) bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
 	q.items.RemoveWhere(callback)
 }
 
+// ToArray converts to array, the array is a copy of the elements taken under the read lock
 func (q *DelayedQueue[Q, T]) ToArray() []Q {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.lock.RLock()
+	defer q.lock.RUnlock()
 	return q.items.ToArray()
 }
 
-func (q *DelayedQueue[Q, T]) ToJSON() ([]byte, error) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
+// All returns a sequence of the indexes and elements in the order of ToArray of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *DelayedQueue[Q, T]) All() func(yield func(index int, value Q) bool) {
+	return snapshotAll(q.ToArray)
+}
+
+// Values returns a sequence of the elements in the order of ToArray of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *DelayedQueue[Q, T]) Values() func(yield func(value Q) bool) {
+	return snapshotValues(q.ToArray)
+}
+
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *DelayedQueue[Q, T]) MemoryFootprint(deep func(value Q) int64) int64 {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return memory.Of[DelayedQueue[Q, T]]() + memory.Of[sync.RWMutex]() + memory.Of[sync.Cond]() + q.items.MemoryFootprint(deep)
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *DelayedQueue[Q, T]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode enqueues the elements read from the reader in the format.
+// It stops and returns [collection.ErrClosed] once the queue is closed.
+func (q *DelayedQueue[Q, T]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[Q](r, format)
+	if err != nil {
+		return err
+	}
+	return q.enqueueAll(items)
+}
+
+// enqueueAll enqueues the items, it stops and returns [collection.ErrClosed] once the queue is closed.
+func (q *DelayedQueue[Q, T]) enqueueAll(items []Q) error {
+	for _, item := range items {
+		if !q.Enqueue(item) {
+			return collection.ErrClosed
+		}
 	}
-	return json.Marshal(q.items.ToArray())
+	return nil
 }
 
+// ToJSON converts to json
+func (q *DelayedQueue[Q, T]) ToJSON() ([]byte, error) {
+	return jsonx.Array(q.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
 func (q *DelayedQueue[Q, T]) MarshalJSON() ([]byte, error) {
 	return q.ToJSON()
 }
 
+// UnmarshalJSON implements [json.Unmarshaller], the elements are enqueued.
+// It stops and returns [collection.ErrClosed] once the queue is closed.
 func (q *DelayedQueue[Q, T]) UnmarshalJSON(data []byte) error {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
+	if jsonx.IsNull(data) {
+		q.Clear()
+		return nil
 	}
 	var items []Q
-	err := json.Unmarshal(data, &items)
-	if err != nil {
+	if err := json.Unmarshal(data, &items); err != nil {
 		return err
 	}
-	for _, item := range items {
-		q.Enqueue(item)
-	}
-	return nil
+	return q.enqueueAll(items)
 }
 
+// String converts to string
 func (q *DelayedQueue[Q, T]) String() string {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("DelayedQueue[%T](len=%d)", *new(T), q.items.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
 	items := q.ToArray()
-	for index, item := range items {
-		str.WriteByte('\t')
-		if v, ok := any(item).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("value: %v, unitl: %v", item.Value(), item.Until().Format("2006-01-02 15:04:05")))
-		}
-		if index >= 4 {
-			break
+	return format.ValuesOf[T, string]("DelayedQueue", int64(len(items)), func(yield func(value string) bool) {
+		for _, item := range items {
+			if v, ok := any(item).(contract.Stringable); ok {
+				if !yield(v.String()) {
+					return
+				}
+				continue
+			}
+			if !yield(fmt.Sprintf("value: %v, until: %v", item.Value(), item.Until().Format(time.DateTime))) {
+				return
+			}
 		}
-	}
-	if q.Count() > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	})
 }
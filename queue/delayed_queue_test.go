@@ -1,11 +1,16 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -147,6 +152,45 @@ func TestDelayedQueue_DequeueTimeout(t *testing.T) {
 	assert.Equal(t, 1, v.Value())
 }
 
+func TestDelayedQueue_DequeueContext(t *testing.T) {
+	queue := NewDelayedQueue[*_delay]()
+	queue.Enqueue(&_delay{value: 1, until: time.Now().Add(time.Hour)})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Enqueue(&_delay{value: 2, until: time.Now()})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, err := queue.DequeueContext(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value.Value())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(1), queue.Count())
+}
+
+func TestDelayedQueue_Close(t *testing.T) {
+	queue := NewDelayedQueue[*_delay]()
+	queue.Enqueue(&_delay{value: 1, until: time.Now()})
+	taken := make(chan bool)
+	go func() {
+		_, ok := queue.Dequeue()
+		taken <- ok
+		_, ok = queue.Dequeue()
+		taken <- ok
+	}()
+	assert.True(t, <-taken)
+	queue.Close()
+	assert.False(t, <-taken)
+	assert.True(t, queue.IsClosed())
+	assert.False(t, queue.Enqueue(&_delay{value: 2, until: time.Now()}))
+	_, ok := queue.DequeueTimeout(time.Second)
+	assert.False(t, ok)
+}
+
 func TestDelayedQueue_Remove(t *testing.T) {
 	queue := NewDelayedQueue[*_delay]()
 	now := time.Now()
@@ -177,6 +221,44 @@ func TestDelayedQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(3), queue.Count())
 }
 
+func TestDelayedQueue_All(t *testing.T) {
+	queue := NewDelayedQueue[*_delay]()
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		queue.Enqueue(&_delay{value: i, until: now.Add(-time.Duration(i) * time.Second)})
+	}
+	var indexes, items []int
+	queue.All()(func(index int, value *_delay) bool {
+		indexes = append(indexes, index)
+		items = append(items, value.value)
+		queue.TryDequeue()
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.ElementsMatch(t, []int{0, 1, 2}, items)
+	assert.True(t, queue.IsEmpty())
+
+	queue.Enqueue(&_delay{value: 3, until: now})
+	items = nil
+	queue.Values()(func(value *_delay) bool {
+		items = append(items, value.value)
+		return true
+	})
+	assert.Equal(t, []int{3}, items)
+}
+
+func TestDelayedQueue_String(t *testing.T) {
+	queue := NewDelayedQueue[*_delay]()
+	until := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	queue.Enqueue(&_delay{value: 1, until: until})
+	assert.Equal(t, "DelayedQueue[int](len=1){\n\tvalue: 1, until: 2024-01-02 03:04:05,\n}", queue.String())
+
+	for i := 0; i < 6; i++ {
+		queue.Enqueue(&_delay{value: i, until: until})
+	}
+	assert.True(t, strings.HasSuffix(queue.String(), ",\n\t...\n}"))
+}
+
 func TestDelayedQueue_ToArray(t *testing.T) {
 	queue := NewDelayedQueue[*_delay]()
 	now := time.Now()
@@ -201,6 +283,25 @@ func TestDelayedQueue_ToArray(t *testing.T) {
 	assert.ElementsMatch(t, expect, actual)
 }
 
+func TestDelayedQueue_Decode(t *testing.T) {
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	queue := NewDelayedQueue[*_delay]()
+	queue.Enqueue(&_delay{value: 1, until: until})
+	buf := new(bytes.Buffer)
+	assert.Nil(t, queue.Encode(buf, codec.NDJSON))
+
+	decoded := NewDelayedQueue[*_delay]()
+	assert.Nil(t, decoded.Decode(buf, codec.NDJSON))
+	value, ok := decoded.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value.Value())
+	assert.True(t, until.Equal(value.Until()))
+
+	decoded.Close()
+	assert.ErrorIs(t, decoded.Decode(strings.NewReader(`{"value":2}`), codec.NDJSON), collection.ErrClosed)
+	assert.Equal(t, int64(1), decoded.Count())
+}
+
 func TestDelayedQueue_MarshalJSON(t *testing.T) {
 	queue := NewDelayedQueue[*_delay]()
 	now := time.Now()
@@ -292,4 +393,9 @@ func TestDelayedQueue_UnmarshalJSON(t *testing.T) {
 	}
 
 	assert.ElementsMatch(t, expect, actual)
+
+	queue.Close()
+	err = json.Unmarshal([]byte(jsonBytes), queue)
+	assert.ErrorIs(t, err, collection.ErrClosed)
+	assert.Equal(t, int64(5), queue.Count())
 }
@@ -0,0 +1,233 @@
+package queue
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// NewDeque new deque, the values are pushed to the back in order
+func NewDeque[E any](values ...E) *Deque[E] {
+	deque := new(Deque[E])
+	deque.PushBack(values...)
+	return deque
+}
+
+// Deque double-ended queue backed by a growable ring buffer,
+// pushing and popping at both ends take amortized O(1)
+type Deque[E any] struct {
+	sync.RWMutex
+	items []E
+	head  int
+	size  int
+}
+
+// index returns the position in the buffer of the offset from the front
+func (d *Deque[E]) index(offset int) int {
+	return (d.head + offset) % len(d.items)
+}
+
+// grow makes room for n more elements, the elements are moved to the start of the new buffer
+func (d *Deque[E]) grow(n int) {
+	if d.size+n <= len(d.items) {
+		return
+	}
+	capacity := max(2*len(d.items), d.size+n, 8)
+	items := make([]E, capacity)
+	if d.size > 0 {
+		tail := copy(items, d.items[d.head:min(d.head+d.size, len(d.items))])
+		copy(items[tail:], d.items[:d.size-tail])
+	}
+	d.items = items
+	d.head = 0
+}
+
+// Count returns the size of deque
+func (d *Deque[E]) Count() int64 {
+	return int64(d.size)
+}
+
+// IsEmpty returns whether the deque is empty
+func (d *Deque[E]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// IsNotEmpty returns whether the deque is not empty
+func (d *Deque[E]) IsNotEmpty() bool {
+	return !d.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (d *Deque[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (d *Deque[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
+// Clear clears the deque
+func (d *Deque[E]) Clear() {
+	clear(d.items)
+	d.head = 0
+	d.size = 0
+}
+
+// PushFront pushes the values to the front, the last value ends up first
+func (d *Deque[E]) PushFront(values ...E) {
+	d.grow(len(values))
+	for _, value := range values {
+		d.head = (d.head - 1 + len(d.items)) % len(d.items)
+		d.items[d.head] = value
+		d.size++
+	}
+}
+
+// PushBack pushes the values to the back
+func (d *Deque[E]) PushBack(values ...E) {
+	d.grow(len(values))
+	for _, value := range values {
+		d.items[d.index(d.size)] = value
+		d.size++
+	}
+}
+
+// PopFront removes and returns the first element
+func (d *Deque[E]) PopFront() (E, bool) {
+	if d.size == 0 {
+		return *new(E), false
+	}
+	value := d.items[d.head]
+	d.items[d.head] = *new(E)
+	d.head = d.index(1)
+	d.size--
+	return value, true
+}
+
+// PopBack removes and returns the last element
+func (d *Deque[E]) PopBack() (E, bool) {
+	if d.size == 0 {
+		return *new(E), false
+	}
+	index := d.index(d.size - 1)
+	value := d.items[index]
+	d.items[index] = *new(E)
+	d.size--
+	return value, true
+}
+
+// PeekFront returns the first element
+func (d *Deque[E]) PeekFront() (E, bool) {
+	if d.size == 0 {
+		return *new(E), false
+	}
+	return d.items[d.head], true
+}
+
+// PeekBack returns the last element
+func (d *Deque[E]) PeekBack() (E, bool) {
+	if d.size == 0 {
+		return *new(E), false
+	}
+	return d.items[d.index(d.size-1)], true
+}
+
+// Get returns the element at the index from the front
+func (d *Deque[E]) Get(index int) (E, bool) {
+	if index < 0 || index >= d.size {
+		return *new(E), false
+	}
+	return d.items[d.index(index)], true
+}
+
+// Peek returns the first element, it is the same as PeekFront
+func (d *Deque[E]) Peek() (E, bool) {
+	return d.PeekFront()
+}
+
+// Enqueue pushes the element to the back, so the deque can be used as a FIFO queue
+func (d *Deque[E]) Enqueue(value E) bool {
+	d.PushBack(value)
+	return true
+}
+
+// Dequeue removes and returns the first element, it is the same as PopFront
+func (d *Deque[E]) Dequeue() (E, bool) {
+	return d.PopFront()
+}
+
+// Each ranges the deque from front to back, it will break the loop when the callback returns false
+func (d *Deque[E]) Each(callback func(index int, value E) bool) {
+	for i := 0; i < d.size; i++ {
+		if !callback(i, d.items[d.index(i)]) {
+			return
+		}
+	}
+}
+
+// All returns a sequence of the indexes and elements from front to back, it stops when yield returns false
+func (d *Deque[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		d.Each(yield)
+	}
+}
+
+// Values returns a sequence of the elements from front to back, it stops when yield returns false
+func (d *Deque[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		d.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Backward returns a sequence of the indexes and elements from back to front, it stops when yield returns false
+func (d *Deque[E]) Backward() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray converts to array from front to back
+func (d *Deque[E]) ToArray() []E {
+	values := make([]E, 0, d.size)
+	d.Each(func(_ int, value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// ToJSON converts to json
+func (d *Deque[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(d.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (d *Deque[E]) MarshalJSON() ([]byte, error) {
+	return d.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the elements are pushed to the back in the order of the array
+func (d *Deque[E]) UnmarshalJSON(data []byte) error {
+	items := []E{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	d.Clear()
+	d.PushBack(items...)
+	return nil
+}
+
+// String converts to string
+func (d *Deque[E]) String() string {
+	return format.Values("Deque", d.Count(), d.Values())
+}
@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque_PushFront(t *testing.T) {
+	deque := NewDeque(3, 4)
+	deque.PushFront(2, 1)
+	assert.Equal(t, []int{1, 2, 3, 4}, deque.ToArray())
+	value, ok := deque.PeekFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = deque.PeekBack()
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+}
+
+func TestDeque_Pop(t *testing.T) {
+	deque := NewDeque(1, 2, 3)
+	value, ok := deque.PopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = deque.PopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	value, ok = deque.PopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	_, ok = deque.PopFront()
+	assert.False(t, ok)
+	_, ok = deque.PopBack()
+	assert.False(t, ok)
+	assert.True(t, deque.IsEmpty())
+}
+
+func TestDeque_Grow(t *testing.T) {
+	deque := NewDeque[int]()
+	var expected []int
+	for i := 0; i < 100; i++ {
+		if i%3 == 0 && len(expected) > 0 {
+			deque.PopFront()
+			expected = expected[1:]
+		}
+		if i%2 == 0 {
+			deque.PushFront(i)
+			expected = append([]int{i}, expected...)
+		} else {
+			deque.PushBack(i)
+			expected = append(expected, i)
+		}
+	}
+	assert.Equal(t, int64(len(expected)), deque.Count())
+	assert.Equal(t, expected, deque.ToArray())
+	value, ok := deque.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, expected[1], value)
+	_, ok = deque.Get(len(expected))
+	assert.False(t, ok)
+}
+
+func TestDeque_Dequeue(t *testing.T) {
+	var queue Interface[int] = NewDeque[int]()
+	queue.Enqueue(1)
+	queue.Enqueue(2)
+	value, ok := queue.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, int64(1), queue.Count())
+}
+
+func TestDeque_All(t *testing.T) {
+	deque := NewDeque(2, 3)
+	deque.PushFront(1)
+	var items []int
+	deque.Values()(func(value int) bool {
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, items)
+	items = nil
+	deque.All()(func(index int, value int) bool {
+		items = append(items, index, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 1, 2, 2, 3}, items)
+	items = nil
+	deque.Backward()(func(index int, value int) bool {
+		items = append(items, index, value)
+		return index > 1
+	})
+	assert.Equal(t, []int{2, 3, 1, 2}, items)
+}
+
+func TestDeque_Clear(t *testing.T) {
+	deque := NewDeque(1, 2, 3)
+	deque.Clear()
+	assert.True(t, deque.IsEmpty())
+	deque.PushBack(4)
+	assert.Equal(t, []int{4}, deque.ToArray())
+}
+
+func TestDeque_MarshalJSON(t *testing.T) {
+	deque := NewDeque(2, 3)
+	deque.PushFront(1)
+	data, err := json.Marshal(deque)
+	assert.Nil(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+	deque = NewDeque[int]()
+	assert.Nil(t, json.Unmarshal([]byte(`[4,5]`), deque))
+	assert.Equal(t, []int{4, 5}, deque.ToArray())
+}
+
+func TestDeque_String(t *testing.T) {
+	deque := NewDeque(1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`Deque\[int\]\(len=%d\)\{\n\t1,\n\t2,\n\t3,\n\t4,\n\t5,\n\t(\.){3}\n\}`, deque.Count()))
+	assert.True(t, pattern.MatchString(deque.String()))
+}
@@ -0,0 +1,83 @@
+package queue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// modelCap capacity of the bounded queues checked against the reference
+const modelCap = 256
+
+var queueOps = []model.Op[Interface[int], *model.List[int]]{
+	{Name: "Enqueue", Run: func(q Interface[int], reference *model.List[int], r *model.Reader) (any, any) {
+		value := int(r.Byte())
+		if reference.Len() == modelCap {
+			return nil, nil
+		}
+		reference.Push(value)
+		return q.Enqueue(value), true
+	}},
+	{Name: "Dequeue", Run: func(q Interface[int], reference *model.List[int], r *model.Reader) (any, any) {
+		if reference.Len() == 0 {
+			return nil, nil
+		}
+		return model.P(q.Dequeue()), model.P(reference.Shift())
+	}},
+	{Name: "Peek", Run: func(q Interface[int], reference *model.List[int], r *model.Reader) (any, any) {
+		peeker, ok := q.(interface{ Peek() (int, bool) })
+		if !ok {
+			return nil, nil
+		}
+		return model.P(peeker.Peek()), model.P(reference.Get(0))
+	}},
+}
+
+func observeQueue(q Interface[int], reference *model.List[int]) (any, any) {
+	return model.P(q.Count(), q.IsEmpty()), model.P(int64(reference.Len()), reference.Len() == 0)
+}
+
+func checkQueueOperations(t *testing.T, data []byte) {
+	for _, q := range []Interface[int]{
+		NewQueue[int](),
+		NewLinkedQueue[int](),
+		NewBlockingQueue[int](modelCap),
+		NewLinkedBlockingQueue[int](modelCap),
+		NewChanQueue[int](modelCap),
+	} {
+		model.Check(t, q, new(model.List[int]), data, queueOps, observeQueue)
+	}
+}
+
+func FuzzQueue_UnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`[1,2,3]`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{"a":1}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, q := range []interface {
+			json.Unmarshaler
+			ToArray() []int
+		}{NewQueue[int](), NewLinkedQueue[int](), NewBlockingQueue[int](1 << 16)} {
+			if err := json.Unmarshal(data, q); err != nil {
+				continue
+			}
+			encoded, err := json.Marshal(q)
+			assert.Nil(t, err)
+			decoded := NewQueue[int]()
+			assert.Nil(t, json.Unmarshal(encoded, decoded))
+			assert.True(t, model.Equal(q.ToArray(), decoded.ToArray()))
+		}
+	})
+}
+
+func FuzzQueue_Operations(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 1, 2, 1, 1, 3, 0, 4})
+	f.Fuzz(checkQueueOperations)
+}
+
+func TestQueue_Model(t *testing.T) {
+	model.Replay(t, 50, 256, checkQueueOperations)
+}
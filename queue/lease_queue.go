@@ -0,0 +1,245 @@
+package queue
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// Receipt identifies a lease of a [LeaseQueue], every delivery gets a new receipt
+type Receipt uint64
+
+// Lease element delivered by a [LeaseQueue]
+type Lease[E any] struct {
+	// Receipt receipt to ack, nack or extend the lease with
+	Receipt Receipt
+	Value   E
+	// Deliveries number of times the element has been delivered, including this one
+	Deliveries int
+	// Deadline time the element becomes visible again unless it is acked
+	Deadline time.Time
+}
+
+type leaseItem[E any] struct {
+	value      E
+	deliveries int
+	deadline   time.Time
+}
+
+// NewLeaseQueue new lease queue, dequeued elements are invisible for the visibility timeout
+func NewLeaseQueue[E any](visibility time.Duration, values ...E) *LeaseQueue[E] {
+	q := new(LeaseQueue[E])
+	q.visibility = visibility
+	q.leased = make(map[Receipt]*leaseItem[E])
+	q.now = time.Now
+	for _, value := range values {
+		q.ready = append(q.ready, &leaseItem[E]{value: value})
+	}
+	return q
+}
+
+// LeaseQueue queue with two-phase dequeue.
+// Dequeue leases the head element instead of removing it, the element is removed once it is acked,
+// and it becomes visible again when the lease is nacked or not acked before the visibility timeout,
+// so an element is not lost when the worker holding it dies.
+// Elements may be delivered more than once, consumers must tolerate redeliveries.
+// It is safe for concurrent use.
+type LeaseQueue[E any] struct {
+	lock       sync.Mutex
+	visibility time.Duration
+	ready      []*leaseItem[E]
+	leased     map[Receipt]*leaseItem[E]
+	receipt    Receipt
+	now        func() time.Time
+}
+
+// reclaim makes the elements whose leases expired visible again, in order of their deadlines ahead of the other elements
+func (q *LeaseQueue[E]) reclaim() {
+	now := q.now()
+	var expired []*leaseItem[E]
+	for receipt, item := range q.leased {
+		if !now.Before(item.deadline) {
+			expired = append(expired, item)
+			delete(q.leased, receipt)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+	slices.SortFunc(expired, func(a, b *leaseItem[E]) int {
+		return a.deadline.Compare(b.deadline)
+	})
+	q.ready = slices.Insert(q.ready, 0, expired...)
+}
+
+// Count returns the number of elements which are not acked yet, including leased elements
+func (q *LeaseQueue[E]) Count() int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return int64(len(q.ready) + len(q.leased))
+}
+
+// Visible returns the number of elements which can be dequeued
+func (q *LeaseQueue[E]) Visible() int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.reclaim()
+	return int64(len(q.ready))
+}
+
+// InFlight returns the number of elements which are leased
+func (q *LeaseQueue[E]) InFlight() int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.reclaim()
+	return int64(len(q.leased))
+}
+
+// IsEmpty returns whether the queue is empty
+func (q *LeaseQueue[E]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// IsNotEmpty returns whether the queue is not empty
+func (q *LeaseQueue[E]) IsNotEmpty() bool {
+	return !q.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (q *LeaseQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *LeaseQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
+// Clear clears the queue, outstanding leases can no longer be acked
+func (q *LeaseQueue[E]) Clear() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.ready = nil
+	clear(q.leased)
+}
+
+// Enqueue enqueues a new element into the queue
+func (q *LeaseQueue[E]) Enqueue(value E) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.ready = append(q.ready, &leaseItem[E]{value: value})
+	return true
+}
+
+// Dequeue leases the first visible element for the visibility timeout of the queue.
+// It will return false when no element is visible.
+func (q *LeaseQueue[E]) Dequeue() (Lease[E], bool) {
+	return q.DequeueFor(q.visibility)
+}
+
+// DequeueFor leases the first visible element for the visibility timeout.
+// It will return false when no element is visible.
+func (q *LeaseQueue[E]) DequeueFor(visibility time.Duration) (Lease[E], bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.reclaim()
+	if len(q.ready) == 0 {
+		return Lease[E]{}, false
+	}
+	item := q.ready[0]
+	q.ready[0] = nil
+	q.ready = q.ready[1:]
+	item.deliveries++
+	item.deadline = q.now().Add(visibility)
+	q.receipt++
+	q.leased[q.receipt] = item
+	return Lease[E]{Receipt: q.receipt, Value: item.value, Deliveries: item.deliveries, Deadline: item.deadline}, true
+}
+
+// lease returns the item of an unexpired lease
+func (q *LeaseQueue[E]) lease(receipt Receipt) (*leaseItem[E], bool) {
+	q.reclaim()
+	item, ok := q.leased[receipt]
+	return item, ok
+}
+
+// Ack removes the leased element from the queue.
+// It returns false when the lease has expired, the element may have been delivered again.
+func (q *LeaseQueue[E]) Ack(receipt Receipt) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if _, ok := q.lease(receipt); !ok {
+		return false
+	}
+	delete(q.leased, receipt)
+	return true
+}
+
+// Nack releases the lease, the element becomes visible again at the head of the queue.
+// It returns false when the lease has expired.
+func (q *LeaseQueue[E]) Nack(receipt Receipt) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	item, ok := q.lease(receipt)
+	if !ok {
+		return false
+	}
+	delete(q.leased, receipt)
+	q.ready = slices.Insert(q.ready, 0, item)
+	return true
+}
+
+// Extend extends the lease to the visibility timeout from now, such as for long running work.
+// It returns false when the lease has expired.
+func (q *LeaseQueue[E]) Extend(receipt Receipt, visibility time.Duration) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	item, ok := q.lease(receipt)
+	if !ok {
+		return false
+	}
+	item.deadline = q.now().Add(visibility)
+	return true
+}
+
+// ToArray returns the elements which are not acked yet, visible elements first
+func (q *LeaseQueue[E]) ToArray() []E {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.reclaim()
+	items := make([]E, 0, len(q.ready)+len(q.leased))
+	for _, item := range q.ready {
+		items = append(items, item.value)
+	}
+	leased := make([]*leaseItem[E], 0, len(q.leased))
+	for _, item := range q.leased {
+		leased = append(leased, item)
+	}
+	slices.SortFunc(leased, func(a, b *leaseItem[E]) int {
+		return a.deadline.Compare(b.deadline)
+	})
+	for _, item := range leased {
+		items = append(items, item.value)
+	}
+	return items
+}
+
+// ToJSON converts to json
+func (q *LeaseQueue[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(q.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (q *LeaseQueue[E]) MarshalJSON() ([]byte, error) {
+	return q.ToJSON()
+}
+
+// String converts to string
+func (q *LeaseQueue[E]) String() string {
+	items := q.ToArray()
+	return format.Values("LeaseQueue", int64(len(items)), format.Slice(items))
+}
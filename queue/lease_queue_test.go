@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLeaseQueue(now *time.Time, values ...int) *LeaseQueue[int] {
+	q := NewLeaseQueue(time.Second, values...)
+	q.now = func() time.Time {
+		return *now
+	}
+	return q
+}
+
+func TestLeaseQueue_Dequeue(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1, 2)
+	lease, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, lease.Value)
+	assert.Equal(t, 1, lease.Deliveries)
+	assert.Equal(t, now.Add(time.Second), lease.Deadline)
+	assert.Equal(t, int64(1), q.Visible())
+	assert.Equal(t, int64(1), q.InFlight())
+	assert.Equal(t, int64(2), q.Count())
+	lease, _ = q.Dequeue()
+	assert.Equal(t, 2, lease.Value)
+	_, ok = q.Dequeue()
+	assert.False(t, ok)
+}
+
+func TestLeaseQueue_Ack(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1)
+	lease, _ := q.Dequeue()
+	assert.True(t, q.Ack(lease.Receipt))
+	assert.False(t, q.Ack(lease.Receipt))
+	assert.True(t, q.IsEmpty())
+}
+
+func TestLeaseQueue_Redeliver(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1, 2)
+	first, _ := q.Dequeue()
+	now = now.Add(time.Second)
+	assert.False(t, q.Ack(first.Receipt))
+	lease, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, lease.Value)
+	assert.Equal(t, 2, lease.Deliveries)
+	assert.True(t, lease.Receipt != first.Receipt)
+	assert.True(t, q.Ack(lease.Receipt))
+	assert.Equal(t, []int{2}, q.ToArray())
+}
+
+func TestLeaseQueue_Nack(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1, 2)
+	lease, _ := q.Dequeue()
+	assert.True(t, q.Nack(lease.Receipt))
+	assert.False(t, q.Nack(lease.Receipt))
+	lease, _ = q.Dequeue()
+	assert.Equal(t, 1, lease.Value)
+	assert.Equal(t, 2, lease.Deliveries)
+}
+
+func TestLeaseQueue_Extend(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1)
+	lease, _ := q.DequeueFor(time.Second)
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, q.Extend(lease.Receipt, time.Second))
+	now = now.Add(900 * time.Millisecond)
+	assert.Equal(t, int64(0), q.Visible())
+	assert.True(t, q.Ack(lease.Receipt))
+	assert.False(t, q.Extend(lease.Receipt, time.Second))
+}
+
+func TestLeaseQueue_Concurrent(t *testing.T) {
+	q := NewLeaseQueue[int](time.Minute)
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				lease, ok := q.Dequeue()
+				if !ok {
+					return
+				}
+				q.Ack(lease.Receipt)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.True(t, q.IsEmpty())
+}
+
+func TestLeaseQueue_MarshalJSON(t *testing.T) {
+	now := time.Now()
+	q := newTestLeaseQueue(&now, 1, 2, 3)
+	q.Dequeue()
+	data, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[2,3,1]`, string(data))
+	q.Clear()
+	assert.True(t, q.IsEmpty())
+}
+
+func TestLeaseQueue_String(t *testing.T) {
+	q := NewLeaseQueue(time.Second, 1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`LeaseQueue\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t(\.){3}\n\}`, q.Count()))
+	assert.True(t, pattern.MatchString(q.String()))
+}
@@ -1,17 +1,19 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"io"
 	"sync"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/collection/list"
-	"github.com/gopi-frame/contract"
-	"github.com/gopi-frame/exception"
-	"github.com/gopi-frame/future"
-	"github.com/gopi-frame/util/catch"
 )
 
 // NewLinkedBlockingQueue new linked blocking queue
@@ -30,45 +32,51 @@ type LinkedBlockingQueue[E any] struct {
 	cap      int
 	takeLock *sync.Cond
 	putLock  *sync.Cond
+	closed   bool
 }
 
 // Count returns the size of queue
 func (q *LinkedBlockingQueue[E]) Count() int64 {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	return q.items.Count()
 }
 
 // IsEmpty returns whether the queue is empty
 func (q *LinkedBlockingQueue[E]) IsEmpty() bool {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	return q.items.IsEmpty()
 }
 
 // IsNotEmpty returns whether the queue is not empty
 func (q *LinkedBlockingQueue[E]) IsNotEmpty() bool {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	return q.items.IsNotEmpty()
 }
 
+// ElementType returns the name of the element type
+func (q *LinkedBlockingQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *LinkedBlockingQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
 // Clear clears the queue
 func (q *LinkedBlockingQueue[E]) Clear() {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.items.Lock()
+	defer q.items.Unlock()
 	q.items.Clear()
 }
 
 // Peek returns the first element of the queue
 func (q *LinkedBlockingQueue[E]) Peek() (E, bool) {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	if q.items.IsEmpty() {
 		return *new(E), false
 	}
@@ -77,10 +85,9 @@ func (q *LinkedBlockingQueue[E]) Peek() (E, bool) {
 
 // TryEnqueue enqueues a new element into the queue, it will return false if the size is up to the capacity
 func (q *LinkedBlockingQueue[E]) TryEnqueue(value E) bool {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	if int64(q.cap) == q.items.Count() {
+	q.items.Lock()
+	defer q.items.Unlock()
+	if q.closed || int64(q.cap) == q.items.Count() {
 		return false
 	}
 	q.items.Push(value)
@@ -91,9 +98,8 @@ func (q *LinkedBlockingQueue[E]) TryEnqueue(value E) bool {
 // TryDequeue dequeues the first element of the queue and returns it.
 // The empty value of the element type and false will be returned when the queue is empty
 func (q *LinkedBlockingQueue[E]) TryDequeue() (E, bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.items.Lock()
+	defer q.items.Unlock()
 	if q.items.IsEmpty() {
 		return *new(E), false
 	}
@@ -102,118 +108,213 @@ func (q *LinkedBlockingQueue[E]) TryDequeue() (E, bool) {
 	return value, ok
 }
 
-// Enqueue enqueues a new element into the queue, it will block if the size is up to capacity
+// Enqueue enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns false when the queue is closed.
 func (q *LinkedBlockingQueue[E]) Enqueue(value E) bool {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	for int64(q.cap) == q.items.Count() {
+	return q.Put(value) == nil
+}
+
+// Dequeue dequeues the first element of queue, it will block if the queue is empty.
+// It returns a zero value and false when the queue is closed and drained.
+func (q *LinkedBlockingQueue[E]) Dequeue() (E, bool) {
+	value, err := q.Take()
+	return value, err == nil
+}
+
+// Put enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns [collection.ErrClosed] when the queue is closed, including while it is blocked.
+func (q *LinkedBlockingQueue[E]) Put(value E) error {
+	return q.EnqueueContext(context.Background(), value)
+}
+
+// EnqueueContext enqueues a new element into the queue, it will block if the size is up to capacity.
+// It returns the error of the context when the context is done before the element is enqueued,
+// or [collection.ErrClosed] when the queue is closed.
+func (q *LinkedBlockingQueue[E]) EnqueueContext(ctx context.Context, value E) error {
+	q.items.Lock()
+	defer q.items.Unlock()
+	defer wakeOnDone(ctx, q.putLock)()
+	for int64(q.cap) == q.items.Count() && !q.closed && ctx.Err() == nil {
 		q.putLock.Wait()
 	}
+	if q.closed {
+		return collection.ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	q.items.Push(value)
 	q.takeLock.Broadcast()
-	return true
+	return nil
 }
 
-// Dequeue dequeues the first element of queue, it will block if the queue is empty
-func (q *LinkedBlockingQueue[E]) Dequeue() (E, bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
-	for q.items.IsEmpty() {
+// Take dequeues the first element of queue, it will block if the queue is empty.
+// The elements left when the queue is closed can still be taken,
+// it returns [collection.ErrClosed] once the queue is closed and drained.
+func (q *LinkedBlockingQueue[E]) Take() (E, error) {
+	return q.DequeueContext(context.Background())
+}
+
+// DequeueContext dequeues the first element of queue, it will block if the queue is empty.
+// It returns the error of the context when the context is done before an element is dequeued,
+// or [collection.ErrClosed] once the queue is closed and drained.
+func (q *LinkedBlockingQueue[E]) DequeueContext(ctx context.Context) (E, error) {
+	q.items.Lock()
+	defer q.items.Unlock()
+	defer wakeOnDone(ctx, q.takeLock)()
+	for q.items.IsEmpty() && !q.closed && ctx.Err() == nil {
 		q.takeLock.Wait()
 	}
-	value, ok := q.items.Shift()
+	if err := ctx.Err(); err != nil {
+		return *new(E), err
+	}
+	if q.items.IsEmpty() {
+		return *new(E), collection.ErrClosed
+	}
+	value, _ := q.items.Shift()
 	q.putLock.Broadcast()
-	return value, ok
+	return value, nil
+}
+
+// Close closes the queue and wakes all blocked producers and consumers.
+// Elements can no longer be enqueued, the elements left can still be dequeued.
+func (q *LinkedBlockingQueue[E]) Close() {
+	q.items.Lock()
+	defer q.items.Unlock()
+	q.closed = true
+	q.takeLock.Broadcast()
+	q.putLock.Broadcast()
+}
+
+// IsClosed returns whether the queue is closed
+func (q *LinkedBlockingQueue[E]) IsClosed() bool {
+	q.items.RLock()
+	defer q.items.RUnlock()
+	return q.closed
 }
 
 // EnqueueTimeout enqueues element into the queue.
 // It will block when the size of queue is up to capacity.
-// It will return true if the element is successfully enqueued or false when time is out
+// It will return true if the element is successfully enqueued or false when time is out or the queue is closed
 func (q *LinkedBlockingQueue[E]) EnqueueTimeout(value E, duration time.Duration) bool {
-	var ok bool
-	catch.Try(func() {
-		done := make(chan struct{})
-		ok = future.Timeout(func() bool {
-			future.Void(func() {
-				q.items.Lock()
-				defer q.items.Unlock()
-				for int64(q.cap) == q.items.Count() {
-					q.putLock.Wait()
-				}
-				done <- struct{}{}
-			})
-			<-done
-			q.items.Push(value)
-			q.takeLock.Broadcast()
-			return true
-		}, duration).Complete(func() {
-			close(done)
-		}).Await()
-	}).Catch(new(exception.TimeoutException), func(err error) {
-	}).Run()
-	return ok
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return q.EnqueueContext(ctx, value) == nil
 }
 
 // DequeueTimeout removes the first element and returns it.
 // It will block when the queue is empty.
-// It will return zero value and false when time is out
+// It will return zero value and false when time is out or the queue is closed and drained
 func (q *LinkedBlockingQueue[E]) DequeueTimeout(duration time.Duration) (E, bool) {
-	var value E
-	var ok bool
-	catch.Try(func() {
-		done := make(chan struct{})
-		future.Timeout(func() bool {
-			future.Void(func() {
-				q.items.Lock()
-				defer q.items.Unlock()
-				for q.items.IsEmpty() {
-					q.takeLock.Wait()
-				}
-				done <- struct{}{}
-			})
-			<-done
-			value, ok = q.items.Shift()
-			q.putLock.Broadcast()
-			return ok
-		}, duration).Complete(func() {
-			close(done)
-		}).Await()
-	}).Catch(new(exception.TimeoutException), func(err error) {
-	}).Run()
-	return value, ok
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	value, err := q.DequeueContext(ctx)
+	return value, err == nil
 }
 
 // Remove removes the specific element
 func (q *LinkedBlockingQueue[E]) Remove(value E) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.items.Lock()
+	defer q.items.Unlock()
 	q.items.Remove(value)
 }
 
 // RemoveWhere removes elements which matches the callback
 func (q *LinkedBlockingQueue[E]) RemoveWhere(callback func(E) bool) {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
-	}
+	q.items.Lock()
+	defer q.items.Unlock()
 	q.items.RemoveWhere(callback)
 }
 
 // ToArray converts to array
 func (q *LinkedBlockingQueue[E]) ToArray() []E {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	return q.items.ToArray()
 }
 
+// All returns a sequence of the indexes and elements from head to tail of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *LinkedBlockingQueue[E]) All() func(yield func(index int, value E) bool) {
+	return snapshotAll(q.ToArray)
+}
+
+// Values returns a sequence of the elements from head to tail of a copy taken under the read lock when it is ranged,
+// so the queue can be used while the sequence is ranged
+func (q *LinkedBlockingQueue[E]) Values() func(yield func(value E) bool) {
+	return snapshotValues(q.ToArray)
+}
+
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *LinkedBlockingQueue[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[LinkedBlockingQueue[E]]() + 2*memory.Of[sync.Cond]() + q.items.MemoryFootprint(deep)
+}
+
+// AppendNDJSON writes the elements of the queue to the writer one JSON element per line without copying the queue
+func (q *LinkedBlockingQueue[E]) AppendNDJSON(w io.Writer) error {
+	q.items.RLock()
+	defer q.items.RUnlock()
+	return codec.WriteNDJSON(w, func(yield func(value E) bool) {
+		q.items.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	})
+}
+
+// ReadNDJSON enqueues the JSON elements read from the reader one per line as they are decoded,
+// it blocks while the queue is full so a slow consumer throttles the reader,
+// elements decoded before an error are kept.
+// It stops reading and returns [collection.ErrClosed] once the queue is closed.
+func (q *LinkedBlockingQueue[E]) ReadNDJSON(r io.Reader) error {
+	var err error
+	if readErr := codec.ReadNDJSON(r, func(value E) bool {
+		err = q.Put(value)
+		return err == nil
+	}); readErr != nil {
+		return readErr
+	}
+	return err
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *LinkedBlockingQueue[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode enqueues the elements read from the reader in the format, it blocks while the queue is full.
+// It stops and returns [collection.ErrClosed] once the queue is closed.
+func (q *LinkedBlockingQueue[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := q.Put(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (q *LinkedBlockingQueue[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := q.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (q *LinkedBlockingQueue[E]) GobDecode(data []byte) error {
+	return q.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (q *LinkedBlockingQueue[E]) ToJSON() ([]byte, error) {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
+	q.items.RLock()
+	defer q.items.RUnlock()
 	return q.items.MarshalJSON()
 }
 
@@ -224,17 +325,23 @@ func (q *LinkedBlockingQueue[E]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements [json.Unmarshaller]
 func (q *LinkedBlockingQueue[E]) UnmarshalJSON(data []byte) error {
-	if q.items.TryLock() {
-		defer q.items.Unlock()
+	if jsonx.IsNull(data) {
+		q.Clear()
+		return nil
 	}
+	q.items.Lock()
+	defer q.items.Unlock()
 	values := make([]E, 0)
 	if err := json.Unmarshal(data, &values); err != nil {
 		return err
 	}
 	for _, value := range values {
-		for q.items.Count() == int64(q.cap) {
+		for q.items.Count() == int64(q.cap) && !q.closed {
 			q.putLock.Wait()
 		}
+		if q.closed {
+			return collection.ErrClosed
+		}
 		q.items.Push(value)
 		q.takeLock.Broadcast()
 	}
@@ -243,27 +350,6 @@ func (q *LinkedBlockingQueue[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (q *LinkedBlockingQueue[E]) String() string {
-	if q.items.TryRLock() {
-		defer q.items.RUnlock()
-	}
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("LinkedBlockingQueue[%T](len=%d)", *new(E), q.items.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	q.items.Each(func(index int, value E) bool {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		return index < 4
-	})
-	if q.items.Count() > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	items := q.ToArray()
+	return format.Values("LinkedBlockingQueue", int64(len(items)), format.Slice(items))
 }
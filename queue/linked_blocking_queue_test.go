@@ -1,12 +1,17 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,6 +130,33 @@ func TestLinkedBlockingQueue_ToArray(t *testing.T) {
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, queue.ToArray())
 }
 
+func TestLinkedBlockingQueue_All(t *testing.T) {
+	queue := NewLinkedBlockingQueue[int](5)
+	for i := 0; i < 3; i++ {
+		queue.Enqueue(i)
+	}
+	var indexes, items []int
+	queue.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		queue.Dequeue()
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{0, 1, 2}, items)
+	assert.True(t, queue.IsEmpty())
+
+	queue.Enqueue(3)
+	items = nil
+	queue.Values()(func(value int) bool {
+		items = append(items, value)
+		queue.Enqueue(4)
+		return true
+	})
+	assert.Equal(t, []int{3}, items)
+	assert.Equal(t, int64(2), queue.Count())
+}
+
 func TestLinkedBlockingQueue_ToJSON(t *testing.T) {
 	queue := NewLinkedBlockingQueue[int](5)
 	for i := 0; i < 5; i++ {
@@ -183,3 +215,107 @@ func TestLinkedBlockingQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(2), queue.Count())
 	assert.Equal(t, []int{1, 3}, queue.ToArray())
 }
+
+func TestLinkedBlockingQueue_Encode(t *testing.T) {
+	q := NewLinkedBlockingQueue[int](2)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.Encode(buf, codec.Gob))
+	q2 := NewLinkedBlockingQueue[int](2)
+	assert.Nil(t, q2.Decode(buf, codec.Gob))
+	assert.Equal(t, []int{1, 2}, q2.ToArray())
+}
+
+func TestLinkedBlockingQueue_ReadNDJSON(t *testing.T) {
+	q := NewLinkedBlockingQueue[int](1)
+	done := make(chan error)
+	go func() {
+		done <- q.ReadNDJSON(strings.NewReader("1\n2\n3\n"))
+	}()
+	var values []int
+	for i := 0; i < 3; i++ {
+		value, _ := q.Dequeue()
+		values = append(values, value)
+	}
+	assert.Nil(t, <-done)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	q.Enqueue(4)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Equal(t, "4\n", buf.String())
+
+	q = NewLinkedBlockingQueue[int](1)
+	go func() {
+		done <- q.ReadNDJSON(strings.NewReader("1\n2\n3\n"))
+	}()
+	value, _ := q.Dequeue()
+	assert.Equal(t, 1, value)
+	q.Close()
+	assert.ErrorIs(t, <-done, collection.ErrClosed)
+	assert.ErrorIs(t, q.Decode(strings.NewReader("5\n"), codec.NDJSON), collection.ErrClosed)
+}
+
+func TestLinkedBlockingQueue_Close(t *testing.T) {
+	queue := NewLinkedBlockingQueue[int](1)
+	taken := make(chan error)
+	go func() {
+		_, err := queue.Take()
+		taken <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-taken, collection.ErrClosed)
+	assert.True(t, queue.IsClosed())
+	assert.ErrorIs(t, queue.Put(1), collection.ErrClosed)
+	assert.False(t, queue.TryEnqueue(1))
+	assert.False(t, queue.EnqueueTimeout(1, 10*time.Millisecond))
+	queue.Close()
+
+	queue = NewLinkedBlockingQueue[int](1)
+	assert.Nil(t, queue.Put(1))
+	put := make(chan error)
+	go func() {
+		put <- queue.Put(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-put, collection.ErrClosed)
+	value, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+	_, ok = queue.DequeueTimeout(10 * time.Millisecond)
+	assert.False(t, ok)
+}
+
+func TestLinkedBlockingQueue_DequeueContext(t *testing.T) {
+	queue := NewLinkedBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Enqueue(1)
+	}()
+	value, err := queue.DequeueContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestLinkedBlockingQueue_EnqueueContext(t *testing.T) {
+	queue := NewLinkedBlockingQueue[int](1)
+	assert.Nil(t, queue.EnqueueContext(context.Background(), 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, queue.EnqueueContext(ctx, 2), context.Canceled)
+	assert.Equal(t, int64(1), queue.Count())
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,11 +1,15 @@
 package queue
 
 import (
-	"fmt"
-	"strings"
+	"bytes"
+	"io"
+	"slices"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/collection/list"
-	"github.com/gopi-frame/contract"
 )
 
 // NewLinkedQueue new linked queue
@@ -17,7 +21,8 @@ func NewLinkedQueue[E any](values ...E) *LinkedQueue[E] {
 
 // LinkedQueue linked queue
 type LinkedQueue[E any] struct {
-	items *list.LinkedList[E]
+	items     *list.LinkedList[E]
+	snapshots []*LinkedQueueSnapshot[E]
 }
 
 // Lock locks the queue
@@ -65,18 +70,33 @@ func (q *LinkedQueue[E]) IsNotEmpty() bool {
 	return q.items.IsNotEmpty()
 }
 
+// ElementType returns the name of the element type
+func (q *LinkedQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *LinkedQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
 // Clear clears the queue
 func (q *LinkedQueue[E]) Clear() {
+	q.detach()
 	q.items.Clear()
 }
 
 // Peek returns the first element of the queue
 func (q *LinkedQueue[E]) Peek() (E, bool) {
+	if q.items.IsEmpty() {
+		return *new(E), false
+	}
 	return q.items.First()
 }
 
 // Enqueue enqueues a new element into the queue, it will block if the size is up to capacity
 func (q *LinkedQueue[E]) Enqueue(value E) bool {
+	q.detach()
 	q.items.Push(value)
 	return true
 }
@@ -86,24 +106,149 @@ func (q *LinkedQueue[E]) Dequeue() (value E, ok bool) {
 	if q.items.IsEmpty() {
 		return
 	}
+	q.detach()
 	return q.items.Shift()
 }
 
 // Remove removes the specific element
 func (q *LinkedQueue[E]) Remove(value E) {
+	q.detach()
 	q.items.Remove(value)
 }
 
 // RemoveWhere removes elements which matches the callback
 func (q *LinkedQueue[E]) RemoveWhere(callback func(value E) bool) {
+	q.detach()
 	q.items.RemoveWhere(callback)
 }
 
+// Each ranges the queue from head to tail without dequeuing, it will break the loop when the callback returns false.
+// It holds the read lock of the queue, so it must not be called while the lock is held and the callback must not modify the queue.
+func (q *LinkedQueue[E]) Each(callback func(index int, value E) bool) {
+	q.items.RLock()
+	defer q.items.RUnlock()
+	q.items.Each(callback)
+}
+
+// Where returns a new queue of the elements which match the callback, the queue is not modified.
+// It holds the read lock of the queue like Each.
+func (q *LinkedQueue[E]) Where(callback func(value E) bool) *LinkedQueue[E] {
+	q.items.RLock()
+	defer q.items.RUnlock()
+	return &LinkedQueue[E]{items: q.items.Where(callback)}
+}
+
+// FirstWhere returns the element closest to the head which matches the callback without dequeuing it.
+// It will return a zero value and false when none matches the callback.
+// It holds the read lock of the queue like Each.
+func (q *LinkedQueue[E]) FirstWhere(callback func(value E) bool) (E, bool) {
+	q.items.RLock()
+	defer q.items.RUnlock()
+	return q.items.FirstWhere(callback)
+}
+
+// All returns a sequence of the indexes and elements from head to tail which walks the nodes without copying them,
+// the queue must not be modified while the sequence is ranged
+func (q *LinkedQueue[E]) All() func(yield func(index int, value E) bool) {
+	return q.items.All()
+}
+
+// Values returns a sequence of the elements from head to tail which walks the nodes without copying them,
+// the queue must not be modified while the sequence is ranged
+func (q *LinkedQueue[E]) Values() func(yield func(value E) bool) {
+	return q.items.Values()
+}
+
+// Iterator returns an iterator over the elements from head to tail which walks the nodes without copying them,
+// the queue must not be modified while it is iterated
+func (q *LinkedQueue[E]) Iterator() *list.LinkedIterator[E] {
+	return q.items.Iterator()
+}
+
+// Snapshot returns a snapshot of the elements in O(1), take it while holding the lock of the queue
+// and read it after the lock is released.
+// The snapshot reads the nodes of the queue until the queue is modified,
+// the first modification copies the elements into the snapshots which are not released yet.
+// Release the snapshot once it is read so later modifications do not copy the elements.
+func (q *LinkedQueue[E]) Snapshot() *LinkedQueueSnapshot[E] {
+	snapshot := &LinkedQueueSnapshot[E]{queue: q, attached: true}
+	q.snapshots = append(q.snapshots, snapshot)
+	return snapshot
+}
+
+// detach copies the elements into the snapshots which still read the nodes, it is called before every modification
+func (q *LinkedQueue[E]) detach() {
+	if len(q.snapshots) == 0 {
+		return
+	}
+	items := q.items.ToArray()
+	for _, snapshot := range q.snapshots {
+		snapshot.items = items
+		snapshot.attached = false
+	}
+	q.snapshots = nil
+}
+
 // ToArray converts to array
 func (q *LinkedQueue[E]) ToArray() []E {
 	return q.items.ToArray()
 }
 
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *LinkedQueue[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[LinkedQueue[E]]() + q.items.MemoryFootprint(deep)
+}
+
+// AppendNDJSON writes the elements of the queue to the writer one JSON element per line without copying the queue
+func (q *LinkedQueue[E]) AppendNDJSON(w io.Writer) error {
+	return codec.WriteNDJSON(w, func(yield func(value E) bool) {
+		q.items.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	})
+}
+
+// ReadNDJSON enqueues the JSON elements read from the reader one per line as they are decoded,
+// elements decoded before an error are kept
+func (q *LinkedQueue[E]) ReadNDJSON(r io.Reader) error {
+	return codec.ReadNDJSON(r, func(value E) bool {
+		q.Enqueue(value)
+		return true
+	})
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *LinkedQueue[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode replaces the elements of the queue with the elements read from the reader in the format
+func (q *LinkedQueue[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	q.detach()
+	q.items.Clear()
+	q.items.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (q *LinkedQueue[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := q.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (q *LinkedQueue[E]) GobDecode(data []byte) error {
+	return q.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (q *LinkedQueue[E]) ToJSON() ([]byte, error) {
 	return q.items.MarshalJSON()
@@ -116,29 +261,67 @@ func (q *LinkedQueue[E]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements [json.Unmarshaller]
 func (q *LinkedQueue[E]) UnmarshalJSON(data []byte) error {
+	q.detach()
 	return q.items.UnmarshalJSON(data)
 }
 
 // String converts to string
 func (q *LinkedQueue[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("LinkedQueue[%T](len=%d)", *new(E), q.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	q.items.Each(func(index int, value E) bool {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
+	return format.Values("LinkedQueue", q.Count(), q.Values())
+}
+
+// LinkedQueueSnapshot snapshot of the elements of a [LinkedQueue].
+// Its methods take the read lock of the queue, so they must not be called while holding the lock of the queue.
+type LinkedQueueSnapshot[E any] struct {
+	queue *LinkedQueue[E]
+	// attached whether the snapshot still reads the nodes of the queue, guarded by the lock of the queue
+	attached bool
+	items    []E
+}
+
+// Each ranges the elements of the snapshot from head to tail, it will break the loop when the callback returns false
+func (s *LinkedQueueSnapshot[E]) Each(callback func(index int, value E) bool) {
+	s.queue.items.RLock()
+	defer s.queue.items.RUnlock()
+	if s.attached {
+		s.queue.items.Each(callback)
+		return
+	}
+	for index, value := range s.items {
+		if !callback(index, value) {
+			break
 		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		return index < 4
-	})
-	if q.Count() > 5 {
-		str.WriteString("\t...\n")
 	}
-	str.WriteByte('}')
-	return str.String()
+}
+
+// Count returns the number of elements of the snapshot
+func (s *LinkedQueueSnapshot[E]) Count() int64 {
+	var count int64
+	s.Each(func(int, E) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// ToArray converts to array
+func (s *LinkedQueueSnapshot[E]) ToArray() []E {
+	var items []E
+	s.Each(func(_ int, value E) bool {
+		items = append(items, value)
+		return true
+	})
+	return items
+}
+
+// Release detaches the snapshot from the queue, so modifications of the queue no longer copy its elements.
+// The snapshot is empty once released.
+func (s *LinkedQueueSnapshot[E]) Release() {
+	s.queue.items.Lock()
+	defer s.queue.items.Unlock()
+	s.queue.snapshots = slices.DeleteFunc(s.queue.snapshots, func(snapshot *LinkedQueueSnapshot[E]) bool {
+		return snapshot == s
+	})
+	s.attached = false
+	s.items = nil
 }
@@ -1,12 +1,15 @@
 package queue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -119,3 +122,156 @@ func TestLinkedQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(3), queue.Count())
 	assert.Equal(t, []int{2, 4, 6}, queue.ToArray())
 }
+
+func TestLinkedQueue_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewLinkedQueue(1, 2, 3).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestLinkedQueue_Decode(t *testing.T) {
+	x := NewLinkedQueue(9)
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+}
+
+func TestLinkedQueue_AppendNDJSON(t *testing.T) {
+	q := NewLinkedQueue[int]()
+	assert.Nil(t, q.ReadNDJSON(strings.NewReader("1\n2\n")))
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Equal(t, "1\n2\n", buf.String())
+}
+
+func TestLinkedQueue_Each(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3, 4)
+	var values []int
+	queue.Each(func(index int, value int) bool {
+		values = append(values, value)
+		return index < 2
+	})
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, int64(4), queue.Count())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			queue.Lock()
+			queue.Enqueue(i)
+			queue.Dequeue()
+			queue.Unlock()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		count := 0
+		queue.Each(func(int, int) bool {
+			count++
+			return true
+		})
+		assert.Equal(t, 4, count)
+	}
+	wg.Wait()
+}
+
+func TestLinkedQueue_Where(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3, 4)
+	even := queue.Where(func(value int) bool {
+		return value%2 == 0
+	})
+	assert.Equal(t, []int{2, 4}, even.ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4}, queue.ToArray())
+}
+
+func TestLinkedQueue_FirstWhere(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3, 4)
+	value, ok := queue.FirstWhere(func(value int) bool {
+		return value > 2
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	_, ok = queue.FirstWhere(func(value int) bool {
+		return value > 4
+	})
+	assert.False(t, ok)
+	assert.Equal(t, int64(4), queue.Count())
+}
+
+func TestLinkedQueue_All(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3)
+	var indexes, values []int
+	queue.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	values = nil
+	queue.Values()(func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	values = nil
+	for it := queue.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
+func TestLinkedQueue_Snapshot(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3)
+	queue.Lock()
+	snapshot := queue.Snapshot()
+	queue.Unlock()
+	assert.Equal(t, []int{1, 2, 3}, snapshot.ToArray())
+
+	queue.Lock()
+	queue.Dequeue()
+	queue.Enqueue(4)
+	queue.Unlock()
+	assert.Equal(t, []int{1, 2, 3}, snapshot.ToArray())
+	assert.Equal(t, int64(3), snapshot.Count())
+	assert.Equal(t, []int{2, 3, 4}, queue.ToArray())
+}
+
+func TestLinkedQueueSnapshot_Release(t *testing.T) {
+	queue := NewLinkedQueue(1, 2, 3)
+	snapshot := queue.Snapshot()
+	snapshot.Release()
+	assert.Empty(t, queue.snapshots)
+	queue.Enqueue(4)
+	assert.Empty(t, snapshot.ToArray())
+}
+
+func TestLinkedQueueSnapshot_Concurrent(t *testing.T) {
+	queue := NewLinkedQueue[int]()
+	for i := 0; i < 100; i++ {
+		queue.Enqueue(i)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				queue.Lock()
+				snapshot := queue.Snapshot()
+				queue.Unlock()
+				assert.Equal(t, int64(100), snapshot.Count())
+				snapshot.Release()
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		queue.Lock()
+		value, _ := queue.Dequeue()
+		queue.Enqueue(value)
+		queue.Unlock()
+	}
+	wg.Wait()
+}
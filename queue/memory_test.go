@@ -0,0 +1,34 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+func TestMemoryFootprint(t *testing.T) {
+	queue := NewQueue(1, 2)
+	linked := NewLinkedQueue(1, 2)
+	priority := NewPriorityQueue[int](_comparator{}, 1, 2)
+	blocking := NewBlockingQueue[int](200)
+	blocking.Enqueue(1)
+	linkedBlocking := NewLinkedBlockingQueue[int](200)
+	priorityBlocking := NewPriorityBlockingQueue[int](_comparator{}, 200)
+	delayed := NewDelayedQueue[*_delay]()
+	delayed.Enqueue(&_delay{value: 1, until: time.Now()})
+	memtest.Run(t, []memtest.Case{
+		{Name: "Queue", Count: 2, Footprint: queue.MemoryFootprint, Add: func(value int) { queue.Enqueue(value) }},
+		{Name: "LinkedQueue", Count: 2, Footprint: linked.MemoryFootprint, Add: func(value int) { linked.Enqueue(value) }},
+		{Name: "PriorityQueue", Count: 2, Footprint: priority.MemoryFootprint, Add: func(value int) { priority.Enqueue(value) }},
+		{Name: "BlockingQueue", Count: 1, Footprint: blocking.MemoryFootprint, Add: func(value int) { blocking.Enqueue(value) }},
+		{Name: "LinkedBlockingQueue", Count: 0, Footprint: linkedBlocking.MemoryFootprint, Add: func(value int) { linkedBlocking.Enqueue(value) }},
+		{Name: "PriorityBlockingQueue", Count: 0, Footprint: priorityBlocking.MemoryFootprint, Add: func(value int) { priorityBlocking.Enqueue(value) }},
+		{Name: "DelayedQueue", Count: 1, Footprint: func(deep func(value int) int64) int64 {
+			if deep == nil {
+				return delayed.MemoryFootprint(nil)
+			}
+			return delayed.MemoryFootprint(func(value *_delay) int64 { return deep(value.value) })
+		}, Add: func(value int) { delayed.Enqueue(&_delay{value: value, until: time.Now()}) }},
+	})
+}
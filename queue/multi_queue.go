@@ -0,0 +1,214 @@
+package queue
+
+import (
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+)
+
+// Policy how a [MultiQueue] picks the class to dequeue from
+type Policy uint8
+
+const (
+	// StrictPriority always dequeues from the first non-empty class,
+	// lower classes only get served when all higher classes are empty
+	StrictPriority Policy = iota
+	// WeightedFair shares dequeues between the non-empty classes in proportion to their weights,
+	// so lower classes are never starved
+	WeightedFair
+)
+
+// Class class of service of a [MultiQueue]
+type Class struct {
+	// Name name of the class
+	Name string
+	// Weight share of dequeues of the class under [WeightedFair], a non-positive weight counts as 1
+	Weight int
+	// Capacity maximum number of elements of the class, a non-positive capacity means unbounded
+	Capacity int
+}
+
+type serviceClass[E any] struct {
+	Class
+	items   []E
+	current int
+	dropped int64
+}
+
+// NewMultiQueue new multi-queue with the classes in order of priority, the first class is the highest
+func NewMultiQueue[E any](policy Policy, classes ...Class) *MultiQueue[E] {
+	q := new(MultiQueue[E])
+	q.policy = policy
+	q.classes = make([]*serviceClass[E], len(classes))
+	for i, class := range classes {
+		if class.Weight <= 0 {
+			class.Weight = 1
+		}
+		q.classes[i] = &serviceClass[E]{Class: class}
+	}
+	return q
+}
+
+// MultiQueue queue of several classes of service, each class is a bounded FIFO queue
+// and Dequeue picks the class by the policy, e.g. to shed low priority requests under load
+type MultiQueue[E any] struct {
+	sync.RWMutex
+	policy  Policy
+	classes []*serviceClass[E]
+	size    int64
+}
+
+// Classes returns the classes in order of priority
+func (q *MultiQueue[E]) Classes() []Class {
+	classes := make([]Class, len(q.classes))
+	for i, class := range q.classes {
+		classes[i] = class.Class
+	}
+	return classes
+}
+
+// Count returns the size of queue
+func (q *MultiQueue[E]) Count() int64 {
+	return q.size
+}
+
+// CountOf returns the number of elements of the class
+func (q *MultiQueue[E]) CountOf(class int) int64 {
+	if class < 0 || class >= len(q.classes) {
+		return 0
+	}
+	return int64(len(q.classes[class].items))
+}
+
+// Dropped returns the number of elements rejected because the class was full
+func (q *MultiQueue[E]) Dropped(class int) int64 {
+	if class < 0 || class >= len(q.classes) {
+		return 0
+	}
+	return q.classes[class].dropped
+}
+
+// IsEmpty returns whether the queue is empty
+func (q *MultiQueue[E]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// IsNotEmpty returns whether the queue is not empty
+func (q *MultiQueue[E]) IsNotEmpty() bool {
+	return !q.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (q *MultiQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *MultiQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
+// Clear clears the queue, the dropped counters are kept
+func (q *MultiQueue[E]) Clear() {
+	for _, class := range q.classes {
+		class.items = nil
+		class.current = 0
+	}
+	q.size = 0
+}
+
+// Enqueue enqueues a new element into the class,
+// it returns false when the class does not exist or is full
+func (q *MultiQueue[E]) Enqueue(class int, value E) bool {
+	if class < 0 || class >= len(q.classes) {
+		return false
+	}
+	c := q.classes[class]
+	if c.Capacity > 0 && len(c.items) >= c.Capacity {
+		c.dropped++
+		return false
+	}
+	c.items = append(c.items, value)
+	q.size++
+	return true
+}
+
+// pick returns the index of the class to dequeue from, -1 when the queue is empty
+func (q *MultiQueue[E]) pick() int {
+	if q.policy == StrictPriority {
+		for i, class := range q.classes {
+			if len(class.items) > 0 {
+				return i
+			}
+		}
+		return -1
+	}
+	// smooth weighted round-robin over the non-empty classes
+	selected, total := -1, 0
+	for i, class := range q.classes {
+		if len(class.items) == 0 {
+			continue
+		}
+		class.current += class.Weight
+		total += class.Weight
+		if selected < 0 || class.current > q.classes[selected].current {
+			selected = i
+		}
+	}
+	if selected >= 0 {
+		q.classes[selected].current -= total
+	}
+	return selected
+}
+
+// Dequeue dequeues the first element of the class picked by the policy
+func (q *MultiQueue[E]) Dequeue() (E, bool) {
+	index := q.pick()
+	if index < 0 {
+		return *new(E), false
+	}
+	value, _ := q.DequeueFrom(index)
+	return value, true
+}
+
+// DequeueFrom dequeues the first element of the class regardless of the policy
+func (q *MultiQueue[E]) DequeueFrom(class int) (E, bool) {
+	if class < 0 || class >= len(q.classes) || len(q.classes[class].items) == 0 {
+		return *new(E), false
+	}
+	c := q.classes[class]
+	value := c.items[0]
+	clear(c.items[:1])
+	c.items = c.items[1:]
+	if len(c.items) == 0 {
+		c.current = 0
+	}
+	q.size--
+	return value, true
+}
+
+// ToArray converts to array, the elements of higher classes come first
+func (q *MultiQueue[E]) ToArray() []E {
+	items := make([]E, 0, q.size)
+	for _, class := range q.classes {
+		items = append(items, class.items...)
+	}
+	return items
+}
+
+// ToJSON converts to json
+func (q *MultiQueue[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(q.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (q *MultiQueue[E]) MarshalJSON() ([]byte, error) {
+	return q.ToJSON()
+}
+
+// String converts to string
+func (q *MultiQueue[E]) String() string {
+	return format.Values("MultiQueue", q.Count(), format.Slice(q.ToArray()))
+}
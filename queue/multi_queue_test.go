@@ -0,0 +1,90 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiQueue_Enqueue(t *testing.T) {
+	q := NewMultiQueue[string](StrictPriority, Class{Name: "high", Capacity: 1}, Class{Name: "low"})
+	assert.True(t, q.Enqueue(0, "a"))
+	assert.False(t, q.Enqueue(0, "b"))
+	assert.False(t, q.Enqueue(2, "c"))
+	assert.True(t, q.Enqueue(1, "d"))
+	assert.Equal(t, int64(2), q.Count())
+	assert.Equal(t, int64(1), q.CountOf(0))
+	assert.Equal(t, int64(1), q.Dropped(0))
+	assert.Equal(t, int64(0), q.Dropped(1))
+	assert.Equal(t, "high", q.Classes()[0].Name)
+}
+
+func TestMultiQueue_Dequeue(t *testing.T) {
+	q := NewMultiQueue[int](StrictPriority, Class{Name: "high"}, Class{Name: "normal"}, Class{Name: "low"})
+	q.Enqueue(2, 7)
+	q.Enqueue(1, 4)
+	q.Enqueue(0, 1)
+	q.Enqueue(1, 5)
+	var values []int
+	for q.IsNotEmpty() {
+		value, ok := q.Dequeue()
+		assert.True(t, ok)
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{1, 4, 5, 7}, values)
+	_, ok := q.Dequeue()
+	assert.False(t, ok)
+}
+
+func TestMultiQueue_WeightedFair(t *testing.T) {
+	q := NewMultiQueue[string](WeightedFair, Class{Name: "high", Weight: 3}, Class{Name: "low", Weight: 1})
+	for i := 0; i < 8; i++ {
+		q.Enqueue(0, "h")
+		q.Enqueue(1, "l")
+	}
+	var served []string
+	for i := 0; i < 8; i++ {
+		value, _ := q.Dequeue()
+		served = append(served, value)
+	}
+	assert.Equal(t, []string{"h", "h", "l", "h", "h", "h", "l", "h"}, served)
+
+	// the remaining low class gets all dequeues once the high class is empty
+	for q.CountOf(0) > 0 {
+		q.DequeueFrom(0)
+	}
+	value, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, "l", value)
+}
+
+func TestMultiQueue_Clear(t *testing.T) {
+	q := NewMultiQueue[int](StrictPriority, Class{Capacity: 1})
+	q.Enqueue(0, 1)
+	q.Enqueue(0, 2)
+	q.Clear()
+	assert.True(t, q.IsEmpty())
+	assert.Equal(t, int64(1), q.Dropped(0))
+	assert.True(t, q.Enqueue(0, 3))
+}
+
+func TestMultiQueue_MarshalJSON(t *testing.T) {
+	q := NewMultiQueue[int](StrictPriority, Class{}, Class{})
+	q.Enqueue(1, 2)
+	q.Enqueue(0, 1)
+	data, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2]", string(data))
+}
+
+func TestMultiQueue_String(t *testing.T) {
+	q := NewMultiQueue[int](StrictPriority, Class{})
+	for i := 0; i < 6; i++ {
+		q.Enqueue(0, i)
+	}
+	pattern := regexp.MustCompile(fmt.Sprintf(`MultiQueue\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, q.Count()))
+	assert.True(t, pattern.MatchString(q.String()))
+}
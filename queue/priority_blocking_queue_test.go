@@ -1,12 +1,17 @@
 package queue
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -165,6 +170,33 @@ func TestPriorityBlockingQueue_ToArray(t *testing.T) {
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, queue.ToArray())
 }
 
+func TestPriorityBlockingQueue_All(t *testing.T) {
+	queue := NewPriorityBlockingQueue[int](_comparator{}, 5)
+	for i := 0; i < 3; i++ {
+		queue.Enqueue(i)
+	}
+	var indexes, items []int
+	queue.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		queue.Dequeue()
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{0, 1, 2}, items)
+	assert.True(t, queue.IsEmpty())
+
+	queue.Enqueue(3)
+	items = nil
+	queue.Values()(func(value int) bool {
+		items = append(items, value)
+		queue.Enqueue(4)
+		return true
+	})
+	assert.Equal(t, []int{3}, items)
+	assert.Equal(t, int64(2), queue.Count())
+}
+
 func TestPriorityBlockingQueue_ToJSON(t *testing.T) {
 	queue := NewPriorityBlockingQueue[int](_comparator{}, 5)
 	for i := 0; i < 5; i++ {
@@ -223,3 +255,100 @@ func TestPriorityBlockingQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(2), queue.Count())
 	assert.Equal(t, []int{1, 3}, queue.ToArray())
 }
+
+func TestPriorityBlockingQueue_Encode(t *testing.T) {
+	q := NewPriorityBlockingQueue[int](_comparator{}, 2)
+	q.Enqueue(1)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n", buf.String())
+	q2 := NewPriorityBlockingQueue[int](_comparator{}, 2)
+	assert.Nil(t, q2.Decode(buf, codec.NDJSON))
+	assert.Equal(t, []int{1}, q2.ToArray())
+}
+
+func TestPriorityBlockingQueue_AppendNDJSON(t *testing.T) {
+	q := NewPriorityBlockingQueue[int](_comparator{}, 2)
+	assert.Nil(t, q.ReadNDJSON(strings.NewReader("1\n")))
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Equal(t, "1\n", buf.String())
+
+	q.Close()
+	assert.ErrorIs(t, q.ReadNDJSON(strings.NewReader("2\n")), collection.ErrClosed)
+	assert.ErrorIs(t, q.Decode(strings.NewReader("2\n"), codec.NDJSON), collection.ErrClosed)
+	assert.Equal(t, []int{1}, q.ToArray())
+}
+
+func TestPriorityBlockingQueue_Repack(t *testing.T) {
+	q := NewPriorityBlockingQueue[int](_comparator{}, 4)
+	q.Enqueue(2)
+	q.Enqueue(1)
+	q.Dequeue()
+	q.Repack()
+	assert.Equal(t, 1, cap(q.items.items))
+	assert.Equal(t, []int{2}, q.ToArray())
+}
+
+func TestPriorityBlockingQueue_Close(t *testing.T) {
+	queue := NewPriorityBlockingQueue[int](_comparator{}, 1)
+	taken := make(chan error)
+	go func() {
+		_, err := queue.Take()
+		taken <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-taken, collection.ErrClosed)
+	assert.True(t, queue.IsClosed())
+	assert.ErrorIs(t, queue.Put(1), collection.ErrClosed)
+	assert.False(t, queue.TryEnqueue(1))
+	assert.False(t, queue.EnqueueTimeout(1, 10*time.Millisecond))
+	queue.Close()
+
+	queue = NewPriorityBlockingQueue[int](_comparator{}, 1)
+	assert.Nil(t, queue.Put(1))
+	put := make(chan error)
+	go func() {
+		put <- queue.Put(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	assert.ErrorIs(t, <-put, collection.ErrClosed)
+	value, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+	_, ok = queue.DequeueTimeout(10 * time.Millisecond)
+	assert.False(t, ok)
+}
+
+func TestPriorityBlockingQueue_DequeueContext(t *testing.T) {
+	queue := NewPriorityBlockingQueue[int](_comparator{}, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Enqueue(1)
+	}()
+	value, err := queue.DequeueContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestPriorityBlockingQueue_EnqueueContext(t *testing.T) {
+	queue := NewPriorityBlockingQueue[int](_comparator{}, 1)
+	assert.Nil(t, queue.EnqueueContext(context.Background(), 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, queue.EnqueueContext(ctx, 2), context.Canceled)
+	assert.Equal(t, int64(1), queue.Count())
+	_, err := queue.DequeueContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
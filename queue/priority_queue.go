@@ -1,40 +1,145 @@
 package queue
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
-	"fmt"
-	"reflect"
+	"io"
 	"slices"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/contract"
 )
 
-// NewPriorityQueue new priority queue
+// NewPriorityQueue new priority queue, the smallest element by the comparator is dequeued first
 func NewPriorityQueue[E any](comparator contract.Comparator[E], values ...E) *PriorityQueue[E] {
+	return newPriorityQueue(comparator.Compare, values...)
+}
+
+// NewOrderedPriorityQueue new priority queue of ordered elements, the smallest element is dequeued first.
+// Elements are compared with [cmp.Compare] directly instead of through a comparator.
+func NewOrderedPriorityQueue[E cmp.Ordered](values ...E) *PriorityQueue[E] {
+	return newPriorityQueue(cmp.Compare[E], values...)
+}
+
+func newPriorityQueue[E any](compare func(a, b E) int, values ...E) *PriorityQueue[E] {
 	queue := new(PriorityQueue[E])
-	queue.comparator = comparator
+	queue.compare = compare
 	for _, value := range values {
 		queue.Enqueue(value)
 	}
 	return queue
 }
 
-// PriorityQueue priority queue
+// PriorityQueue priority queue backed by a binary heap
 type PriorityQueue[E any] struct {
 	sync.RWMutex
-	size       int64
-	items      []E
-	comparator contract.Comparator[E]
+	size     int64
+	items    []E
+	compare  func(a, b E) int
+	aging    func(value E, wait time.Duration) E
+	interval time.Duration
+	// keys the aged elements the heap is ordered by and since the times the elements were enqueued,
+	// both are only kept when aging is enabled
+	keys   []E
+	since  []time.Time
+	agedAt time.Time
+	now    func() time.Time
 }
 
 func (q *PriorityQueue[E]) less(i, j int64) bool {
-	return q.comparator.Compare(q.items[i], q.items[j]) < 0
+	if q.aging != nil {
+		return q.compare(q.keys[i], q.keys[j]) < 0
+	}
+	return q.compare(q.items[i], q.items[j]) < 0
 }
 
 func (q *PriorityQueue[E]) swap(i, j int64) {
 	q.items[i], q.items[j] = q.items[j], q.items[i]
+	if q.aging != nil {
+		q.keys[i], q.keys[j] = q.keys[j], q.keys[i]
+		q.since[i], q.since[j] = q.since[j], q.since[i]
+	}
+}
+
+// down moves the element at the index down until the heap is ordered below it
+func (q *PriorityQueue[E]) down(index int64) {
+	lastIndex := q.size - 1
+	for {
+		leftIndex := index*2 + 1
+		if leftIndex > lastIndex || leftIndex < 0 {
+			break
+		}
+		swapIndex := leftIndex
+		if rightIndex := leftIndex + 1; rightIndex <= lastIndex && q.less(rightIndex, leftIndex) {
+			swapIndex = rightIndex
+		}
+		if !q.less(swapIndex, index) {
+			break
+		}
+		q.swap(swapIndex, index)
+		index = swapIndex
+	}
+}
+
+func (q *PriorityQueue[E]) heapify() {
+	for index := q.size/2 - 1; index >= 0; index-- {
+		q.down(index)
+	}
+}
+
+func (q *PriorityQueue[E]) clock() time.Time {
+	if q.now != nil {
+		return q.now()
+	}
+	return time.Now()
+}
+
+// age reorders the heap by the elements aged for their waiting times once the aging interval has elapsed
+func (q *PriorityQueue[E]) age() {
+	if q.aging == nil || q.size == 0 {
+		return
+	}
+	now := q.clock()
+	if now.Sub(q.agedAt) < q.interval {
+		return
+	}
+	q.agedAt = now
+	for index, value := range q.items {
+		q.keys[index] = q.aging(value, now.Sub(q.since[index]))
+	}
+	q.heapify()
+}
+
+// Aging makes the elements gain priority while they wait, so elements with a low priority are eventually dequeued
+// even when elements with a higher priority keep coming.
+// aging returns the element as the comparator should order it after waiting for wait,
+// such as a copy with a raised priority field, the queue still returns the original elements.
+// The queue is reordered by the aged elements in O(n) at most once every interval when it is peeked or dequeued,
+// so Peek changes the queue and needs Lock rather than RLock. A nil aging disables aging.
+// The elements already in the queue start waiting now.
+func (q *PriorityQueue[E]) Aging(interval time.Duration, aging func(value E, wait time.Duration) E) *PriorityQueue[E] {
+	q.aging = aging
+	q.interval = interval
+	q.keys, q.since = nil, nil
+	if aging != nil {
+		now := q.clock()
+		q.agedAt = now
+		q.keys = slices.Clone(q.items)
+		q.since = make([]time.Time, len(q.items))
+		for index := range q.since {
+			q.since[index] = now
+		}
+	}
+	q.heapify()
+	return q
 }
 
 // Count returns the size of queue
@@ -52,14 +157,35 @@ func (q *PriorityQueue[E]) IsNotEmpty() bool {
 	return !q.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (q *PriorityQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *PriorityQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
 // Clear clears the queue
 func (q *PriorityQueue[E]) Clear() {
 	q.items = make([]E, 0)
 	q.size = 0
+	if q.aging != nil {
+		q.keys, q.since = nil, nil
+	}
+}
+
+// Repack reallocates the backing array to fit the elements, releasing the capacity left over by dequeues
+func (q *PriorityQueue[E]) Repack() {
+	items := make([]E, len(q.items))
+	copy(items, q.items)
+	q.items = items
 }
 
 // Peek returns the first element of the queue
 func (q *PriorityQueue[E]) Peek() (E, bool) {
+	q.age()
 	if q.size == 0 {
 		return *new(E), false
 	}
@@ -69,6 +195,10 @@ func (q *PriorityQueue[E]) Peek() (E, bool) {
 // Enqueue enqueues a new element into the queue, it will block if the size is up to capacity
 func (q *PriorityQueue[E]) Enqueue(value E) bool {
 	q.items = append(q.items, value)
+	if q.aging != nil {
+		q.keys = append(q.keys, q.aging(value, 0))
+		q.since = append(q.since, q.clock())
+	}
 	q.size++
 	for index := q.size - 1; q.less(index, (index-1)/2); index = (index - 1) / 2 {
 		q.swap(index, (index-1)/2)
@@ -78,55 +208,136 @@ func (q *PriorityQueue[E]) Enqueue(value E) bool {
 
 // Dequeue dequeues the first element of queue, it will block if the queue is empty
 func (q *PriorityQueue[E]) Dequeue() (value E, ok bool) {
+	q.age()
 	if q.size == 0 {
 		return *new(E), false
 	}
 	value = q.items[0]
 	ok = true
 	q.swap(0, q.size-1)
+	clear(q.items[q.size-1:])
 	q.items = q.items[:q.size-1]
-	q.size--
-	index := int64(0)
-	lastIndex := q.size - 1
-	for {
-		leftIndex := index*2 + 1
-		if leftIndex > lastIndex || leftIndex < 0 {
-			break
-		}
-		swapIndex := leftIndex
-		if rightIndex := leftIndex + 1; rightIndex <= lastIndex && q.less(rightIndex, leftIndex) {
-			swapIndex = rightIndex
-		}
-		if !q.less(swapIndex, index) {
-			break
-		}
-		q.swap(swapIndex, index)
-		index = swapIndex
+	if q.aging != nil {
+		clear(q.keys[q.size-1:])
+		q.keys = q.keys[:q.size-1]
+		q.since = q.since[:q.size-1]
 	}
+	q.size--
+	q.down(0)
 	return
 }
 
 // Remove removes the specific element
 func (q *PriorityQueue[E]) Remove(value E) {
 	q.RemoveWhere(func(e E) bool {
-		return reflect.DeepEqual(e, value)
+		return equal.Equal(e, value)
 	})
 }
 
 // RemoveWhere removes elements which matches the callback
 func (q *PriorityQueue[E]) RemoveWhere(callback func(E) bool) {
-	q.items = slices.DeleteFunc(q.items, callback)
+	if q.aging == nil {
+		q.items = slices.DeleteFunc(q.items, callback)
+	} else {
+		kept := 0
+		for index, value := range q.items {
+			if !callback(value) {
+				q.items[kept], q.keys[kept], q.since[kept] = value, q.keys[index], q.since[index]
+				kept++
+			}
+		}
+		clear(q.items[kept:])
+		clear(q.keys[kept:])
+		q.items, q.keys, q.since = q.items[:kept], q.keys[:kept], q.since[:kept]
+	}
 	q.size = int64(len(q.items))
+	q.heapify()
 }
 
-// ToArray converts to array
+// All returns a sequence of the indexes and elements in heap order rather than priority order,
+// the queue must not be modified while the sequence is ranged
+func (q *PriorityQueue[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		for index, value := range q.items {
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the elements in heap order rather than priority order,
+// the queue must not be modified while the sequence is ranged
+func (q *PriorityQueue[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for _, value := range q.items {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray converts to array, the array is a copy of the elements
 func (q *PriorityQueue[E]) ToArray() []E {
-	return q.items
+	return slices.Clone(q.items)
+}
+
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *PriorityQueue[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[PriorityQueue[E]]() + memory.Slice(q.items, deep) + memory.Slice(q.keys, deep) + memory.Slice(q.since, nil)
+}
+
+// AppendNDJSON writes the elements of the queue to the writer one JSON element per line without copying the queue
+func (q *PriorityQueue[E]) AppendNDJSON(w io.Writer) error {
+	return codec.Encode(w, q.items, codec.NDJSON)
+}
+
+// ReadNDJSON enqueues the JSON elements read from the reader one per line as they are decoded,
+// elements decoded before an error are kept
+func (q *PriorityQueue[E]) ReadNDJSON(r io.Reader) error {
+	return codec.ReadNDJSON(r, func(value E) bool {
+		q.Enqueue(value)
+		return true
+	})
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *PriorityQueue[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode replaces the elements of the queue with the elements read from the reader in the format
+func (q *PriorityQueue[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	q.Clear()
+	for _, item := range items {
+		q.Enqueue(item)
+	}
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (q *PriorityQueue[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := q.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (q *PriorityQueue[E]) GobDecode(data []byte) error {
+	return q.Decode(bytes.NewReader(data), codec.Gob)
 }
 
 // ToJSON converts to json
 func (q *PriorityQueue[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(q.ToArray())
+	return jsonx.Array(q.ToArray())
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -139,7 +350,7 @@ func (q *PriorityQueue[E]) UnmarshalJSON(data []byte) error {
 	items := []E{}
 	err := json.Unmarshal(data, &items)
 	if err != nil {
-		return nil
+		return err
 	}
 	q.Clear()
 	for _, item := range items {
@@ -150,26 +361,5 @@ func (q *PriorityQueue[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (q *PriorityQueue[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("PriorityQueue[%T](len=%d)", *new(E), q.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	for index, value := range q.items {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		if index >= 4 {
-			break
-		}
-	}
-	if q.Count() > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Values("PriorityQueue", q.Count(), q.Values())
 }
@@ -1,11 +1,16 @@
 package queue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gopi-frame/collection/cmpx"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,6 +62,32 @@ func TestPriorityQueue_Enqueue(t *testing.T) {
 	assert.EqualValues(t, []int{1, 2, 3, 4}, queue.ToArray())
 }
 
+func TestPriorityQueue_ToArray(t *testing.T) {
+	q := NewPriorityQueue[int](_comparator{}, 1, 2)
+	items := q.ToArray()
+	q.Dequeue()
+	assert.ElementsMatch(t, []int{1, 2}, items)
+}
+
+func TestPriorityQueue_All(t *testing.T) {
+	q := NewPriorityQueue[int](_comparator{}, 3, 1, 2)
+	var indexes, items []int
+	q.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, q.ToArray(), items)
+
+	items = nil
+	q.Values()(func(value int) bool {
+		items = append(items, value)
+		return false
+	})
+	assert.Equal(t, []int{1}, items)
+}
+
 func TestPriorityQueue_Dequeue(t *testing.T) {
 	queue := NewPriorityQueue(_comparator{}, 1, 2, 3)
 	v, ok := queue.Dequeue()
@@ -65,6 +96,56 @@ func TestPriorityQueue_Dequeue(t *testing.T) {
 	assert.EqualValues(t, []int{2, 3}, queue.ToArray())
 }
 
+func TestPriorityQueue_Aging(t *testing.T) {
+	type task struct {
+		Name     string
+		Priority int
+	}
+	// a task gains one priority level for every second it waits
+	aging := func(value task, wait time.Duration) task {
+		value.Priority -= int(wait / time.Second)
+		return value
+	}
+	now := time.Now()
+	newQueue := func(interval time.Duration) *PriorityQueue[task] {
+		queue := NewPriorityQueue[task](cmpx.By(func(value task) int { return value.Priority }))
+		queue.now = func() time.Time {
+			return now
+		}
+		return queue.Aging(interval, aging)
+	}
+
+	queue := newQueue(0)
+	queue.Enqueue(task{Name: "low", Priority: 5})
+	now = now.Add(time.Second)
+	queue.Enqueue(task{Name: "high", Priority: 1})
+	value, _ := queue.Dequeue()
+	assert.Equal(t, task{Name: "high", Priority: 1}, value)
+	now = now.Add(5 * time.Second)
+	queue.Enqueue(task{Name: "high", Priority: 1})
+	value, _ = queue.Peek()
+	assert.Equal(t, task{Name: "low", Priority: 5}, value)
+	queue.RemoveWhere(func(value task) bool { return value.Name == "low" })
+	value, _ = queue.Dequeue()
+	assert.Equal(t, "high", value.Name)
+	assert.True(t, queue.IsEmpty())
+
+	// the queue is only reordered once the interval elapses
+	queue = newQueue(10 * time.Second)
+	queue.Enqueue(task{Name: "low", Priority: 5})
+	now = now.Add(6 * time.Second)
+	queue.Enqueue(task{Name: "high", Priority: 1})
+	value, _ = queue.Peek()
+	assert.Equal(t, "high", value.Name)
+	now = now.Add(4 * time.Second)
+	value, _ = queue.Peek()
+	assert.Equal(t, "low", value.Name)
+
+	queue.Aging(0, nil)
+	value, _ = queue.Dequeue()
+	assert.Equal(t, "high", value.Name)
+}
+
 func TestPriorityQueue_ToJSON(t *testing.T) {
 	queue := NewPriorityQueue(_comparator{}, 1, 2, 3)
 	jsonBytes, err := queue.ToJSON()
@@ -92,3 +173,58 @@ func TestPriorityQueue_String(t *testing.T) {
 	pattern := regexp.MustCompile(fmt.Sprintf(`PriorityQueue\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t(\.){3}\n\}`, queue.Count()))
 	assert.True(t, pattern.Match([]byte(str)))
 }
+
+func TestPriorityQueue_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewPriorityQueue[int](_comparator{}, 1).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n", buf.String())
+}
+
+func TestPriorityQueue_Decode(t *testing.T) {
+	q := NewPriorityQueue[int](_comparator{}, 9)
+	assert.Nil(t, q.Decode(strings.NewReader("2\n1\n3\n"), codec.NDJSON))
+	assert.Equal(t, int64(3), q.Count())
+	assert.ElementsMatch(t, []int{1, 2, 3}, q.ToArray())
+}
+
+func TestPriorityQueue_AppendNDJSON(t *testing.T) {
+	q := NewPriorityQueue[int](_comparator{})
+	assert.Nil(t, q.ReadNDJSON(strings.NewReader("2\n1\n")))
+	assert.Equal(t, int64(2), q.Count())
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 2)
+}
+
+func TestPriorityQueue_JSONNull(t *testing.T) {
+	q := NewPriorityQueue[int](_comparator{}, 1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), q))
+	assert.True(t, q.IsEmpty())
+	data, _ := json.Marshal(q)
+	assert.Equal(t, "[]", string(data))
+	assert.NotNil(t, json.Unmarshal([]byte(`["a"]`), q))
+}
+
+func TestPriorityQueue_Repack(t *testing.T) {
+	q := NewPriorityQueue[*int](cmpx.NullsFirst(cmpx.Natural[int]()))
+	for i := range 4 {
+		q.Enqueue(&i)
+	}
+	q.Dequeue()
+	assert.Nil(t, q.items[:4][3])
+	q.Repack()
+	assert.Equal(t, 3, cap(q.items))
+	value, _ := q.Dequeue()
+	assert.Equal(t, 1, *value)
+}
+
+func TestNewOrderedPriorityQueue(t *testing.T) {
+	queue := NewOrderedPriorityQueue(3, 1, 2)
+	for _, expected := range []int{1, 2, 3} {
+		value, ok := queue.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, expected, value)
+	}
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+}
@@ -1,12 +1,15 @@
 package queue
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"io"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/collection/list"
-	"github.com/gopi-frame/contract"
 )
 
 // NewQueue new queue
@@ -16,6 +19,13 @@ func NewQueue[E any](values ...E) *Queue[E] {
 	return queue
 }
 
+// Collect new queue of the elements of the sequence in order, such as an [iter.Seq]
+func Collect[E any](seq func(yield func(value E) bool)) *Queue[E] {
+	queue := new(Queue[E])
+	queue.items = list.Collect(seq)
+	return queue
+}
+
 // Queue array queue
 type Queue[E any] struct {
 	items *list.List[E]
@@ -66,13 +76,31 @@ func (q *Queue[E]) IsNotEmpty() bool {
 	return !q.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (q *Queue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *Queue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
 // Clear clears the queue
 func (q *Queue[E]) Clear() {
 	q.items.Clear()
 }
 
+// Repack reallocates the backing array to fit the elements, releasing the capacity left over by dequeues
+func (q *Queue[E]) Repack() {
+	q.items.Repack()
+}
+
 // Peek returns the first element of the queue
 func (q *Queue[E]) Peek() (E, bool) {
+	if q.items.IsEmpty() {
+		return *new(E), false
+	}
 	return q.items.First()
 }
 
@@ -84,6 +112,9 @@ func (q *Queue[E]) Enqueue(value E) bool {
 
 // Dequeue dequeues the first element of queue, it will block if the queue is empty
 func (q *Queue[E]) Dequeue() (E, bool) {
+	if q.items.IsEmpty() {
+		return *new(E), false
+	}
 	return q.items.Shift()
 }
 
@@ -97,11 +128,70 @@ func (q *Queue[E]) RemoveWhere(callback func(value E) bool) {
 	q.items.RemoveWhere(callback)
 }
 
-// ToArray converts to array
+// All returns a sequence of the indexes and elements from head to tail, the queue must not be modified while the sequence is ranged
+func (q *Queue[E]) All() func(yield func(index int, value E) bool) {
+	return q.items.All()
+}
+
+// Values returns a sequence of the elements from head to tail, the queue must not be modified while the sequence is ranged
+func (q *Queue[E]) Values() func(yield func(value E) bool) {
+	return q.items.Values()
+}
+
+// ToArray converts to array, the array is a copy of the elements
 func (q *Queue[E]) ToArray() []E {
 	return q.items.ToArray()
 }
 
+// MemoryFootprint estimates the memory used by the queue in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (q *Queue[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[Queue[E]]() + q.items.MemoryFootprint(deep)
+}
+
+// AppendNDJSON writes the elements of the queue to the writer one JSON element per line without copying the queue
+func (q *Queue[E]) AppendNDJSON(w io.Writer) error {
+	return codec.Encode(w, q.items.ToArray(), codec.NDJSON)
+}
+
+// ReadNDJSON enqueues the JSON elements read from the reader one per line as they are decoded,
+// elements decoded before an error are kept
+func (q *Queue[E]) ReadNDJSON(r io.Reader) error {
+	return codec.ReadNDJSON(r, func(value E) bool {
+		q.Enqueue(value)
+		return true
+	})
+}
+
+// Encode writes the elements of the queue to the writer in the format
+func (q *Queue[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, q.ToArray(), format)
+}
+
+// Decode replaces the elements of the queue with the elements read from the reader in the format
+func (q *Queue[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	q.items = list.NewList[E](items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (q *Queue[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := q.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (q *Queue[E]) GobDecode(data []byte) error {
+	return q.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (q *Queue[E]) ToJSON() ([]byte, error) {
 	return q.items.ToJSON()
@@ -125,24 +215,5 @@ func (q *Queue[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (q *Queue[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("Queue[%T](len=%d)", *new(E), q.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	q.items.Each(func(index int, value E) bool {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		return index < 4
-	})
-	if q.Count() > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Values("Queue", q.Count(), q.Values())
 }
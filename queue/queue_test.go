@@ -1,12 +1,16 @@
 package queue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/stretchr/testify/assert"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/gopi-frame/collection/codec"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestQueue_Count(t *testing.T) {
@@ -74,6 +78,25 @@ func TestQueue_Dequeue(t *testing.T) {
 	assert.EqualValues(t, []int{2, 3}, queue.ToArray())
 }
 
+func TestQueue_All(t *testing.T) {
+	queue := Collect(NewQueue(1, 2, 3).Values())
+	value, ok := queue.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	var items []int
+	queue.All()(func(index int, value int) bool {
+		items = append(items, index, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 2, 1, 3}, items)
+	items = nil
+	queue.Values()(func(value int) bool {
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{2, 3}, items)
+}
+
 func TestQueue_ToJSON(t *testing.T) {
 	queue := NewQueue(1, 2, 3)
 	jsonBytes, err := queue.ToJSON()
@@ -117,3 +140,31 @@ func TestQueue_RemoveWhere(t *testing.T) {
 	assert.Equal(t, int64(2), queue.Count())
 	assert.Equal(t, []int{2, 4}, queue.ToArray())
 }
+
+func TestQueue_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewQueue(1, 2, 3).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestQueue_Decode(t *testing.T) {
+	x := NewQueue(9)
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+}
+
+func TestQueue_AppendNDJSON(t *testing.T) {
+	q := NewQueue[int]()
+	assert.Nil(t, q.ReadNDJSON(strings.NewReader("1\n2\n")))
+	buf := new(bytes.Buffer)
+	assert.Nil(t, q.AppendNDJSON(buf))
+	assert.Equal(t, "1\n2\n", buf.String())
+}
+
+func TestQueue_Repack(t *testing.T) {
+	q := NewQueue(1, 2, 3)
+	q.Dequeue()
+	q.Repack()
+	assert.Equal(t, []int{2, 3}, q.ToArray())
+	assert.Equal(t, 2, cap(q.ToArray()))
+}
@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/contract"
+)
+
+type stableItem[E any] struct {
+	value E
+	seq   uint64
+}
+
+// NewStablePriorityQueue new stable priority queue, the smallest element by the comparator is dequeued first
+func NewStablePriorityQueue[E any](comparator contract.Comparator[E], values ...E) *StablePriorityQueue[E] {
+	queue := new(StablePriorityQueue[E])
+	queue.compare = comparator.Compare
+	queue.items = newPriorityQueue(func(a, b stableItem[E]) int {
+		if c := queue.compare(a.value, b.value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	for _, value := range values {
+		queue.Enqueue(value)
+	}
+	return queue
+}
+
+// StablePriorityQueue priority queue which dequeues equal elements in insertion order,
+// every element carries its sequence number to break the ties of the comparator
+type StablePriorityQueue[E any] struct {
+	sync.RWMutex
+	items   *PriorityQueue[stableItem[E]]
+	compare func(a, b E) int
+	seq     uint64
+}
+
+// Count returns the size of queue
+func (q *StablePriorityQueue[E]) Count() int64 {
+	return q.items.Count()
+}
+
+// IsEmpty returns whether the queue is empty
+func (q *StablePriorityQueue[E]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// IsNotEmpty returns whether the queue is not empty
+func (q *StablePriorityQueue[E]) IsNotEmpty() bool {
+	return !q.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (q *StablePriorityQueue[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindQueue]
+func (q *StablePriorityQueue[E]) Kind() collection.CollectionKind {
+	return collection.KindQueue
+}
+
+// Clear clears the queue
+func (q *StablePriorityQueue[E]) Clear() {
+	q.items.Clear()
+}
+
+// Peek returns the first element of the queue
+func (q *StablePriorityQueue[E]) Peek() (E, bool) {
+	item, ok := q.items.Peek()
+	return item.value, ok
+}
+
+// Enqueue enqueues a new element into the queue
+func (q *StablePriorityQueue[E]) Enqueue(value E) bool {
+	q.seq++
+	return q.items.Enqueue(stableItem[E]{value: value, seq: q.seq})
+}
+
+// Dequeue dequeues the first element of queue, equal elements are dequeued in insertion order
+func (q *StablePriorityQueue[E]) Dequeue() (E, bool) {
+	item, ok := q.items.Dequeue()
+	return item.value, ok
+}
+
+// Remove removes the specific element
+func (q *StablePriorityQueue[E]) Remove(value E) {
+	q.RemoveWhere(func(e E) bool {
+		return equal.Equal(e, value)
+	})
+}
+
+// RemoveWhere removes elements which matches the callback
+func (q *StablePriorityQueue[E]) RemoveWhere(callback func(E) bool) {
+	q.items.RemoveWhere(func(item stableItem[E]) bool {
+		return callback(item.value)
+	})
+}
+
+// ToArray converts to array in dequeue order
+func (q *StablePriorityQueue[E]) ToArray() []E {
+	items := slices.Clone(q.items.ToArray())
+	slices.SortFunc(items, q.items.compare)
+	values := make([]E, len(items))
+	for index, item := range items {
+		values[index] = item.value
+	}
+	return values
+}
+
+// ToJSON converts to json
+func (q *StablePriorityQueue[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(q.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (q *StablePriorityQueue[E]) MarshalJSON() ([]byte, error) {
+	return q.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the elements are enqueued in the order of the array
+func (q *StablePriorityQueue[E]) UnmarshalJSON(data []byte) error {
+	items := []E{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	q.Clear()
+	for _, item := range items {
+		q.Enqueue(item)
+	}
+	return nil
+}
+
+// String converts to string
+func (q *StablePriorityQueue[E]) String() string {
+	return format.Values("StablePriorityQueue", q.Count(), format.Slice(q.ToArray()))
+}
@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/gopi-frame/collection/cmpx"
+	"github.com/stretchr/testify/assert"
+)
+
+type stableTask struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+}
+
+func newTestStablePriorityQueue(values ...stableTask) *StablePriorityQueue[stableTask] {
+	return NewStablePriorityQueue[stableTask](cmpx.By(func(task stableTask) int {
+		return task.Priority
+	}), values...)
+}
+
+func TestStablePriorityQueue_Dequeue(t *testing.T) {
+	queue := newTestStablePriorityQueue()
+	for i := 0; i < 20; i++ {
+		queue.Enqueue(stableTask{Name: fmt.Sprint(i), Priority: i % 2})
+	}
+	for i := 0; i < 20; i++ {
+		task, ok := queue.Dequeue()
+		assert.True(t, ok)
+		expected := i * 2
+		if i >= 10 {
+			expected = (i-10)*2 + 1
+		}
+		assert.Equal(t, fmt.Sprint(expected), task.Name)
+	}
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+}
+
+func TestStablePriorityQueue_Peek(t *testing.T) {
+	queue := newTestStablePriorityQueue(stableTask{Name: "a", Priority: 1}, stableTask{Name: "b", Priority: 1})
+	task, ok := queue.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "a", task.Name)
+	queue.Clear()
+	assert.True(t, queue.IsEmpty())
+	_, ok = queue.Peek()
+	assert.False(t, ok)
+}
+
+func TestStablePriorityQueue_Remove(t *testing.T) {
+	queue := newTestStablePriorityQueue(stableTask{Name: "a", Priority: 2}, stableTask{Name: "b", Priority: 1}, stableTask{Name: "c", Priority: 1})
+	queue.Remove(stableTask{Name: "b", Priority: 1})
+	assert.Equal(t, []stableTask{{Name: "c", Priority: 1}, {Name: "a", Priority: 2}}, queue.ToArray())
+	queue.RemoveWhere(func(task stableTask) bool { return task.Priority == 2 })
+	assert.Equal(t, int64(1), queue.Count())
+}
+
+func TestStablePriorityQueue_MarshalJSON(t *testing.T) {
+	queue := newTestStablePriorityQueue()
+	assert.Nil(t, json.Unmarshal([]byte(`[{"name":"a","priority":1},{"name":"b","priority":0},{"name":"c","priority":1}]`), queue))
+	data, err := json.Marshal(queue)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"name":"b","priority":0},{"name":"a","priority":1},{"name":"c","priority":1}]`, string(data))
+}
+
+func TestStablePriorityQueue_String(t *testing.T) {
+	queue := NewStablePriorityQueue[int](cmpx.Natural[int](), 6, 5, 4, 3, 2, 1)
+	pattern := regexp.MustCompile(fmt.Sprintf(`StablePriorityQueue\[int\]\(len=%d\)\{\n\t1,\n\t2,\n\t3,\n\t4,\n\t5,\n\t(\.){3}\n\}`, queue.Count()))
+	assert.True(t, pattern.MatchString(queue.String()))
+}
@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection/internal/stress"
+)
+
+const (
+	stressWorkers    = 8
+	stressIterations = 200
+	stressCap        = 16
+)
+
+type blockingQueue[E any] interface {
+	Interface[E]
+	TryEnqueue(value E) bool
+	TryDequeue() (E, bool)
+}
+
+func stressBlockingQueue(t *testing.T, queue blockingQueue[int], cap int64) {
+	var expected []int
+	for i := 0; i < stressWorkers*stressIterations; i++ {
+		expected = append(expected, i)
+	}
+	recorder := stress.Exactly[int]()
+	stop := stress.Watch(t, func() error {
+		if count := queue.Count(); count < 0 || count > cap {
+			return fmt.Errorf("count %d out of [0, %d]", count, cap)
+		}
+		return nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stress.Run(t, stressWorkers, stressIterations, func(_, _ int) {
+			value, _ := queue.Dequeue()
+			recorder.Record(value)
+		})
+	}()
+	stress.Run(t, stressWorkers, stressIterations, func(worker, iteration int) {
+		queue.Enqueue(worker*stressIterations + iteration)
+	})
+	<-done
+	stop()
+	recorder.Verify(t, expected...)
+	if queue.IsNotEmpty() {
+		t.Errorf("queue is not empty, count %d", queue.Count())
+	}
+}
+
+func TestBlockingQueue_Stress(t *testing.T) {
+	stressBlockingQueue(t, NewBlockingQueue[int](stressCap), stressCap)
+}
+
+func TestLinkedBlockingQueue_Stress(t *testing.T) {
+	stressBlockingQueue(t, NewLinkedBlockingQueue[int](stressCap), stressCap)
+}
+
+func TestPriorityBlockingQueue_Stress(t *testing.T) {
+	stressBlockingQueue(t, NewPriorityBlockingQueue[int](_comparator{}, stressCap), stressCap)
+}
+
+func TestChanQueue_Stress(t *testing.T) {
+	stressBlockingQueue(t, NewChanQueue[int](stressCap), stressCap)
+}
+
+func TestDelayedQueue_Stress(t *testing.T) {
+	queue := NewDelayedQueue[*_delay]()
+	var expected []int
+	for i := 0; i < stressWorkers*stressIterations; i++ {
+		expected = append(expected, i)
+	}
+	recorder := stress.Exactly[int]()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stress.Run(t, stressWorkers, stressIterations, func(_, _ int) {
+			value, _ := queue.Dequeue()
+			recorder.Record(value.Value())
+		})
+	}()
+	stress.Run(t, stressWorkers, stressIterations, func(worker, iteration int) {
+		queue.Enqueue(&_delay{value: worker*stressIterations + iteration, until: time.Now()})
+		queue.ToArray()
+	})
+	<-done
+	recorder.Verify(t, expected...)
+	if queue.IsNotEmpty() {
+		t.Errorf("queue is not empty, count %d", queue.Count())
+	}
+}
+
+func stressTryOperations(t *testing.T, queue blockingQueue[int], cap int64) {
+	recorder := stress.Exactly[int]()
+	stress.Run(t, stressWorkers, stressIterations, func(worker, iteration int) {
+		if count := queue.Count(); count < 0 || count > cap {
+			t.Errorf("count %d out of [0, %d]", count, cap)
+		}
+		if iteration%2 == 0 {
+			queue.TryEnqueue(worker*stressIterations + iteration)
+		} else if value, ok := queue.TryDequeue(); ok {
+			recorder.Record(value)
+		}
+	})
+	for {
+		value, ok := queue.TryDequeue()
+		if !ok {
+			break
+		}
+		recorder.Record(value)
+	}
+	recorder.VerifyUnique(t)
+}
+
+func TestBlockingQueue_StressTry(t *testing.T) {
+	stressTryOperations(t, NewBlockingQueue[int](stressCap), stressCap)
+}
+
+func TestLinkedBlockingQueue_StressTry(t *testing.T) {
+	stressTryOperations(t, NewLinkedBlockingQueue[int](stressCap), stressCap)
+}
+
+func TestPriorityBlockingQueue_StressTry(t *testing.T) {
+	stressTryOperations(t, NewPriorityBlockingQueue[int](_comparator{}, stressCap), stressCap)
+}
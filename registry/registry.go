@@ -0,0 +1,202 @@
+// Package registry provides a named registry for plugins, drivers and factories.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+)
+
+var (
+	// ErrDuplicate the name is already registered
+	ErrDuplicate = errors.New("registry: duplicate registration")
+	// ErrFrozen the registry is frozen
+	ErrFrozen = errors.New("registry: registry is frozen")
+)
+
+// Policy duplicate registration policy
+type Policy int
+
+const (
+	// Reject rejects a duplicate registration with [ErrDuplicate]
+	Reject Policy = iota
+	// Replace replaces the registered value
+	Replace
+	// KeepFirst keeps the registered value and ignores the duplicate registration
+	KeepFirst
+)
+
+// NewRegistry new registry with the duplicate registration policy
+func NewRegistry[T any](policy Policy) *Registry[T] {
+	registry := new(Registry[T])
+	registry.policy = policy
+	registry.items = make(map[string]T)
+	return registry
+}
+
+// Registry values registered by name, it is safe for concurrent use.
+// A registry is usually filled during initialization and frozen afterwards,
+// a frozen registry rejects registrations with [ErrFrozen].
+type Registry[T any] struct {
+	lock   sync.RWMutex
+	policy Policy
+	items  map[string]T
+	frozen bool
+}
+
+// Register registers the value by the name.
+// It returns [ErrFrozen] when the registry is frozen, or [ErrDuplicate] when the name is registered and the policy is [Reject].
+func (r *Registry[T]) Register(name string, value T) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.frozen {
+		return fmt.Errorf("%w: register %q", ErrFrozen, name)
+	}
+	if _, ok := r.items[name]; ok {
+		switch r.policy {
+		case Reject:
+			return fmt.Errorf("%w: %q", ErrDuplicate, name)
+		case KeepFirst:
+			return nil
+		}
+	}
+	r.items[name] = value
+	return nil
+}
+
+// MustRegister registers the value by the name, it panics when the registration fails
+func (r *Registry[T]) MustRegister(name string, value T) {
+	if err := r.Register(name, value); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes the name, it returns [ErrFrozen] when the registry is frozen
+func (r *Registry[T]) Unregister(name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.frozen {
+		return fmt.Errorf("%w: unregister %q", ErrFrozen, name)
+	}
+	delete(r.items, name)
+	return nil
+}
+
+// Get returns the value registered by the name.
+// A zero value and false will be returned when the name is not registered
+func (r *Registry[T]) Get(name string) (T, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	value, ok := r.items[name]
+	return value, ok
+}
+
+// MustGet returns the value registered by the name,
+// it panics with [collection.ErrKeyNotFound] when the name is not registered
+func (r *Registry[T]) MustGet(name string) T {
+	value, ok := r.Get(name)
+	if !ok {
+		panic(collection.NewKeyError(name))
+	}
+	return value
+}
+
+// Has returns whether the name is registered
+func (r *Registry[T]) Has(name string) bool {
+	_, ok := r.Get(name)
+	return ok
+}
+
+// Names returns the registered names in ascending order
+func (r *Registry[T]) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.items))
+	for name := range r.items {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Count returns the number of registered names
+func (r *Registry[T]) Count() int64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return int64(len(r.items))
+}
+
+// Each ranges the registry in the order of names, it breaks when the callback returns false
+func (r *Registry[T]) Each(callback func(name string, value T) bool) {
+	for _, name := range r.Names() {
+		value, ok := r.Get(name)
+		if ok && !callback(name, value) {
+			break
+		}
+	}
+}
+
+// Freeze freezes the registry, later registrations fail with [ErrFrozen]
+func (r *Registry[T]) Freeze() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.frozen = true
+}
+
+// Frozen returns whether the registry is frozen
+func (r *Registry[T]) Frozen() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.frozen
+}
+
+// Namespace returns the scope of the registry whose names are prefixed by the namespace and a slash
+func (r *Registry[T]) Namespace(namespace string) *Scope[T] {
+	return &Scope[T]{registry: r, prefix: namespace + "/"}
+}
+
+// Scope namespace of a registry
+type Scope[T any] struct {
+	registry *Registry[T]
+	prefix   string
+}
+
+// Register registers the value by the name in the namespace
+func (s *Scope[T]) Register(name string, value T) error {
+	return s.registry.Register(s.prefix+name, value)
+}
+
+// MustRegister registers the value by the name in the namespace, it panics when the registration fails
+func (s *Scope[T]) MustRegister(name string, value T) {
+	s.registry.MustRegister(s.prefix+name, value)
+}
+
+// Get returns the value registered by the name in the namespace
+func (s *Scope[T]) Get(name string) (T, bool) {
+	return s.registry.Get(s.prefix + name)
+}
+
+// MustGet returns the value registered by the name in the namespace, it panics when the name is not registered
+func (s *Scope[T]) MustGet(name string) T {
+	return s.registry.MustGet(s.prefix + name)
+}
+
+// Names returns the names registered in the namespace without the prefix in ascending order
+func (s *Scope[T]) Names() []string {
+	var names []string
+	for _, name := range s.registry.Names() {
+		if strings.HasPrefix(name, s.prefix) {
+			names = append(names, strings.TrimPrefix(name, s.prefix))
+		}
+	}
+	return names
+}
+
+// Namespace returns the nested namespace of the scope
+func (s *Scope[T]) Namespace(namespace string) *Scope[T] {
+	return &Scope[T]{registry: s.registry, prefix: s.prefix + namespace + "/"}
+}
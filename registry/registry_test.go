@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("reject", func(t *testing.T) {
+		r := NewRegistry[int](Reject)
+		assert.Nil(t, r.Register("a", 1))
+		assert.ErrorIs(t, r.Register("a", 2), ErrDuplicate)
+		assert.Equal(t, 1, r.MustGet("a"))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		r := NewRegistry[int](Replace)
+		r.MustRegister("a", 1)
+		r.MustRegister("a", 2)
+		assert.Equal(t, 2, r.MustGet("a"))
+	})
+
+	t.Run("keep first", func(t *testing.T) {
+		r := NewRegistry[int](KeepFirst)
+		r.MustRegister("a", 1)
+		assert.Nil(t, r.Register("a", 2))
+		assert.Equal(t, 1, r.MustGet("a"))
+	})
+}
+
+func TestRegistry_MustRegister(t *testing.T) {
+	r := NewRegistry[int](Reject)
+	r.MustRegister("a", 1)
+	assert.Panics(t, func() {
+		r.MustRegister("a", 1)
+	})
+}
+
+func TestRegistry_MustGet(t *testing.T) {
+	r := NewRegistry[int](Reject)
+	defer func() {
+		err, ok := recover().(error)
+		assert.True(t, ok)
+		assert.ErrorIs(t, err, collection.ErrKeyNotFound)
+	}()
+	r.MustGet("a")
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	r := NewRegistry[int](Reject)
+	r.MustRegister("a", 1)
+	assert.Nil(t, r.Unregister("a"))
+	assert.False(t, r.Has("a"))
+}
+
+func TestRegistry_Freeze(t *testing.T) {
+	r := NewRegistry[int](Reject)
+	r.MustRegister("a", 1)
+	r.Freeze()
+	assert.True(t, r.Frozen())
+	assert.ErrorIs(t, r.Register("b", 2), ErrFrozen)
+	assert.ErrorIs(t, r.Unregister("a"), ErrFrozen)
+	assert.Equal(t, 1, r.MustGet("a"))
+}
+
+func TestRegistry_Names(t *testing.T) {
+	r := NewRegistry[int](Reject)
+	r.MustRegister("c", 3)
+	r.MustRegister("a", 1)
+	r.MustRegister("b", 2)
+	assert.Equal(t, []string{"a", "b", "c"}, r.Names())
+	assert.Equal(t, int64(3), r.Count())
+	var values []int
+	r.Each(func(_ string, value int) bool {
+		values = append(values, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{1, 2}, values)
+}
+
+func TestRegistry_Namespace(t *testing.T) {
+	r := NewRegistry[string](Reject)
+	drivers := r.Namespace("drivers")
+	drivers.MustRegister("mysql", "MySQL")
+	drivers.Namespace("legacy").MustRegister("mssql", "SQL Server")
+	r.MustRegister("other", "Other")
+	assert.Equal(t, "MySQL", drivers.MustGet("mysql"))
+	assert.Equal(t, "MySQL", r.MustGet("drivers/mysql"))
+	assert.Equal(t, []string{"legacy/mssql", "mysql"}, drivers.Names())
+	assert.ErrorIs(t, drivers.Register("mysql", "again"), ErrDuplicate)
+}
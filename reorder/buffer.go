@@ -0,0 +1,174 @@
+package reorder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection/intervals"
+)
+
+// NewBuffer new reorder buffer which expects the sequence number next first.
+// maxGap limits how far ahead of the expected sequence number an element can be buffered,
+// zero means no limit.
+func NewBuffer[E any](next uint64, maxGap uint64) *Buffer[E] {
+	buffer := new(Buffer[E])
+	buffer.next = next
+	buffer.maxGap = maxGap
+	buffer.items = make(map[uint64]E)
+	return buffer
+}
+
+// Buffer reassembles elements tagged with sequence numbers arriving out of order
+type Buffer[E any] struct {
+	sync.RWMutex
+	next   uint64
+	maxGap uint64
+	items  map[uint64]E
+}
+
+// Next returns the next expected sequence number
+func (b *Buffer[E]) Next() uint64 {
+	return b.next
+}
+
+// Count returns the number of buffered elements
+func (b *Buffer[E]) Count() int64 {
+	return int64(len(b.items))
+}
+
+// IsEmpty returns whether the buffer is empty
+func (b *Buffer[E]) IsEmpty() bool {
+	return b.Count() == 0
+}
+
+// IsNotEmpty returns whether the buffer is not empty
+func (b *Buffer[E]) IsNotEmpty() bool {
+	return !b.IsEmpty()
+}
+
+// Push buffers the element with its sequence number.
+// It returns false when the element is already delivered or buffered,
+// or when the sequence number is beyond the max gap.
+func (b *Buffer[E]) Push(seq uint64, value E) bool {
+	if seq < b.next {
+		return false
+	}
+	if b.maxGap > 0 && seq-b.next >= b.maxGap {
+		return false
+	}
+	if _, ok := b.items[seq]; ok {
+		return false
+	}
+	b.items[seq] = value
+	return true
+}
+
+// Ready returns whether the element of the next expected sequence number has arrived
+func (b *Buffer[E]) Ready() bool {
+	_, ok := b.items[b.next]
+	return ok
+}
+
+// PopReady removes and returns the contiguous run of elements starting at the next expected sequence number
+func (b *Buffer[E]) PopReady() []E {
+	var values []E
+	for {
+		value, ok := b.items[b.next]
+		if !ok {
+			break
+		}
+		values = append(values, value)
+		delete(b.items, b.next)
+		b.next++
+	}
+	return values
+}
+
+// MissingLimit the most sequence numbers returned by Missing
+const MissingLimit = 1024
+
+// Gaps returns the ranges of sequence numbers missing before the last buffered element in ascending order,
+// it takes time in the number of buffered elements rather than in the width of the gaps
+func (b *Buffer[E]) Gaps() []intervals.Interval[uint64] {
+	seqs := make([]uint64, 0, len(b.items))
+	for seq := range b.items {
+		seqs = append(seqs, seq)
+	}
+	slices.Sort(seqs)
+	var gaps []intervals.Interval[uint64]
+	next := b.next
+	for _, seq := range seqs {
+		if seq > next {
+			gaps = append(gaps, intervals.Interval[uint64]{Start: next, End: seq})
+		}
+		next = seq + 1
+	}
+	return gaps
+}
+
+// Missing returns the sequence numbers missing before the last buffered element in ascending order.
+// It returns at most [MissingLimit] of them, use Gaps for the ranges of wide gaps.
+func (b *Buffer[E]) Missing() []uint64 {
+	var missing []uint64
+	for _, gap := range b.Gaps() {
+		for seq := gap.Start; seq < gap.End; seq++ {
+			if len(missing) == MissingLimit {
+				return missing
+			}
+			missing = append(missing, seq)
+		}
+	}
+	return missing
+}
+
+// SkipGap gives up waiting for the missing elements and moves the next expected sequence number
+// to the first buffered element. It returns false when the buffer is empty.
+func (b *Buffer[E]) SkipGap() bool {
+	if len(b.items) == 0 {
+		return false
+	}
+	first := uint64(0)
+	found := false
+	for seq := range b.items {
+		if !found || seq < first {
+			first = seq
+			found = true
+		}
+	}
+	b.next = first
+	return true
+}
+
+// Clear clears the buffer, the next expected sequence number is kept
+func (b *Buffer[E]) Clear() {
+	b.items = make(map[uint64]E)
+}
+
+// String converts to string
+func (b *Buffer[E]) String() string {
+	seqs := make([]uint64, 0, len(b.items))
+	for seq := range b.items {
+		seqs = append(seqs, seq)
+	}
+	slices.Sort(seqs)
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("Buffer[%T](len=%d, next=%d)", *new(E), len(b.items), b.next))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	for index, seq := range seqs {
+		str.WriteByte('\t')
+		str.WriteString(fmt.Sprintf("%d: %v", seq, b.items[seq]))
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		if index >= 4 {
+			break
+		}
+	}
+	if len(seqs) > 5 {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
@@ -0,0 +1,85 @@
+package reorder
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/intervals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer_Push(t *testing.T) {
+	buffer := NewBuffer[string](1, 3)
+	assert.True(t, buffer.Push(2, "b"))
+	assert.False(t, buffer.Push(2, "b"))
+	assert.False(t, buffer.Push(0, "z"))
+	assert.False(t, buffer.Push(4, "d"))
+	assert.True(t, buffer.Push(3, "c"))
+	assert.Equal(t, int64(2), buffer.Count())
+}
+
+func TestBuffer_PopReady(t *testing.T) {
+	buffer := NewBuffer[string](1, 0)
+	buffer.Push(3, "c")
+	buffer.Push(2, "b")
+	assert.False(t, buffer.Ready())
+	assert.Nil(t, buffer.PopReady())
+
+	buffer.Push(1, "a")
+	buffer.Push(5, "e")
+	assert.True(t, buffer.Ready())
+	assert.Equal(t, []string{"a", "b", "c"}, buffer.PopReady())
+	assert.Equal(t, uint64(4), buffer.Next())
+	assert.False(t, buffer.Push(2, "b"))
+
+	buffer.Push(4, "d")
+	assert.Equal(t, []string{"d", "e"}, buffer.PopReady())
+	assert.True(t, buffer.IsEmpty())
+}
+
+func TestBuffer_Missing(t *testing.T) {
+	buffer := NewBuffer[int](0, 0)
+	buffer.Push(2, 2)
+	buffer.Push(5, 5)
+	assert.Equal(t, []uint64{0, 1, 3, 4}, buffer.Missing())
+
+	buffer.Push(1<<40, 0)
+	missing := buffer.Missing()
+	assert.Len(t, missing, MissingLimit)
+	assert.Equal(t, []uint64{0, 1, 3, 4, 6}, missing[:5])
+}
+
+func TestBuffer_Gaps(t *testing.T) {
+	buffer := NewBuffer[int](1, 0)
+	assert.Empty(t, buffer.Gaps())
+	buffer.Push(1, 1)
+	buffer.Push(2, 2)
+	assert.Empty(t, buffer.Gaps())
+	buffer.Push(5, 5)
+	buffer.Push(1<<40, 0)
+	assert.Equal(t, []intervals.Interval[uint64]{{Start: 3, End: 5}, {Start: 6, End: 1 << 40}}, buffer.Gaps())
+}
+
+func TestBuffer_SkipGap(t *testing.T) {
+	buffer := NewBuffer[int](0, 0)
+	assert.False(t, buffer.SkipGap())
+	buffer.Push(3, 3)
+	buffer.Push(4, 4)
+	assert.True(t, buffer.SkipGap())
+	assert.Equal(t, uint64(3), buffer.Next())
+	assert.Equal(t, []int{3, 4}, buffer.PopReady())
+}
+
+func TestBuffer_Clear(t *testing.T) {
+	buffer := NewBuffer[int](0, 0)
+	buffer.Push(3, 3)
+	buffer.Clear()
+	assert.True(t, buffer.IsEmpty())
+	assert.Equal(t, uint64(0), buffer.Next())
+}
+
+func TestBuffer_String(t *testing.T) {
+	buffer := NewBuffer[int](0, 0)
+	buffer.Push(2, 20)
+	buffer.Push(1, 10)
+	assert.Equal(t, "Buffer[int](len=2, next=0){\n\t1: 10,\n\t2: 20,\n}", buffer.String())
+}
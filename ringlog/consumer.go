@@ -0,0 +1,58 @@
+package ringlog
+
+import (
+	"context"
+	"errors"
+)
+
+// NewConsumer new consumer of the log starting at the offset
+func NewConsumer[E any](log *Log[E], offset int64) *Consumer[E] {
+	return &Consumer[E]{log: log, offset: offset}
+}
+
+// Consumer reader of a log which tracks its own offset, it is not safe for concurrent use
+type Consumer[E any] struct {
+	log     *Log[E]
+	offset  int64
+	skipped int64
+}
+
+// Offset returns the offset of the next record to read
+func (c *Consumer[E]) Offset() int64 {
+	return c.offset
+}
+
+// SetOffset moves the consumer to the offset
+func (c *Consumer[E]) SetOffset(offset int64) {
+	c.offset = offset
+}
+
+// Skipped returns the number of records which were overwritten before the consumer read them
+func (c *Consumer[E]) Skipped() int64 {
+	return c.skipped
+}
+
+// Poll returns at most limit records from the offset of the consumer and moves past them,
+// it blocks until there are records, the context is done or the log is closed.
+// A consumer which fell behind continues at the oldest record, see [Consumer.Skipped].
+func (c *Consumer[E]) Poll(ctx context.Context, limit int) ([]Record[E], error) {
+	for {
+		if err := c.log.Wait(ctx, c.offset); err != nil {
+			return nil, err
+		}
+		records, err := c.log.ReadFrom(c.offset, limit)
+		if errors.Is(err, ErrTruncated) {
+			oldest := c.log.Oldest()
+			c.skipped += oldest - c.offset
+			c.offset = oldest
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			c.offset = records[len(records)-1].Offset + 1
+		}
+		return records, nil
+	}
+}
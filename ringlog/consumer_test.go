@@ -0,0 +1,67 @@
+package ringlog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumer_Poll(t *testing.T) {
+	l := NewLog[int](4)
+	c := NewConsumer(l, 0)
+	_, _ = l.Append(1, 2, 3)
+	records, err := c.Poll(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []Record[int]{{0, 1}, {1, 2}}, records)
+	assert.Equal(t, int64(2), c.Offset())
+
+	_, _ = l.Append(4, 5, 6, 7)
+	records, err = c.Poll(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []Record[int]{{3, 4}, {4, 5}, {5, 6}, {6, 7}}, records)
+	assert.Equal(t, int64(1), c.Skipped())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Poll(ctx, 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	c.SetOffset(5)
+	records, _ = c.Poll(context.Background(), 1)
+	assert.Equal(t, []Record[int]{{5, 6}}, records)
+}
+
+func TestConsumer_concurrent(t *testing.T) {
+	l := NewLog[int](1000)
+	var wg sync.WaitGroup
+	results := make([][]int, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := NewConsumer(l, 0)
+			for {
+				records, err := c.Poll(context.Background(), 7)
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					return
+				}
+				for _, record := range records {
+					results[i] = append(results[i], record.Value)
+				}
+			}
+		}(i)
+	}
+	for i := 0; i < 500; i++ {
+		_, _ = l.Append(i)
+	}
+	l.Close()
+	wg.Wait()
+	for _, result := range results {
+		assert.Len(t, result, 500)
+		assert.Equal(t, 499, result[499])
+	}
+}
@@ -0,0 +1,199 @@
+// Package ringlog provides a bounded log whose records are addressed by offset.
+package ringlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection/queue"
+	"github.com/gopi-frame/contract"
+)
+
+var (
+	// ErrTruncated the records at the offset have been overwritten
+	ErrTruncated = errors.New("ringlog: offset truncated")
+	// ErrOutOfRange the offset has not been written yet
+	ErrOutOfRange = errors.New("ringlog: offset out of range")
+	// ErrClosed the log is closed
+	ErrClosed = errors.New("ringlog: closed")
+)
+
+// Record record of the log
+type Record[E any] struct {
+	Offset int64 `json:"offset"`
+	Value  E     `json:"value"`
+}
+
+// NewLog new log keeping the last capacity records, capacity must be positive
+func NewLog[E any](capacity int) *Log[E] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("ringlog: capacity %d must be positive", capacity))
+	}
+	l := new(Log[E])
+	l.capacity = capacity
+	l.records = queue.NewDeque[E]()
+	l.appended = make(chan struct{})
+	return l
+}
+
+// Log bounded log, records get increasing offsets starting at zero
+// and the oldest records are overwritten once the log is full.
+// Every consumer keeps its own offset, see [Consumer].
+// The records are kept in a [queue.Deque] used as a ring buffer,
+// and waiting consumers are woken by closing the channel of the last append like the watches of the kv package.
+// It is safe for concurrent use.
+type Log[E any] struct {
+	lock     sync.RWMutex
+	capacity int
+	records  *queue.Deque[E]
+	next     int64
+	closed   bool
+	appended chan struct{}
+}
+
+// Capacity returns the maximum number of records kept
+func (l *Log[E]) Capacity() int {
+	return l.capacity
+}
+
+// Count returns the number of records kept
+func (l *Log[E]) Count() int64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.next - l.oldest()
+}
+
+// Oldest returns the offset of the oldest record kept
+func (l *Log[E]) Oldest() int64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.oldest()
+}
+
+// Next returns the offset of the next appended record
+func (l *Log[E]) Next() int64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.next
+}
+
+func (l *Log[E]) oldest() int64 {
+	return l.next - l.records.Count()
+}
+
+// Append appends the values and returns the offset of the first one, it returns [ErrClosed] when the log is closed
+func (l *Log[E]) Append(values ...E) (int64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closed {
+		return l.next, ErrClosed
+	}
+	first := l.next
+	for _, value := range values {
+		l.records.PushBack(value)
+		if l.records.Count() > int64(l.capacity) {
+			l.records.PopFront()
+		}
+		l.next++
+	}
+	if len(values) > 0 {
+		close(l.appended)
+		l.appended = make(chan struct{})
+	}
+	return first, nil
+}
+
+// Get returns the value at the offset
+func (l *Log[E]) Get(offset int64) (E, bool) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if offset < l.oldest() || offset >= l.next {
+		return *new(E), false
+	}
+	return l.records.Get(int(offset - l.oldest()))
+}
+
+// ReadFrom returns at most limit records starting at the offset, reading at the next offset returns no records.
+// It returns [ErrTruncated] when the offset has been overwritten and [ErrOutOfRange] when it is beyond the next offset.
+func (l *Log[E]) ReadFrom(offset int64, limit int) ([]Record[E], error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if oldest := l.oldest(); offset < oldest {
+		return nil, fmt.Errorf("%w: offset %d, oldest %d", ErrTruncated, offset, oldest)
+	}
+	if offset > l.next {
+		return nil, fmt.Errorf("%w: offset %d, next %d", ErrOutOfRange, offset, l.next)
+	}
+	oldest := l.oldest()
+	end := offset + min(int64(max(limit, 0)), l.next-offset)
+	records := make([]Record[E], 0, end-offset)
+	for o := offset; o < end; o++ {
+		value, _ := l.records.Get(int(o - oldest))
+		records = append(records, Record[E]{Offset: o, Value: value})
+	}
+	return records, nil
+}
+
+// Wait blocks until a record at the offset is appended, the context is done or the log is closed
+func (l *Log[E]) Wait(ctx context.Context, offset int64) error {
+	for {
+		l.lock.RLock()
+		next, closed, appended := l.next, l.closed, l.appended
+		l.lock.RUnlock()
+		if offset < next {
+			return nil
+		}
+		if closed {
+			return ErrClosed
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-appended:
+		}
+	}
+}
+
+// Close closes the log, appending fails and waiting consumers return [ErrClosed] once they have read every record
+func (l *Log[E]) Close() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	close(l.appended)
+	l.appended = make(chan struct{})
+}
+
+// String converts to string
+func (l *Log[E]) String() string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	str := new(strings.Builder)
+	oldest := l.oldest()
+	str.WriteString(fmt.Sprintf("Log[%T](len=%d, oldest=%d, next=%d)", *new(E), l.next-oldest, oldest, l.next))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	for offset := oldest; offset < l.next; offset++ {
+		if offset-oldest >= 5 {
+			str.WriteString("\t...\n")
+			break
+		}
+		str.WriteByte('\t')
+		str.WriteString(fmt.Sprintf("%d: ", offset))
+		value, _ := l.records.Get(int(offset - oldest))
+		if v, ok := any(value).(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+	}
+	str.WriteByte('}')
+	return str.String()
+}
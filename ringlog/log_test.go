@@ -0,0 +1,90 @@
+package ringlog
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLog(t *testing.T) {
+	assert.Panics(t, func() {
+		NewLog[int](0)
+	})
+	assert.Equal(t, 3, NewLog[int](3).Capacity())
+}
+
+func TestLog_Append(t *testing.T) {
+	l := NewLog[string](3)
+	first, err := l.Append("a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), first)
+	first, _ = l.Append("c", "d")
+	assert.Equal(t, int64(2), first)
+	assert.Equal(t, int64(3), l.Count())
+	assert.Equal(t, int64(1), l.Oldest())
+	assert.Equal(t, int64(4), l.Next())
+
+	_, ok := l.Get(0)
+	assert.False(t, ok)
+	value, ok := l.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "d", value)
+	_, ok = l.Get(4)
+	assert.False(t, ok)
+}
+
+func TestLog_ReadFrom(t *testing.T) {
+	l := NewLog[string](3)
+	_, _ = l.Append("a", "b", "c", "d")
+	records, err := l.ReadFrom(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []Record[string]{{1, "b"}, {2, "c"}}, records)
+	records, err = l.ReadFrom(2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []Record[string]{{2, "c"}, {3, "d"}}, records)
+	records, err = l.ReadFrom(4, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+	records, err = l.ReadFrom(2, math.MaxInt)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	_, err = l.ReadFrom(0, 1)
+	assert.ErrorIs(t, err, ErrTruncated)
+	_, err = l.ReadFrom(5, 1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestLog_Wait(t *testing.T) {
+	l := NewLog[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx, 0), context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(context.Background(), 1)
+	}()
+	_, _ = l.Append(1)
+	_, _ = l.Append(2)
+	assert.Nil(t, <-done)
+
+	go func() {
+		done <- l.Wait(context.Background(), 2)
+	}()
+	l.Close()
+	l.Close()
+	assert.ErrorIs(t, <-done, ErrClosed)
+	assert.Nil(t, l.Wait(context.Background(), 1))
+	_, err := l.Append(3)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestLog_String(t *testing.T) {
+	l := NewLog[int](10)
+	_, _ = l.Append(1, 2, 3, 4, 5, 6)
+	assert.Equal(t, "Log[int](len=6, oldest=0, next=6){\n\t0: 1,\n\t1: 2,\n\t2: 3,\n\t3: 4,\n\t4: 5,\n\t...\n}", l.String())
+}
@@ -0,0 +1,230 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCron the cron expression is malformed
+var ErrInvalidCron = errors.New("scheduler: invalid cron expression")
+
+// Recurrence times of a recurring job
+type Recurrence interface {
+	// Next returns the first time after the time, false means there are no more times
+	Next(after time.Time) (time.Time, bool)
+}
+
+// Every returns the recurrence of a fixed interval
+func Every(interval time.Duration) Recurrence {
+	return every(interval)
+}
+
+type every time.Duration
+
+// Next implements [Recurrence]
+func (e every) Next(after time.Time) (time.Time, bool) {
+	if e <= 0 {
+		return time.Time{}, false
+	}
+	return after.Add(time.Duration(e)), true
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	dayNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+type cronField struct {
+	min, max int
+	names    []string
+}
+
+var cronFields = [5]cronField{
+	{min: 0, max: 59},
+	{min: 0, max: 23},
+	{min: 1, max: 31},
+	{min: 1, max: 12, names: monthNames},
+	{min: 0, max: 7, names: dayNames},
+}
+
+// Cron recurrence of a cron expression
+type Cron struct {
+	expr                                string
+	minutes, hours, days, months, weeks uint64
+	anyDay, anyWeekday                  bool
+}
+
+// ParseCron parses a standard five field cron expression: minute, hour, day of month, month and day of week.
+// Fields support *, lists, ranges, steps and the names of months and days,
+// 7 is Sunday as well as 0 and the macros @yearly, @monthly, @weekly, @daily and @hourly are supported.
+// Like cron, a time matches when either the day of month or the day of week matches if both are restricted.
+// Times are computed in the location of the time passed to [Cron.Next].
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields, got %d", ErrInvalidCron, expr, len(fields))
+	}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidCron, expr, err)
+		}
+		sets[i] = set
+	}
+	c := &Cron{
+		expr:       expr,
+		minutes:    sets[0],
+		hours:      sets[1],
+		days:       sets[2],
+		months:     sets[3],
+		weeks:      sets[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	if c.weeks&(1<<7) != 0 {
+		c.weeks |= 1
+	}
+	return c, nil
+}
+
+// MustParseCron is like [ParseCron] but panics when the expression is malformed
+func MustParseCron(expr string) *Cron {
+	c, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, s, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", s)
+			}
+			part, step = base, n
+		}
+		low, high := spec.min, spec.max
+		if part != "*" {
+			from, to, isRange := strings.Cut(part, "-")
+			var err error
+			if low, err = parseCronValue(from, spec); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseCronValue(to, spec); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				high = spec.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		}
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(value string, spec cronField) (int, error) {
+	for index, name := range spec.names {
+		if name != "" && strings.EqualFold(name, value) {
+			return index, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("value %q out of range [%d, %d]", value, spec.min, spec.max)
+	}
+	return n, nil
+}
+
+// String returns the expression
+func (c *Cron) String() string {
+	return c.expr
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	day := c.days&(1<<t.Day()) != 0
+	weekday := c.weeks&(1<<t.Weekday()) != 0
+	if c.anyDay || c.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+// Next implements [Recurrence], it returns false when no time matches in the next five years.
+// The result is always after the given time, a repeated wall clock hour, such as when daylight saving time ends,
+// matches at both of its occurrences.
+func (c *Cron) Next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	// advance in absolute time, building the next minute from the wall clock would resolve
+	// a repeated hour to its first occurrence, which may be before after
+	t := after.Truncate(time.Minute).Add(time.Minute).In(loc)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.months&(1<<t.Month()) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if c.hours&(1<<t.Hour()) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if c.minutes&(1<<t.Minute()) == 0 {
+			// jump straight to the next matching minute of the hour
+			minute := 60
+			if rest := c.minutes >> (t.Minute() + 1); rest != 0 {
+				minute = t.Minute() + 1 + bits.TrailingZeros64(rest)
+			}
+			t = t.Add(time.Duration(minute-t.Minute()) * time.Minute)
+			continue
+		}
+		if !t.After(after) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// forward returns next, the wall clock time a jump of [Cron.Next] lands on, unless it is not after t.
+// time.Date resolves a wall clock time skipped when daylight saving time starts backwards,
+// so the first time after t following the skipped hour is returned instead.
+func forward(t, next time.Time) time.Time {
+	for !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
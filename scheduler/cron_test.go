@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	next, ok := Every(time.Minute).Next(now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Minute), next)
+	_, ok = Every(0).Next(now)
+	assert.False(t, ok)
+}
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "a * * * *"} {
+		_, err := ParseCron(expr)
+		assert.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+	c, err := ParseCron("@Daily")
+	assert.Nil(t, err)
+	assert.Equal(t, "@Daily", c.String())
+	assert.Panics(t, func() {
+		MustParseCron("* * *")
+	})
+}
+
+func TestCron_Next(t *testing.T) {
+	// 2024-03-02 is a Saturday
+	base := time.Date(2024, 3, 2, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 2, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 2, 10, 15, 0, 0, time.UTC)},
+		{"5,50 * * * *", time.Date(2024, 3, 2, 10, 50, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2024, 3, 2, 11, 5, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 3, 2, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * fri", time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"30 8-18/4 * * *", time.Date(2024, 3, 2, 12, 30, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		next, ok := MustParseCron(c.expr).Next(base)
+		assert.True(t, ok, c.expr)
+		assert.Equal(t, c.next, next, c.expr)
+	}
+	_, ok := MustParseCron("0 0 30 2 *").Next(base)
+	assert.False(t, ok)
+}
+
+func TestCron_Next_Location(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	next, ok := MustParseCron("0 * * * *").Next(time.Date(2024, 1, 1, 10, 10, 0, 0, kolkata))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, kolkata), next)
+}
+
+func TestCron_Next_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	// 2024-11-03 01:10 EST, the second 01:10 of the day
+	after := time.Date(2024, 11, 3, 5, 10, 0, 0, time.UTC).In(newYork)
+	next, ok := MustParseCron("* * * * *").Next(after)
+	assert.True(t, ok)
+	assert.Equal(t, after.Add(time.Minute), next)
+
+	// the repeated hour matches at both of its occurrences
+	first := time.Date(2024, 11, 3, 0, 30, 0, 0, newYork)
+	next, ok = MustParseCron("30 1 * * *").Next(first)
+	assert.True(t, ok)
+	assert.Equal(t, first.Add(time.Hour), next)
+	next, ok = MustParseCron("30 1 * * *").Next(next)
+	assert.True(t, ok)
+	assert.Equal(t, first.Add(2*time.Hour), next)
+	next, ok = MustParseCron("30 1 * * *").Next(next)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 11, 4, 1, 30, 0, 0, newYork), next)
+
+	// the skipped hour of spring forward moves to the next matching time
+	next, ok = MustParseCron("30 2 * * *").Next(time.Date(2024, 3, 10, 0, 0, 0, 0, newYork))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 3, 11, 2, 30, 0, 0, newYork), next)
+	for at, i := after, 0; i < 120; i++ {
+		next, ok := MustParseCron("*/7 * * * *").Next(at)
+		assert.True(t, ok)
+		assert.True(t, next.After(at))
+		at = next
+	}
+}
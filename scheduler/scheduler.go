@@ -0,0 +1,322 @@
+// Package scheduler runs callbacks at scheduled times.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection/queue"
+)
+
+// ErrRunning the scheduler is already running
+var ErrRunning = errors.New("scheduler: already running")
+
+// ID identifier of a scheduled job
+type ID uint64
+
+// Job callback of a scheduled job, the context is the one passed to [Scheduler.Run]
+type Job func(ctx context.Context)
+
+type entry struct {
+	id         ID
+	at         time.Time
+	job        Job
+	recurrence Recurrence
+	paused     bool
+	version    uint64
+}
+
+// timer position of an entry in the pending queue, it is stale once the entry is cancelled or rescheduled
+type timer struct {
+	id      ID
+	at      time.Time
+	version uint64
+	seq     uint64
+}
+
+type timerComparator struct{}
+
+func (timerComparator) Compare(a, b timer) int {
+	if c := a.at.Compare(b.at); c != 0 {
+		return c
+	}
+	if a.seq < b.seq {
+		return -1
+	}
+	if a.seq > b.seq {
+		return 1
+	}
+	return 0
+}
+
+// New new scheduler
+func New() *Scheduler {
+	s := new(Scheduler)
+	s.pending = queue.NewPriorityQueue[timer](timerComparator{})
+	s.entries = make(map[ID]*entry)
+	s.wake = make(chan struct{}, 1)
+	s.now = time.Now
+	return s
+}
+
+// Scheduler runs jobs at their scheduled times once [Scheduler.Run] is called,
+// jobs scheduled at the same time run in the order they were scheduled.
+// It is safe for concurrent use.
+type Scheduler struct {
+	lock    sync.Mutex
+	pending *queue.PriorityQueue[timer]
+	entries map[ID]*entry
+	lastID  ID
+	seq     uint64
+	running bool
+	wake    chan struct{}
+	now     func() time.Time
+}
+
+// Count returns the number of scheduled jobs
+func (s *Scheduler) Count() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return int64(len(s.entries))
+}
+
+// Schedule schedules the job to run at the time, a time in the past runs the job as soon as possible
+func (s *Scheduler) Schedule(at time.Time, job Job) ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastID++
+	e := &entry{id: s.lastID, at: at, job: job}
+	s.entries[e.id] = e
+	s.push(e)
+	return e.id
+}
+
+// After schedules the job to run after the duration
+func (s *Scheduler) After(duration time.Duration, job Job) ID {
+	return s.Schedule(s.now().Add(duration), job)
+}
+
+// Repeat schedules the job to run at the times of the recurrence, e.g. [Every] or [ParseCron].
+// A run which is missed while the scheduler is busy or not running is skipped rather than caught up,
+// and a run starts even when the previous run has not returned.
+// The job is removed once the recurrence has no more times.
+func (s *Scheduler) Repeat(recurrence Recurrence, job Job) (ID, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	at, ok := recurrence.Next(s.now())
+	if !ok {
+		return 0, false
+	}
+	s.lastID++
+	e := &entry{id: s.lastID, at: at, job: job, recurrence: recurrence}
+	s.entries[e.id] = e
+	s.push(e)
+	return e.id, true
+}
+
+// Pause pauses the job until it is resumed, it returns false when the job is not scheduled
+func (s *Scheduler) Pause(id ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return false
+	}
+	if !e.paused {
+		e.paused = true
+		e.version++
+		s.compact()
+	}
+	return true
+}
+
+// Resume resumes the paused job, it returns false when the job is not scheduled.
+// A recurring job runs at the next time of its recurrence,
+// while a one-off job keeps its time and runs right away when the time has passed.
+func (s *Scheduler) Resume(id ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return false
+	}
+	if !e.paused {
+		return true
+	}
+	if e.recurrence != nil {
+		at, ok := e.recurrence.Next(s.now())
+		if !ok {
+			delete(s.entries, id)
+			return true
+		}
+		e.at = at
+	}
+	e.paused = false
+	e.version++
+	s.push(e)
+	return true
+}
+
+// Paused returns whether the job is paused
+func (s *Scheduler) Paused(id ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[id]
+	return ok && e.paused
+}
+
+// Cancel cancels the job, a recurring job does not run again.
+// It returns false when the job is not scheduled or a one-off job has already started.
+func (s *Scheduler) Cancel(id ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.entries[id]; !ok {
+		return false
+	}
+	delete(s.entries, id)
+	s.compact()
+	return true
+}
+
+// Reschedule moves the next run of the job to the time, a recurring job follows its recurrence afterward.
+// It returns false when the job is not scheduled or has already started, a paused job stays paused.
+func (s *Scheduler) Reschedule(id ID, at time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return false
+	}
+	e.at = at
+	e.version++
+	if !e.paused {
+		s.push(e)
+	}
+	s.compact()
+	return true
+}
+
+// NextRun returns the time the job is scheduled at, it returns false when the job is not scheduled or paused
+func (s *Scheduler) NextRun(id ID) (time.Time, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e, ok := s.entries[id]; ok && !e.paused {
+		return e.at, true
+	}
+	return time.Time{}, false
+}
+
+// Run runs the jobs when they are due, each job in its own goroutine.
+// It blocks until the context is done, waits for the running jobs and returns the error of the context.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.lock.Lock()
+	if s.running {
+		s.lock.Unlock()
+		return ErrRunning
+	}
+	s.running = true
+	s.lock.Unlock()
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		s.lock.Lock()
+		s.running = false
+		s.lock.Unlock()
+	}()
+	t := time.NewTimer(time.Hour)
+	t.Stop()
+	for {
+		due, wait, ok := s.due()
+		for _, job := range due {
+			wg.Add(1)
+			go func(job Job) {
+				defer wg.Done()
+				job(ctx)
+			}(job)
+		}
+		var fire <-chan time.Time
+		if ok {
+			t.Reset(wait)
+			fire = t.C
+		}
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-s.wake:
+		case <-fire:
+		}
+		if !t.Stop() && ok {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+	}
+}
+
+// due removes the due jobs, it returns them with the duration until the next job and whether there is a next job
+func (s *Scheduler) due() ([]Job, time.Duration, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	var jobs []Job
+	for {
+		next, ok := s.pending.Peek()
+		if !ok {
+			return jobs, 0, false
+		}
+		e, ok := s.entries[next.id]
+		if !ok || e.paused || e.version != next.version {
+			s.pending.Dequeue()
+			continue
+		}
+		if next.at.After(now) {
+			return jobs, next.at.Sub(now), true
+		}
+		s.pending.Dequeue()
+		jobs = append(jobs, e.job)
+		if e.recurrence == nil {
+			delete(s.entries, e.id)
+			continue
+		}
+		at, ok := e.recurrence.Next(e.at)
+		if ok && !at.After(now) {
+			at, ok = e.recurrence.Next(now)
+		}
+		// a recurrence returning a time which is not in the future would run the job again in this loop forever
+		if !ok || !at.After(now) {
+			delete(s.entries, e.id)
+			continue
+		}
+		e.at = at
+		e.version++
+		s.seq++
+		s.pending.Enqueue(timer{id: e.id, at: e.at, version: e.version, seq: s.seq})
+	}
+}
+
+func (s *Scheduler) push(e *entry) {
+	s.seq++
+	s.pending.Enqueue(timer{id: e.id, at: e.at, version: e.version, seq: s.seq})
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// compact drops the stale timers once they outnumber the scheduled jobs
+func (s *Scheduler) compact() {
+	if s.pending.Count() <= 2*int64(len(s.entries))+64 {
+		return
+	}
+	pending := queue.NewPriorityQueue[timer](timerComparator{})
+	for _, t := range s.pending.ToArray() {
+		if e, ok := s.entries[t.id]; ok && e.version == t.version {
+			pending.Enqueue(t)
+		}
+	}
+	s.pending = pending
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func run(t *testing.T, s *Scheduler) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	}
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	s := New()
+	order := make(chan int, 3)
+	now := time.Now()
+	s.Schedule(now.Add(40*time.Millisecond), func(context.Context) { order <- 3 })
+	s.Schedule(now.Add(20*time.Millisecond), func(context.Context) { order <- 2 })
+	s.Schedule(now.Add(-time.Second), func(context.Context) { order <- 1 })
+	assert.Equal(t, int64(3), s.Count())
+	stop := run(t, s)
+	defer stop()
+	assert.Equal(t, 1, <-order)
+	assert.Equal(t, 2, <-order)
+	assert.Equal(t, 3, <-order)
+	assert.Equal(t, int64(0), s.Count())
+
+	s.After(10*time.Millisecond, func(context.Context) { order <- 4 })
+	assert.Equal(t, 4, <-order)
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	s := New()
+	var ran atomic.Int32
+	id := s.After(20*time.Millisecond, func(context.Context) { ran.Add(1) })
+	done := make(chan struct{})
+	s.After(40*time.Millisecond, func(context.Context) { close(done) })
+	assert.True(t, s.Cancel(id))
+	assert.False(t, s.Cancel(id))
+	stop := run(t, s)
+	defer stop()
+	<-done
+	assert.Equal(t, int32(0), ran.Load())
+	_, ok := s.NextRun(id)
+	assert.False(t, ok)
+}
+
+func TestScheduler_Reschedule(t *testing.T) {
+	s := New()
+	order := make(chan int, 2)
+	now := time.Now()
+	first := s.Schedule(now.Add(time.Hour), func(context.Context) { order <- 1 })
+	s.Schedule(now.Add(30*time.Millisecond), func(context.Context) { order <- 2 })
+	assert.True(t, s.Reschedule(first, now.Add(10*time.Millisecond)))
+	at, ok := s.NextRun(first)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(10*time.Millisecond), at)
+	assert.False(t, s.Reschedule(ID(100), now))
+	stop := run(t, s)
+	defer stop()
+	assert.Equal(t, 1, <-order)
+	assert.Equal(t, 2, <-order)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var finished atomic.Bool
+	s.After(0, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished.Store(true)
+	})
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+	<-started
+	assert.ErrorIs(t, s.Run(ctx), ErrRunning)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.True(t, finished.Load())
+}
+
+func TestScheduler_compact(t *testing.T) {
+	s := New()
+	var ids []ID
+	for i := 0; i < 200; i++ {
+		ids = append(ids, s.After(time.Hour, func(context.Context) {}))
+	}
+	for _, id := range ids[1:] {
+		s.Cancel(id)
+	}
+	for i := 0; i < 100; i++ {
+		s.Reschedule(ids[0], time.Now().Add(time.Duration(i)*time.Minute))
+	}
+	assert.Equal(t, int64(1), s.Count())
+	assert.LessOrEqual(t, s.pending.Count(), int64(66))
+}
+
+type times []time.Time
+
+func (ts *times) Next(after time.Time) (time.Time, bool) {
+	for len(*ts) > 0 {
+		next := (*ts)[0]
+		*ts = (*ts)[1:]
+		if next.After(after) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestScheduler_Repeat(t *testing.T) {
+	s := New()
+	runs := make(chan int, 10)
+	var n atomic.Int32
+	id, ok := s.Repeat(Every(10*time.Millisecond), func(context.Context) {
+		runs <- int(n.Add(1))
+	})
+	assert.True(t, ok)
+	next, ok := s.NextRun(id)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(10*time.Millisecond), next, 10*time.Millisecond)
+	stop := run(t, s)
+	defer stop()
+	assert.Equal(t, 1, <-runs)
+	assert.Equal(t, 2, <-runs)
+	assert.Equal(t, 3, <-runs)
+	assert.True(t, s.Cancel(id))
+
+	now := time.Now()
+	done := make(chan struct{})
+	finite := &times{now.Add(-time.Second), now.Add(10 * time.Millisecond), now.Add(20 * time.Millisecond)}
+	id, ok = s.Repeat(finite, func(context.Context) {
+		if n.Add(1) == 5 {
+			close(done)
+		}
+	})
+	assert.True(t, ok)
+	<-done
+	assert.Eventually(t, func() bool {
+		_, ok := s.NextRun(id)
+		return !ok && s.Count() == 0
+	}, time.Second, time.Millisecond)
+
+	_, ok = s.Repeat(Every(0), func(context.Context) {})
+	assert.False(t, ok)
+}
+
+func TestScheduler_Pause(t *testing.T) {
+	s := New()
+	runs := make(chan struct{}, 10)
+	id, _ := s.Repeat(Every(10*time.Millisecond), func(context.Context) {
+		runs <- struct{}{}
+	})
+	assert.True(t, s.Pause(id))
+	assert.True(t, s.Paused(id))
+	_, ok := s.NextRun(id)
+	assert.False(t, ok)
+	assert.True(t, s.Reschedule(id, time.Now()))
+	assert.True(t, s.Paused(id))
+	stop := run(t, s)
+	defer stop()
+	select {
+	case <-runs:
+		t.Fatal("paused job ran")
+	case <-time.After(40 * time.Millisecond):
+	}
+
+	assert.True(t, s.Resume(id))
+	assert.True(t, s.Resume(id))
+	assert.False(t, s.Paused(id))
+	<-runs
+	assert.False(t, s.Pause(ID(100)))
+	assert.False(t, s.Resume(ID(100)))
+
+	once := New()
+	fired := make(chan struct{}, 1)
+	id = once.Schedule(time.Now().Add(-time.Second), func(context.Context) { fired <- struct{}{} })
+	assert.True(t, once.Pause(id))
+	stopOnce := run(t, once)
+	defer stopOnce()
+	select {
+	case <-fired:
+		t.Fatal("paused job ran")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.True(t, once.Resume(id))
+	<-fired
+}
+
+type stuck struct{}
+
+func (stuck) Next(after time.Time) (time.Time, bool) {
+	return after, true
+}
+
+func TestScheduler_due_Stuck(t *testing.T) {
+	s := New()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	id := s.Schedule(now, func(context.Context) {})
+	s.entries[id].recurrence = stuck{}
+	jobs, _, _ := s.due()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, int64(0), s.Count())
+}
@@ -0,0 +1,99 @@
+package set
+
+import "github.com/gopi-frame/collection/list"
+
+// FromList new set of the distinct elements of the list
+func FromList[E comparable](l *list.List[E]) *Set[E] {
+	return NewSet(l.ToArray()...)
+}
+
+// ToList converts to a list, the elements follow the iteration order of the set
+func (s *Set[E]) ToList() *list.List[E] {
+	return list.NewList(s.ToArray()...)
+}
+
+// Add adds the element to the set, it returns false when the set already contains it
+func (s *Set[E]) Add(value E) bool {
+	if s.Contains(value) {
+		return false
+	}
+	s.elements[value] = struct{}{}
+	return true
+}
+
+// derive returns an empty set with the order of the set and room for size elements
+func (s *Set[E]) derive(size int) *Set[E] {
+	return &Set[E]{elements: make(map[E]struct{}, size), order: s.order}
+}
+
+// Union returns a new set of the elements which are in the set or in the other set
+func (s *Set[E]) Union(other *Set[E]) *Set[E] {
+	result := s.derive(len(s.elements) + len(other.elements))
+	for item := range s.elements {
+		result.elements[item] = struct{}{}
+	}
+	for item := range other.elements {
+		result.elements[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersect returns a new set of the elements which are in both sets
+func (s *Set[E]) Intersect(other *Set[E]) *Set[E] {
+	small, large := s, other
+	if len(small.elements) > len(large.elements) {
+		small, large = large, small
+	}
+	result := s.derive(len(small.elements))
+	for item := range small.elements {
+		if large.Contains(item) {
+			result.elements[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Diff returns a new set of the elements which are in the set but not in the other set
+func (s *Set[E]) Diff(other *Set[E]) *Set[E] {
+	result := s.derive(len(s.elements))
+	for item := range s.elements {
+		if !other.Contains(item) {
+			result.elements[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDiff returns a new set of the elements which are in exactly one of the sets
+func (s *Set[E]) SymmetricDiff(other *Set[E]) *Set[E] {
+	result := s.Diff(other)
+	for item := range other.elements {
+		if !s.Contains(item) {
+			result.elements[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SubsetOf returns whether every element of the set is in the other set
+func (s *Set[E]) SubsetOf(other *Set[E]) bool {
+	if len(s.elements) > len(other.elements) {
+		return false
+	}
+	for item := range s.elements {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// SupersetOf returns whether the set contains every element of the other set
+func (s *Set[E]) SupersetOf(other *Set[E]) bool {
+	return other.SubsetOf(s)
+}
+
+// Equal returns whether both sets contain the same elements
+func (s *Set[E]) Equal(other *Set[E]) bool {
+	return len(s.elements) == len(other.elements) && s.SubsetOf(other)
+}
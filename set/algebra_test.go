@@ -0,0 +1,58 @@
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/collection/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_Add(t *testing.T) {
+	set := NewSet[int](1)
+	assert.True(t, set.Add(2))
+	assert.False(t, set.Add(1))
+	assert.Equal(t, int64(2), set.Count())
+}
+
+func TestSet_Union(t *testing.T) {
+	a := NewSet(1, 2, 3).OrderBy(cmp.Compare[int])
+	b := NewSet(3, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, a.Union(b).ToArray())
+	assert.Equal(t, []int{1, 2, 3}, a.ToArray())
+}
+
+func TestSet_Intersect(t *testing.T) {
+	a := NewSet(1, 2, 3).OrderBy(cmp.Compare[int])
+	b := NewSet(2, 3, 4, 5)
+	assert.Equal(t, []int{2, 3}, a.Intersect(b).ToArray())
+	assert.True(t, a.Intersect(NewSet[int]()).IsEmpty())
+}
+
+func TestSet_Diff(t *testing.T) {
+	a := NewSet(1, 2, 3).OrderBy(cmp.Compare[int])
+	b := NewSet(2, 4)
+	assert.Equal(t, []int{1, 3}, a.Diff(b).ToArray())
+	assert.Equal(t, []int{1, 3, 4}, a.SymmetricDiff(b).ToArray())
+}
+
+func TestSet_SubsetOf(t *testing.T) {
+	a := NewSet(1, 2)
+	b := NewSet(1, 2, 3)
+	assert.True(t, a.SubsetOf(b))
+	assert.False(t, b.SubsetOf(a))
+	assert.True(t, b.SupersetOf(a))
+	assert.True(t, NewSet[int]().SubsetOf(a))
+	assert.True(t, a.Equal(NewSet(2, 1)))
+	assert.False(t, a.Equal(b))
+}
+
+func TestSet_ToList(t *testing.T) {
+	set := FromList(list.NewList(3, 1, 3, 2)).OrderBy(cmp.Compare[int])
+	assert.Equal(t, int64(3), set.Count())
+	assert.Equal(t, []int{1, 2, 3}, set.ToList().ToArray())
+	data, err := json.Marshal(set.Union(NewSet(4)))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[1,2,3,4]`, string(data))
+}
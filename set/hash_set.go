@@ -0,0 +1,241 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash/maphash"
+	"io"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/equality"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+)
+
+// NewHashSet new hash set whose elements are compared and hashed by the hasher
+func NewHashSet[E any](hasher equality.Hasher[E], values ...E) *HashSet[E] {
+	set := new(HashSet[E])
+	set.hasher = hasher
+	set.seed = maphash.MakeSeed()
+	set.buckets = make(map[uint64][]E)
+	set.Push(values...)
+	return set
+}
+
+// HashSet set with custom equality, it holds elements of any type including non-comparable ones
+type HashSet[E any] struct {
+	sync.RWMutex
+	hasher  equality.Hasher[E]
+	seed    maphash.Seed
+	buckets map[uint64][]E
+	size    int64
+}
+
+func (s *HashSet[E]) find(value E) (uint64, int) {
+	sum := equality.Sum(s.hasher, s.seed, value)
+	for index, item := range s.buckets[sum] {
+		if s.hasher.Equal(item, value) {
+			return sum, index
+		}
+	}
+	return sum, -1
+}
+
+// Count returns the size of set
+func (s *HashSet[E]) Count() int64 {
+	return s.size
+}
+
+// IsEmpty returns whether the set is empty
+func (s *HashSet[E]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// IsNotEmpty returns whether the set is not empty
+func (s *HashSet[E]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (s *HashSet[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *HashSet[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
+// Contains returns whether the set contains an element equal to the specific element
+func (s *HashSet[E]) Contains(value E) bool {
+	_, index := s.find(value)
+	return index >= 0
+}
+
+// Get returns the element of the set equal to the specific element
+func (s *HashSet[E]) Get(value E) (E, bool) {
+	sum, index := s.find(value)
+	if index < 0 {
+		return *new(E), false
+	}
+	return s.buckets[sum][index], true
+}
+
+// Push pushes elements into the set, elements equal to an existing one are ignored
+func (s *HashSet[E]) Push(values ...E) {
+	for _, value := range values {
+		sum, index := s.find(value)
+		if index >= 0 {
+			continue
+		}
+		s.buckets[sum] = append(s.buckets[sum], value)
+		s.size++
+	}
+}
+
+// Remove removes the element equal to the specific element
+func (s *HashSet[E]) Remove(value E) {
+	sum, index := s.find(value)
+	if index < 0 {
+		return
+	}
+	bucket := s.buckets[sum]
+	if len(bucket) == 1 {
+		delete(s.buckets, sum)
+	} else {
+		s.buckets[sum] = append(bucket[:index:index], bucket[index+1:]...)
+	}
+	s.size--
+}
+
+// Each runs callback for each element, it breaks when callback false
+func (s *HashSet[E]) Each(callback func(_ int, item E) bool) {
+	for _, bucket := range s.buckets {
+		for _, item := range bucket {
+			if !callback(-1, item) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of the positions and elements in the order of Each, it stops when yield returns false.
+// Positions count from 0 in the arbitrary order of the buckets.
+func (s *HashSet[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		index := 0
+		s.Each(func(_ int, value E) bool {
+			if !yield(index, value) {
+				return false
+			}
+			index++
+			return true
+		})
+	}
+}
+
+// Values returns a sequence of the elements in the order of Each, it stops when yield returns false
+func (s *HashSet[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		s.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Clear clears the set
+func (s *HashSet[E]) Clear() {
+	s.buckets = make(map[uint64][]E)
+	s.size = 0
+}
+
+// Clone clones the set
+func (s *HashSet[E]) Clone() *HashSet[E] {
+	return NewHashSet(s.hasher, s.ToArray()...)
+}
+
+// ToArray converts to array
+func (s *HashSet[E]) ToArray() []E {
+	values := make([]E, 0, s.size)
+	s.Each(func(_ int, item E) bool {
+		values = append(values, item)
+		return true
+	})
+	return values
+}
+
+// MemoryFootprint estimates the memory used by the set in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (s *HashSet[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	size := memory.Of[HashSet[E]]() + memory.Map[uint64, []E](len(s.buckets))
+	for _, bucket := range s.buckets {
+		size += memory.Slice(bucket, deep)
+	}
+	return size
+}
+
+// Encode writes the elements of the set to the writer in the format
+func (s *HashSet[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, s.ToArray(), format)
+}
+
+// Decode replaces the elements of the set with the elements read from the reader in the format
+func (s *HashSet[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (s *HashSet[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := s.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (s *HashSet[E]) GobDecode(data []byte) error {
+	return s.Decode(bytes.NewReader(data), codec.Gob)
+}
+
+// ToJSON converts to json
+func (s *HashSet[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(s.ToArray())
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the set is not changed
+func (s *HashSet[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(s.ToArray(), callback)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *HashSet[E]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (s *HashSet[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// String converts to string
+func (s *HashSet[E]) String() string {
+	return format.Values("HashSet", s.Count(), s.Values())
+}
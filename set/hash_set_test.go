@@ -0,0 +1,101 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/equality"
+	"github.com/stretchr/testify/assert"
+)
+
+type tagged struct {
+	ID   int
+	Tags []string
+}
+
+func TestHashSet_Push(t *testing.T) {
+	s := NewHashSet(equality.FoldCase(), "Go", "GO", "rust")
+	assert.Equal(t, int64(2), s.Count())
+	assert.True(t, s.Contains("go"))
+	value, ok := s.Get("go")
+	assert.True(t, ok)
+	assert.Equal(t, "Go", value)
+}
+
+func TestHashSet_Remove(t *testing.T) {
+	s := NewHashSet(equality.Field(func(v tagged) int { return v.ID }), tagged{ID: 1, Tags: []string{"a"}}, tagged{ID: 2})
+	s.Remove(tagged{ID: 1})
+	s.Remove(tagged{ID: 3})
+	assert.Equal(t, []tagged{{ID: 2}}, s.ToArray())
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+}
+
+func TestHashSet_Collisions(t *testing.T) {
+	// every element hashes the same, equality alone tells them apart
+	s := NewHashSet(equality.New(func(a, b int) bool { return a == b }, func(*maphash.Hash, int) {}), 1, 2, 3, 2)
+	assert.Equal(t, int64(3), s.Count())
+	s.Remove(2)
+	assert.ElementsMatch(t, []int{1, 3}, s.ToArray())
+	assert.False(t, s.Contains(2))
+}
+
+func TestHashSet_Clone(t *testing.T) {
+	s := NewHashSet(equality.Comparable[int](), 1, 2)
+	clone := s.Clone()
+	clone.Push(3)
+	assert.Equal(t, int64(2), s.Count())
+	assert.Equal(t, int64(3), clone.Count())
+}
+
+func TestHashSet_All(t *testing.T) {
+	s := NewHashSet(equality.Comparable[int](), 1, 2, 3)
+	var indexes, items []int
+	s.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.ElementsMatch(t, []int{1, 2, 3}, items)
+
+	items = nil
+	s.Values()(func(value int) bool {
+		items = append(items, value)
+		return false
+	})
+	assert.Len(t, items, 1)
+}
+
+func TestHashSet_UnmarshalJSON(t *testing.T) {
+	s := NewHashSet(equality.FoldCase())
+	assert.Nil(t, json.Unmarshal([]byte(`["a","A","b"]`), s))
+	assert.Equal(t, int64(2), s.Count())
+	data, err := json.Marshal(NewHashSet(equality.FoldCase(), "x"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `["x"]`, string(data))
+}
+
+func TestHashSet_String(t *testing.T) {
+	s := NewHashSet(equality.Comparable[int](), 1, 2, 3, 4, 5, 6)
+	str := s.String()
+	assert.True(t, strings.HasPrefix(str, "HashSet[int](len=6){\n"))
+	assert.True(t, strings.HasSuffix(str, "\t...\n}"))
+	assert.Equal(t, 5, strings.Count(str, ","))
+}
+
+func TestHashSet_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewHashSet(equality.FoldCase(), "a", "A").Encode(buf, codec.JSON))
+	assert.Equal(t, "[\"a\"]\n", buf.String())
+}
+
+func TestHashSet_Decode(t *testing.T) {
+	s := NewHashSet(equality.FoldCase(), "z")
+	assert.Nil(t, s.Decode(strings.NewReader("\"a\"\n\"A\"\n\"b\"\n"), codec.NDJSON))
+	assert.ElementsMatch(t, []string{"a", "b"}, s.ToArray())
+}
@@ -1,13 +1,17 @@
 package set
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"io"
 	"sync"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/collection/list"
-	"github.com/gopi-frame/contract"
 )
 
 // NewLinkedSet creates a new linked hash set
@@ -41,6 +45,16 @@ func (s *LinkedSet[E]) IsNotEmpty() bool {
 	return !s.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (s *LinkedSet[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *LinkedSet[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
 // Contains returns whether the set contains the specific element
 func (s *LinkedSet[E]) Contains(value E) bool {
 	_, contains := s.elements[value]
@@ -92,6 +106,22 @@ func (s *LinkedSet[E]) Each(callback func(int, E) bool) {
 	s.link.Each(callback)
 }
 
+// All returns a sequence of the indexes and elements in insertion order, it stops when yield returns false
+func (s *LinkedSet[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		s.Each(yield)
+	}
+}
+
+// Values returns a sequence of the elements in the order of Each, it stops when yield returns false
+func (s *LinkedSet[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		s.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
 // Clone clones the set
 func (s *LinkedSet[E]) Clone() *LinkedSet[E] {
 	return NewLinkedSet(s.ToArray()...)
@@ -102,9 +132,51 @@ func (s *LinkedSet[E]) ToArray() []E {
 	return s.link.ToArray()
 }
 
+// MemoryFootprint estimates the memory used by the set in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (s *LinkedSet[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[LinkedSet[E]]() + memory.Map[E, struct{}](len(s.elements)) + s.link.MemoryFootprint(deep)
+}
+
+// Encode writes the elements of the set to the writer in the format
+func (s *LinkedSet[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, s.ToArray(), format)
+}
+
+// Decode replaces the elements of the set with the elements read from the reader in the format
+func (s *LinkedSet[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (s *LinkedSet[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := s.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (s *LinkedSet[E]) GobDecode(data []byte) error {
+	return s.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (s *LinkedSet[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(s.ToArray())
+	return jsonx.Array(s.ToArray())
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the set is not changed
+func (s *LinkedSet[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(s.ToArray(), callback)
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -125,24 +197,5 @@ func (s *LinkedSet[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (s *LinkedSet[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("LinkedSet[%T](len=%d)", *new(E), s.Count()))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	s.link.Each(func(index int, value E) bool {
-		str.WriteByte('\t')
-		if v, ok := any(value).(contract.Stringable); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", value))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		return index < 4
-	})
-	if s.Count() > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Values("LinkedSet", s.Count(), s.Values())
 }
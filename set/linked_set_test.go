@@ -1,11 +1,14 @@
 package set
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,6 +69,25 @@ func TestLinkedSet_Each(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, items)
 }
 
+func TestLinkedSet_All(t *testing.T) {
+	set := NewLinkedSet(3, 1, 2)
+	var indexes, items []int
+	set.All()(func(index int, item int) bool {
+		indexes = append(indexes, index)
+		items = append(items, item)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{3, 1, 2}, items)
+
+	items = nil
+	set.Values()(func(item int) bool {
+		items = append(items, item)
+		return item != 1
+	})
+	assert.Equal(t, []int{3, 1}, items)
+}
+
 func TestLinkedSet_Cleaar(t *testing.T) {
 	set := NewLinkedSet(1, 2, 3)
 	assert.True(t, set.IsNotEmpty())
@@ -111,3 +133,23 @@ func TestLinkedSet_String(t *testing.T) {
 	pattern := regexp.MustCompile(fmt.Sprintf(`LinkedSet\[int]\(len=%d\)\{\n(\t\d+,\n){3}\}`, set.Count()))
 	assert.True(t, pattern.MatchString(str))
 }
+
+func TestLinkedSet_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewLinkedSet(3, 1, 2).Encode(buf, codec.JSON))
+	assert.Equal(t, "[3,1,2]\n", buf.String())
+}
+
+func TestLinkedSet_Decode(t *testing.T) {
+	x := NewLinkedSet(9)
+	assert.Nil(t, x.Decode(strings.NewReader("[3,1,2]\n"), codec.JSON))
+	assert.Equal(t, []int{3, 1, 2}, x.ToArray())
+}
+
+func TestLinkedSet_ToJSONSorted(t *testing.T) {
+	s := NewLinkedSet("b", "c", "a")
+	data, err := s.ToJSONSorted(strings.Compare)
+	assert.Nil(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(data))
+	assert.Equal(t, []string{"b", "c", "a"}, s.ToArray())
+}
@@ -0,0 +1,21 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/equality"
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+func TestMemoryFootprint(t *testing.T) {
+	set := NewSet(1, 2)
+	hash := NewHashSet(equality.Comparable[int](), 1, 2, 2)
+	linked := NewLinkedSet(1, 2)
+	sorted := NewSortedSet[int](_cmp{}, 1, 2, 2)
+	memtest.Run(t, []memtest.Case{
+		{Name: "Set", Count: 2, Footprint: set.MemoryFootprint, Add: func(value int) { set.Push(value) }},
+		{Name: "HashSet", Count: 2, Footprint: hash.MemoryFootprint, Add: func(value int) { hash.Push(value) }},
+		{Name: "LinkedSet", Count: 2, Footprint: linked.MemoryFootprint, Add: func(value int) { linked.Push(value) }},
+		{Name: "SortedSet", Count: 2, Footprint: sorted.MemoryFootprint, Add: func(value int) { sorted.Push(value) }},
+	})
+}
@@ -0,0 +1,138 @@
+package set
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor the cursor is malformed
+var ErrInvalidCursor = errors.New("set: invalid cursor")
+
+// Page page of a sorted set
+type Page[E any] struct {
+	// Items the elements of the page in ascending order
+	Items []E `json:"items"`
+	// Next the cursor of the following page, it is empty when there are no greater elements
+	Next string `json:"next,omitempty"`
+	// Previous the cursor of the preceding page, it is empty when there are no lesser elements
+	Previous string `json:"previous,omitempty"`
+}
+
+// EncodeCursor encodes the element into an opaque cursor
+func EncodeCursor[E any](value E) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the element of the cursor.
+// It returns [ErrInvalidCursor] when the cursor is malformed.
+func DecodeCursor[E any](cursor string) (E, error) {
+	var value E
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return value, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, ErrInvalidCursor
+	}
+	return value, nil
+}
+
+// After returns the page of at most n elements greater than the key
+func (s *SortedSet[E]) After(key E, n int) (*Page[E], error) {
+	var items []E
+	s.items.Ascend(key, func(value E) bool {
+		if len(items) >= n {
+			return false
+		}
+		if s.comparator.Compare(value, key) > 0 {
+			items = append(items, value)
+		}
+		return true
+	})
+	return s.page(items)
+}
+
+// Before returns the page of at most n elements less than the key, the closest ones to the key
+func (s *SortedSet[E]) Before(key E, n int) (*Page[E], error) {
+	var items []E
+	s.items.Descend(key, func(value E) bool {
+		if len(items) >= n {
+			return false
+		}
+		if s.comparator.Compare(value, key) < 0 {
+			items = append(items, value)
+		}
+		return true
+	})
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+	return s.page(items)
+}
+
+// PageAfter returns the page following the cursor, an empty cursor returns the first page.
+// It returns [ErrInvalidCursor] when the cursor is malformed.
+func (s *SortedSet[E]) PageAfter(cursor string, n int) (*Page[E], error) {
+	if cursor == "" {
+		first, ok := s.items.First()
+		if !ok {
+			return s.page(nil)
+		}
+		page, err := s.After(first, n-1)
+		if err != nil || n <= 0 {
+			return page, err
+		}
+		return s.page(append([]E{first}, page.Items...))
+	}
+	key, err := DecodeCursor[E](cursor)
+	if err != nil {
+		return nil, err
+	}
+	return s.After(key, n)
+}
+
+// PageBefore returns the page preceding the cursor, an empty cursor returns the last page.
+// It returns [ErrInvalidCursor] when the cursor is malformed.
+func (s *SortedSet[E]) PageBefore(cursor string, n int) (*Page[E], error) {
+	if cursor == "" {
+		last, ok := s.items.Last()
+		if !ok {
+			return s.page(nil)
+		}
+		page, err := s.Before(last, n-1)
+		if err != nil || n <= 0 {
+			return page, err
+		}
+		return s.page(append(page.Items, last))
+	}
+	key, err := DecodeCursor[E](cursor)
+	if err != nil {
+		return nil, err
+	}
+	return s.Before(key, n)
+}
+
+// page builds the page of the items with the cursors of its neighbors
+func (s *SortedSet[E]) page(items []E) (*Page[E], error) {
+	page := &Page[E]{Items: items}
+	if len(items) == 0 {
+		return page, nil
+	}
+	var err error
+	if first, _ := s.items.First(); s.comparator.Compare(first, items[0]) < 0 {
+		if page.Previous, err = EncodeCursor(items[0]); err != nil {
+			return nil, err
+		}
+	}
+	if last, _ := s.items.Last(); s.comparator.Compare(last, items[len(items)-1]) > 0 {
+		if page.Next, err = EncodeCursor(items[len(items)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
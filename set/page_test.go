@@ -0,0 +1,77 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSet_After(t *testing.T) {
+	s := NewSortedSet[int](_cmp{}, 10, 20, 30, 40, 50)
+	page, err := s.After(20, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{30, 40}, page.Items)
+	assert.NotEmpty(t, page.Previous)
+	assert.NotEmpty(t, page.Next)
+	page, err = s.After(25, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{30, 40, 50}, page.Items)
+	assert.Empty(t, page.Next)
+}
+
+func TestSortedSet_Before(t *testing.T) {
+	s := NewSortedSet[int](_cmp{}, 10, 20, 30, 40, 50)
+	page, err := s.Before(40, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{20, 30}, page.Items)
+	page, err = s.Before(30, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{10, 20}, page.Items)
+	assert.Empty(t, page.Previous)
+	assert.NotEmpty(t, page.Next)
+}
+
+func TestSortedSet_PageAfter(t *testing.T) {
+	s := NewSortedSet[int](_cmp{}, 1, 2, 3, 4, 5)
+	var pages [][]int
+	cursor := ""
+	for {
+		page, err := s.PageAfter(cursor, 2)
+		assert.Nil(t, err)
+		pages = append(pages, page.Items)
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, pages)
+
+	_, err := s.PageAfter("not a cursor!", 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	page, err := NewSortedSet[int](_cmp{}).PageAfter("", 2)
+	assert.Nil(t, err)
+	assert.Empty(t, page.Items)
+}
+
+func TestSortedSet_PageBefore(t *testing.T) {
+	s := NewSortedSet[int](_cmp{}, 1, 2, 3, 4, 5)
+	page, err := s.PageBefore("", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 5}, page.Items)
+	page, err = s.PageBefore(page.Previous, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3}, page.Items)
+	page, err = s.PageAfter(page.Next, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 5}, page.Items)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursor, err := EncodeCursor("key")
+	assert.Nil(t, err)
+	value, err := DecodeCursor[string](cursor)
+	assert.Nil(t, err)
+	assert.Equal(t, "key", value)
+	_, err = DecodeCursor[int](cursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
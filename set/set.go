@@ -1,10 +1,17 @@
 package set
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"io"
+	"slices"
 	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 )
 
 // NewSet new set
@@ -18,10 +25,30 @@ func NewSet[E comparable](values ...E) *Set[E] {
 	return set
 }
 
+// Collect new set of the elements of the sequence, such as an [iter.Seq]
+func Collect[E comparable](seq func(yield func(value E) bool)) *Set[E] {
+	set := NewSet[E]()
+	seq(func(value E) bool {
+		set.elements[value] = struct{}{}
+		return true
+	})
+	return set
+}
+
 // Set hash set
 type Set[E comparable] struct {
 	sync.RWMutex
 	elements map[E]struct{}
+	order    func(a, b E) int
+}
+
+// OrderBy makes the set iterate in the order of elements sorted by the callback,
+// which makes iteration reproducible at the cost of sorting elements on each iteration.
+// A nil callback restores the hash order.
+// Use [LinkedSet] to iterate in insertion order.
+func (s *Set[E]) OrderBy(callback func(a, b E) int) *Set[E] {
+	s.order = callback
+	return s
 }
 
 // Count returns the size of set
@@ -39,6 +66,16 @@ func (s *Set[E]) IsNotEmpty() bool {
 	return !s.IsEmpty()
 }
 
+// ElementType returns the name of the element type
+func (s *Set[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *Set[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
 // Contains returns whether the set contains the specific element
 func (s *Set[E]) Contains(value E) bool {
 	_, contains := s.elements[value]
@@ -82,8 +119,44 @@ func (s *Set[E]) RemoveWhere(callback func(E) bool) {
 	s.elements = items
 }
 
+// RetainWhere removes elements which do not match the callback
+func (s *Set[E]) RetainWhere(callback func(E) bool) {
+	s.RemoveWhere(func(item E) bool {
+		return !callback(item)
+	})
+}
+
+// CountWhere returns the number of elements which match the callback
+func (s *Set[E]) CountWhere(callback func(E) bool) int64 {
+	var count int64
+	for item := range s.elements {
+		if callback(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// AllWhere returns whether all elements match the callback, it returns true when the set is empty
+func (s *Set[E]) AllWhere(callback func(E) bool) bool {
+	for item := range s.elements {
+		if !callback(item) {
+			return false
+		}
+	}
+	return true
+}
+
 // Each runs callback for each element, it breaks when callback false
 func (s *Set[E]) Each(callback func(_ int, item E) bool) {
+	if s.order != nil {
+		for index, item := range s.ToArray() {
+			if !callback(index, item) {
+				break
+			}
+		}
+		return
+	}
 	for item := range s.elements {
 		if !callback(-1, item) {
 			break
@@ -100,6 +173,31 @@ func (s *Set[E]) Clear() {
 func (s *Set[E]) Clone() *Set[E] {
 	return &Set[E]{
 		elements: s.elements,
+		order:    s.order,
+	}
+}
+
+// All returns a sequence of the positions and elements in the order of Each, it stops when yield returns false.
+// Positions count from 0 even when the set is not ordered.
+func (s *Set[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		index := 0
+		s.Each(func(_ int, value E) bool {
+			if !yield(index, value) {
+				return false
+			}
+			index++
+			return true
+		})
+	}
+}
+
+// Values returns a sequence of the elements in the order of Each, it stops when yield returns false
+func (s *Set[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		s.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
 	}
 }
 
@@ -109,12 +207,57 @@ func (s *Set[E]) ToArray() []E {
 	for item := range s.elements {
 		values = append(values, item)
 	}
+	if s.order != nil {
+		slices.SortFunc(values, s.order)
+	}
 	return values
 }
 
+// MemoryFootprint estimates the memory used by the set in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (s *Set[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[Set[E]]() + memory.Map[E, struct{}](len(s.elements)) + memory.Deep(deep, s.Each)
+}
+
+// Encode writes the elements of the set to the writer in the format
+func (s *Set[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, s.ToArray(), format)
+}
+
+// Decode replaces the elements of the set with the elements read from the reader in the format
+func (s *Set[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (s *Set[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := s.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (s *Set[E]) GobDecode(data []byte) error {
+	return s.Decode(bytes.NewReader(data), codec.Gob)
+}
+
 // ToJSON converts to json
 func (s *Set[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(s.ToArray())
+	return jsonx.Array(s.ToArray())
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the set is not changed
+func (s *Set[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(s.ToArray(), callback)
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -136,28 +279,5 @@ func (s *Set[E]) UnmarshalJSON(data []byte) error {
 
 // String converts to string
 func (s *Set[E]) String() string {
-	str := new(strings.Builder)
-	str.WriteString(fmt.Sprintf("Set[%T](len=%d)", *new(E), len(s.elements)))
-	str.WriteByte('{')
-	str.WriteByte('\n')
-	index := 0
-	for item := range s.elements {
-		index++
-		str.WriteByte('\t')
-		if v, ok := any(item).(fmt.Stringer); ok {
-			str.WriteString(v.String())
-		} else {
-			str.WriteString(fmt.Sprintf("%v", item))
-		}
-		str.WriteByte(',')
-		str.WriteByte('\n')
-		if index >= 4 {
-			break
-		}
-	}
-	if len(s.elements) > 5 {
-		str.WriteString("\t...\n")
-	}
-	str.WriteByte('}')
-	return str.String()
+	return format.Values("Set", s.Count(), s.Values())
 }
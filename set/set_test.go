@@ -1,11 +1,16 @@
 package set
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +61,64 @@ func TestSet_RemoveWhere(t *testing.T) {
 	}))
 }
 
+func TestSet_RetainWhere(t *testing.T) {
+	set := NewSet[int](1, 2, 3, 4)
+	set.RetainWhere(func(i int) bool {
+		return i%2 == 0
+	})
+	assert.ElementsMatch(t, []int{2, 4}, set.ToArray())
+}
+
+func TestSet_CountWhere(t *testing.T) {
+	set := NewSet[int](1, 2, 3, 4)
+	assert.Equal(t, int64(2), set.CountWhere(func(i int) bool {
+		return i > 2
+	}))
+}
+
+func TestSet_AllWhere(t *testing.T) {
+	set := NewSet[int](2, 4)
+	assert.True(t, set.AllWhere(func(i int) bool {
+		return i%2 == 0
+	}))
+	set.Push(3)
+	assert.False(t, set.AllWhere(func(i int) bool {
+		return i%2 == 0
+	}))
+	assert.True(t, NewSet[int]().AllWhere(func(int) bool { return false }))
+}
+
+func TestSet_All(t *testing.T) {
+	set := NewSet(3, 1, 2).OrderBy(cmp.Compare[int])
+	var indexes, items []int
+	set.All()(func(index int, value int) bool {
+		indexes = append(indexes, index)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	indexes = nil
+	NewSet(3, 1, 2).All()(func(index int, _ int) bool {
+		indexes = append(indexes, index)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+
+	items = nil
+	set.Values()(func(value int) bool {
+		items = append(items, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestCollect(t *testing.T) {
+	set := Collect(NewSet(1, 2, 2, 3).Values())
+	assert.ElementsMatch(t, []int{1, 2, 3}, set.ToArray())
+}
+
 func TestSet_Each(t *testing.T) {
 	set := NewSet[int](1, 2, 3)
 	var items []int
@@ -121,3 +184,53 @@ func TestSet_String(t *testing.T) {
 	pattern := regexp.MustCompile(fmt.Sprintf(`Set\[int\]\(len=%d\)\{\n(\t\d+,\n){3}\}`, set.Count()))
 	assert.True(t, pattern.MatchString(str))
 }
+
+func TestSet_OrderBy(t *testing.T) {
+	set := NewSet[int](3, 1, 2).OrderBy(cmp.Compare[int])
+	assert.Equal(t, []int{1, 2, 3}, set.ToArray())
+	jsonBytes, err := set.ToJSON()
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "[1,2,3]", string(jsonBytes))
+	var items []int
+	set.Each(func(index int, item int) bool {
+		items = append(items, item)
+		return index < 1
+	})
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, "Set[int](len=3){\n\t1,\n\t2,\n\t3,\n}", set.String())
+}
+
+func TestSet_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewSet(1).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n", buf.String())
+}
+
+func TestSet_Decode(t *testing.T) {
+	s := NewSet(9)
+	assert.Nil(t, s.Decode(strings.NewReader("1\n2\n2\n"), codec.NDJSON))
+	assert.ElementsMatch(t, []int{1, 2}, s.ToArray())
+}
+
+func TestSet_JSONNull(t *testing.T) {
+	defer collection.SetMarshalEmptyAsNull(collection.MarshalEmptyAsNull())
+	s := NewSet(1)
+	assert.Nil(t, json.Unmarshal([]byte("null"), s))
+	assert.True(t, s.IsEmpty())
+	data, _ := json.Marshal(s)
+	assert.Equal(t, "[]", string(data))
+	collection.SetMarshalEmptyAsNull(true)
+	data, _ = json.Marshal(s)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestSet_ToJSONSorted(t *testing.T) {
+	s := NewSet(5, 3, 9, 1)
+	for range 3 {
+		data, err := s.ToJSONSorted(cmp.Compare[int])
+		assert.Nil(t, err)
+		assert.Equal(t, "[1,3,5,9]", string(data))
+	}
+}
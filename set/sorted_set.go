@@ -0,0 +1,257 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+	"github.com/gopi-frame/collection/tree"
+	"github.com/gopi-frame/contract"
+)
+
+// NewSortedSet new sorted set, elements are ordered and deduplicated by the comparator
+func NewSortedSet[E any](comparator contract.Comparator[E], values ...E) *SortedSet[E] {
+	set := new(SortedSet[E])
+	set.comparator = comparator
+	set.items = tree.NewRBTree[E](comparator)
+	set.Push(values...)
+	return set
+}
+
+// SortedSet set whose elements are kept sorted in a red-black tree
+type SortedSet[E any] struct {
+	sync.RWMutex
+	items      *tree.RBTree[E]
+	comparator contract.Comparator[E]
+	size       int64
+}
+
+// Count returns the size of set
+func (s *SortedSet[E]) Count() int64 {
+	return s.size
+}
+
+// IsEmpty returns whether the set is empty
+func (s *SortedSet[E]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// IsNotEmpty returns whether the set is not empty
+func (s *SortedSet[E]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (s *SortedSet[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindSet]
+func (s *SortedSet[E]) Kind() collection.CollectionKind {
+	return collection.KindSet
+}
+
+// Contains returns whether the set contains the specific element
+func (s *SortedSet[E]) Contains(value E) bool {
+	return s.items.Contains(value)
+}
+
+// Push pushes elements into the set, elements equal to an existing element are ignored
+func (s *SortedSet[E]) Push(values ...E) {
+	for _, value := range values {
+		if s.items.Contains(value) {
+			continue
+		}
+		s.items.Push(value)
+		s.size++
+	}
+}
+
+// Remove removes the specific element
+func (s *SortedSet[E]) Remove(value E) {
+	if s.items.Contains(value) {
+		s.items.Remove(value)
+		s.size--
+	}
+}
+
+// RemoveRange removes the elements in range [lo, hi) and returns the number of removed elements
+func (s *SortedSet[E]) RemoveRange(lo, hi E) int {
+	count := 0
+	for value, ok := s.items.Ceiling(lo); ok && s.comparator.Compare(value, hi) < 0; value, ok = s.items.Ceiling(value) {
+		s.items.Remove(value)
+		s.size--
+		count++
+	}
+	return count
+}
+
+// First returns the least element.
+// It returns zero value and false when the set is empty.
+func (s *SortedSet[E]) First() (E, bool) {
+	return s.items.First()
+}
+
+// Last returns the greatest element.
+// It returns zero value and false when the set is empty.
+func (s *SortedSet[E]) Last() (E, bool) {
+	return s.items.Last()
+}
+
+// PopFirst removes the least element and returns it.
+// It returns zero value and false when the set is empty.
+func (s *SortedSet[E]) PopFirst() (E, bool) {
+	value, ok := s.items.First()
+	if ok {
+		s.Remove(value)
+	}
+	return value, ok
+}
+
+// PopLast removes the greatest element and returns it.
+// It returns zero value and false when the set is empty.
+func (s *SortedSet[E]) PopLast() (E, bool) {
+	value, ok := s.items.Last()
+	if ok {
+		s.Remove(value)
+	}
+	return value, ok
+}
+
+// Floor returns the greatest element less than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (s *SortedSet[E]) Floor(value E) (E, bool) {
+	return s.items.Floor(value)
+}
+
+// Ceiling returns the least element greater than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (s *SortedSet[E]) Ceiling(value E) (E, bool) {
+	return s.items.Ceiling(value)
+}
+
+// Nearest returns the element closest to the given value by the distance callback,
+// the lesser element wins a tie. It returns zero value and false when the set is empty.
+func (s *SortedSet[E]) Nearest(value E, distance func(a, b E) float64) (E, bool) {
+	floor, hasFloor := s.items.Floor(value)
+	ceiling, hasCeiling := s.items.Ceiling(value)
+	if !hasFloor {
+		return ceiling, hasCeiling
+	}
+	if !hasCeiling || distance(value, floor) <= distance(value, ceiling) {
+		return floor, true
+	}
+	return ceiling, true
+}
+
+// Each runs callback for each element in ascending order, it breaks when callback false
+func (s *SortedSet[E]) Each(callback func(index int, item E) bool) {
+	s.items.Each(callback)
+}
+
+// All returns a sequence of the indexes and elements in ascending order, it stops when yield returns false
+func (s *SortedSet[E]) All() func(yield func(index int, value E) bool) {
+	return func(yield func(index int, value E) bool) {
+		s.Each(yield)
+	}
+}
+
+// Values returns a sequence of the elements in the order of Each, it stops when yield returns false
+func (s *SortedSet[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		s.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Clear clears the set
+func (s *SortedSet[E]) Clear() {
+	s.items.Clear()
+	s.size = 0
+}
+
+// Clone clones the set
+func (s *SortedSet[E]) Clone() *SortedSet[E] {
+	return NewSortedSet(s.comparator, s.ToArray()...)
+}
+
+// ToArray converts to array in ascending order
+func (s *SortedSet[E]) ToArray() []E {
+	return s.items.ToArray()
+}
+
+// MemoryFootprint estimates the memory used by the set in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (s *SortedSet[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[SortedSet[E]]() + s.items.MemoryFootprint(deep)
+}
+
+// Encode writes the elements of the set to the writer in the format
+func (s *SortedSet[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, s.ToArray(), format)
+}
+
+// Decode replaces the elements of the set with the elements read from the reader in the format
+func (s *SortedSet[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (s *SortedSet[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := s.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (s *SortedSet[E]) GobDecode(data []byte) error {
+	return s.Decode(bytes.NewReader(data), codec.Gob)
+}
+
+// ToJSON converts to json
+func (s *SortedSet[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(s.ToArray())
+}
+
+// ToJSONSorted converts to json with the elements sorted by the callback, such as for diffs and golden files,
+// the order of the set is not changed
+func (s *SortedSet[E]) ToJSONSorted(callback func(a, b E) int) ([]byte, error) {
+	return jsonx.SortedArray(s.ToArray(), callback)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *SortedSet[E]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (s *SortedSet[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	s.Clear()
+	s.Push(items...)
+	return nil
+}
+
+// String converts to string
+func (s *SortedSet[E]) String() string {
+	return format.Values("SortedSet", s.Count(), s.Values())
+}
@@ -0,0 +1,141 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+type _cmp struct{}
+
+func (c _cmp) Compare(a, b int) int {
+	return a - b
+}
+
+func distance(a, b int) float64 {
+	return math.Abs(float64(a - b))
+}
+
+func TestSortedSet_Push(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 3, 1, 2, 3, 1)
+	assert.Equal(t, int64(3), set.Count())
+	assert.Equal(t, []int{1, 2, 3}, set.ToArray())
+	assert.True(t, set.Contains(2))
+	assert.False(t, set.Contains(4))
+}
+
+func TestSortedSet_Remove(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 1, 2, 3)
+	set.Remove(2)
+	set.Remove(4)
+	assert.Equal(t, int64(2), set.Count())
+	assert.Equal(t, []int{1, 3}, set.ToArray())
+}
+
+func TestSortedSet_RemoveRange(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 1, 3, 5, 7, 9)
+	assert.Equal(t, 2, set.RemoveRange(2, 7))
+	assert.Equal(t, []int{1, 7, 9}, set.ToArray())
+	assert.Equal(t, 0, set.RemoveRange(10, 20))
+	assert.Equal(t, 3, set.RemoveRange(0, 10))
+	assert.True(t, set.IsEmpty())
+}
+
+func TestSortedSet_PopFirst(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 2, 1)
+	value, ok := set.PopFirst()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = set.PopFirst()
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	_, ok = set.PopFirst()
+	assert.False(t, ok)
+}
+
+func TestSortedSet_PopLast(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 2, 1)
+	value, ok := set.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, int64(1), set.Count())
+}
+
+func TestSortedSet_Nearest(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 10, 20, 30)
+	value, ok := set.Nearest(14, distance)
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	value, _ = set.Nearest(16, distance)
+	assert.Equal(t, 20, value)
+	value, _ = set.Nearest(15, distance)
+	assert.Equal(t, 10, value)
+	value, _ = set.Nearest(5, distance)
+	assert.Equal(t, 10, value)
+	value, _ = set.Nearest(50, distance)
+	assert.Equal(t, 30, value)
+	_, ok = NewSortedSet[int](_cmp{}).Nearest(1, distance)
+	assert.False(t, ok)
+}
+
+func TestSortedSet_All(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 3, 1, 2)
+	var indexes, items []int
+	set.All()(func(index int, item int) bool {
+		indexes = append(indexes, index)
+		items = append(items, item)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	items = nil
+	set.Values()(func(item int) bool {
+		items = append(items, item)
+		return item < 2
+	})
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestSortedSet_Clone(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 1, 2)
+	clone := set.Clone()
+	clone.Push(3)
+	assert.Equal(t, []int{1, 2}, set.ToArray())
+	assert.Equal(t, []int{1, 2, 3}, clone.ToArray())
+}
+
+func TestSortedSet_UnmarshalJSON(t *testing.T) {
+	set := NewSortedSet[int](_cmp{})
+	err := json.Unmarshal([]byte(`[3,1,2,1]`), set)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, set.ToArray())
+	jsonBytes, err := json.Marshal(set)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[1,2,3]`, string(jsonBytes))
+}
+
+func TestSortedSet_String(t *testing.T) {
+	set := NewSortedSet[int](_cmp{}, 6, 5, 4, 3, 2, 1)
+	pattern := regexp.MustCompile(fmt.Sprintf(`SortedSet\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, set.Count()))
+	assert.True(t, pattern.MatchString(set.String()))
+}
+
+func TestSortedSet_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewSortedSet[int](_cmp{}, 3, 1, 2).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestSortedSet_Decode(t *testing.T) {
+	x := NewSortedSet[int](_cmp{}, 9)
+	assert.Nil(t, x.Decode(strings.NewReader("1\n2\n3\n"), codec.NDJSON))
+	assert.Equal(t, []int{1, 2, 3}, x.ToArray())
+}
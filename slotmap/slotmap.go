@@ -0,0 +1,268 @@
+// Package slotmap provides a generational arena whose keys stay valid until their element is deleted,
+// such as for entity systems and object registries.
+package slotmap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/contract"
+)
+
+// Key key of an element of a [SlotMap].
+// A key never refers to another element once its element is deleted, even after its slot is reused,
+// the zero value refers to no element.
+type Key struct {
+	index      uint32
+	generation uint32
+}
+
+// IsZero returns whether the key is the zero value
+func (k Key) IsZero() bool {
+	return k.generation == 0
+}
+
+// String converts to string
+func (k Key) String() string {
+	return fmt.Sprintf("%dv%d", k.index, k.generation)
+}
+
+type slot[E any] struct {
+	value      E
+	generation uint32
+	// next index of the next free slot while the slot is free
+	next     uint32
+	occupied bool
+}
+
+const noSlot = ^uint32(0)
+
+// New new slot map
+func New[E any](values ...E) *SlotMap[E] {
+	m := new(SlotMap[E])
+	for _, value := range values {
+		m.Insert(value)
+	}
+	return m
+}
+
+// SlotMap arena of elements addressed by generational keys.
+// Insert, Get and Delete are O(1), the slots of deleted elements are reused
+// and their generation is bumped so the keys of deleted elements are rejected.
+type SlotMap[E any] struct {
+	sync.RWMutex
+	slots []slot[E]
+	free  uint32
+	size  int
+}
+
+func (m *SlotMap[E]) init() {
+	if m.slots == nil {
+		m.slots = []slot[E]{}
+		m.free = noSlot
+	}
+}
+
+func (m *SlotMap[E]) slot(key Key) *slot[E] {
+	if key.generation == 0 || int(key.index) >= len(m.slots) {
+		return nil
+	}
+	s := &m.slots[key.index]
+	if !s.occupied || s.generation != key.generation {
+		return nil
+	}
+	return s
+}
+
+// Count returns the number of elements
+func (m *SlotMap[E]) Count() int64 {
+	return int64(m.size)
+}
+
+// IsEmpty returns whether the slot map is empty
+func (m *SlotMap[E]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// IsNotEmpty returns whether the slot map is not empty
+func (m *SlotMap[E]) IsNotEmpty() bool {
+	return m.size > 0
+}
+
+// ElementType returns the name of the element type
+func (m *SlotMap[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindMap]
+func (m *SlotMap[E]) Kind() collection.CollectionKind {
+	return collection.KindMap
+}
+
+// KeyType returns the name of [Key]
+func (m *SlotMap[E]) KeyType() string {
+	return collection.TypeName[Key]()
+}
+
+// Capacity returns the number of slots, occupied or free
+func (m *SlotMap[E]) Capacity() int {
+	return len(m.slots)
+}
+
+// Insert inserts an element into a free slot, or a new one when none is free, and returns its key
+func (m *SlotMap[E]) Insert(value E) Key {
+	m.init()
+	var index uint32
+	if m.free != noSlot {
+		index = m.free
+		m.free = m.slots[index].next
+	} else {
+		index = uint32(len(m.slots))
+		m.slots = append(m.slots, slot[E]{})
+	}
+	s := &m.slots[index]
+	s.value = value
+	s.generation++
+	if s.generation == 0 {
+		s.generation = 1
+	}
+	s.occupied = true
+	m.size++
+	return Key{index: index, generation: s.generation}
+}
+
+// ContainsKey returns whether the key refers to an element
+func (m *SlotMap[E]) ContainsKey(key Key) bool {
+	return m.slot(key) != nil
+}
+
+// Get returns the element of the key, it returns false when the element is deleted
+func (m *SlotMap[E]) Get(key Key) (E, bool) {
+	s := m.slot(key)
+	if s == nil {
+		return *new(E), false
+	}
+	return s.value, true
+}
+
+// GetRef returns a pointer to the element of the key to update it in place,
+// the pointer is only valid until the next Insert.
+// It returns false when the element is deleted.
+func (m *SlotMap[E]) GetRef(key Key) (*E, bool) {
+	s := m.slot(key)
+	if s == nil {
+		return nil, false
+	}
+	return &s.value, true
+}
+
+// Set replaces the element of the key, it returns false when the element is deleted
+func (m *SlotMap[E]) Set(key Key, value E) bool {
+	s := m.slot(key)
+	if s == nil {
+		return false
+	}
+	s.value = value
+	return true
+}
+
+// Delete deletes the element of the key and frees its slot, it returns false when the element is already deleted
+func (m *SlotMap[E]) Delete(key Key) (E, bool) {
+	s := m.slot(key)
+	if s == nil {
+		return *new(E), false
+	}
+	value := s.value
+	s.value = *new(E)
+	s.occupied = false
+	s.next = m.free
+	m.free = key.index
+	m.size--
+	return value, true
+}
+
+// Clear deletes all elements, the slots are kept for reuse
+func (m *SlotMap[E]) Clear() {
+	m.free = noSlot
+	for i := len(m.slots) - 1; i >= 0; i-- {
+		s := &m.slots[i]
+		s.value = *new(E)
+		s.occupied = false
+		s.next = m.free
+		m.free = uint32(i)
+	}
+	m.size = 0
+}
+
+// Each ranges the elements in slot order, it will break the loop when the callback returns false.
+// Deleting the element of the given key in the callback is allowed.
+func (m *SlotMap[E]) Each(callback func(key Key, value E) bool) {
+	for i := range m.slots {
+		s := &m.slots[i]
+		if s.occupied && !callback(Key{index: uint32(i), generation: s.generation}, s.value) {
+			break
+		}
+	}
+}
+
+// Keys returns the keys of the elements in slot order
+func (m *SlotMap[E]) Keys() []Key {
+	keys := make([]Key, 0, m.size)
+	m.Each(func(key Key, _ E) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ToArray returns the elements in slot order
+func (m *SlotMap[E]) ToArray() []E {
+	items := make([]E, 0, m.size)
+	m.Each(func(_ Key, value E) bool {
+		items = append(items, value)
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json, the elements are encoded as an array in slot order
+func (m *SlotMap[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(m.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (m *SlotMap[E]) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// String converts to string
+func (m *SlotMap[E]) String() string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("SlotMap[%T](len=%d)", *new(E), m.Count()))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	count := 0
+	m.Each(func(key Key, value E) bool {
+		str.WriteByte('\t')
+		str.WriteString(key.String())
+		str.WriteByte(':')
+		str.WriteByte(' ')
+		if v, ok := any(value).(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		count++
+		return count < 5
+	})
+	if m.size > 5 {
+		str.WriteString("\t...\n")
+	}
+	str.WriteByte('}')
+	return str.String()
+}
@@ -0,0 +1,103 @@
+package slotmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotMap_Insert(t *testing.T) {
+	m := New[string]()
+	a := m.Insert("a")
+	b := m.Insert("b")
+	assert.True(t, a != b)
+	assert.False(t, a.IsZero())
+	value, ok := m.Get(b)
+	assert.True(t, ok)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, int64(2), m.Count())
+	_, ok = m.Get(Key{})
+	assert.False(t, ok)
+}
+
+func TestSlotMap_Delete(t *testing.T) {
+	m := New[string]()
+	a := m.Insert("a")
+	m.Insert("b")
+	value, ok := m.Delete(a)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+	_, ok = m.Delete(a)
+	assert.False(t, ok)
+	assert.False(t, m.ContainsKey(a))
+
+	c := m.Insert("c")
+	assert.Equal(t, 2, m.Capacity())
+	assert.True(t, a != c)
+	_, ok = m.Get(a)
+	assert.False(t, ok)
+	assert.False(t, m.Set(a, "x"))
+	value, _ = m.Get(c)
+	assert.Equal(t, "c", value)
+}
+
+func TestSlotMap_GetRef(t *testing.T) {
+	type _entity struct{ HP int }
+	m := New[_entity]()
+	key := m.Insert(_entity{HP: 10})
+	entity, ok := m.GetRef(key)
+	assert.True(t, ok)
+	entity.HP -= 3
+	value, _ := m.Get(key)
+	assert.Equal(t, 7, value.HP)
+	assert.True(t, m.Set(key, _entity{HP: 1}))
+	value, _ = m.Get(key)
+	assert.Equal(t, 1, value.HP)
+}
+
+func TestSlotMap_Each(t *testing.T) {
+	m := New(1, 2, 3, 4)
+	m.Each(func(key Key, value int) bool {
+		if value%2 == 0 {
+			m.Delete(key)
+		}
+		return true
+	})
+	assert.Equal(t, []int{1, 3}, m.ToArray())
+	assert.Len(t, m.Keys(), 2)
+
+	var visited []int
+	m.Each(func(_ Key, value int) bool {
+		visited = append(visited, value)
+		return false
+	})
+	assert.Equal(t, []int{1}, visited)
+}
+
+func TestSlotMap_Clear(t *testing.T) {
+	m := New(1, 2, 3)
+	keys := m.Keys()
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+	assert.False(t, m.ContainsKey(keys[0]))
+	key := m.Insert(4)
+	assert.Equal(t, 3, m.Capacity())
+	assert.True(t, key != keys[0])
+	assert.Equal(t, []int{4}, m.ToArray())
+}
+
+func TestSlotMap_MarshalJSON(t *testing.T) {
+	m := New(1, 2)
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2]", string(data))
+}
+
+func TestSlotMap_String(t *testing.T) {
+	m := New(1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`SlotMap\[int\]\(len=%d\)\{\n(\t\d+v\d+:\s\d+,\n){5}\t...\n\}`, m.Count()))
+	assert.True(t, pattern.MatchString(m.String()))
+}
@@ -0,0 +1,258 @@
+// Package snapshot persists collections to files and restores them.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Format format of a snapshot file, an encoding optionally combined with [Gzip]
+type Format uint8
+
+const (
+	// JSON encodes the value with encoding/json, collections implement [json.Marshaler]
+	JSON Format = iota
+	// Gob encodes the value with encoding/gob
+	Gob
+	// Gzip compresses the encoded value, e.g. JSON|Gzip
+	Gzip Format = 1 << 7
+)
+
+var (
+	// ErrUnknownFormat the format is not supported
+	ErrUnknownFormat = errors.New("snapshot: unknown format")
+	// ErrFormatMismatch the file was saved in another format
+	ErrFormatMismatch = errors.New("snapshot: format mismatch")
+	// ErrCorrupted the file is not a valid snapshot
+	ErrCorrupted = errors.New("snapshot: corrupted")
+)
+
+// CorruptionError error of a damaged snapshot file, it matches [ErrCorrupted]
+type CorruptionError struct {
+	Path   string
+	Reason string
+}
+
+// Error implements [error]
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("snapshot: %s is corrupted: %s", e.Path, e.Reason)
+}
+
+// Unwrap returns [ErrCorrupted]
+func (e *CorruptionError) Unwrap() error {
+	return ErrCorrupted
+}
+
+var magic = [4]byte{'C', 'S', 'N', 'P'}
+
+const version = 1
+
+// header header of a snapshot file, it is followed by the encoded value
+type header struct {
+	Magic    [4]byte
+	Version  uint8
+	Format   Format
+	_        [2]byte
+	Length   uint64
+	Checksum [sha256.Size]byte
+}
+
+var headerSize = int64(binary.Size(header{}))
+
+func (f Format) encoding() Format {
+	return f &^ Gzip
+}
+
+func (f Format) compressed() bool {
+	return f&Gzip != 0
+}
+
+// SaveToFile writes the value to the file in the format.
+// The value is written to a temporary file in the same directory which replaces the file once it is synced,
+// so readers never observe a partially written snapshot. The permissions of an existing file are kept.
+// The file starts with a header holding the length and the SHA-256 checksum of the encoded value.
+func SaveToFile(path string, value any, format Format) (err error) {
+	if format.encoding() != JSON && format.encoding() != Gob {
+		return ErrUnknownFormat
+	}
+	perm := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Seek(headerSize, io.SeekStart); err != nil {
+		return err
+	}
+	hash := sha256.New()
+	counter := new(countingWriter)
+	if err = write(io.MultiWriter(tmp, hash, counter), value, format); err != nil {
+		return err
+	}
+	h := header{Magic: magic, Version: version, Format: format, Length: counter.n}
+	hash.Sum(h.Checksum[:0])
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err = binary.Write(tmp, binary.BigEndian, &h); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// LoadFromFile reads the file in the format into the value, which must be a pointer.
+// The checksum is verified before the value is decoded, a damaged file returns a [*CorruptionError].
+func LoadFromFile(path string, value any, format Format) error {
+	if format.encoding() != JSON && format.encoding() != Gob {
+		return ErrUnknownFormat
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	var h header
+	if info.Size() < headerSize {
+		return &CorruptionError{Path: path, Reason: "truncated header"}
+	}
+	if err := binary.Read(file, binary.BigEndian, &h); err != nil {
+		return err
+	}
+	if h.Magic != magic {
+		return &CorruptionError{Path: path, Reason: "not a snapshot file"}
+	}
+	if h.Version != version {
+		return &CorruptionError{Path: path, Reason: fmt.Sprintf("unsupported version %d", h.Version)}
+	}
+	if h.Format != format {
+		return fmt.Errorf("%w: %s was saved in format %d", ErrFormatMismatch, path, h.Format)
+	}
+	if uint64(info.Size()-headerSize) != h.Length {
+		return &CorruptionError{Path: path, Reason: fmt.Sprintf("expected %d bytes, got %d", h.Length, info.Size()-headerSize)}
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, headerSize, int64(h.Length))); err != nil {
+		return err
+	}
+	if !bytes.Equal(hash.Sum(nil), h.Checksum[:]) {
+		return &CorruptionError{Path: path, Reason: "checksum mismatch"}
+	}
+	return read(io.NewSectionReader(file, headerSize, int64(h.Length)), value, format)
+}
+
+type countingWriter struct {
+	n uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += uint64(len(p))
+	return len(p), nil
+}
+
+func write(w io.Writer, value any, format Format) error {
+	buffered := bufio.NewWriter(w)
+	var out io.Writer = buffered
+	var zw *gzip.Writer
+	if format.compressed() {
+		zw = gzip.NewWriter(buffered)
+		out = zw
+	}
+	if err := encode(out, value, format.encoding()); err != nil {
+		return err
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+func read(r io.Reader, value any, format Format) error {
+	in := bufio.NewReader(r)
+	if !format.compressed() {
+		return decode(in, value, format.encoding())
+	}
+	zr, err := gzip.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	return decode(zr, value, format.encoding())
+}
+
+func encode(w io.Writer, value any, format Format) error {
+	switch format {
+	case JSON:
+		return json.NewEncoder(w).Encode(value)
+	case Gob:
+		return gob.NewEncoder(w).Encode(value)
+	}
+	return ErrUnknownFormat
+}
+
+func decode(r io.Reader, value any, format Format) error {
+	var err error
+	switch format {
+	case JSON:
+		err = json.NewDecoder(r).Decode(value)
+	case Gob:
+		err = gob.NewDecoder(r).Decode(value)
+	default:
+		return ErrUnknownFormat
+	}
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("snapshot: empty snapshot: %w", io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// syncDir persists the rename, it is best effort since not every platform can sync a directory
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
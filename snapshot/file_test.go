@@ -0,0 +1,151 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gopi-frame/collection/kv"
+	"github.com/gopi-frame/collection/list"
+	"github.com/gopi-frame/collection/set"
+	"github.com/gopi-frame/collection/stack"
+	"github.com/stretchr/testify/assert"
+)
+
+func payload(t *testing.T, path string) []byte {
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Greater(t, int64(len(data)), headerSize)
+	return data[headerSize:]
+}
+
+func TestSaveToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.json")
+	assert.Nil(t, SaveToFile(path, list.NewList(1, 2, 3), JSON))
+	assert.JSONEq(t, `[1,2,3]`, string(payload(t, path)))
+
+	restored := list.NewList[int]()
+	assert.Nil(t, LoadFromFile(path, restored, JSON))
+	assert.Equal(t, []int{1, 2, 3}, restored.ToArray())
+}
+
+func TestSaveToFile_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.json.gz")
+	m := kv.NewLinkedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	assert.Nil(t, SaveToFile(path, m, JSON|Gzip))
+
+	zr, err := gzip.NewReader(bytes.NewReader(payload(t, path)))
+	assert.Nil(t, err)
+	data, err := io.ReadAll(zr)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"entries":{"a":1,"b":2},"keys":["b","a"]}`, string(data))
+
+	restored := kv.NewLinkedMap[string, int]()
+	assert.Nil(t, LoadFromFile(path, restored, JSON|Gzip))
+	assert.Equal(t, []string{"b", "a"}, restored.Keys())
+}
+
+func TestSaveToFile_Gob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.gob")
+	state := map[string][]int{"a": {1, 2}, "b": {3}}
+	assert.Nil(t, SaveToFile(path, state, Gob|Gzip))
+	var restored map[string][]int
+	assert.Nil(t, LoadFromFile(path, &restored, Gob|Gzip))
+	assert.Equal(t, state, restored)
+
+	path = filepath.Join(t.TempDir(), "list.gob")
+	assert.Nil(t, SaveToFile(path, list.NewList(1, 2, 3), Gob))
+	restoredList := list.NewList[int]()
+	assert.Nil(t, LoadFromFile(path, restoredList, Gob))
+	assert.Equal(t, []int{1, 2, 3}, restoredList.ToArray())
+
+	path = filepath.Join(t.TempDir(), "map.gob.gz")
+	m := kv.NewLinkedMap[string, []int]()
+	m.Set("b", []int{2})
+	m.Set("a", []int{1, 1})
+	assert.Nil(t, SaveToFile(path, m, Gob|Gzip))
+	restoredMap := kv.NewLinkedMap[string, []int]()
+	assert.Nil(t, LoadFromFile(path, restoredMap, Gob|Gzip))
+	assert.Equal(t, []string{"b", "a"}, restoredMap.Keys())
+	assert.Equal(t, [][]int{{2}, {1, 1}}, restoredMap.Values())
+
+	// collections nested in other values are encoded too
+	type document struct {
+		Tags  *set.Set[string]
+		Stack *stack.Stack[int]
+	}
+	path = filepath.Join(t.TempDir(), "document.gob")
+	assert.Nil(t, SaveToFile(path, document{Tags: set.NewSet("x"), Stack: stack.NewStack(1, 2)}, Gob))
+	var restoredDocument document
+	assert.Nil(t, LoadFromFile(path, &restoredDocument, Gob))
+	assert.Equal(t, []string{"x"}, restoredDocument.Tags.ToArray())
+	assert.Equal(t, []int{1, 2}, restoredDocument.Stack.ToArray())
+}
+
+func TestSaveToFile_Replace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`[0]`), 0o600))
+	assert.Nil(t, SaveToFile(path, list.NewList(1), JSON))
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+
+	err = SaveToFile(path, list.NewList(make(chan int)), JSON)
+	assert.NotNil(t, err)
+	assert.JSONEq(t, `[1]`, string(payload(t, path)))
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestLoadFromFile(t *testing.T) {
+	dir := t.TempDir()
+	restored := list.NewList[int]()
+	assert.True(t, errors.Is(LoadFromFile(filepath.Join(dir, "missing.json"), restored, JSON), fs.ErrNotExist))
+
+	empty := filepath.Join(dir, "empty.json")
+	var items []int
+	assert.Nil(t, SaveToFile(empty, items, Gob))
+	assert.Nil(t, LoadFromFile(empty, &items, Gob))
+	assert.ErrorIs(t, LoadFromFile(empty, restored, JSON), ErrFormatMismatch)
+
+	assert.ErrorIs(t, LoadFromFile(empty, restored, Format(5)), ErrUnknownFormat)
+	assert.ErrorIs(t, SaveToFile(empty, restored, Format(5)), ErrUnknownFormat)
+}
+
+func TestLoadFromFile_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.json")
+	assert.Nil(t, SaveToFile(path, list.NewList(1, 2, 3), JSON))
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+
+	corrupt := func(data []byte, reason string) {
+		assert.Nil(t, os.WriteFile(path, data, 0o644))
+		restored := list.NewList[int]()
+		err := LoadFromFile(path, restored, JSON)
+		var corruption *CorruptionError
+		assert.True(t, errors.As(err, &corruption), reason)
+		assert.ErrorIs(t, err, ErrCorrupted)
+		assert.Equal(t, path, corruption.Path)
+		assert.Equal(t, reason, corruption.Reason)
+		assert.True(t, restored.IsEmpty())
+	}
+	corrupt(data[:10], "truncated header")
+	corrupt(data[:len(data)-2], "expected 8 bytes, got 6")
+	corrupt(append([]byte("[1,2,3]\n"), make([]byte, headerSize)...), "not a snapshot file")
+
+	flipped := bytes.Clone(data)
+	flipped[len(flipped)-3] = '9'
+	corrupt(flipped, "checksum mismatch")
+
+	future := bytes.Clone(data)
+	future[4] = 2
+	corrupt(future, "unsupported version 2")
+}
@@ -0,0 +1,375 @@
+// Package spatial provides spatial indexes over points.
+package spatial
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/queue"
+	"github.com/gopi-frame/contract"
+)
+
+type kdNode[E any] struct {
+	value   E
+	point   []float64
+	left    *kdNode[E]
+	right   *kdNode[E]
+	removed bool
+}
+
+// NewKDTree new k-d tree of the given dimensions, position returns the coordinates of an element.
+// The tree is built balanced from the values.
+func NewKDTree[E any](dimensions int, position func(E) []float64, values ...E) *KDTree[E] {
+	tree := new(KDTree[E])
+	tree.dimensions = dimensions
+	tree.position = position
+	tree.Push(values...)
+	tree.Rebuild()
+	return tree
+}
+
+// KDTree k-d tree supporting nearest neighbor and range queries with euclidean distances.
+// Pushed elements are inserted without rebalancing, call [KDTree.Rebuild] after large batches of changes.
+type KDTree[E any] struct {
+	sync.RWMutex
+	dimensions int
+	position   func(E) []float64
+	root       *kdNode[E]
+	size       int64
+	removed    int64
+}
+
+func (t *KDTree[E]) pointOf(value E) []float64 {
+	point := t.position(value)
+	if len(point) != t.dimensions {
+		panic(fmt.Sprintf("spatial: point of %d dimensions in a tree of %d dimensions", len(point), t.dimensions))
+	}
+	return point
+}
+
+// Dimensions returns the number of dimensions
+func (t *KDTree[E]) Dimensions() int {
+	return t.dimensions
+}
+
+// Count returns the size of the tree
+func (t *KDTree[E]) Count() int64 {
+	return t.size
+}
+
+// IsEmpty returns whether the tree is empty
+func (t *KDTree[E]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// IsNotEmpty returns whether the tree is not empty
+func (t *KDTree[E]) IsNotEmpty() bool {
+	return !t.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (t *KDTree[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindTree]
+func (t *KDTree[E]) Kind() collection.CollectionKind {
+	return collection.KindTree
+}
+
+// Push inserts elements into the tree
+func (t *KDTree[E]) Push(values ...E) {
+	for _, value := range values {
+		node := &kdNode[E]{value: value, point: t.pointOf(value)}
+		t.size++
+		if t.root == nil {
+			t.root = node
+			continue
+		}
+		for parent, depth := t.root, 0; ; depth++ {
+			axis := depth % t.dimensions
+			next := &parent.right
+			if node.point[axis] < parent.point[axis] {
+				next = &parent.left
+			}
+			if *next == nil {
+				*next = node
+				break
+			}
+			parent = *next
+		}
+	}
+}
+
+// Remove removes the specific element, the tree is rebuilt once half of its nodes are removed
+func (t *KDTree[E]) Remove(value E) bool {
+	point := t.pointOf(value)
+	var node *kdNode[E]
+	t.walk(t.root, 0, func(n *kdNode[E]) bool {
+		if !n.removed && equal.Equal(n.value, value) {
+			node = n
+			return false
+		}
+		return true
+	}, func(n *kdNode[E], axis int) (left, right bool) {
+		return point[axis] < n.point[axis], point[axis] >= n.point[axis]
+	})
+	if node == nil {
+		return false
+	}
+	node.removed = true
+	t.size--
+	t.removed++
+	if t.removed > t.size {
+		t.Rebuild()
+	}
+	return true
+}
+
+// walk visits the nodes in depth first order until visit returns false, prune tells which subtrees may match
+func (t *KDTree[E]) walk(node *kdNode[E], depth int, visit func(n *kdNode[E]) bool, prune func(n *kdNode[E], axis int) (left, right bool)) bool {
+	if node == nil {
+		return true
+	}
+	axis := depth % t.dimensions
+	if !visit(node) {
+		return false
+	}
+	left, right := prune(node, axis)
+	if left && !t.walk(node.left, depth+1, visit, prune) {
+		return false
+	}
+	if right && !t.walk(node.right, depth+1, visit, prune) {
+		return false
+	}
+	return true
+}
+
+// Rebuild rebuilds the tree balanced and drops removed nodes
+func (t *KDTree[E]) Rebuild() {
+	nodes := make([]*kdNode[E], 0, t.size)
+	t.eachNode(t.root, func(node *kdNode[E]) {
+		node.left, node.right = nil, nil
+		nodes = append(nodes, node)
+	})
+	t.root = t.build(nodes, 0)
+	t.removed = 0
+}
+
+func (t *KDTree[E]) eachNode(node *kdNode[E], callback func(node *kdNode[E])) {
+	if node == nil {
+		return
+	}
+	left, right := node.left, node.right
+	t.eachNode(left, callback)
+	if !node.removed {
+		callback(node)
+	}
+	t.eachNode(right, callback)
+}
+
+func (t *KDTree[E]) build(nodes []*kdNode[E], depth int) *kdNode[E] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	axis := depth % t.dimensions
+	slices.SortStableFunc(nodes, func(a, b *kdNode[E]) int {
+		if a.point[axis] < b.point[axis] {
+			return -1
+		} else if a.point[axis] > b.point[axis] {
+			return 1
+		}
+		return 0
+	})
+	median := len(nodes) / 2
+	// equal coordinates go right, as they do on insertion
+	for median > 0 && nodes[median-1].point[axis] == nodes[median].point[axis] {
+		median--
+	}
+	node := nodes[median]
+	node.left = t.build(nodes[:median], depth+1)
+	node.right = t.build(nodes[median+1:], depth+1)
+	return node
+}
+
+// Neighbor element with its distance to a query point
+type Neighbor[E any] struct {
+	Value    E       `json:"value"`
+	Distance float64 `json:"distance"`
+}
+
+type farthestFirst[E any] struct{}
+
+func (farthestFirst[E]) Compare(a, b Neighbor[E]) int {
+	if a.Distance > b.Distance {
+		return -1
+	} else if a.Distance < b.Distance {
+		return 1
+	}
+	return 0
+}
+
+// Nearest returns the k elements nearest to the point ordered by distance
+func (t *KDTree[E]) Nearest(point []float64, k int) []Neighbor[E] {
+	if k <= 0 {
+		return nil
+	}
+	best := queue.NewPriorityQueue[Neighbor[E]](farthestFirst[E]{})
+	var search func(node *kdNode[E], depth int)
+	search = func(node *kdNode[E], depth int) {
+		if node == nil {
+			return
+		}
+		if !node.removed {
+			best.Enqueue(Neighbor[E]{Value: node.value, Distance: distance(point, node.point)})
+			if best.Count() > int64(k) {
+				best.Dequeue()
+			}
+		}
+		axis := depth % t.dimensions
+		delta := point[axis] - node.point[axis]
+		near, far := node.right, node.left
+		if delta < 0 {
+			near, far = node.left, node.right
+		}
+		search(near, depth+1)
+		if farthest, _ := best.Peek(); best.Count() < int64(k) || math.Abs(delta) <= farthest.Distance {
+			search(far, depth+1)
+		}
+	}
+	search(t.root, 0)
+	neighbors := make([]Neighbor[E], best.Count())
+	for index := len(neighbors) - 1; index >= 0; index-- {
+		neighbors[index], _ = best.Dequeue()
+	}
+	return neighbors
+}
+
+// InBox returns the elements within the bounding box from the lower to the upper corner, bounds included
+func (t *KDTree[E]) InBox(lower, upper []float64) []E {
+	var values []E
+	t.walk(t.root, 0, func(n *kdNode[E]) bool {
+		if !n.removed && inBox(n.point, lower, upper) {
+			values = append(values, n.value)
+		}
+		return true
+	}, func(n *kdNode[E], axis int) (left, right bool) {
+		return lower[axis] < n.point[axis], upper[axis] >= n.point[axis]
+	})
+	return values
+}
+
+// InRadius returns the elements within the radius around the center, the boundary included
+func (t *KDTree[E]) InRadius(center []float64, radius float64) []E {
+	var values []E
+	t.walk(t.root, 0, func(n *kdNode[E]) bool {
+		if !n.removed && distance(center, n.point) <= radius {
+			values = append(values, n.value)
+		}
+		return true
+	}, func(n *kdNode[E], axis int) (left, right bool) {
+		return center[axis]-radius < n.point[axis], center[axis]+radius >= n.point[axis]
+	})
+	return values
+}
+
+// Each runs callback for each element, it breaks when callback false
+func (t *KDTree[E]) Each(callback func(index int, value E) bool) {
+	for index, value := range t.ToArray() {
+		if !callback(index, value) {
+			break
+		}
+	}
+}
+
+// Clear clears the tree
+func (t *KDTree[E]) Clear() {
+	t.root = nil
+	t.size = 0
+	t.removed = 0
+}
+
+// Clone clones the tree
+func (t *KDTree[E]) Clone() *KDTree[E] {
+	return NewKDTree(t.dimensions, t.position, t.ToArray()...)
+}
+
+// ToArray converts to array
+func (t *KDTree[E]) ToArray() []E {
+	values := make([]E, 0, t.size)
+	t.eachNode(t.root, func(node *kdNode[E]) {
+		values = append(values, node.value)
+	})
+	return values
+}
+
+// ToJSON converts to json
+func (t *KDTree[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(t.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (t *KDTree[E]) MarshalJSON() ([]byte, error) {
+	return t.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the tree must be created by [NewKDTree] first
+func (t *KDTree[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	t.Clear()
+	t.Push(items...)
+	t.Rebuild()
+	return nil
+}
+
+// String converts to string
+func (t *KDTree[E]) String() string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("KDTree[%T](len=%d)", *new(E), t.Count()))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	for index, value := range t.ToArray() {
+		if index >= 5 {
+			str.WriteString("\t...\n")
+			break
+		}
+		str.WriteByte('\t')
+		if v, ok := any(value).(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+	}
+	str.WriteByte('}')
+	return str.String()
+}
+
+func distance(a, b []float64) float64 {
+	sum := 0.0
+	for index := range a {
+		delta := a[index] - b[index]
+		sum += delta * delta
+	}
+	return math.Sqrt(sum)
+}
+
+func inBox(point, lower, upper []float64) bool {
+	for index := range point {
+		if point[index] < lower[index] || point[index] > upper[index] {
+			return false
+		}
+	}
+	return true
+}
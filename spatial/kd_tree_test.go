@@ -0,0 +1,126 @@
+package spatial
+
+import (
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type place struct {
+	Name string  `json:"name"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+func placePosition(p place) []float64 {
+	return []float64{p.X, p.Y}
+}
+
+func newPlaces() *KDTree[place] {
+	return NewKDTree(2, placePosition,
+		place{"a", 0, 0},
+		place{"b", 1, 1},
+		place{"c", 2, 2},
+		place{"d", 5, 5},
+		place{"e", -3, 4},
+	)
+}
+
+func names(places []place) []string {
+	var result []string
+	for _, p := range places {
+		result = append(result, p.Name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestKDTree_Nearest(t *testing.T) {
+	tree := newPlaces()
+	neighbors := tree.Nearest([]float64{1.2, 1.2}, 2)
+	assert.Len(t, neighbors, 2)
+	assert.Equal(t, "b", neighbors[0].Value.Name)
+	assert.Equal(t, "c", neighbors[1].Value.Name)
+	assert.InDelta(t, 0.2828, neighbors[0].Distance, 0.0001)
+	assert.Len(t, tree.Nearest([]float64{0, 0}, 10), 5)
+	assert.Empty(t, tree.Nearest([]float64{0, 0}, 0))
+}
+
+func TestKDTree_InBox(t *testing.T) {
+	tree := newPlaces()
+	assert.Equal(t, []string{"a", "b", "c"}, names(tree.InBox([]float64{0, 0}, []float64{2, 2})))
+	assert.Empty(t, tree.InBox([]float64{10, 10}, []float64{20, 20}))
+}
+
+func TestKDTree_InRadius(t *testing.T) {
+	tree := newPlaces()
+	assert.Equal(t, []string{"a", "b", "c"}, names(tree.InRadius([]float64{-1, 1}, 3.5)))
+}
+
+func TestKDTree_Remove(t *testing.T) {
+	tree := newPlaces()
+	assert.True(t, tree.Remove(place{"b", 1, 1}))
+	assert.False(t, tree.Remove(place{"b", 1, 1}))
+	assert.Equal(t, int64(4), tree.Count())
+	assert.Equal(t, "a", tree.Nearest([]float64{1, 1}, 1)[0].Value.Name)
+	tree.Remove(place{"a", 0, 0})
+	tree.Remove(place{"c", 2, 2})
+	assert.Equal(t, []string{"d", "e"}, names(tree.ToArray()))
+}
+
+func TestKDTree_Push(t *testing.T) {
+	tree := NewKDTree[place](2, placePosition)
+	assert.True(t, tree.IsEmpty())
+	tree.Push(place{"a", 1, 1}, place{"b", 1, 2}, place{"c", 1, 0})
+	assert.Equal(t, []string{"a", "b", "c"}, names(tree.InBox([]float64{1, 0}, []float64{1, 2})))
+	assert.Panics(t, func() {
+		NewKDTree(3, placePosition, place{})
+	})
+}
+
+func TestKDTree_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var points []place
+	for i := 0; i < 500; i++ {
+		points = append(points, place{X: float64(r.Intn(50)), Y: float64(r.Intn(50))})
+	}
+	tree := NewKDTree(2, placePosition, points[:250]...)
+	tree.Push(points[250:]...)
+	for i := 0; i < 50; i++ {
+		query := []float64{r.Float64() * 50, r.Float64() * 50}
+		distances := make([]float64, len(points))
+		for index, p := range points {
+			distances[index] = distance(query, placePosition(p))
+		}
+		slices.Sort(distances)
+		neighbors := tree.Nearest(query, 5)
+		for index, neighbor := range neighbors {
+			assert.Equal(t, distances[index], neighbor.Distance)
+		}
+		within := 0
+		for _, d := range distances {
+			if d <= 10 {
+				within++
+			}
+		}
+		assert.Len(t, tree.InRadius(query, 10), within)
+	}
+}
+
+func TestKDTree_UnmarshalJSON(t *testing.T) {
+	tree := NewKDTree[place](2, placePosition)
+	assert.Nil(t, json.Unmarshal([]byte(`[{"name":"a","x":1,"y":2}]`), tree))
+	assert.Equal(t, "a", tree.Nearest([]float64{0, 0}, 1)[0].Value.Name)
+	data, err := json.Marshal(tree)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"name":"a","x":1,"y":2}]`, string(data))
+}
+
+func TestKDTree_String(t *testing.T) {
+	tree := NewKDTree(1, func(v float64) []float64 { return []float64{v} }, 1, 2, 3, 4, 5, 6)
+	assert.Equal(t, "KDTree[float64](len=6){\n\t1,\n\t2,\n\t3,\n\t4,\n\t5,\n\t...\n}", tree.String())
+}
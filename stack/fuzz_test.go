@@ -0,0 +1,71 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/model"
+)
+
+var stackOps = []model.Op[*Stack[int], *model.List[int]]{
+	{Name: "Push", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		value := int(r.Byte())
+		s.Push(value)
+		reference.Push(value)
+		return nil, nil
+	}},
+	{Name: "Pop", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		return model.P(s.Pop()), model.P(reference.Pop())
+	}},
+	{Name: "Peek", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		return model.P(s.Peek()), model.P(reference.Get(reference.Len() - 1))
+	}},
+	{Name: "PopN", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		n := r.Intn(4)
+		var values []int
+		for i := 0; i < n; i++ {
+			if value, ok := reference.Pop(); ok {
+				values = append(values, value)
+			}
+		}
+		return s.PopN(n), values
+	}},
+	{Name: "Dup", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		value, ok := reference.Get(reference.Len() - 1)
+		if ok {
+			reference.Push(value)
+		}
+		return s.Dup(), ok
+	}},
+	{Name: "SwapTop", Run: func(s *Stack[int], reference *model.List[int], r *model.Reader) (any, any) {
+		a, ok := reference.Pop()
+		b, ok2 := reference.Pop()
+		if ok && ok2 {
+			reference.Push(a)
+			reference.Push(b)
+		} else if ok {
+			reference.Push(a)
+		}
+		return s.SwapTop(), ok && ok2
+	}},
+}
+
+func observeStack(s *Stack[int], reference *model.List[int]) (any, any) {
+	return s.items, reference.Items
+}
+
+func checkStackOperations(t *testing.T, data []byte) {
+	// the model returns false where strict mode panics
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	model.Check(t, NewStack[int](), new(model.List[int]), data, stackOps, observeStack)
+}
+
+func FuzzStack_Operations(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 4, 5, 3, 2, 1, 2})
+	f.Fuzz(checkStackOperations)
+}
+
+func TestStack_Model(t *testing.T) {
+	model.Replay(t, 50, 256, checkStackOperations)
+}
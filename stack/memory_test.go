@@ -0,0 +1,14 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+func TestMemoryFootprint(t *testing.T) {
+	stack := NewStack(1, 2)
+	memtest.Run(t, []memtest.Case{
+		{Name: "Stack", Count: 2, Footprint: stack.MemoryFootprint, Add: func(value int) { stack.Push(value) }},
+	})
+}
@@ -0,0 +1,265 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/gopi-frame/collection/internal/format"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
+)
+
+// NewStack new stack, the last value is on the top
+func NewStack[E any](values ...E) *Stack[E] {
+	stack := new(Stack[E])
+	stack.Push(values...)
+	return stack
+}
+
+// Stack last-in-first-out stack
+type Stack[E any] struct {
+	sync.RWMutex
+	items    []E
+	onRemove []func(value E)
+}
+
+// OnRemove registers a callback which is called with every element popped off the stack by Pop and PopN or removed by Clear.
+// Callbacks are called synchronously once the stack is updated.
+func (s *Stack[E]) OnRemove(callback func(value E)) {
+	s.onRemove = append(s.onRemove, callback)
+}
+
+func (s *Stack[E]) removed(values ...E) {
+	for _, value := range values {
+		for _, handler := range s.onRemove {
+			handler(value)
+		}
+	}
+}
+
+// Count returns the size of the stack
+func (s *Stack[E]) Count() int64 {
+	return int64(len(s.items))
+}
+
+// IsEmpty returns whether the stack is empty
+func (s *Stack[E]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// IsNotEmpty returns whether the stack is not empty
+func (s *Stack[E]) IsNotEmpty() bool {
+	return !s.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (s *Stack[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindStack]
+func (s *Stack[E]) Kind() collection.CollectionKind {
+	return collection.KindStack
+}
+
+// Push pushes elements onto the stack, the last value ends up on the top
+func (s *Stack[E]) Push(values ...E) {
+	s.items = append(s.items, values...)
+}
+
+// Clear removes all elements, they are reported to the OnRemove callbacks from the top
+func (s *Stack[E]) Clear() {
+	items := s.items
+	s.items = nil
+	for i := len(items) - 1; i >= 0; i-- {
+		s.removed(items[i])
+	}
+}
+
+// Contains returns whether the stack contains the specific element
+func (s *Stack[E]) Contains(value E) bool {
+	return s.ContainsWhere(func(item E) bool {
+		return equal.Equal(item, value)
+	})
+}
+
+// ContainsWhere returns whether the stack contains elements which match the callback
+func (s *Stack[E]) ContainsWhere(callback func(value E) bool) bool {
+	return slices.ContainsFunc(s.items, callback)
+}
+
+// Repack reallocates the backing array to fit the elements, releasing the capacity left over by pops
+func (s *Stack[E]) Repack() {
+	items := make([]E, len(s.items))
+	copy(items, s.items)
+	s.items = items
+}
+
+// Pop removes the top element and returns it.
+// It will return a zero value and false when the stack is empty.
+func (s *Stack[E]) Pop() (E, bool) {
+	if len(s.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	value := s.items[len(s.items)-1]
+	clear(s.items[len(s.items)-1:])
+	s.items = s.items[:len(s.items)-1]
+	s.removed(value)
+	return value, true
+}
+
+// Peek returns the top element without removing it.
+// It will return a zero value and false when the stack is empty.
+func (s *Stack[E]) Peek() (E, bool) {
+	if len(s.items) == 0 {
+		collection.Fail(collection.ErrEmptyCollection)
+		return *new(E), false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// PopN removes at most n elements and returns them, the top element first
+func (s *Stack[E]) PopN(n int) []E {
+	values := s.PeekN(n)
+	clear(s.items[len(s.items)-len(values):])
+	s.items = s.items[:len(s.items)-len(values)]
+	s.removed(values...)
+	return values
+}
+
+// PeekN returns at most n elements without removing them, the top element first
+func (s *Stack[E]) PeekN(n int) []E {
+	n = min(max(n, 0), len(s.items))
+	values := slices.Clone(s.items[len(s.items)-n:])
+	slices.Reverse(values)
+	return values
+}
+
+// Dup pushes a copy of the top element, it returns false when the stack is empty
+func (s *Stack[E]) Dup() bool {
+	if len(s.items) == 0 {
+		return false
+	}
+	s.items = append(s.items, s.items[len(s.items)-1])
+	return true
+}
+
+// SwapTop swaps the two top elements, it returns false when the stack has less than two elements
+func (s *Stack[E]) SwapTop() bool {
+	length := len(s.items)
+	if length < 2 {
+		return false
+	}
+	s.items[length-1], s.items[length-2] = s.items[length-2], s.items[length-1]
+	return true
+}
+
+// Rotate moves the n-th element from the top onto the top, the elements above it move down by one.
+// Rotate(2) equals SwapTop, and Rotate(3) is the "rot" of stack languages.
+// It returns false when n is less than 1 or greater than the size of the stack.
+func (s *Stack[E]) Rotate(n int) bool {
+	length := len(s.items)
+	if n < 1 || n > length {
+		return false
+	}
+	value := s.items[length-n]
+	copy(s.items[length-n:], s.items[length-n+1:])
+	s.items[length-1] = value
+	return true
+}
+
+// Encode writes the elements of the stack from bottom to top to the writer in the format
+func (s *Stack[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, s.items, format)
+}
+
+// Decode replaces the elements of the stack with the elements read from the reader in the format,
+// the last element is the top of the stack
+func (s *Stack[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	s.items = items
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder], the elements are encoded like Encode with [codec.Gob]
+func (s *Stack[E]) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := s.Encode(buf, codec.Gob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder], the elements are decoded like Decode with [codec.Gob]
+func (s *Stack[E]) GobDecode(data []byte) error {
+	return s.Decode(bytes.NewReader(data), codec.Gob)
+}
+
+// All returns a sequence of the depths and elements from the top to the bottom, the top has depth 0.
+// It stops when yield returns false
+func (s *Stack[E]) All() func(yield func(depth int, value E) bool) {
+	return func(yield func(depth int, value E) bool) {
+		for index := len(s.items) - 1; index >= 0; index-- {
+			if !yield(len(s.items)-1-index, s.items[index]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the elements from the top to the bottom, it stops when yield returns false
+func (s *Stack[E]) Values() func(yield func(value E) bool) {
+	return func(yield func(value E) bool) {
+		for index := len(s.items) - 1; index >= 0; index-- {
+			if !yield(s.items[index]) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray converts to array from bottom to top, so the last element is the top of the stack
+func (s *Stack[E]) ToArray() []E {
+	return slices.Clone(s.items)
+}
+
+// ToJSON converts to json array from bottom to top
+func (s *Stack[E]) ToJSON() ([]byte, error) {
+	return jsonx.Array(s.items)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (s *Stack[E]) MarshalJSON() ([]byte, error) {
+	return s.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], the last element of the array is the top of the stack
+func (s *Stack[E]) UnmarshalJSON(data []byte) error {
+	items := []E{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	s.items = items
+	return nil
+}
+
+// String converts to string, the elements are listed from the top
+func (s *Stack[E]) String() string {
+	return format.Values("Stack", s.Count(), s.Values())
+}
+
+// MemoryFootprint estimates the memory used by the stack in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (s *Stack[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	return memory.Of[Stack[E]]() + memory.Slice(s.items, deep)
+}
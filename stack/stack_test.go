@@ -0,0 +1,185 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/equal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack_Pop(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(false)
+	stack := NewStack(1, 2, 3)
+	value, ok := stack.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, int64(2), stack.Count())
+
+	stack = NewStack[int]()
+	_, ok = stack.Pop()
+	assert.False(t, ok)
+}
+
+func TestStack_Peek(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	value, ok := stack.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, int64(3), stack.Count())
+}
+
+func TestStack_Strict(t *testing.T) {
+	defer collection.SetStrict(collection.Strict())
+	collection.SetStrict(true)
+	s := NewStack[int]()
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { s.Pop() })
+	assert.PanicsWithValue(t, collection.ErrEmptyCollection, func() { s.Peek() })
+	assert.False(t, s.Dup())
+}
+
+func TestStack_PopN(t *testing.T) {
+	stack := NewStack(1, 2, 3, 4)
+	assert.Equal(t, []int{4, 3}, stack.PopN(2))
+	assert.Equal(t, []int{2, 1}, stack.PopN(10))
+	assert.True(t, stack.IsEmpty())
+	assert.Empty(t, stack.PopN(1))
+}
+
+func TestStack_PeekN(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	assert.Equal(t, []int{3, 2}, stack.PeekN(2))
+	assert.Empty(t, stack.PeekN(-1))
+	assert.Equal(t, int64(3), stack.Count())
+}
+
+func TestStack_Dup(t *testing.T) {
+	stack := NewStack(1, 2)
+	assert.True(t, stack.Dup())
+	assert.Equal(t, []int{2, 2, 1}, stack.PeekN(3))
+	assert.False(t, NewStack[int]().Dup())
+}
+
+func TestStack_SwapTop(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	assert.True(t, stack.SwapTop())
+	assert.Equal(t, []int{2, 3, 1}, stack.PeekN(3))
+	assert.False(t, NewStack(1).SwapTop())
+}
+
+func TestStack_Rotate(t *testing.T) {
+	stack := NewStack(1, 2, 3, 4)
+	assert.True(t, stack.Rotate(3))
+	assert.Equal(t, []int{2, 4, 3, 1}, stack.PeekN(4))
+	assert.True(t, stack.Rotate(1))
+	assert.Equal(t, []int{2, 4, 3, 1}, stack.PeekN(4))
+	assert.False(t, stack.Rotate(0))
+	assert.False(t, stack.Rotate(5))
+}
+
+func TestStack_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewStack(1, 2, 3).Encode(buf, codec.JSON))
+	assert.Equal(t, "[1,2,3]\n", buf.String())
+}
+
+func TestStack_Decode(t *testing.T) {
+	x := NewStack(9)
+	assert.Nil(t, x.Decode(strings.NewReader("[1,2,3]\n"), codec.JSON))
+	assert.Equal(t, []int{3, 2, 1}, x.PeekN(3))
+}
+
+func TestStack_Repack(t *testing.T) {
+	s := NewStack(new(int), new(int), new(int))
+	s.Pop()
+	s.PopN(1)
+	assert.Nil(t, s.items[:3][1])
+	assert.Nil(t, s.items[:3][2])
+	s.Repack()
+	assert.Equal(t, 1, cap(s.items))
+}
+
+func TestStack_OnRemove(t *testing.T) {
+	s := NewStack(1, 2, 3, 4)
+	var removed []int
+	s.OnRemove(func(value int) {
+		removed = append(removed, value)
+	})
+	s.Pop()
+	s.PopN(2)
+	s.Peek()
+	assert.Equal(t, []int{4, 3, 2}, removed)
+	s.Push(5, 6)
+	s.Clear()
+	assert.Equal(t, []int{4, 3, 2, 6, 5, 1}, removed)
+}
+
+func TestStack_Clear(t *testing.T) {
+	s := NewStack(1, 2, 3)
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+	s.Push(4)
+	assert.Equal(t, []int{4}, s.ToArray())
+}
+
+func TestStack_Contains(t *testing.T) {
+	s := NewStack(1, 2)
+	assert.True(t, s.Contains(1))
+	assert.False(t, s.Contains(3))
+	assert.True(t, s.ContainsWhere(func(value int) bool { return value == 2 }))
+
+	// slices are only equal by their elements when reflection is enabled
+	slices := NewStack([]int{1}, []int{2})
+	assert.Equal(t, equal.Reflect, slices.Contains([]int{1}))
+	assert.False(t, slices.Contains([]int{3}))
+}
+
+func TestStack_All(t *testing.T) {
+	s := NewStack(1, 2, 3)
+	var depths, items []int
+	s.All()(func(depth int, value int) bool {
+		depths = append(depths, depth)
+		items = append(items, value)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2}, depths)
+	assert.Equal(t, []int{3, 2, 1}, items)
+
+	items = nil
+	s.Values()(func(value int) bool {
+		items = append(items, value)
+		return value > 2
+	})
+	assert.Equal(t, []int{3, 2}, items)
+}
+
+func TestStack_ToArray(t *testing.T) {
+	s := NewStack(1, 2, 3)
+	items := s.ToArray()
+	items[0] = 100
+	assert.Equal(t, []int{1, 2, 3}, s.ToArray())
+}
+
+func TestStack_MarshalJSON(t *testing.T) {
+	s := NewStack(1, 2, 3)
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+	x := NewStack[int]()
+	assert.Nil(t, json.Unmarshal(data, x))
+	value, _ := x.Peek()
+	assert.Equal(t, 3, value)
+}
+
+func TestStack_String(t *testing.T) {
+	s := NewStack(1, 2, 3, 4, 5, 6)
+	pattern := regexp.MustCompile(fmt.Sprintf(`Stack\[int\]\(len=%d\)\{\n\t6,\n\t5,\n\t4,\n\t3,\n\t2,\n\t(\.){3}\n\}`, s.Count()))
+	assert.True(t, pattern.MatchString(s.String()))
+}
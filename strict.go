@@ -0,0 +1,29 @@
+package collection
+
+import "sync/atomic"
+
+var strict atomic.Bool
+
+// SetStrict sets whether the collections fail fast. In strict mode, operations on an empty collection
+// and out-of-range indexes which would return false panic with [ErrEmptyCollection] or a [*RangeError] instead.
+// Strict mode is disabled by default, the collection_strict build tag enables it.
+//
+// Strict mode covers At, SetAt, First, Last, Pop and Shift of lists and Pop and Peek of stacks.
+// The Try methods keep returning errors, and queues keep returning false
+// since an empty queue is the normal state of a consumer.
+func SetStrict(enabled bool) {
+	strict.Store(enabled)
+}
+
+// Strict returns whether the collections are in strict mode
+func Strict() bool {
+	return strict.Load()
+}
+
+// Fail panics with the error in strict mode, otherwise it does nothing.
+// Collections call it right before returning false.
+func Fail(err error) {
+	if strict.Load() {
+		panic(err)
+	}
+}
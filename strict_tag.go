@@ -0,0 +1,7 @@
+//go:build collection_strict
+
+package collection
+
+func init() {
+	strict.Store(true)
+}
@@ -0,0 +1,21 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetStrict(t *testing.T) {
+	defer SetStrict(Strict())
+	SetStrict(false)
+	assert.False(t, Strict())
+	assert.NotPanics(t, func() {
+		Fail(ErrEmptyCollection)
+	})
+	SetStrict(true)
+	assert.True(t, Strict())
+	assert.PanicsWithValue(t, ErrEmptyCollection, func() {
+		Fail(ErrEmptyCollection)
+	})
+}
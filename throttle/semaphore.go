@@ -0,0 +1,119 @@
+// Package throttle provides concurrency limiting primitives.
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/queue"
+)
+
+type waiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+// NewSemaphore new weighted semaphore with the given capacity
+func NewSemaphore(capacity int64) *Semaphore {
+	s := new(Semaphore)
+	s.capacity = capacity
+	s.waiters = queue.NewLinkedQueue[*waiter]()
+	return s
+}
+
+// Semaphore weighted semaphore, waiters are served in FIFO order so heavy acquisitions are not starved by light ones.
+// It is safe for concurrent use.
+type Semaphore struct {
+	lock     sync.Mutex
+	capacity int64
+	used     int64
+	waiters  *queue.LinkedQueue[*waiter]
+}
+
+// Capacity returns the capacity of the semaphore
+func (s *Semaphore) Capacity() int64 {
+	return s.capacity
+}
+
+// Available returns the weight which can be acquired right now
+func (s *Semaphore) Available() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.capacity - s.used
+}
+
+// Waiting returns the number of blocked acquisitions
+func (s *Semaphore) Waiting() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.waiters.Count()
+}
+
+// TryAcquire acquires the weight without blocking, it fails when other acquisitions are waiting
+func (s *Semaphore) TryAcquire(weight int64) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.waiters.IsEmpty() && s.capacity-s.used >= weight {
+		s.used += weight
+		return true
+	}
+	return false
+}
+
+// Acquire acquires the weight, it blocks until the weight is available or the context is done.
+// It returns [collection.ErrCapacityExceeded] when the weight exceeds the capacity.
+func (s *Semaphore) Acquire(ctx context.Context, weight int64) error {
+	s.lock.Lock()
+	if weight > s.capacity {
+		s.lock.Unlock()
+		return fmt.Errorf("%w: weight %d, capacity %d", collection.ErrCapacityExceeded, weight, s.capacity)
+	}
+	if s.waiters.IsEmpty() && s.capacity-s.used >= weight {
+		s.used += weight
+		s.lock.Unlock()
+		return nil
+	}
+	w := &waiter{weight: weight, ready: make(chan struct{})}
+	s.waiters.Enqueue(w)
+	s.lock.Unlock()
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		select {
+		case <-w.ready:
+			// acquired right after the context was done, give the weight back
+			s.used -= weight
+		default:
+			s.waiters.RemoveWhere(func(item *waiter) bool {
+				return item == w
+			})
+		}
+		s.notify()
+		return ctx.Err()
+	}
+}
+
+// Release releases the weight
+func (s *Semaphore) Release(weight int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.used -= weight
+	if s.used < 0 {
+		panic("throttle: semaphore released more than held")
+	}
+	s.notify()
+}
+
+// notify wakes the waiters in order while their weight fits
+func (s *Semaphore) notify() {
+	for w, ok := s.waiters.Peek(); ok && s.capacity-s.used >= w.weight; w, ok = s.waiters.Peek() {
+		s.waiters.Dequeue()
+		s.used += w.weight
+		close(w.ready)
+	}
+}
@@ -0,0 +1,79 @@
+package throttle
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/internal/stress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore_TryAcquire(t *testing.T) {
+	s := NewSemaphore(3)
+	assert.True(t, s.TryAcquire(2))
+	assert.False(t, s.TryAcquire(2))
+	assert.True(t, s.TryAcquire(1))
+	assert.Equal(t, int64(0), s.Available())
+	s.Release(3)
+	assert.Equal(t, int64(3), s.Available())
+	assert.Panics(t, func() {
+		s.Release(1)
+	})
+}
+
+func TestSemaphore_Acquire(t *testing.T) {
+	s := NewSemaphore(2)
+	assert.ErrorIs(t, s.Acquire(context.Background(), 3), collection.ErrCapacityExceeded)
+	assert.Nil(t, s.Acquire(context.Background(), 2))
+	acquired := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 1)
+		close(acquired)
+	}()
+	assert.Eventually(t, func() bool { return s.Waiting() == 1 }, time.Second, time.Millisecond)
+	s.Release(1)
+	<-acquired
+	assert.Equal(t, int64(0), s.Available())
+}
+
+func TestSemaphore_Acquire_FIFO(t *testing.T) {
+	s := NewSemaphore(2)
+	assert.True(t, s.TryAcquire(1))
+	heavy := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 2)
+		close(heavy)
+	}()
+	assert.Eventually(t, func() bool { return s.Waiting() == 1 }, time.Second, time.Millisecond)
+	// a light acquisition may not overtake the waiting heavy one
+	assert.False(t, s.TryAcquire(1))
+	s.Release(1)
+	<-heavy
+	assert.Equal(t, int64(0), s.Available())
+}
+
+func TestSemaphore_Acquire_Context(t *testing.T) {
+	s := NewSemaphore(2)
+	assert.True(t, s.TryAcquire(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Acquire(ctx, 2), context.DeadlineExceeded)
+	assert.Equal(t, int64(0), s.Waiting())
+	assert.True(t, s.TryAcquire(1))
+}
+
+func TestSemaphore_Stress(t *testing.T) {
+	s := NewSemaphore(3)
+	var held atomic.Int64
+	stress.Run(t, 8, 200, func(worker, _ int) {
+		weight := int64(worker%3 + 1)
+		assert.Nil(t, s.Acquire(context.Background(), weight))
+		assert.LessOrEqual(t, held.Add(weight), int64(3))
+		held.Add(-weight)
+		s.Release(weight)
+	})
+	assert.Equal(t, int64(3), s.Available())
+}
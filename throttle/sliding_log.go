@@ -0,0 +1,128 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection/queue"
+)
+
+type slidingEntry struct {
+	times  *queue.Deque[time.Time]
+	window time.Duration
+}
+
+// NewSlidingLog new sliding log rate limiter
+func NewSlidingLog[K comparable]() *SlidingLog[K] {
+	l := new(SlidingLog[K])
+	l.logs = make(map[K]*slidingEntry)
+	l.now = time.Now
+	return l
+}
+
+// SlidingLog rate limiter recording the times of the allowed events of every key, such as a client or an API key,
+// in a ring buffer holding at most limit times.
+// Unlike a fixed window counter it never allows a burst of twice the limit across the boundary of two windows.
+// It is safe for concurrent use.
+type SlidingLog[K comparable] struct {
+	lock sync.Mutex
+	logs map[K]*slidingEntry
+	now  func() time.Time
+}
+
+// Allow returns whether an event of the key is allowed, which is when fewer than limit events were allowed within the window,
+// the event is recorded when it is allowed. Checking and recording happen under the same lock.
+// The limit and window may change between calls, for example when the plan of a client changes.
+func (l *SlidingLog[K]) Allow(key K, limit int, window time.Duration) bool {
+	if limit <= 0 {
+		return false
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	entry, ok := l.logs[key]
+	if !ok {
+		entry = &slidingEntry{times: queue.NewDeque[time.Time]()}
+		l.logs[key] = entry
+	}
+	entry.window = window
+	entry.evict(now)
+	if int(entry.times.Count()) >= limit {
+		return false
+	}
+	entry.times.PushBack(now)
+	return true
+}
+
+// Recorded returns the number of events of the key allowed within the window
+func (l *SlidingLog[K]) Recorded(key K, window time.Duration) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	entry, ok := l.logs[key]
+	if !ok {
+		return 0
+	}
+	cutoff := l.now().Add(-window)
+	count := 0
+	entry.times.Each(func(_ int, t time.Time) bool {
+		if t.After(cutoff) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// Reset forgets the events of the key
+func (l *SlidingLog[K]) Reset(key K) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.logs, key)
+}
+
+// Count returns the number of tracked keys
+func (l *SlidingLog[K]) Count() int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return int64(len(l.logs))
+}
+
+// Purge forgets the keys which have no event within the window of their last call, it returns the number of them
+func (l *SlidingLog[K]) Purge() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	count := 0
+	for key, entry := range l.logs {
+		if entry.evict(now); entry.times.IsEmpty() {
+			delete(l.logs, key)
+			count++
+		}
+	}
+	return count
+}
+
+// Janitor purges idle keys periodically until the context is done
+func (l *SlidingLog[K]) Janitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.Purge()
+			}
+		}
+	}()
+}
+
+// evict drops the times which are out of the window
+func (e *slidingEntry) evict(now time.Time) {
+	cutoff := now.Add(-e.window)
+	for oldest, ok := e.times.PeekFront(); ok && !oldest.After(cutoff); oldest, ok = e.times.PeekFront() {
+		e.times.PopFront()
+	}
+}
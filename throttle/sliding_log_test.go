@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingLog_Allow(t *testing.T) {
+	now := time.Now()
+	l := NewSlidingLog[string]()
+	l.now = func() time.Time { return now }
+	assert.True(t, l.Allow("a", 2, time.Second))
+	now = now.Add(600 * time.Millisecond)
+	assert.True(t, l.Allow("a", 2, time.Second))
+	assert.False(t, l.Allow("a", 2, time.Second))
+	assert.True(t, l.Allow("b", 2, time.Second))
+	assert.Equal(t, 2, l.Recorded("a", time.Second))
+
+	// the first event leaves the window, the second one is still in it
+	now = now.Add(400 * time.Millisecond)
+	assert.True(t, l.Allow("a", 2, time.Second))
+	assert.False(t, l.Allow("a", 2, time.Second))
+	assert.True(t, l.Allow("a", 3, time.Second))
+	assert.False(t, l.Allow("a", 0, time.Second))
+
+	l.Reset("a")
+	assert.Equal(t, 0, l.Recorded("a", time.Second))
+	assert.True(t, l.Allow("a", 1, time.Second))
+}
+
+func TestSlidingLog_Purge(t *testing.T) {
+	now := time.Now()
+	l := NewSlidingLog[int]()
+	l.now = func() time.Time { return now }
+	l.Allow(1, 10, time.Second)
+	l.Allow(2, 10, time.Minute)
+	assert.Equal(t, int64(2), l.Count())
+	now = now.Add(time.Second)
+	assert.Equal(t, 1, l.Purge())
+	assert.Equal(t, int64(1), l.Count())
+}
+
+func TestSlidingLog_Allow_Concurrent(t *testing.T) {
+	l := NewSlidingLog[string]()
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if l.Allow("key", 50, time.Hour) {
+					allowed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(50), allowed.Load())
+}
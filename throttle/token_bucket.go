@@ -0,0 +1,101 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gopi-frame/collection"
+)
+
+// NewTokenBucket new full token bucket holding at most capacity tokens, refilled by rate tokens per second
+func NewTokenBucket(capacity int64, rate float64) *TokenBucket {
+	b := new(TokenBucket)
+	b.capacity = capacity
+	b.rate = rate
+	b.tokens = float64(capacity)
+	b.now = time.Now
+	b.updatedAt = b.now()
+	return b
+}
+
+// TokenBucket token bucket rate limiter.
+// It is safe for concurrent use.
+type TokenBucket struct {
+	lock      sync.Mutex
+	capacity  int64
+	rate      float64
+	tokens    float64
+	updatedAt time.Time
+	now       func() time.Time
+}
+
+// Capacity returns the capacity of the bucket
+func (b *TokenBucket) Capacity() int64 {
+	return b.capacity
+}
+
+// Rate returns the number of tokens refilled per second
+func (b *TokenBucket) Rate() float64 {
+	return b.rate
+}
+
+// Available returns the number of whole tokens in the bucket
+func (b *TokenBucket) Available() int64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	return int64(b.tokens)
+}
+
+// TryTake takes the tokens without blocking, it returns false when there are not enough tokens
+func (b *TokenBucket) TryTake(tokens int64) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	if b.tokens < float64(tokens) {
+		return false
+	}
+	b.tokens -= float64(tokens)
+	return true
+}
+
+// TakeCtx takes the tokens, it blocks until enough tokens are refilled or the context is done.
+// It returns [collection.ErrCapacityExceeded] when the tokens exceed the capacity.
+func (b *TokenBucket) TakeCtx(ctx context.Context, tokens int64) error {
+	if tokens > b.capacity {
+		return fmt.Errorf("%w: tokens %d, capacity %d", collection.ErrCapacityExceeded, tokens, b.capacity)
+	}
+	for {
+		b.lock.Lock()
+		b.refill()
+		missing := float64(tokens) - b.tokens
+		if missing <= 0 {
+			b.tokens -= float64(tokens)
+			b.lock.Unlock()
+			return nil
+		}
+		b.lock.Unlock()
+		if b.rate <= 0 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		timer := time.NewTimer(time.Duration(missing / b.rate * float64(time.Second)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// refill adds the tokens produced since the last update
+func (b *TokenBucket) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = min(float64(b.capacity), b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.updatedAt = now
+}
@@ -0,0 +1,39 @@
+package throttle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket_TryTake(t *testing.T) {
+	now := time.Now()
+	b := NewTokenBucket(10, 2)
+	b.now = func() time.Time { return now }
+	b.updatedAt = now
+	assert.True(t, b.TryTake(8))
+	assert.False(t, b.TryTake(3))
+	assert.Equal(t, int64(2), b.Available())
+	now = now.Add(time.Second)
+	assert.Equal(t, int64(4), b.Available())
+	now = now.Add(time.Hour)
+	assert.Equal(t, int64(10), b.Available())
+}
+
+func TestTokenBucket_TakeCtx(t *testing.T) {
+	b := NewTokenBucket(1, 100)
+	assert.ErrorIs(t, b.TakeCtx(context.Background(), 2), collection.ErrCapacityExceeded)
+	assert.Nil(t, b.TakeCtx(context.Background(), 1))
+	start := time.Now()
+	assert.Nil(t, b.TakeCtx(context.Background(), 1))
+	assert.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+
+	b = NewTokenBucket(1, 0)
+	assert.True(t, b.TryTake(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.TakeCtx(ctx, 1), context.DeadlineExceeded)
+}
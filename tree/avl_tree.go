@@ -3,9 +3,14 @@ package tree
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/contract"
 )
 
@@ -39,6 +44,16 @@ func (t *AVLTree[E]) IsNotEmpty() bool {
 	return t.Count() > 0
 }
 
+// ElementType returns the name of the element type
+func (t *AVLTree[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindTree]
+func (t *AVLTree[E]) Kind() collection.CollectionKind {
+	return collection.KindTree
+}
+
 // Contains returns whether the tree contains the specific element
 func (t *AVLTree[E]) Contains(value E) bool {
 	if t.root == nil {
@@ -104,6 +119,24 @@ func (t *AVLTree[E]) LastOr(value E) E {
 	return t.root.max().value
 }
 
+// Floor returns the greatest element less than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (t *AVLTree[E]) Floor(value E) (E, bool) {
+	if node := t.root.floor(value, t.comparator); node != nil {
+		return node.value, true
+	}
+	return *new(E), false
+}
+
+// Ceiling returns the least element greater than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (t *AVLTree[E]) Ceiling(value E) (E, bool) {
+	if node := t.root.ceiling(value, t.comparator); node != nil {
+		return node.value, true
+	}
+	return *new(E), false
+}
+
 // Each runs callback for each element, it breaks when callback returns false
 func (t *AVLTree[E]) Each(callback func(_ int, value E) bool) {
 	for index, node := range t.root.inOrderRange() {
@@ -129,9 +162,43 @@ func (t *AVLTree[E]) ToArray() []E {
 	return values
 }
 
+// MemoryFootprint estimates the memory used by the tree in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (t *AVLTree[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	size := memory.Of[AVLTree[E]]()
+	var previous *avlNode[E]
+	for _, node := range t.root.inOrderRange() {
+		if node == previous {
+			continue
+		}
+		previous = node
+		size += memory.Of[avlNode[E]]()
+		if deep != nil {
+			size += deep(node.value)
+		}
+	}
+	return size
+}
+
+// Encode writes the elements of the tree to the writer in the format, in the order of the comparator
+func (t *AVLTree[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, t.ToArray(), format)
+}
+
+// Decode replaces the elements of the tree with the elements read from the reader in the format
+func (t *AVLTree[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	t.Clear()
+	t.Push(items...)
+	return nil
+}
+
 // ToJSON converts to json
 func (t *AVLTree[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(t.ToArray())
+	return jsonx.Array(t.ToArray())
 }
 
 // MarshalJSON implements [json.Marshaller]
@@ -120,6 +120,38 @@ func (node *avlNode[E]) find(value E, comparator contract.Comparator[E]) *avlNod
 	}
 }
 
+func (node *avlNode[E]) floor(value E, comparator contract.Comparator[E]) *avlNode[E] {
+	var found *avlNode[E]
+	for n := node; n != nil; {
+		result := comparator.Compare(value, n.value)
+		if result == 0 {
+			return n
+		} else if result < 0 {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+	return found
+}
+
+func (node *avlNode[E]) ceiling(value E, comparator contract.Comparator[E]) *avlNode[E] {
+	var found *avlNode[E]
+	for n := node; n != nil; {
+		result := comparator.Compare(value, n.value)
+		if result == 0 {
+			return n
+		} else if result > 0 {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return found
+}
+
 func (node *avlNode[E]) min() *avlNode[E] {
 	if node.left == nil {
 		return node
@@ -1,11 +1,14 @@
 package tree
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -131,6 +134,32 @@ func TestAVLTree_LastOr(t *testing.T) {
 	})
 }
 
+func TestAVLTree_Floor(t *testing.T) {
+	tree := NewAVLTree(_cmp{}, 10, 20, 30, 40)
+	value, ok := tree.Floor(25)
+	assert.True(t, ok)
+	assert.Equal(t, 20, value)
+	value, ok = tree.Floor(30)
+	assert.True(t, ok)
+	assert.Equal(t, 30, value)
+	value, ok = tree.Floor(5)
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+}
+
+func TestAVLTree_Ceiling(t *testing.T) {
+	tree := NewAVLTree(_cmp{}, 10, 20, 30, 40)
+	value, ok := tree.Ceiling(25)
+	assert.True(t, ok)
+	assert.Equal(t, 30, value)
+	value, ok = tree.Ceiling(10)
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	value, ok = tree.Ceiling(45)
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+}
+
 func TestAVLTree_Each(t *testing.T) {
 	tree := NewAVLTree(_cmp{}, 1, 2, 3, 5, 2)
 	var items []int
@@ -152,6 +181,20 @@ func TestAVLTree_ToArray(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 2, 3, 5}, tree.ToArray())
 }
 
+func TestAVLTree_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewAVLTree(_cmp{}, 3, 1, 2).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestAVLTree_Decode(t *testing.T) {
+	tree := NewAVLTree(_cmp{}, 9)
+	assert.Nil(t, tree.Decode(strings.NewReader("[2,3,1]"), codec.JSON))
+	assert.Equal(t, []int{1, 2, 3}, tree.ToArray())
+	assert.NotNil(t, tree.Decode(strings.NewReader("[2,"), codec.JSON))
+	assert.Equal(t, []int{1, 2, 3}, tree.ToArray())
+}
+
 func TestAVLTree_ToJSON(t *testing.T) {
 	tree := NewAVLTree(_cmp{}, 1, 2, 3, 5, 2)
 	jsonBytes, err := tree.ToJSON()
@@ -0,0 +1,16 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/collection/internal/memtest"
+)
+
+func TestMemoryFootprint(t *testing.T) {
+	rb := NewRBTree[int](_cmp{}, 1, 2, 2)
+	avl := NewAVLTree[int](_cmp{}, 1, 2, 2)
+	memtest.Run(t, []memtest.Case{
+		{Name: "RBTree", Count: 2, Footprint: rb.MemoryFootprint, Add: func(value int) { rb.Push(value) }},
+		{Name: "AVLTree", Count: 2, Footprint: avl.MemoryFootprint, Add: func(value int) { avl.Push(value) }},
+	})
+}
@@ -3,9 +3,14 @@ package tree
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/collection/codec"
+	"github.com/gopi-frame/collection/internal/jsonx"
+	"github.com/gopi-frame/collection/internal/memory"
 	"github.com/gopi-frame/contract"
 )
 
@@ -36,6 +41,16 @@ func (t *RBTree[E]) IsNotEmpty() bool {
 	return t.Count() > 0
 }
 
+// ElementType returns the name of the element type
+func (t *RBTree[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindTree]
+func (t *RBTree[E]) Kind() collection.CollectionKind {
+	return collection.KindTree
+}
+
 func (t *RBTree[E]) Contains(value E) bool {
 	if t.root == nil {
 		return false
@@ -112,6 +127,36 @@ func (t *RBTree[E]) LastOr(value E) E {
 	return v
 }
 
+// Floor returns the greatest element less than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (t *RBTree[E]) Floor(value E) (E, bool) {
+	if node := t.root.floor(value, t.comparator); node != nil {
+		return node.value, true
+	}
+	return *new(E), false
+}
+
+// Ceiling returns the least element greater than or equal to the given value.
+// It returns zero value and false when there is no such element.
+func (t *RBTree[E]) Ceiling(value E) (E, bool) {
+	if node := t.root.ceiling(value, t.comparator); node != nil {
+		return node.value, true
+	}
+	return *new(E), false
+}
+
+// Ascend ranges the elements greater than or equal to the given value in ascending order,
+// it breaks when callback returns false
+func (t *RBTree[E]) Ascend(from E, callback func(value E) bool) {
+	t.root.ascend(from, t.comparator, callback)
+}
+
+// Descend ranges the elements less than or equal to the given value in descending order,
+// it breaks when callback returns false
+func (t *RBTree[E]) Descend(from E, callback func(value E) bool) {
+	t.root.descend(from, t.comparator, callback)
+}
+
 func (t *RBTree[E]) Each(callback func(_ int, value E) bool) {
 	for index, node := range t.root.inOrderRange() {
 		if !callback(index, node.value) {
@@ -134,8 +179,41 @@ func (t *RBTree[E]) ToArray() []E {
 	return values
 }
 
+// MemoryFootprint estimates the memory used by the tree in bytes,
+// deep returns the memory referenced by an element beyond its inline size and may be nil
+func (t *RBTree[E]) MemoryFootprint(deep func(value E) int64) int64 {
+	size := memory.Of[RBTree[E]]()
+	var previous *rbNode[E]
+	for _, node := range t.root.inOrderRange() {
+		if node == previous {
+			continue
+		}
+		previous = node
+		size += memory.Of[rbNode[E]]()
+		if deep != nil {
+			size += deep(node.value)
+		}
+	}
+	return size
+}
+
+// Encode writes the elements of the tree to the writer in the format, in the order of the comparator
+func (t *RBTree[E]) Encode(w io.Writer, format codec.Format) error {
+	return codec.Encode(w, t.ToArray(), format)
+}
+
+// Decode replaces the elements of the tree with the elements read from the reader in the format
+func (t *RBTree[E]) Decode(r io.Reader, format codec.Format) error {
+	items, err := codec.Decode[E](r, format)
+	if err != nil {
+		return err
+	}
+	t.Clear().Push(items...)
+	return nil
+}
+
 func (t *RBTree[E]) ToJSON() ([]byte, error) {
-	return json.Marshal(t.ToArray())
+	return jsonx.Array(t.ToArray())
 }
 
 func (t *RBTree[E]) MarshalJSON() ([]byte, error) {
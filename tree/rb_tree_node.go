@@ -167,6 +167,38 @@ func (node *rbNode[E]) fix() *rbNode[E] {
 	return activeNode
 }
 
+func (node *rbNode[E]) floor(value E, comparator contract.Comparator[E]) *rbNode[E] {
+	var found *rbNode[E]
+	for n := node; n != nil; {
+		result := comparator.Compare(value, n.value)
+		if result == 0 {
+			return n
+		} else if result < 0 {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+	return found
+}
+
+func (node *rbNode[E]) ceiling(value E, comparator contract.Comparator[E]) *rbNode[E] {
+	var found *rbNode[E]
+	for n := node; n != nil; {
+		result := comparator.Compare(value, n.value)
+		if result == 0 {
+			return n
+		} else if result > 0 {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return found
+}
+
 func (node *rbNode[E]) min() *rbNode[E] {
 	if node.left == nil {
 		return node
@@ -206,3 +238,37 @@ func (node *rbNode[E]) inOrderRange() (nodes []*rbNode[E]) {
 	nodes = append(nodes, node.right.inOrderRange()...)
 	return
 }
+
+func (node *rbNode[E]) ascend(from E, comparator contract.Comparator[E], callback func(value E) bool) bool {
+	if node == nil {
+		return true
+	}
+	if comparator.Compare(node.value, from) >= 0 {
+		if !node.left.ascend(from, comparator, callback) {
+			return false
+		}
+		for i := 0; i < node.count; i++ {
+			if !callback(node.value) {
+				return false
+			}
+		}
+	}
+	return node.right.ascend(from, comparator, callback)
+}
+
+func (node *rbNode[E]) descend(from E, comparator contract.Comparator[E], callback func(value E) bool) bool {
+	if node == nil {
+		return true
+	}
+	if comparator.Compare(node.value, from) <= 0 {
+		if !node.right.descend(from, comparator, callback) {
+			return false
+		}
+		for i := 0; i < node.count; i++ {
+			if !callback(node.value) {
+				return false
+			}
+		}
+	}
+	return node.left.descend(from, comparator, callback)
+}
@@ -1,11 +1,14 @@
 package tree
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gopi-frame/collection/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -120,6 +123,52 @@ func TestRBTree_LastOr(t *testing.T) {
 	})
 }
 
+func TestRBTree_Floor(t *testing.T) {
+	tree := NewRBTree(_cmp{}, 10, 20, 30, 40)
+	value, ok := tree.Floor(25)
+	assert.True(t, ok)
+	assert.Equal(t, 20, value)
+	value, ok = tree.Floor(30)
+	assert.True(t, ok)
+	assert.Equal(t, 30, value)
+	value, ok = tree.Floor(5)
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+}
+
+func TestRBTree_Ceiling(t *testing.T) {
+	tree := NewRBTree(_cmp{}, 10, 20, 30, 40)
+	value, ok := tree.Ceiling(25)
+	assert.True(t, ok)
+	assert.Equal(t, 30, value)
+	value, ok = tree.Ceiling(10)
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	value, ok = tree.Ceiling(45)
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+}
+
+func TestRBTree_Ascend(t *testing.T) {
+	tree := NewRBTree(_cmp{}, 10, 20, 30, 40, 30)
+	var values []int
+	tree.Ascend(25, func(value int) bool {
+		values = append(values, value)
+		return value < 40
+	})
+	assert.Equal(t, []int{30, 30, 40}, values)
+}
+
+func TestRBTree_Descend(t *testing.T) {
+	tree := NewRBTree(_cmp{}, 10, 20, 30, 40)
+	var values []int
+	tree.Descend(30, func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []int{30, 20, 10}, values)
+}
+
 func TestRBTree_Each(t *testing.T) {
 	tree := NewRBTree(_cmp{}, 1, 2, 3, 5, 2)
 	var items []int
@@ -141,6 +190,20 @@ func TestRBTree_ToArray(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 2, 3, 5}, tree.ToArray())
 }
 
+func TestRBTree_Encode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, NewRBTree(_cmp{}, 3, 1, 2).Encode(buf, codec.NDJSON))
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func TestRBTree_Decode(t *testing.T) {
+	tree := NewRBTree(_cmp{}, 9)
+	assert.Nil(t, tree.Decode(strings.NewReader("[2,3,1]"), codec.JSON))
+	assert.Equal(t, []int{1, 2, 3}, tree.ToArray())
+	assert.NotNil(t, tree.Decode(strings.NewReader("[2,"), codec.JSON))
+	assert.Equal(t, []int{1, 2, 3}, tree.ToArray())
+}
+
 func TestRBTree_ToJSON(t *testing.T) {
 	tree := NewRBTree(_cmp{}, 1, 2, 3, 5, 2)
 	jsonBytes, err := tree.ToJSON()
@@ -0,0 +1,213 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/contract"
+)
+
+// WalkOrder the order of walking through the hierarchical tree
+type WalkOrder int
+
+const (
+	// PreOrder visits the node before its children
+	PreOrder WalkOrder = iota
+	// PostOrder visits the node after its children
+	PostOrder
+	// LevelOrder visits nodes level by level
+	LevelOrder
+)
+
+// NewTree new hierarchical tree
+func NewTree[E any]() *Tree[E] {
+	return new(Tree[E])
+}
+
+// Tree hierarchical (n-ary) tree
+type Tree[E any] struct {
+	sync.RWMutex
+	root *Node[E]
+}
+
+// Root returns the root node, it returns nil when the tree is empty
+func (t *Tree[E]) Root() *Node[E] {
+	return t.root
+}
+
+// SetRoot replaces the whole tree with a new root node of the given value and returns it
+func (t *Tree[E]) SetRoot(value E) *Node[E] {
+	t.root = &Node[E]{value: value}
+	return t.root
+}
+
+// Count returns the size of tree
+func (t *Tree[E]) Count() int64 {
+	var count int64
+	t.Walk(PreOrder, func(*Node[E]) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// IsEmpty returns whether the tree is empty
+func (t *Tree[E]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// IsNotEmpty returns whether the tree is not empty
+func (t *Tree[E]) IsNotEmpty() bool {
+	return !t.IsEmpty()
+}
+
+// ElementType returns the name of the element type
+func (t *Tree[E]) ElementType() string {
+	return collection.TypeName[E]()
+}
+
+// Kind returns [collection.KindTree]
+func (t *Tree[E]) Kind() collection.CollectionKind {
+	return collection.KindTree
+}
+
+// Depth returns the number of levels of the tree
+func (t *Tree[E]) Depth() int {
+	return t.root.height()
+}
+
+// Remove removes the node and its subtree from the tree
+func (t *Tree[E]) Remove(node *Node[E]) {
+	if node == nil {
+		return
+	}
+	if node == t.root {
+		t.root = nil
+		return
+	}
+	node.detach()
+}
+
+// Clear clears the tree
+func (t *Tree[E]) Clear() {
+	t.root = nil
+}
+
+// Walk walks through the tree in the given order, it breaks when callback returns false
+func (t *Tree[E]) Walk(order WalkOrder, callback func(node *Node[E]) bool) {
+	if t.root == nil {
+		return
+	}
+	switch order {
+	case PostOrder:
+		t.root.postOrder(callback)
+	case LevelOrder:
+		t.root.levelOrder(callback)
+	default:
+		t.root.preOrder(callback)
+	}
+}
+
+// Find returns the first node in pre-order which matches the callback.
+// It returns nil when none matches the callback.
+func (t *Tree[E]) Find(callback func(value E) bool) *Node[E] {
+	var found *Node[E]
+	t.Walk(PreOrder, func(node *Node[E]) bool {
+		if callback(node.value) {
+			found = node
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// FindPath returns values from the root node to the first node which matches the callback.
+// It returns nil and false when none matches the callback.
+func (t *Tree[E]) FindPath(callback func(value E) bool) ([]E, bool) {
+	node := t.Find(callback)
+	if node == nil {
+		return nil, false
+	}
+	return node.Path(), true
+}
+
+// Each travers the tree in pre-order, if the callback returns false then break
+func (t *Tree[E]) Each(callback func(index int, value E) bool) {
+	index := 0
+	t.Walk(PreOrder, func(node *Node[E]) bool {
+		ok := callback(index, node.value)
+		index++
+		return ok
+	})
+}
+
+// Clone clones the tree
+func (t *Tree[E]) Clone() *Tree[E] {
+	tt := NewTree[E]()
+	if t.root == nil {
+		return tt
+	}
+	var clone func(src, dst *Node[E])
+	clone = func(src, dst *Node[E]) {
+		for _, child := range src.children {
+			clone(child, dst.AddChild(child.value))
+		}
+	}
+	clone(t.root, tt.SetRoot(t.root.value))
+	return tt
+}
+
+// ToArray converts to array in pre-order
+func (t *Tree[E]) ToArray() []E {
+	var values []E
+	t.Each(func(_ int, value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// ToJSON converts to json
+func (t *Tree[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(t.root)
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (t *Tree[E]) MarshalJSON() ([]byte, error) {
+	return t.ToJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaller]
+func (t *Tree[E]) UnmarshalJSON(data []byte) error {
+	var root *Node[E]
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	t.root = root
+	return nil
+}
+
+// String converts to string
+func (t *Tree[E]) String() string {
+	str := new(strings.Builder)
+	str.WriteString(fmt.Sprintf("Tree[%T](len=%d)", *new(E), t.Count()))
+	str.WriteByte('{')
+	str.WriteByte('\n')
+	t.Walk(PreOrder, func(node *Node[E]) bool {
+		str.WriteString(strings.Repeat("\t", node.Depth()+1))
+		if v, ok := any(node.value).(contract.Stringable); ok {
+			str.WriteString(v.String())
+		} else {
+			str.WriteString(fmt.Sprintf("%v", node.value))
+		}
+		str.WriteByte(',')
+		str.WriteByte('\n')
+		return true
+	})
+	str.WriteByte('}')
+	return str.String()
+}
@@ -0,0 +1,152 @@
+package tree
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+type jsonNode[E any] struct {
+	Value    E          `json:"value"`
+	Children []*Node[E] `json:"children,omitempty"`
+}
+
+// Node node of the hierarchical tree
+type Node[E any] struct {
+	value    E
+	parent   *Node[E]
+	children []*Node[E]
+}
+
+// Value returns the value of the node
+func (node *Node[E]) Value() E {
+	return node.value
+}
+
+// SetValue sets the value of the node
+func (node *Node[E]) SetValue(value E) {
+	node.value = value
+}
+
+// Parent returns the parent of the node, it returns nil for the root node
+func (node *Node[E]) Parent() *Node[E] {
+	return node.parent
+}
+
+// Children returns the children of the node
+func (node *Node[E]) Children() []*Node[E] {
+	return slices.Clone(node.children)
+}
+
+// IsRoot returns whether the node is the root node
+func (node *Node[E]) IsRoot() bool {
+	return node.parent == nil
+}
+
+// IsLeaf returns whether the node has no children
+func (node *Node[E]) IsLeaf() bool {
+	return len(node.children) == 0
+}
+
+// AddChild appends a child with the given value to the node and returns the child
+func (node *Node[E]) AddChild(value E) *Node[E] {
+	child := &Node[E]{value: value, parent: node}
+	node.children = append(node.children, child)
+	return child
+}
+
+// Depth returns the depth of the node, the depth of the root node is 0
+func (node *Node[E]) Depth() int {
+	depth := 0
+	for n := node.parent; n != nil; n = n.parent {
+		depth++
+	}
+	return depth
+}
+
+// Path returns values from the root node to the node
+func (node *Node[E]) Path() []E {
+	var path []E
+	for n := node; n != nil; n = n.parent {
+		path = append(path, n.value)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+func (node *Node[E]) detach() {
+	if node.parent == nil {
+		return
+	}
+	node.parent.children = slices.DeleteFunc(node.parent.children, func(child *Node[E]) bool {
+		return child == node
+	})
+	node.parent = nil
+}
+
+func (node *Node[E]) height() int {
+	if node == nil {
+		return 0
+	}
+	h := 0
+	for _, child := range node.children {
+		h = max(h, child.height())
+	}
+	return h + 1
+}
+
+func (node *Node[E]) preOrder(callback func(node *Node[E]) bool) bool {
+	if !callback(node) {
+		return false
+	}
+	for _, child := range node.children {
+		if !child.preOrder(callback) {
+			return false
+		}
+	}
+	return true
+}
+
+func (node *Node[E]) postOrder(callback func(node *Node[E]) bool) bool {
+	for _, child := range node.children {
+		if !child.postOrder(callback) {
+			return false
+		}
+	}
+	return callback(node)
+}
+
+func (node *Node[E]) levelOrder(callback func(node *Node[E]) bool) {
+	nodes := []*Node[E]{node}
+	for len(nodes) > 0 {
+		n := nodes[0]
+		nodes = nodes[1:]
+		if !callback(n) {
+			return
+		}
+		nodes = append(nodes, n.children...)
+	}
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (node *Node[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonNode[E]{
+		Value:    node.value,
+		Children: node.children,
+	})
+}
+
+// UnmarshalJSON implements [json.Unmarshaller], null children are skipped
+func (node *Node[E]) UnmarshalJSON(data []byte) error {
+	var container = new(jsonNode[E])
+	if err := json.Unmarshal(data, container); err != nil {
+		return err
+	}
+	node.value = container.Value
+	node.children = slices.DeleteFunc(container.Children, func(child *Node[E]) bool {
+		return child == nil
+	})
+	for _, child := range node.children {
+		child.parent = node
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package tree
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTree() *Tree[string] {
+	tree := NewTree[string]()
+	root := tree.SetRoot("ceo")
+	cto := root.AddChild("cto")
+	cto.AddChild("dev")
+	cto.AddChild("ops")
+	root.AddChild("cfo").AddChild("accountant")
+	return tree
+}
+
+func TestTree_Count(t *testing.T) {
+	assert.Equal(t, int64(0), NewTree[string]().Count())
+	assert.Equal(t, int64(6), newTestTree().Count())
+}
+
+func TestTree_IsEmpty(t *testing.T) {
+	tree := NewTree[int]()
+	assert.True(t, tree.IsEmpty())
+	tree.SetRoot(1)
+	assert.True(t, tree.IsNotEmpty())
+}
+
+func TestTree_Depth(t *testing.T) {
+	assert.Equal(t, 0, NewTree[string]().Depth())
+	tree := newTestTree()
+	assert.Equal(t, 3, tree.Depth())
+	assert.Equal(t, 2, tree.Find(func(value string) bool {
+		return value == "ops"
+	}).Depth())
+}
+
+func TestTree_Remove(t *testing.T) {
+	t.Run("subtree", func(t *testing.T) {
+		tree := newTestTree()
+		tree.Remove(tree.Find(func(value string) bool {
+			return value == "cto"
+		}))
+		assert.Equal(t, []string{"ceo", "cfo", "accountant"}, tree.ToArray())
+	})
+
+	t.Run("root", func(t *testing.T) {
+		tree := newTestTree()
+		tree.Remove(tree.Root())
+		assert.True(t, tree.IsEmpty())
+	})
+}
+
+func TestTree_Walk(t *testing.T) {
+	tree := newTestTree()
+	walk := func(order WalkOrder) []string {
+		var values []string
+		tree.Walk(order, func(node *Node[string]) bool {
+			values = append(values, node.Value())
+			return true
+		})
+		return values
+	}
+	assert.Equal(t, []string{"ceo", "cto", "dev", "ops", "cfo", "accountant"}, walk(PreOrder))
+	assert.Equal(t, []string{"dev", "ops", "cto", "accountant", "cfo", "ceo"}, walk(PostOrder))
+	assert.Equal(t, []string{"ceo", "cto", "cfo", "dev", "ops", "accountant"}, walk(LevelOrder))
+}
+
+func TestTree_FindPath(t *testing.T) {
+	tree := newTestTree()
+	path, ok := tree.FindPath(func(value string) bool {
+		return value == "accountant"
+	})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"ceo", "cfo", "accountant"}, path)
+
+	path, ok = tree.FindPath(func(value string) bool {
+		return value == "intern"
+	})
+	assert.False(t, ok)
+	assert.Nil(t, path)
+}
+
+func TestTree_Clone(t *testing.T) {
+	tree := newTestTree()
+	clone := tree.Clone()
+	clone.Root().AddChild("coo")
+	assert.Equal(t, int64(6), tree.Count())
+	assert.Equal(t, int64(7), clone.Count())
+}
+
+func TestTree_MarshalJSON(t *testing.T) {
+	tree := NewTree[int]()
+	root := tree.SetRoot(1)
+	root.AddChild(2).AddChild(3)
+	root.AddChild(4)
+	jsonBytes, err := json.Marshal(tree)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `{"value":1,"children":[{"value":2,"children":[{"value":3}]},{"value":4}]}`, string(jsonBytes))
+}
+
+func TestTree_UnmarshalJSON(t *testing.T) {
+	tree := NewTree[int]()
+	err := json.Unmarshal([]byte(`{"value":1,"children":[{"value":2,"children":[{"value":3}]},{"value":4}]}`), tree)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, tree.ToArray())
+	node := tree.Find(func(value int) bool {
+		return value == 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, node.Path())
+
+	tree = NewTree[int]()
+	assert.Nil(t, json.Unmarshal([]byte(`{"value":1,"children":[null,{"value":2,"children":[null]}]}`), tree))
+	assert.Equal(t, []int{1, 2}, tree.ToArray())
+}
+
+func TestTree_String(t *testing.T) {
+	tree := NewTree[int]()
+	tree.SetRoot(1).AddChild(2)
+	assert.Equal(t, "Tree[int](len=2){\n\t1,\n\t\t2,\n}", tree.String())
+}
@@ -0,0 +1,239 @@
+// Package variant provides closed unions of two or three types,
+// so collections can hold values of several types without resorting to any and type switches.
+package variant
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gopi-frame/collection"
+	"github.com/gopi-frame/contract"
+)
+
+// Of2A new union holding a value of type A
+func Of2A[A, B any](value A) Of2[A, B] {
+	return Of2[A, B]{a: value}
+}
+
+// Of2B new union holding a value of type B
+func Of2B[A, B any](value B) Of2[A, B] {
+	return Of2[A, B]{index: 1, b: value}
+}
+
+// Of2 union holding either a value of type A or a value of type B,
+// the zero value holds the zero value of A
+type Of2[A, B any] struct {
+	index uint8
+	a     A
+	b     B
+}
+
+// Index returns the index of the held type, 0 for A and 1 for B
+func (v Of2[A, B]) Index() int {
+	return int(v.index)
+}
+
+// A returns the held value when it is of type A
+func (v Of2[A, B]) A() (A, bool) {
+	return v.a, v.index == 0
+}
+
+// B returns the held value when it is of type B
+func (v Of2[A, B]) B() (B, bool) {
+	return v.b, v.index == 1
+}
+
+// Value returns the held value
+func (v Of2[A, B]) Value() any {
+	if v.index == 1 {
+		return v.b
+	}
+	return v.a
+}
+
+// Switch calls the callback of the held type
+func (v Of2[A, B]) Switch(onA func(value A), onB func(value B)) {
+	if v.index == 1 {
+		onB(v.b)
+	} else {
+		onA(v.a)
+	}
+}
+
+// String converts to string
+func (v Of2[A, B]) String() string {
+	return fmt.Sprintf("Of2[%T, %T](%c: %s)", *new(A), *new(B), 'A'+v.index, format(v.Value()))
+}
+
+// MarshalJSON implements [json.Marshaler]
+func (v Of2[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encoded{Index: v.index, Value: v.Value()})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]
+func (v *Of2[A, B]) UnmarshalJSON(data []byte) error {
+	var raw decoded
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var result Of2[A, B]
+	var err error
+	switch raw.Index {
+	case 0:
+		err = json.Unmarshal(raw.Value, &result.a)
+	case 1:
+		result.index = 1
+		err = json.Unmarshal(raw.Value, &result.b)
+	default:
+		return collection.NewRangeError(int(raw.Index), 2)
+	}
+	if err != nil {
+		return err
+	}
+	*v = result
+	return nil
+}
+
+// Match2 returns the result of the function of the held type,
+// every type must be handled so adding a type to the union breaks the build instead of falling through
+func Match2[A, B, R any](v Of2[A, B], onA func(value A) R, onB func(value B) R) R {
+	if v.index == 1 {
+		return onB(v.b)
+	}
+	return onA(v.a)
+}
+
+// Of3A new union holding a value of type A
+func Of3A[A, B, C any](value A) Of3[A, B, C] {
+	return Of3[A, B, C]{a: value}
+}
+
+// Of3B new union holding a value of type B
+func Of3B[A, B, C any](value B) Of3[A, B, C] {
+	return Of3[A, B, C]{index: 1, b: value}
+}
+
+// Of3C new union holding a value of type C
+func Of3C[A, B, C any](value C) Of3[A, B, C] {
+	return Of3[A, B, C]{index: 2, c: value}
+}
+
+// Of3 union holding a value of type A, B or C,
+// the zero value holds the zero value of A
+type Of3[A, B, C any] struct {
+	index uint8
+	a     A
+	b     B
+	c     C
+}
+
+// Index returns the index of the held type, 0 for A, 1 for B and 2 for C
+func (v Of3[A, B, C]) Index() int {
+	return int(v.index)
+}
+
+// A returns the held value when it is of type A
+func (v Of3[A, B, C]) A() (A, bool) {
+	return v.a, v.index == 0
+}
+
+// B returns the held value when it is of type B
+func (v Of3[A, B, C]) B() (B, bool) {
+	return v.b, v.index == 1
+}
+
+// C returns the held value when it is of type C
+func (v Of3[A, B, C]) C() (C, bool) {
+	return v.c, v.index == 2
+}
+
+// Value returns the held value
+func (v Of3[A, B, C]) Value() any {
+	switch v.index {
+	case 1:
+		return v.b
+	case 2:
+		return v.c
+	default:
+		return v.a
+	}
+}
+
+// Switch calls the callback of the held type
+func (v Of3[A, B, C]) Switch(onA func(value A), onB func(value B), onC func(value C)) {
+	switch v.index {
+	case 1:
+		onB(v.b)
+	case 2:
+		onC(v.c)
+	default:
+		onA(v.a)
+	}
+}
+
+// String converts to string
+func (v Of3[A, B, C]) String() string {
+	return fmt.Sprintf("Of3[%T, %T, %T](%c: %s)", *new(A), *new(B), *new(C), 'A'+v.index, format(v.Value()))
+}
+
+// MarshalJSON implements [json.Marshaler]
+func (v Of3[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encoded{Index: v.index, Value: v.Value()})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]
+func (v *Of3[A, B, C]) UnmarshalJSON(data []byte) error {
+	var raw decoded
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var result Of3[A, B, C]
+	var err error
+	switch raw.Index {
+	case 0:
+		err = json.Unmarshal(raw.Value, &result.a)
+	case 1:
+		result.index = 1
+		err = json.Unmarshal(raw.Value, &result.b)
+	case 2:
+		result.index = 2
+		err = json.Unmarshal(raw.Value, &result.c)
+	default:
+		return collection.NewRangeError(int(raw.Index), 3)
+	}
+	if err != nil {
+		return err
+	}
+	*v = result
+	return nil
+}
+
+// Match3 returns the result of the function of the held type,
+// every type must be handled so adding a type to the union breaks the build instead of falling through
+func Match3[A, B, C, R any](v Of3[A, B, C], onA func(value A) R, onB func(value B) R, onC func(value C) R) R {
+	switch v.index {
+	case 1:
+		return onB(v.b)
+	case 2:
+		return onC(v.c)
+	default:
+		return onA(v.a)
+	}
+}
+
+type encoded struct {
+	Index uint8 `json:"index"`
+	Value any   `json:"value"`
+}
+
+type decoded struct {
+	Index uint8           `json:"index"`
+	Value json.RawMessage `json:"value"`
+}
+
+func format(value any) string {
+	if v, ok := value.(contract.Stringable); ok {
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,97 @@
+package variant
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/gopi-frame/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf2_A(t *testing.T) {
+	v := Of2A[int, string](1)
+	a, ok := v.A()
+	assert.True(t, ok)
+	assert.Equal(t, 1, a)
+	_, ok = v.B()
+	assert.False(t, ok)
+	assert.Equal(t, 0, v.Index())
+	assert.Equal(t, 1, v.Value())
+
+	var zero Of2[int, string]
+	_, ok = zero.A()
+	assert.True(t, ok)
+}
+
+func TestOf2_Switch(t *testing.T) {
+	var got string
+	Of2B[int, string]("b").Switch(func(value int) {
+		got = "int"
+	}, func(value string) {
+		got = value
+	})
+	assert.Equal(t, "b", got)
+}
+
+func TestMatch2(t *testing.T) {
+	describe := func(v Of2[int, string]) string {
+		return Match2(v, strconv.Itoa, func(value string) string { return "'" + value + "'" })
+	}
+	assert.Equal(t, "1", describe(Of2A[int, string](1)))
+	assert.Equal(t, "'a'", describe(Of2B[int, string]("a")))
+}
+
+func TestOf2_String(t *testing.T) {
+	assert.Equal(t, "Of2[int, string](B: a)", Of2B[int, string]("a").String())
+}
+
+func TestOf2_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal([]Of2[int, string]{Of2A[int, string](1), Of2B[int, string]("a")})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"index":0,"value":1},{"index":1,"value":"a"}]`, string(data))
+}
+
+func TestOf2_UnmarshalJSON(t *testing.T) {
+	var values []Of2[int, string]
+	assert.Nil(t, json.Unmarshal([]byte(`[{"index":1,"value":"a"},{"index":0,"value":1}]`), &values))
+	assert.Equal(t, []Of2[int, string]{Of2B[int, string]("a"), Of2A[int, string](1)}, values)
+
+	var v Of2[int, string]
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"index":2,"value":1}`), &v), collection.ErrIndexOutOfRange)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"index":0,"value":"a"}`), &v))
+}
+
+func TestOf3_C(t *testing.T) {
+	v := Of3C[int, string, bool](true)
+	c, ok := v.C()
+	assert.True(t, ok)
+	assert.True(t, c)
+	_, ok = v.A()
+	assert.False(t, ok)
+	_, ok = v.B()
+	assert.False(t, ok)
+	assert.Equal(t, 2, v.Index())
+	assert.Equal(t, "Of3[int, string, bool](C: true)", v.String())
+}
+
+func TestMatch3(t *testing.T) {
+	values := []Of3[int, string, bool]{Of3A[int, string, bool](1), Of3B[int, string, bool]("a"), Of3C[int, string, bool](true)}
+	var kinds []string
+	for _, v := range values {
+		kinds = append(kinds, Match3(v,
+			func(int) string { return "int" },
+			func(string) string { return "string" },
+			func(bool) string { return "bool" }))
+	}
+	assert.Equal(t, []string{"int", "string", "bool"}, kinds)
+}
+
+func TestOf3_UnmarshalJSON(t *testing.T) {
+	v := Of3A[int, string, bool](1)
+	data, err := json.Marshal(Of3C[int, string, bool](true))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"index":2,"value":true}`, string(data))
+	assert.Nil(t, json.Unmarshal(data, &v))
+	assert.Equal(t, Of3C[int, string, bool](true), v)
+}
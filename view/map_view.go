@@ -0,0 +1,149 @@
+package view
+
+import (
+	"encoding/json"
+
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+// MapSource map which can be viewed
+type MapSource[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Each(callback func(key K, value V) bool)
+}
+
+// FilterMap returns a view of the entries of the source which match the predicate
+func FilterMap[K comparable, V any](source MapSource[K, V], predicate func(key K, value V) bool) *MapView[K, V] {
+	return &MapView[K, V]{
+		get: func(key K) (V, bool) {
+			value, ok := source.Get(key)
+			if !ok || !predicate(key, value) {
+				return *new(V), false
+			}
+			return value, true
+		},
+		each: func(callback func(key K, value V) bool) {
+			source.Each(func(key K, value V) bool {
+				if !predicate(key, value) {
+					return true
+				}
+				return callback(key, value)
+			})
+		},
+	}
+}
+
+// MappedValues returns a view of the entries of the source whose values are transformed by the mapper.
+// Values are transformed lazily on every read, no second map is allocated.
+func MappedValues[K comparable, V, R any](source MapSource[K, V], mapper func(key K, value V) R) *MapView[K, R] {
+	return &MapView[K, R]{
+		get: func(key K) (R, bool) {
+			value, ok := source.Get(key)
+			if !ok {
+				return *new(R), false
+			}
+			return mapper(key, value), true
+		},
+		each: func(callback func(key K, value R) bool) {
+			source.Each(func(key K, value V) bool {
+				return callback(key, mapper(key, value))
+			})
+		},
+	}
+}
+
+// MapView live read-only view over a map
+type MapView[K comparable, V any] struct {
+	get  func(key K) (V, bool)
+	each func(callback func(key K, value V) bool)
+}
+
+// Get gets element by specific key.
+// A zero value and false will be returned when the given key is not in the view
+func (v *MapView[K, V]) Get(key K) (V, bool) {
+	return v.get(key)
+}
+
+// GetOr gets element by specific key, the default value will be returned when the given key is not in the view
+func (v *MapView[K, V]) GetOr(key K, value V) V {
+	if found, ok := v.get(key); ok {
+		return found
+	}
+	return value
+}
+
+// ContainsKey returns whether the view contains the specific key
+func (v *MapView[K, V]) ContainsKey(key K) bool {
+	_, ok := v.get(key)
+	return ok
+}
+
+// Each ranges the view, it breaks when the callback returns false
+func (v *MapView[K, V]) Each(callback func(key K, value V) bool) {
+	v.each(callback)
+}
+
+// Count returns the number of entries in the view
+func (v *MapView[K, V]) Count() int64 {
+	var count int64
+	v.each(func(K, V) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// IsEmpty returns whether the view is empty
+func (v *MapView[K, V]) IsEmpty() bool {
+	empty := true
+	v.each(func(K, V) bool {
+		empty = false
+		return false
+	})
+	return empty
+}
+
+// IsNotEmpty returns whether the view is not empty
+func (v *MapView[K, V]) IsNotEmpty() bool {
+	return !v.IsEmpty()
+}
+
+// Where returns a view of the entries of this view which match the predicate
+func (v *MapView[K, V]) Where(predicate func(key K, value V) bool) *MapView[K, V] {
+	return FilterMap[K, V](v, predicate)
+}
+
+// Keys returns the keys in the view
+func (v *MapView[K, V]) Keys() []K {
+	var keys []K
+	v.each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ToMap materializes the view into a new map
+func (v *MapView[K, V]) ToMap() map[K]V {
+	items := make(map[K]V)
+	v.each(func(key K, value V) bool {
+		items[key] = value
+		return true
+	})
+	return items
+}
+
+// ToJSON converts to json
+func (v *MapView[K, V]) ToJSON() ([]byte, error) {
+	return json.Marshal(v.ToMap())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (v *MapView[K, V]) MarshalJSON() ([]byte, error) {
+	return v.ToJSON()
+}
+
+// String converts to string
+func (v *MapView[K, V]) String() string {
+	return format.Entries[K, V]("MapView", v.Count(), v.each)
+}
@@ -0,0 +1,81 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapSource map[string]int
+
+func (m mapSource) Get(key string) (int, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+func (m mapSource) Each(callback func(key string, value int) bool) {
+	for key, value := range m {
+		if !callback(key, value) {
+			break
+		}
+	}
+}
+
+func isPositive(_ string, value int) bool {
+	return value > 0
+}
+
+func TestFilterMap(t *testing.T) {
+	source := mapSource{"a": 1, "b": -1}
+	v := FilterMap[string, int](source, isPositive)
+	assert.Equal(t, map[string]int{"a": 1}, v.ToMap())
+	source["b"] = 2
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, v.ToMap())
+}
+
+func TestMappedValues(t *testing.T) {
+	source := mapSource{"a": 1, "b": -1}
+	v := MappedValues[string, int](FilterMap[string, int](source, isPositive), func(_ string, value int) float64 {
+		return float64(value) / 2
+	})
+	assert.Equal(t, map[string]float64{"a": 0.5}, v.ToMap())
+	value, ok := v.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, value)
+	_, ok = v.Get("b")
+	assert.False(t, ok)
+}
+
+func TestMapView_Get(t *testing.T) {
+	v := FilterMap[string, int](mapSource{"a": 1, "b": -1}, isPositive)
+	value, ok := v.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	_, ok = v.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 0, v.GetOr("c", 0))
+	assert.False(t, v.ContainsKey("b"))
+}
+
+func TestMapView_Count(t *testing.T) {
+	v := FilterMap[string, int](mapSource{"a": 1, "b": -1, "c": 3}, isPositive)
+	assert.Equal(t, int64(2), v.Count())
+	assert.ElementsMatch(t, []string{"a", "c"}, v.Keys())
+	assert.True(t, FilterMap[string, int](mapSource{"b": -1}, isPositive).IsEmpty())
+}
+
+func TestMapView_Where(t *testing.T) {
+	v := FilterMap[string, int](mapSource{"a": 1, "b": -1, "c": 3}, isPositive).Where(func(key string, _ int) bool {
+		return key != "a"
+	})
+	assert.Equal(t, map[string]int{"c": 3}, v.ToMap())
+}
+
+func TestMapView_ToJSON(t *testing.T) {
+	v := FilterMap[string, int](mapSource{"a": 1, "b": -1}, isPositive)
+	jsonBytes, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(jsonBytes))
+	assert.Equal(t, "MapView[string, int](len=1){\n\ta: 1,\n}", v.String())
+}
@@ -0,0 +1,141 @@
+// Package view provides live read-only views over collections.
+// A view holds no elements, every read goes through to the underlying collection,
+// so the view reflects later mutations of it. Lock the underlying collection around reads when it is shared.
+package view
+
+import (
+	"encoding/json"
+
+	"github.com/gopi-frame/collection/internal/format"
+)
+
+// Source collection which can be viewed, such as lists and sets
+type Source[E any] interface {
+	Each(callback func(index int, value E) bool)
+}
+
+// Filter returns a view of the elements of the source which match the predicate
+func Filter[E any](source Source[E], predicate func(value E) bool) *View[E] {
+	return &View[E]{each: func(callback func(value E) bool) {
+		source.Each(func(_ int, value E) bool {
+			if !predicate(value) {
+				return true
+			}
+			return callback(value)
+		})
+	}}
+}
+
+// Mapped returns a view of the elements of the source transformed by the mapper.
+// Elements are transformed lazily on every read, no second collection is allocated.
+func Mapped[E, R any](source Source[E], mapper func(value E) R) *View[R] {
+	return &View[R]{each: func(callback func(value R) bool) {
+		source.Each(func(_ int, value E) bool {
+			return callback(mapper(value))
+		})
+	}}
+}
+
+// View live read-only view over a collection
+type View[E any] struct {
+	each func(callback func(value E) bool)
+}
+
+// Each ranges the view, index is the position in the view. It breaks when the callback returns false
+func (v *View[E]) Each(callback func(index int, value E) bool) {
+	index := 0
+	v.each(func(value E) bool {
+		ok := callback(index, value)
+		index++
+		return ok
+	})
+}
+
+// Count returns the number of elements in the view
+func (v *View[E]) Count() int64 {
+	var count int64
+	v.each(func(E) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// IsEmpty returns whether the view is empty
+func (v *View[E]) IsEmpty() bool {
+	empty := true
+	v.each(func(E) bool {
+		empty = false
+		return false
+	})
+	return empty
+}
+
+// IsNotEmpty returns whether the view is not empty
+func (v *View[E]) IsNotEmpty() bool {
+	return !v.IsEmpty()
+}
+
+// Get returns the element on the specific index of the view.
+// It will return a zero value and false when the index is out of range.
+func (v *View[E]) Get(index int) (E, bool) {
+	var found E
+	var ok bool
+	v.Each(func(i int, value E) bool {
+		if i == index {
+			found, ok = value, true
+		}
+		return i < index
+	})
+	return found, ok
+}
+
+// First returns the first element of the view.
+// It will return a zero value and false when the view is empty.
+func (v *View[E]) First() (E, bool) {
+	return v.Get(0)
+}
+
+// ContainsWhere returns whether the view contains elements which match the callback
+func (v *View[E]) ContainsWhere(callback func(value E) bool) bool {
+	found := false
+	v.each(func(value E) bool {
+		found = callback(value)
+		return !found
+	})
+	return found
+}
+
+// Where returns a view of the elements of this view which match the predicate
+func (v *View[E]) Where(predicate func(value E) bool) *View[E] {
+	return Filter[E](v, predicate)
+}
+
+// ToArray materializes the view into a new slice
+func (v *View[E]) ToArray() []E {
+	var values []E
+	v.each(func(value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// ToJSON converts to json
+func (v *View[E]) ToJSON() ([]byte, error) {
+	return json.Marshal(v.ToArray())
+}
+
+// MarshalJSON implements [json.Marshaller]
+func (v *View[E]) MarshalJSON() ([]byte, error) {
+	return v.ToJSON()
+}
+
+// String converts to string
+func (v *View[E]) String() string {
+	return format.Values("View", v.Count(), func(yield func(value E) bool) {
+		v.Each(func(_ int, value E) bool {
+			return yield(value)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sliceSource []int
+
+func (s *sliceSource) Each(callback func(index int, value int) bool) {
+	for index, value := range *s {
+		if !callback(index, value) {
+			break
+		}
+	}
+}
+
+func isEven(value int) bool {
+	return value%2 == 0
+}
+
+func TestFilter(t *testing.T) {
+	source := &sliceSource{1, 2, 3, 4}
+	v := Filter[int](source, isEven)
+	assert.Equal(t, []int{2, 4}, v.ToArray())
+	*source = append(*source, 6)
+	assert.Equal(t, []int{2, 4, 6}, v.ToArray())
+}
+
+func TestMapped(t *testing.T) {
+	source := &sliceSource{1, 2, 3, 4}
+	calls := 0
+	v := Mapped[int](Filter[int](source, isEven), func(value int) string {
+		calls++
+		return fmt.Sprintf("#%d", value)
+	})
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, []string{"#2", "#4"}, v.ToArray())
+	*source = append(*source, 6)
+	value, ok := v.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "#6", value)
+	assert.Equal(t, []string{"#4", "#6"}, v.Where(func(value string) bool {
+		return value != "#2"
+	}).ToArray())
+}
+
+func TestView_Count(t *testing.T) {
+	v := Filter[int](&sliceSource{1, 2, 3, 4}, isEven)
+	assert.Equal(t, int64(2), v.Count())
+	assert.True(t, v.IsNotEmpty())
+	assert.True(t, Filter[int](&sliceSource{1, 3}, isEven).IsEmpty())
+}
+
+func TestView_Get(t *testing.T) {
+	v := Filter[int](&sliceSource{1, 2, 3, 4}, isEven)
+	value, ok := v.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+	_, ok = v.Get(2)
+	assert.False(t, ok)
+	value, ok = v.First()
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestView_Each(t *testing.T) {
+	v := Filter[int](&sliceSource{1, 2, 3, 4, 6}, isEven)
+	var indexes []int
+	v.Each(func(index int, _ int) bool {
+		indexes = append(indexes, index)
+		return index < 1
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+}
+
+func TestView_Where(t *testing.T) {
+	v := Filter[int](&sliceSource{1, 2, 3, 4, 6}, isEven).Where(func(value int) bool {
+		return value > 2
+	})
+	assert.Equal(t, []int{4, 6}, v.ToArray())
+	assert.True(t, v.ContainsWhere(func(value int) bool {
+		return value == 6
+	}))
+}
+
+func TestView_ToJSON(t *testing.T) {
+	v := Filter[int](&sliceSource{1, 2, 3, 4}, isEven)
+	jsonBytes, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[2,4]`, string(jsonBytes))
+}
+
+func TestView_String(t *testing.T) {
+	v := Filter[int](&sliceSource{2, 4, 6, 8, 10, 12}, isEven)
+	pattern := regexp.MustCompile(fmt.Sprintf(`View\[int\]\(len=%d\)\{\n(\t\d+,\n){5}\t...\n\}`, v.Count()))
+	assert.True(t, pattern.MatchString(v.String()))
+}